@@ -59,6 +59,7 @@ type mainFlags struct {
 	blockProfileRate     int `category:"advanced"`
 	printVersion         bool
 	printModules         bool
+	printModulesJSON     bool
 	printHelp            bool
 	printHelpAll         bool
 }
@@ -69,6 +70,7 @@ func (mf *mainFlags) registerFlags(fs *flag.FlagSet) {
 	fs.IntVar(&mf.blockProfileRate, "debug.block-profile-rate", 0, "Fraction of goroutine blocking events that are reported in the blocking profile. 1 to include every blocking event in the profile, 0 to disable.")
 	fs.BoolVar(&mf.printVersion, "version", false, "Print application version and exit.")
 	fs.BoolVar(&mf.printModules, "modules", false, "List available values that can be used as target.")
+	fs.BoolVar(&mf.printModulesJSON, "modules-json", false, "Print all the modules as JSON, with whether they can be used as target and their dependencies, and exit.")
 	fs.BoolVar(&mf.printHelp, "help", false, "Print basic help.")
 	fs.BoolVar(&mf.printHelp, "h", false, "Print basic help.")
 	fs.BoolVar(&mf.printHelpAll, "help-all", false, "Print help, also including advanced and experimental parameters.")
@@ -147,9 +149,9 @@ func main() {
 		}
 	}
 
-	// Continue on if -modules flag is given. Code handling the
-	// -modules flag will not start mimir.
-	if testMode && !mainFlags.printModules {
+	// Continue on if -modules or -modules-json flag is given. Code handling the
+	// -modules and -modules-json flags will not start mimir.
+	if testMode && !mainFlags.printModules && !mainFlags.printModulesJSON {
 		DumpYaml(&cfg)
 		return
 	}
@@ -188,6 +190,11 @@ func main() {
 	t, err := mimir.New(cfg, prometheus.DefaultRegisterer)
 	util_log.CheckFatal("initializing application", err)
 
+	if mainFlags.printModulesJSON {
+		util_log.CheckFatal("printing modules", t.WriteModuleGraphJSON(os.Stdout))
+		return
+	}
+
 	if mainFlags.printModules {
 		allDeps := t.ModuleManager.DependenciesForModule(mimir.All)
 
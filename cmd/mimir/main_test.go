@@ -97,6 +97,12 @@ func TestFlagParsing(t *testing.T) {
 			stderrExcluded: "ingester\n",
 		},
 
+		"module graph as JSON": {
+			arguments:      []string{"-modules-json"},
+			stdoutMessage:  "\"name\": \"ingester\",\n    \"target\": true,",
+			stdoutExcluded: "target: all",
+		},
+
 		"root level configuration option specified as an empty node in YAML does not set entire config to zero value": {
 			yaml: "querier:",
 			assertConfig: func(t *testing.T, cfg *mimir.Config) {
@@ -271,6 +271,9 @@ func NewQuerierHandler(
 	router.Path(path.Join(prefix, "/api/v1/metadata")).Methods("GET").Handler(metadataQueryStats.Wrap(querier.NewMetadataHandler(metadataSupplier)))
 	router.Path(path.Join(prefix, "/api/v1/cardinality/label_names")).Methods("GET", "POST").Handler(cardinalityQueryStats.Wrap(querier.LabelNamesCardinalityHandler(distributor, limits)))
 	router.Path(path.Join(prefix, "/api/v1/cardinality/label_values")).Methods("GET", "POST").Handler(cardinalityQueryStats.Wrap(querier.LabelValuesCardinalityHandler(distributor, limits)))
+	router.Path(path.Join(prefix, "/api/v1/cardinality/label_values/metrics")).Methods("GET").Handler(cardinalityQueryStats.Wrap(querier.LabelValuesCardinalityMetricsHandler(distributor, limits)))
+	router.Path(path.Join(prefix, "/api/v1/cardinality/label_names/arrow")).Methods("GET", "POST").Handler(cardinalityQueryStats.Wrap(querier.LabelNamesCardinalityArrowHandler(distributor, limits)))
+	router.Path(path.Join(prefix, "/api/v1/cardinality/label_values/arrow")).Methods("GET", "POST").Handler(cardinalityQueryStats.Wrap(querier.LabelValuesCardinalityArrowHandler(distributor, limits)))
 
 	// Track execution time.
 	return stats.NewWallTimeMiddleware().Wrap(router)
@@ -1179,7 +1179,9 @@ func (d *Distributor) LabelValuesForLabelName(ctx context.Context, from, to mode
 }
 
 // LabelNamesAndValues query ingesters for label names and values and returns labels with distinct list of values.
-func (d *Distributor) LabelNamesAndValues(ctx context.Context, matchers []*labels.Matcher) (*ingester_client.LabelNamesAndValuesResponse, error) {
+// If includeBlocks is true, the values of the blocks of the ingesters are also returned, and each value is flagged
+// with where it's present.
+func (d *Distributor) LabelNamesAndValues(ctx context.Context, matchers []*labels.Matcher, includeBlocks bool) (*ingester_client.LabelNamesAndValuesResponse, error) {
 	replicationSet, err := d.GetIngesters(ctx)
 	if err != nil {
 		return nil, err
@@ -1189,12 +1191,13 @@ func (d *Distributor) LabelNamesAndValues(ctx context.Context, matchers []*label
 	if err != nil {
 		return nil, err
 	}
+	req.IncludePresence = includeBlocks
 	userID, err := tenant.TenantID(ctx)
 	if err != nil {
 		return nil, err
 	}
 	sizeLimitBytes := d.limits.LabelNamesAndValuesResultsMaxSizeBytes(userID)
-	merger := &labelNamesAndValuesResponseMerger{result: map[string]map[string]struct{}{}, sizeLimitBytes: sizeLimitBytes}
+	merger := &labelNamesAndValuesResponseMerger{result: map[string]map[string]ingester_client.LabelValuePresence{}, sizeLimitBytes: sizeLimitBytes, includePresence: includeBlocks}
 	_, err = d.forReplicationSet(ctx, replicationSet, func(ctx context.Context, client ingester_client.IngesterClient) (interface{}, error) {
 		stream, err := client.LabelNamesAndValues(ctx, req)
 		if err != nil {
@@ -1211,9 +1214,11 @@ func (d *Distributor) LabelNamesAndValues(ctx context.Context, matchers []*label
 
 type labelNamesAndValuesResponseMerger struct {
 	lock             sync.Mutex
-	result           map[string]map[string]struct{}
+	result           map[string]map[string]ingester_client.LabelValuePresence
 	sizeLimitBytes   int
 	currentSizeBytes int
+	// includePresence enables merging the presence of the values returned by the ingesters.
+	includePresence bool
 }
 
 func toLabelNamesCardinalityRequest(matchers []*labels.Matcher) (*ingester_client.LabelNamesAndValuesRequest, error) {
@@ -1233,12 +1238,20 @@ func (m *labelNamesAndValuesResponseMerger) toLabelNamesAndValuesResponses() *in
 	responses := make([]*ingester_client.LabelValues, 0, len(m.result))
 	for name, values := range m.result {
 		labelValues := make([]string, 0, len(values))
-		for val := range values {
+		var presence []ingester_client.LabelValuePresence
+		if m.includePresence {
+			presence = make([]ingester_client.LabelValuePresence, 0, len(values))
+		}
+		for val, valPresence := range values {
 			labelValues = append(labelValues, val)
+			if m.includePresence {
+				presence = append(presence, valPresence)
+			}
 		}
 		responses = append(responses, &ingester_client.LabelValues{
 			LabelName: name,
 			Values:    labelValues,
+			Presence:  presence,
 		})
 	}
 	return &ingester_client.LabelNamesAndValuesResponse{Items: responses}
@@ -1268,22 +1281,37 @@ func (m *labelNamesAndValuesResponseMerger) putItemsToMap(message *ingester_clie
 		values, exists := m.result[item.LabelName]
 		if !exists {
 			m.currentSizeBytes += len(item.LabelName)
-			values = make(map[string]struct{}, len(item.Values))
+			values = make(map[string]ingester_client.LabelValuePresence, len(item.Values))
 			m.result[item.LabelName] = values
 		}
-		for _, val := range item.Values {
-			if _, valueExists := values[val]; !valueExists {
-				m.currentSizeBytes += len(val)
-				if m.currentSizeBytes > m.sizeLimitBytes {
-					return fmt.Errorf("size of distinct label names and values is greater than %v bytes", m.sizeLimitBytes)
-				}
-				values[val] = struct{}{}
+		for i, val := range item.Values {
+			// The values are present in the head of the ingesters not returning their presence.
+			presence := ingester_client.PRESENT_IN_HEAD_ONLY
+			if i < len(item.Presence) {
+				presence = item.Presence[i]
+			}
+			if existing, valueExists := values[val]; valueExists {
+				values[val] = mergeLabelValuePresence(existing, presence)
+				continue
 			}
+			m.currentSizeBytes += len(val)
+			if m.currentSizeBytes > m.sizeLimitBytes {
+				return fmt.Errorf("size of distinct label names and values is greater than %v bytes", m.sizeLimitBytes)
+			}
+			values[val] = presence
 		}
 	}
 	return nil
 }
 
+// mergeLabelValuePresence returns the presence of a value returned by several ingesters with the given presences.
+func mergeLabelValuePresence(a, b ingester_client.LabelValuePresence) ingester_client.LabelValuePresence {
+	if a == b {
+		return a
+	}
+	return ingester_client.PRESENT_IN_HEAD_AND_BLOCKS
+}
+
 // LabelValuesCardinality performs the following two operations in parallel:
 //   - queries ingesters for label values cardinality of a set of labelNames
 //   - queries ingesters for user stats to get the ingester's series head count
@@ -2186,7 +2186,7 @@ func TestDistributor_LabelNamesAndValuesLimitTest(t *testing.T) {
 				require.NoError(t, err)
 			}
 
-			_, err := ds[0].LabelNamesAndValues(ctx, []*labels.Matcher{})
+			_, err := ds[0].LabelNamesAndValues(ctx, []*labels.Matcher{}, false)
 			if len(testData.expectedError) == 0 {
 				require.NoError(t, err)
 			} else {
@@ -2257,7 +2257,7 @@ func TestDistributor_LabelNamesAndValues(t *testing.T) {
 
 			// Assert on metric metadata
 			timeBeforeExecution := time.Now()
-			response, err := ds[0].LabelNamesAndValues(ctx, []*labels.Matcher{})
+			response, err := ds[0].LabelNamesAndValues(ctx, []*labels.Matcher{}, false)
 			require.NoError(t, err)
 			if len(testData.zonesResponseDelay) > 0 {
 				executionDuration := time.Since(timeBeforeExecution)
@@ -2291,12 +2291,37 @@ func TestDistributor_LabelValuesCardinality_ExpectedAllIngestersResponsesToBeCom
 // Also, it simulates delay from zone C to verify that there is no race condition. must be run with `-race` flag (race detection).
 func TestDistributor_LabelNamesAndValues_ExpectedAllPossibleLabelNamesAndValuesToBeReturned(t *testing.T) {
 	ctx, ds := prepareWithZoneAwarenessAndZoneDelay(t, createSeries(10000))
-	response, err := ds[0].LabelNamesAndValues(ctx, []*labels.Matcher{})
+	response, err := ds[0].LabelNamesAndValues(ctx, []*labels.Matcher{}, false)
 	require.NoError(t, err)
 	require.Len(t, response.Items, 1)
 	require.Equal(t, 10000, len(response.Items[0].Values))
 }
 
+func TestLabelNamesAndValuesResponseMerger_Presence(t *testing.T) {
+	merger := &labelNamesAndValuesResponseMerger{result: map[string]map[string]client.LabelValuePresence{}, sizeLimitBytes: 1024, includePresence: true}
+	// Each ingester returns the presence of the values in its own head and blocks.
+	require.NoError(t, merger.putItemsToMap(&client.LabelNamesAndValuesResponse{Items: []*client.LabelValues{
+		{LabelName: "job", Values: []string{"api", "db", "cache"}, Presence: []client.LabelValuePresence{client.PRESENT_IN_HEAD_ONLY, client.PRESENT_IN_BLOCKS_ONLY, client.PRESENT_IN_BLOCKS_ONLY}},
+	}}))
+	require.NoError(t, merger.putItemsToMap(&client.LabelNamesAndValuesResponse{Items: []*client.LabelValues{
+		{LabelName: "job", Values: []string{"api", "db", "web"}, Presence: []client.LabelValuePresence{client.PRESENT_IN_HEAD_ONLY, client.PRESENT_IN_HEAD_ONLY, client.PRESENT_IN_BLOCKS_ONLY}},
+	}}))
+
+	response := merger.toLabelNamesAndValuesResponses()
+	require.Len(t, response.Items, 1)
+	actual := map[string]client.LabelValuePresence{}
+	require.Len(t, response.Items[0].Presence, len(response.Items[0].Values))
+	for i, value := range response.Items[0].Values {
+		actual[value] = response.Items[0].Presence[i]
+	}
+	require.Equal(t, map[string]client.LabelValuePresence{
+		"api":   client.PRESENT_IN_HEAD_ONLY,
+		"cache": client.PRESENT_IN_BLOCKS_ONLY,
+		"db":    client.PRESENT_IN_HEAD_AND_BLOCKS,
+		"web":   client.PRESENT_IN_BLOCKS_ONLY,
+	}, actual)
+}
+
 func TestDistributor_IngestionIsControlledByForwarder(t *testing.T) {
 	type testcase struct {
 		name                  string
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package client
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// maxLabelValuesBloomFilterHashCount is the maximum number of hash functions of a LabelValuesBloomFilter.
+	maxLabelValuesBloomFilterHashCount = 32
+	// maxLabelValuesBloomFilterBytes is the maximum size of the bits of a LabelValuesBloomFilter.
+	maxLabelValuesBloomFilterBytes = 16 * 1024 * 1024
+)
+
+// NewLabelValuesBloomFilter returns an empty bloom filter sized to hold the expected number of values
+// with the given false positive rate.
+func NewLabelValuesBloomFilter(expectedValues int, falsePositiveRate float64) *LabelValuesBloomFilter {
+	if expectedValues < 1 {
+		expectedValues = 1
+	}
+	numBits := math.Ceil(-float64(expectedValues) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	numBytes := int(math.Ceil(numBits / 8))
+	if numBytes < 1 {
+		numBytes = 1
+	} else if numBytes > maxLabelValuesBloomFilterBytes {
+		numBytes = maxLabelValuesBloomFilterBytes
+	}
+	hashCount := int(math.Round(float64(numBytes*8) / float64(expectedValues) * math.Ln2))
+	if hashCount < 1 {
+		hashCount = 1
+	} else if hashCount > maxLabelValuesBloomFilterHashCount {
+		hashCount = maxLabelValuesBloomFilterHashCount
+	}
+	return &LabelValuesBloomFilter{Bits: make([]byte, numBytes), HashCount: uint32(hashCount)}
+}
+
+// Validate returns an error if the filter can't be used, for example because it has been received from a client.
+func (m *LabelValuesBloomFilter) Validate() error {
+	if len(m.Bits) == 0 || len(m.Bits) > maxLabelValuesBloomFilterBytes {
+		return fmt.Errorf("the bloom filter size must be between 1 and %d bytes, got %d", maxLabelValuesBloomFilterBytes, len(m.Bits))
+	}
+	if m.HashCount == 0 || m.HashCount > maxLabelValuesBloomFilterHashCount {
+		return fmt.Errorf("the bloom filter hash count must be between 1 and %d, got %d", maxLabelValuesBloomFilterHashCount, m.HashCount)
+	}
+	return nil
+}
+
+// Add adds the value to the filter.
+func (m *LabelValuesBloomFilter) Add(value string) {
+	m.forEachBit(value, func(byteIdx int, mask byte) bool {
+		m.Bits[byteIdx] |= mask
+		return true
+	})
+}
+
+// MayContain returns false if the value has certainly not been added to the filter,
+// and true if it may have been added.
+func (m *LabelValuesBloomFilter) MayContain(value string) bool {
+	contains := true
+	m.forEachBit(value, func(byteIdx int, mask byte) bool {
+		contains = m.Bits[byteIdx]&mask != 0
+		return contains
+	})
+	return contains
+}
+
+// forEachBit calls f with the position of each of the bits of the value, until f returns false.
+// The positions are computed with double hashing over the two halves of the fnv64a hash of the value,
+// mixed with the murmur3 finalizer because fnv64a doesn't spread similar values well enough.
+func (m *LabelValuesBloomFilter) forEachBit(value string, f func(byteIdx int, mask byte) bool) {
+	h := hashAddString(hashNew(), value)
+	h ^= h >> 33
+	h *= 0xff51afd7ed558ccd
+	h ^= h >> 33
+	h *= 0xc4ceb9fe1a85ec53
+	h ^= h >> 33
+	h1, h2 := h&math.MaxUint32, h>>32|1
+	numBits := uint64(len(m.Bits)) * 8
+	for i := uint64(0); i < uint64(m.HashCount); i++ {
+		bit := (h1 + i*h2) % numBits
+		if !f(int(bit/8), 1<<(bit%8)) {
+			return
+		}
+	}
+}
@@ -5,6 +5,15 @@
 
 package client
 
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"sort"
+)
+
+// crc32IEEETable is the table of the CRC32 (IEEE) checksums of the label values cardinality responses.
+var crc32IEEETable = crc32.MakeTable(crc32.IEEE)
+
 // ChunksCount returns the number of chunks in response.
 func (m *QueryStreamResponse) ChunksCount() int {
 	if len(m.Chunkseries) == 0 {
@@ -32,3 +41,64 @@ func (m *QueryStreamResponse) ChunksSize() int {
 	}
 	return size
 }
+
+// ComputeItemsChecksum returns the CRC32 (IEEE) of the items in the response. The checksum is computed
+// over a canonical encoding of the items, where label values are sorted, so that it doesn't depend
+// on the order in which the protobuf maps are serialized.
+func (m *LabelValuesCardinalityResponse) ComputeItemsChecksum() uint32 {
+	itemsChecksum, _, _ := m.ComputeChainedItemsChecksums(0)
+	return itemsChecksum
+}
+
+// ComputeChainedItemsChecksums returns the CRC32 (IEEE) of the items in the response, like ComputeItemsChecksum(),
+// the CRC32 (IEEE) of the items chained from the running checksum of the previous messages, and the size in bytes of
+// the canonical encoding of the items. The running checksum of a stream is the CRC32 of the concatenated encoding of
+// the items of all its messages, chained from 0 for the first message.
+func (m *LabelValuesCardinalityResponse) ComputeChainedItemsChecksums(prevRunningChecksum uint32) (itemsChecksum, runningChecksum uint32, size uint64) {
+	runningChecksum = prevRunningChecksum
+	buf := make([]byte, binary.MaxVarintLen64)
+
+	write := func(b []byte) {
+		itemsChecksum = crc32.Update(itemsChecksum, crc32IEEETable, b)
+		runningChecksum = crc32.Update(runningChecksum, crc32IEEETable, b)
+		size += uint64(len(b))
+	}
+	writeUvarint := func(v uint64) {
+		n := binary.PutUvarint(buf, v)
+		write(buf[:n])
+	}
+	writeString := func(s string) {
+		writeUvarint(uint64(len(s)))
+		write([]byte(s))
+	}
+
+	for _, item := range m.Items {
+		writeString(item.LabelName)
+		writeUvarint(uint64(len(item.LabelValueSeries)))
+		for _, value := range sortedLabelValues(item.LabelValueSeries) {
+			writeString(value)
+			writeUvarint(item.LabelValueSeries[value])
+
+			metricNames := item.LabelValueMetricNamesSeries[value]
+			if metricNames == nil {
+				writeUvarint(0)
+				continue
+			}
+			writeUvarint(uint64(len(metricNames.Items)))
+			for _, metricName := range metricNames.Items {
+				writeString(metricName.MetricName)
+				writeUvarint(metricName.SeriesCount)
+			}
+		}
+	}
+	return itemsChecksum, runningChecksum, size
+}
+
+func sortedLabelValues(m map[string]uint64) []string {
+	values := make([]string, 0, len(m))
+	for v := range m {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -37,6 +37,57 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 
+type LabelValuePresence int32
+
+const (
+	PRESENT_IN_HEAD_AND_BLOCKS LabelValuePresence = 0
+	PRESENT_IN_HEAD_ONLY       LabelValuePresence = 1
+	PRESENT_IN_BLOCKS_ONLY     LabelValuePresence = 2
+)
+
+var LabelValuePresence_name = map[int32]string{
+	0: "PRESENT_IN_HEAD_AND_BLOCKS",
+	1: "PRESENT_IN_HEAD_ONLY",
+	2: "PRESENT_IN_BLOCKS_ONLY",
+}
+
+var LabelValuePresence_value = map[string]int32{
+	"PRESENT_IN_HEAD_AND_BLOCKS": 0,
+	"PRESENT_IN_HEAD_ONLY":       1,
+	"PRESENT_IN_BLOCKS_ONLY":     2,
+}
+
+func (LabelValuePresence) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{0}
+}
+
+type LabelValueRelabelOutcome int32
+
+const (
+	// The value survives the relabeling unchanged.
+	RELABEL_KEPT LabelValueRelabelOutcome = 0
+	// The series with the value are dropped by the relabeling.
+	RELABEL_DROPPED LabelValueRelabelOutcome = 1
+	// The value is replaced, or the label is removed, by the relabeling.
+	RELABEL_REWRITTEN LabelValueRelabelOutcome = 2
+)
+
+var LabelValueRelabelOutcome_name = map[int32]string{
+	0: "RELABEL_KEPT",
+	1: "RELABEL_DROPPED",
+	2: "RELABEL_REWRITTEN",
+}
+
+var LabelValueRelabelOutcome_value = map[string]int32{
+	"RELABEL_KEPT":      0,
+	"RELABEL_DROPPED":   1,
+	"RELABEL_REWRITTEN": 2,
+}
+
+func (LabelValueRelabelOutcome) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{1}
+}
+
 type MatchType int32
 
 const (
@@ -61,7 +112,7 @@ var MatchType_value = map[string]int32{
 }
 
 func (MatchType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{0}
+	return fileDescriptor_60f6df4f3586b478, []int{2}
 }
 
 type ReadRequest_ResponseType int32
@@ -82,7 +133,7 @@ var ReadRequest_ResponseType_value = map[string]int32{
 }
 
 func (ReadRequest_ResponseType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{6, 0}
+	return fileDescriptor_60f6df4f3586b478, []int{17, 0}
 }
 
 type StreamChunk_Encoding int32
@@ -103,11 +154,80 @@ var StreamChunk_Encoding_value = map[string]int32{
 }
 
 func (StreamChunk_Encoding) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{10, 0}
+	return fileDescriptor_60f6df4f3586b478, []int{21, 0}
 }
 
 type LabelNamesAndValuesRequest struct {
 	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
+	// If true, the total number of series matching the matchers is returned in the last message.
+	IncludeSeriesCount bool `protobuf:"varint,2,opt,name=include_series_count,json=includeSeriesCount,proto3" json:"include_series_count,omitempty"`
+	// Fields to return: "names" to only return the label names, or "names" and "values" to also return
+	// the label values. If empty, both the label names and values are returned.
+	Fields []string `protobuf:"bytes,3,rep,name=fields,proto3" json:"fields,omitempty"`
+	// If greater than 0, the label values longer than this number of bytes are reported in long_values.
+	LongValueLengthThreshold uint32 `protobuf:"varint,4,opt,name=long_value_length_threshold,json=longValueLengthThreshold,proto3" json:"long_value_length_threshold,omitempty"`
+	// If true, the labels are partitioned by the first character of their name, so that clients can dispatch
+	// the partitions to different workers. Each message only carries labels of the partition set in partition_key.
+	PartitionByFirstCharacter bool `protobuf:"varint,5,opt,name=partition_by_first_character,json=partitionByFirstCharacter,proto3" json:"partition_by_first_character,omitempty"`
+	// If true, the labels and values of both the in-memory head and the persisted blocks are returned,
+	// and each value is flagged with where it's present.
+	IncludePresence bool `protobuf:"varint,6,opt,name=include_presence,json=includePresence,proto3" json:"include_presence,omitempty"`
+	// If set, only the label values which may be in the bloom filter are returned. Clients must verify the
+	// returned values, because they include the false positives of the filter.
+	ValuesBloomFilter *LabelValuesBloomFilter `protobuf:"bytes,7,opt,name=values_bloom_filter,json=valuesBloomFilter,proto3" json:"values_bloom_filter,omitempty"`
+	// If greater than 0, only the labels with at most this number of distinct values are returned,
+	// for example to find the labels suitable for grouping.
+	MaxDistinctValues uint32 `protobuf:"varint,8,opt,name=max_distinct_values,json=maxDistinctValues,proto3" json:"max_distinct_values,omitempty"`
+	// If true, the first messages carry a dictionary of the symbols of the index, and the label values
+	// are returned as IDs in the dictionary in value_ids instead of values. It can't be used with include_presence
+	// or include_blocks.
+	UseValueIds bool `protobuf:"varint,9,opt,name=use_value_ids,json=useValueIds,proto3" json:"use_value_ids,omitempty"`
+	// If set, a checkpoint is persisted in the object store after each message sent, and a request with the same
+	// token resumes from the last checkpoint, even after the ingester restarted. It must be 1 to 128 letters, digits,
+	// underscores or dashes, and unique per request. Once the request has completed, a request with the same token
+	// returns nothing.
+	CheckpointToken string `protobuf:"bytes,10,opt,name=checkpoint_token,json=checkpointToken,proto3" json:"checkpoint_token,omitempty"`
+	// If greater than 0, each label name is sent with a preview of up to values_preview_size of its values, and the
+	// remaining values of all the labels are sent after the previews, in other items with the same label name.
+	// It can't be used with partition_by_first_character or checkpoint_token.
+	ValuesPreviewSize uint32 `protobuf:"varint,11,opt,name=values_preview_size,json=valuesPreviewSize,proto3" json:"values_preview_size,omitempty"`
+	// If not empty, the values of each label are compressed with DEFLATE using this preset dictionary, and returned
+	// in compressed_values instead of values. A dictionary trained on the common label values, for example with
+	// NewLabelValuesCompressionDictionary, compresses repetitive values much better than generic compression.
+	// It must be at most 32KiB, and it can't be used with use_value_ids.
+	ValuesCompressionDictionary []byte `protobuf:"bytes,12,opt,name=values_compression_dictionary,json=valuesCompressionDictionary,proto3" json:"values_compression_dictionary,omitempty"`
+	// If true, each label name is returned with the number of its distinct values in value_count. Combined with
+	// fields set to "names", it returns the cardinality of each label, which is much cheaper than returning the values.
+	IncludeValueCount bool `protobuf:"varint,13,opt,name=include_value_count,json=includeValueCount,proto3" json:"include_value_count,omitempty"`
+	// If greater than 0, at most this number of label values are returned across the whole response. Once the cap is
+	// reached, the remaining labels and values are not returned, and the last message has truncated set. The ingester
+	// may enforce a lower cap.
+	MaxValues uint32 `protobuf:"varint,14,opt,name=max_values,json=maxValues,proto3" json:"max_values,omitempty"`
+	// If true, the labels and values of the persisted blocks are merged with the ones of the in-memory head, and the
+	// deduplicated result is returned as if it was looked up from a single index, so that clients don't need to merge
+	// them. It's implied by include_presence, which additionally flags each value with where it's present.
+	IncludeBlocks bool `protobuf:"varint,15,opt,name=include_blocks,json=includeBlocks,proto3" json:"include_blocks,omitempty"`
+	// If true, the duplicate values of each label returned by the index are dropped, keeping the first occurrence
+	// of each value, so that they don't inflate the response.
+	DedupValues bool `protobuf:"varint,16,opt,name=dedup_values,json=dedupValues,proto3" json:"dedup_values,omitempty"`
+	// If true, each value is returned with the outcome of the metric relabel configs of the tenant, to audit which
+	// values they drop or rewrite. The rules are applied to a series made of the label alone, so the rules relying
+	// on other labels are applied as if these labels were missing.
+	IncludeRelabelOutcomes bool `protobuf:"varint,17,opt,name=include_relabel_outcomes,json=includeRelabelOutcomes,proto3" json:"include_relabel_outcomes,omitempty"`
+	// If shard_count is greater than 0, only the series whose hash modulo shard_count equals shard_index are
+	// considered, so that sharded queriers can split the label names and values across requests. The labels of the
+	// series of the shard are read from the series, which is more expensive than reading them from the index.
+	// It can't be used with include_presence or include_blocks.
+	ShardIndex uint64 `protobuf:"varint,18,opt,name=shard_index,json=shardIndex,proto3" json:"shard_index,omitempty"`
+	ShardCount uint64 `protobuf:"varint,19,opt,name=shard_count,json=shardCount,proto3" json:"shard_count,omitempty"`
+	// If not empty, the values of each label are sorted with the collation of this BCP 47 language tag, for example
+	// "sv" or "de-u-co-phonebk", instead of byte order, before they're split across messages.
+	ValuesCollation string `protobuf:"bytes,20,opt,name=values_collation,json=valuesCollation,proto3" json:"values_collation,omitempty"`
+	// If true, the label names and values are uploaded to the object store of the tenant as a single gzip-compressed
+	// JSON object instead of being streamed, and the response is a single message carrying the name of the object in
+	// export_object_name. It can't be used with use_value_ids, values_compression_dictionary, include_presence,
+	// include_relabel_outcomes, values_preview_size or checkpoint_token.
+	Export bool `protobuf:"varint,21,opt,name=export,proto3" json:"export,omitempty"`
 }
 
 func (m *LabelNamesAndValuesRequest) Reset()      { *m = LabelNamesAndValuesRequest{} }
@@ -149,14 +269,231 @@ func (m *LabelNamesAndValuesRequest) GetMatchers() []*LabelMatcher {
 	return nil
 }
 
+func (m *LabelNamesAndValuesRequest) GetIncludeSeriesCount() bool {
+	if m != nil {
+		return m.IncludeSeriesCount
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetFields() []string {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *LabelNamesAndValuesRequest) GetLongValueLengthThreshold() uint32 {
+	if m != nil {
+		return m.LongValueLengthThreshold
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesRequest) GetPartitionByFirstCharacter() bool {
+	if m != nil {
+		return m.PartitionByFirstCharacter
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetIncludePresence() bool {
+	if m != nil {
+		return m.IncludePresence
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetValuesBloomFilter() *LabelValuesBloomFilter {
+	if m != nil {
+		return m.ValuesBloomFilter
+	}
+	return nil
+}
+
+func (m *LabelNamesAndValuesRequest) GetMaxDistinctValues() uint32 {
+	if m != nil {
+		return m.MaxDistinctValues
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesRequest) GetUseValueIds() bool {
+	if m != nil {
+		return m.UseValueIds
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetCheckpointToken() string {
+	if m != nil {
+		return m.CheckpointToken
+	}
+	return ""
+}
+
+func (m *LabelNamesAndValuesRequest) GetValuesPreviewSize() uint32 {
+	if m != nil {
+		return m.ValuesPreviewSize
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesRequest) GetValuesCompressionDictionary() []byte {
+	if m != nil {
+		return m.ValuesCompressionDictionary
+	}
+	return nil
+}
+
+func (m *LabelNamesAndValuesRequest) GetIncludeValueCount() bool {
+	if m != nil {
+		return m.IncludeValueCount
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetMaxValues() uint32 {
+	if m != nil {
+		return m.MaxValues
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesRequest) GetIncludeBlocks() bool {
+	if m != nil {
+		return m.IncludeBlocks
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetDedupValues() bool {
+	if m != nil {
+		return m.DedupValues
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetIncludeRelabelOutcomes() bool {
+	if m != nil {
+		return m.IncludeRelabelOutcomes
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesRequest) GetShardIndex() uint64 {
+	if m != nil {
+		return m.ShardIndex
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesRequest) GetShardCount() uint64 {
+	if m != nil {
+		return m.ShardCount
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesRequest) GetValuesCollation() string {
+	if m != nil {
+		return m.ValuesCollation
+	}
+	return ""
+}
+
+func (m *LabelNamesAndValuesRequest) GetExport() bool {
+	if m != nil {
+		return m.Export
+	}
+	return false
+}
+
+// LabelValuesBloomFilter is a bloom filter of label values, built with NewLabelValuesBloomFilter.
+type LabelValuesBloomFilter struct {
+	Bits      []byte `protobuf:"bytes,1,opt,name=bits,proto3" json:"bits,omitempty"`
+	HashCount uint32 `protobuf:"varint,2,opt,name=hash_count,json=hashCount,proto3" json:"hash_count,omitempty"`
+}
+
+func (m *LabelValuesBloomFilter) Reset()      { *m = LabelValuesBloomFilter{} }
+func (*LabelValuesBloomFilter) ProtoMessage() {}
+func (*LabelValuesBloomFilter) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{1}
+}
+func (m *LabelValuesBloomFilter) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelValuesBloomFilter) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelValuesBloomFilter.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelValuesBloomFilter) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesBloomFilter.Merge(m, src)
+}
+func (m *LabelValuesBloomFilter) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelValuesBloomFilter) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesBloomFilter.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelValuesBloomFilter proto.InternalMessageInfo
+
+func (m *LabelValuesBloomFilter) GetBits() []byte {
+	if m != nil {
+		return m.Bits
+	}
+	return nil
+}
+
+func (m *LabelValuesBloomFilter) GetHashCount() uint32 {
+	if m != nil {
+		return m.HashCount
+	}
+	return 0
+}
+
 type LabelNamesAndValuesResponse struct {
 	Items []*LabelValues `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Total number of series matching the matchers. It's only set in the last message,
+	// when the request has include_series_count set.
+	SeriesCount uint64 `protobuf:"varint,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
+	// Report of the labels having values longer than the requested threshold. The report of a label
+	// is sent in the same message as its last values, or in one of the following messages.
+	LongValues []*LongLabelValues `protobuf:"bytes,3,rep,name=long_values,json=longValues,proto3" json:"long_values,omitempty"`
+	// First character of the names of the labels in the message. It's only populated when the request
+	// has partition_by_first_character set. A partition can span multiple messages.
+	PartitionKey string `protobuf:"bytes,4,opt,name=partition_key,json=partitionKey,proto3" json:"partition_key,omitempty"`
+	// Symbols of the dictionary of the label values. It's only populated in the first messages, before any
+	// item, when the request has use_value_ids set. The ID of a symbol is its position in the concatenation
+	// of the dictionary of all the messages.
+	Dictionary []string `protobuf:"bytes,5,rep,name=dictionary,proto3" json:"dictionary,omitempty"`
+	// ID of the dictionary the values have been compressed with, as computed by LabelValuesCompressionDictionaryID.
+	// It's only populated when the request has values_compression_dictionary set.
+	ValuesCompressionDictionaryId uint32 `protobuf:"varint,6,opt,name=values_compression_dictionary_id,json=valuesCompressionDictionaryId,proto3" json:"values_compression_dictionary_id,omitempty"`
+	// True if the response has been truncated because it reached the maximum number of label values, so that some
+	// labels or values are missing. It's only set in the last message.
+	Truncated bool `protobuf:"varint,7,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// True if only the first label names, in lexicographic order, have been returned because the matching label names
+	// exceeded the maximum the ingester is configured to look up the values of. It's only set in the last message.
+	LabelNamesTruncated bool `protobuf:"varint,8,opt,name=label_names_truncated,json=labelNamesTruncated,proto3" json:"label_names_truncated,omitempty"`
+	// Name of the object of the object store of the tenant holding the label names and values. It's only populated,
+	// in the only message of the response, when the request has export set.
+	ExportObjectName string `protobuf:"bytes,9,opt,name=export_object_name,json=exportObjectName,proto3" json:"export_object_name,omitempty"`
 }
 
 func (m *LabelNamesAndValuesResponse) Reset()      { *m = LabelNamesAndValuesResponse{} }
 func (*LabelNamesAndValuesResponse) ProtoMessage() {}
 func (*LabelNamesAndValuesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{1}
+	return fileDescriptor_60f6df4f3586b478, []int{2}
 }
 func (m *LabelNamesAndValuesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -192,15 +529,86 @@ func (m *LabelNamesAndValuesResponse) GetItems() []*LabelValues {
 	return nil
 }
 
+func (m *LabelNamesAndValuesResponse) GetSeriesCount() uint64 {
+	if m != nil {
+		return m.SeriesCount
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesResponse) GetLongValues() []*LongLabelValues {
+	if m != nil {
+		return m.LongValues
+	}
+	return nil
+}
+
+func (m *LabelNamesAndValuesResponse) GetPartitionKey() string {
+	if m != nil {
+		return m.PartitionKey
+	}
+	return ""
+}
+
+func (m *LabelNamesAndValuesResponse) GetDictionary() []string {
+	if m != nil {
+		return m.Dictionary
+	}
+	return nil
+}
+
+func (m *LabelNamesAndValuesResponse) GetValuesCompressionDictionaryId() uint32 {
+	if m != nil {
+		return m.ValuesCompressionDictionaryId
+	}
+	return 0
+}
+
+func (m *LabelNamesAndValuesResponse) GetTruncated() bool {
+	if m != nil {
+		return m.Truncated
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesResponse) GetLabelNamesTruncated() bool {
+	if m != nil {
+		return m.LabelNamesTruncated
+	}
+	return false
+}
+
+func (m *LabelNamesAndValuesResponse) GetExportObjectName() string {
+	if m != nil {
+		return m.ExportObjectName
+	}
+	return ""
+}
+
 type LabelValues struct {
 	LabelName string   `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
 	Values    []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// Where each value is present, in the same order as values.
+	// It's only populated when the request has include_presence set.
+	Presence []LabelValuePresence `protobuf:"varint,3,rep,packed,name=presence,proto3,enum=cortex.LabelValuePresence" json:"presence,omitempty"`
+	// IDs of the values in the dictionary. It's only populated, instead of values, when the request
+	// has use_value_ids set.
+	ValueIds []uint32 `protobuf:"varint,4,rep,packed,name=value_ids,json=valueIds,proto3" json:"value_ids,omitempty"`
+	// Values compressed with the dictionary, to decompress with DecompressLabelValues. It's only populated,
+	// instead of values, when the request has values_compression_dictionary set.
+	CompressedValues []byte `protobuf:"bytes,5,opt,name=compressed_values,json=compressedValues,proto3" json:"compressed_values,omitempty"`
+	// Number of distinct values of the label. It's only populated when the request has include_value_count set,
+	// and it's set in the first item of the label.
+	ValueCount uint64 `protobuf:"varint,6,opt,name=value_count,json=valueCount,proto3" json:"value_count,omitempty"`
+	// Outcome of the metric relabel configs of the tenant for each value, in the same order as values.
+	// It's only populated when the request has include_relabel_outcomes set.
+	RelabelOutcomes []LabelValueRelabelOutcome `protobuf:"varint,7,rep,packed,name=relabel_outcomes,json=relabelOutcomes,proto3,enum=cortex.LabelValueRelabelOutcome" json:"relabel_outcomes,omitempty"`
 }
 
 func (m *LabelValues) Reset()      { *m = LabelValues{} }
 func (*LabelValues) ProtoMessage() {}
 func (*LabelValues) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{2}
+	return fileDescriptor_60f6df4f3586b478, []int{3}
 }
 func (m *LabelValues) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -243,15 +651,212 @@ func (m *LabelValues) GetValues() []string {
 	return nil
 }
 
+func (m *LabelValues) GetPresence() []LabelValuePresence {
+	if m != nil {
+		return m.Presence
+	}
+	return nil
+}
+
+func (m *LabelValues) GetValueIds() []uint32 {
+	if m != nil {
+		return m.ValueIds
+	}
+	return nil
+}
+
+func (m *LabelValues) GetCompressedValues() []byte {
+	if m != nil {
+		return m.CompressedValues
+	}
+	return nil
+}
+
+func (m *LabelValues) GetValueCount() uint64 {
+	if m != nil {
+		return m.ValueCount
+	}
+	return 0
+}
+
+func (m *LabelValues) GetRelabelOutcomes() []LabelValueRelabelOutcome {
+	if m != nil {
+		return m.RelabelOutcomes
+	}
+	return nil
+}
+
+type LongLabelValues struct {
+	LabelName string `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	// Number of values of the label longer than the requested threshold.
+	Count uint64 `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Up to a few of the values longer than the requested threshold.
+	Exemplars []string `protobuf:"bytes,3,rep,name=exemplars,proto3" json:"exemplars,omitempty"`
+}
+
+func (m *LongLabelValues) Reset()      { *m = LongLabelValues{} }
+func (*LongLabelValues) ProtoMessage() {}
+func (*LongLabelValues) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{4}
+}
+func (m *LongLabelValues) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LongLabelValues) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LongLabelValues.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LongLabelValues) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LongLabelValues.Merge(m, src)
+}
+func (m *LongLabelValues) XXX_Size() int {
+	return m.Size()
+}
+func (m *LongLabelValues) XXX_DiscardUnknown() {
+	xxx_messageInfo_LongLabelValues.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LongLabelValues proto.InternalMessageInfo
+
+func (m *LongLabelValues) GetLabelName() string {
+	if m != nil {
+		return m.LabelName
+	}
+	return ""
+}
+
+func (m *LongLabelValues) GetCount() uint64 {
+	if m != nil {
+		return m.Count
+	}
+	return 0
+}
+
+func (m *LongLabelValues) GetExemplars() []string {
+	if m != nil {
+		return m.Exemplars
+	}
+	return nil
+}
+
 type LabelValuesCardinalityRequest struct {
-	LabelNames []string        `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
-	Matchers   []*LabelMatcher `protobuf:"bytes,2,rep,name=matchers,proto3" json:"matchers,omitempty"`
+	LabelNames []string `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
+	// The series must match all the matchers, including when several matchers are on the same label name.
+	Matchers []*LabelMatcher `protobuf:"bytes,2,rep,name=matchers,proto3" json:"matchers,omitempty"`
+	// If true, the series count of each label value is also broken down by metric name.
+	GroupByMetricName bool `protobuf:"varint,3,opt,name=group_by_metric_name,json=groupByMetricName,proto3" json:"group_by_metric_name,omitempty"`
+	// If true, each response message carries a sequence number and a checksum of its items.
+	IncludeChecksums bool `protobuf:"varint,4,opt,name=include_checksums,json=includeChecksums,proto3" json:"include_checksums,omitempty"`
+	// If shard_count is greater than 0, only the label values whose hash modulo shard_count
+	// equals shard_index are counted, so that the work can be split across multiple requests.
+	ShardIndex uint64 `protobuf:"varint,5,opt,name=shard_index,json=shardIndex,proto3" json:"shard_index,omitempty"`
+	ShardCount uint64 `protobuf:"varint,6,opt,name=shard_count,json=shardCount,proto3" json:"shard_count,omitempty"`
+	// If true, the number of distinct series of each label is estimated with a HyperLogLog sketch,
+	// using bounded memory.
+	EstimateLabelSeries bool `protobuf:"varint,7,opt,name=estimate_label_series,json=estimateLabelSeries,proto3" json:"estimate_label_series,omitempty"`
+	// If true, the number of chunks of the series of each label value is also returned.
+	// It's more expensive, because it requires reading the chunks metadata of each series.
+	IncludeChunkCount bool `protobuf:"varint,8,opt,name=include_chunk_count,json=includeChunkCount,proto3" json:"include_chunk_count,omitempty"`
+	// If true, the last message carries a breakdown of where the time has been spent, for debugging purposes.
+	Explain bool `protobuf:"varint,9,opt,name=explain,proto3" json:"explain,omitempty"`
+	// If greater than 0, progress messages are sent at this interval while the series are being counted.
+	ProgressIntervalMs int64 `protobuf:"varint,10,opt,name=progress_interval_ms,json=progressIntervalMs,proto3" json:"progress_interval_ms,omitempty"`
+	// If greater than 0, the label values with fewer series than this are omitted from the response.
+	MinSeriesCount uint64 `protobuf:"varint,11,opt,name=min_series_count,json=minSeriesCount,proto3" json:"min_series_count,omitempty"`
+	// If true, the cardinality of all the labels matching the matchers is returned. label_names must be empty.
+	AllLabels bool `protobuf:"varint,12,opt,name=all_labels,json=allLabels,proto3" json:"all_labels,omitempty"`
+	// If not empty, the label values are replaced in the response by their HMAC-SHA256 hash keyed with this salt,
+	// so that the distribution of the values can be inspected without exposing the raw values.
+	ValueHashSalt string `protobuf:"bytes,13,opt,name=value_hash_salt,json=valueHashSalt,proto3" json:"value_hash_salt,omitempty"`
+	// If true, the ratio of the series of each label value to the series of the label is also returned.
+	IncludeRatios bool `protobuf:"varint,14,opt,name=include_ratios,json=includeRatios,proto3" json:"include_ratios,omitempty"`
+	// If greater than 0, the series of at most sample_values values of each label are counted. The values are
+	// selected by their hash seeded with sample_seed, so that the same seed selects the same values across requests
+	// and ingesters. If sample_seed is 0, a random seed is used.
+	SampleValues uint32 `protobuf:"varint,15,opt,name=sample_values,json=sampleValues,proto3" json:"sample_values,omitempty"`
+	SampleSeed   int64  `protobuf:"varint,16,opt,name=sample_seed,json=sampleSeed,proto3" json:"sample_seed,omitempty"`
+	// If not empty, the label values are grouped by the first capture group of this regex, and the counts of the
+	// values of a group are aggregated under the captured key. The regex is anchored at both ends, and the values
+	// not matching it are grouped under "__unmatched__".
+	ValueGroupRegex string `protobuf:"bytes,17,opt,name=value_group_regex,json=valueGroupRegex,proto3" json:"value_group_regex,omitempty"`
+	// If greater than 0, the co_occurrence_top_k label values most frequently found in the series of each of the
+	// co_occurrence_top_k values with the most series of each label are also returned. It can't be used with
+	// value_group_regex.
+	CoOccurrenceTopK uint32 `protobuf:"varint,18,opt,name=co_occurrence_top_k,json=coOccurrenceTopK,proto3" json:"co_occurrence_top_k,omitempty"`
+	// If greater than 0, the series count of each label value is also broken down by metric name, like with
+	// group_by_metric_name, but only the metric_names_top_k metric names with the most series are returned.
+	MetricNamesTopK uint32 `protobuf:"varint,19,opt,name=metric_names_top_k,json=metricNamesTopK,proto3" json:"metric_names_top_k,omitempty"`
+	// If true, the labels are processed and returned in descending order of their series selected by the matchers,
+	// estimated from the postings lengths of their values restricted to the postings of the matchers, instead of the
+	// requested order. The labels with the same estimate keep their relative order.
+	OrderByLabelSeries bool `protobuf:"varint,20,opt,name=order_by_label_series,json=orderByLabelSeries,proto3" json:"order_by_label_series,omitempty"`
+	// If not empty, the approximate percentiles of the distribution of the series count of the values of each label
+	// are also returned. The percentiles are between 0 and 1, and they're computed with a streaming sketch, whose
+	// rank error is bounded to 0.1%, using bounded memory regardless of the number of values. At most 16 percentiles
+	// can be requested.
+	SeriesCountPercentiles []float64 `protobuf:"fixed64,21,rep,packed,name=series_count_percentiles,json=seriesCountPercentiles,proto3" json:"series_count_percentiles,omitempty"`
+	// If greater than 0, the number of series counted between two checks of whether the request has been cancelled,
+	// instead of the default of 1000. A lower interval cancels the request faster, at a small CPU cost.
+	ContextCheckIntervalSeries uint32 `protobuf:"varint,22,opt,name=context_check_interval_series,json=contextCheckIntervalSeries,proto3" json:"context_check_interval_series,omitempty"`
+	// If true, the values of each label are sent in sorted order, so that the messages of identical requests carry
+	// the same values and can be diffed or cached. The values are sorted per label, after their series are counted.
+	SortLabelValues bool `protobuf:"varint,23,opt,name=sort_label_values,json=sortLabelValues,proto3" json:"sort_label_values,omitempty"`
+	// If not 0, only the series having samples between start_timestamp_ms and end_timestamp_ms, according to the time
+	// range of their chunks, are counted, for example to only count the currently active series. An end of 0 is
+	// unbounded. If both are 0, all the series are counted.
+	StartTimestampMs int64 `protobuf:"varint,24,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64 `protobuf:"varint,25,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	// If true, the errors counting the series of single label values don't abort the request. The failed values are
+	// omitted from the response, and they're reported in the last message, so that the counts of the other values
+	// aren't lost because of a transient error.
+	BestEffort bool `protobuf:"varint,26,opt,name=best_effort,json=bestEffort,proto3" json:"best_effort,omitempty"`
+	// If true, the values of each label are partitioned by the order of magnitude of their series count, and each
+	// partition is sent in its own items tagged with series_count_magnitude, from the highest magnitude to the lowest,
+	// so that operators can focus on the values with the most series.
+	GroupByMagnitude bool `protobuf:"varint,27,opt,name=group_by_magnitude,json=groupByMagnitude,proto3" json:"group_by_magnitude,omitempty"`
+	// If true, the last message carries a summary of each label, with the number of its values and their total
+	// series, so that clients don't have to sum the counts of all the streamed items.
+	IncludeSummary bool `protobuf:"varint,28,opt,name=include_summary,json=includeSummary,proto3" json:"include_summary,omitempty"`
+	// If greater than 0, the response is resumed from this byte offset of the stream, as returned in the byte_offset
+	// of the first message which hasn't been received, and the messages before it aren't sent. resume_checksum must be
+	// the running_checksum of the last message which has been received, so that the continuity of the stream can be
+	// verified. It requires include_checksums. The request fails with the Aborted code if the stream has changed since
+	// the messages have been received, for example because series have been added, or because the offset isn't the
+	// start of a message. The label values should be sorted with sort_label_values for the stream to be reproducible.
+	ResumeOffset   uint64 `protobuf:"varint,29,opt,name=resume_offset,json=resumeOffset,proto3" json:"resume_offset,omitempty"`
+	ResumeChecksum uint32 `protobuf:"varint,30,opt,name=resume_checksum,json=resumeChecksum,proto3" json:"resume_checksum,omitempty"`
+	// If compare_end_timestamp_ms is not 0, the series counts of the time window between compare_start_timestamp_ms and
+	// compare_end_timestamp_ms are compared with the ones of the time window between start_timestamp_ms and
+	// end_timestamp_ms, and the change of the series count of each label value is returned in label_value_series_delta
+	// instead of its series count. The label values whose series count didn't change are omitted. It can't be used with
+	// include_checksums, explain, progress_interval_ms, include_summary or best_effort.
+	CompareStartTimestampMs int64 `protobuf:"varint,32,opt,name=compare_start_timestamp_ms,json=compareStartTimestampMs,proto3" json:"compare_start_timestamp_ms,omitempty"`
+	CompareEndTimestampMs   int64 `protobuf:"varint,33,opt,name=compare_end_timestamp_ms,json=compareEndTimestampMs,proto3" json:"compare_end_timestamp_ms,omitempty"`
+	// If true, the change of the series count of each label value between the compared time windows is returned as a
+	// growth rate in label_value_series_growth_rate instead of label_value_series_delta, in series per second over the
+	// duration between the ends of the windows. end_timestamp_ms must be after compare_end_timestamp_ms.
+	CompareGrowthRate bool `protobuf:"varint,34,opt,name=compare_growth_rate,json=compareGrowthRate,proto3" json:"compare_growth_rate,omitempty"`
+	// If true, the series of the labels whose series haven't changed since the previous run of the request aren't
+	// counted again, and their series counts are taken from changed_labels_state, which must be the state returned
+	// by the previous run, or empty for the first run. The last message carries the state to send in the next run.
+	// It can't be used with group_by_metric_name, metric_names_top_k, include_chunk_count, estimate_label_series,
+	// best_effort, start_timestamp_ms or end_timestamp_ms.
+	ChangedLabelsOnly  bool   `protobuf:"varint,35,opt,name=changed_labels_only,json=changedLabelsOnly,proto3" json:"changed_labels_only,omitempty"`
+	ChangedLabelsState []byte `protobuf:"bytes,36,opt,name=changed_labels_state,json=changedLabelsState,proto3" json:"changed_labels_state,omitempty"`
 }
 
 func (m *LabelValuesCardinalityRequest) Reset()      { *m = LabelValuesCardinalityRequest{} }
 func (*LabelValuesCardinalityRequest) ProtoMessage() {}
 func (*LabelValuesCardinalityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{3}
+	return fileDescriptor_60f6df4f3586b478, []int{5}
 }
 func (m *LabelValuesCardinalityRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -294,166 +899,264 @@ func (m *LabelValuesCardinalityRequest) GetMatchers() []*LabelMatcher {
 	return nil
 }
 
-type LabelValuesCardinalityResponse struct {
-	Items []*LabelValueSeriesCount `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+func (m *LabelValuesCardinalityRequest) GetGroupByMetricName() bool {
+	if m != nil {
+		return m.GroupByMetricName
+	}
+	return false
 }
 
-func (m *LabelValuesCardinalityResponse) Reset()      { *m = LabelValuesCardinalityResponse{} }
-func (*LabelValuesCardinalityResponse) ProtoMessage() {}
-func (*LabelValuesCardinalityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{4}
+func (m *LabelValuesCardinalityRequest) GetIncludeChecksums() bool {
+	if m != nil {
+		return m.IncludeChecksums
+	}
+	return false
 }
-func (m *LabelValuesCardinalityResponse) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+
+func (m *LabelValuesCardinalityRequest) GetShardIndex() uint64 {
+	if m != nil {
+		return m.ShardIndex
+	}
+	return 0
 }
-func (m *LabelValuesCardinalityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_LabelValuesCardinalityResponse.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+
+func (m *LabelValuesCardinalityRequest) GetShardCount() uint64 {
+	if m != nil {
+		return m.ShardCount
 	}
+	return 0
 }
-func (m *LabelValuesCardinalityResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelValuesCardinalityResponse.Merge(m, src)
+
+func (m *LabelValuesCardinalityRequest) GetEstimateLabelSeries() bool {
+	if m != nil {
+		return m.EstimateLabelSeries
+	}
+	return false
 }
-func (m *LabelValuesCardinalityResponse) XXX_Size() int {
-	return m.Size()
+
+func (m *LabelValuesCardinalityRequest) GetIncludeChunkCount() bool {
+	if m != nil {
+		return m.IncludeChunkCount
+	}
+	return false
 }
-func (m *LabelValuesCardinalityResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelValuesCardinalityResponse.DiscardUnknown(m)
+
+func (m *LabelValuesCardinalityRequest) GetExplain() bool {
+	if m != nil {
+		return m.Explain
+	}
+	return false
 }
 
-var xxx_messageInfo_LabelValuesCardinalityResponse proto.InternalMessageInfo
+func (m *LabelValuesCardinalityRequest) GetProgressIntervalMs() int64 {
+	if m != nil {
+		return m.ProgressIntervalMs
+	}
+	return 0
+}
 
-func (m *LabelValuesCardinalityResponse) GetItems() []*LabelValueSeriesCount {
+func (m *LabelValuesCardinalityRequest) GetMinSeriesCount() uint64 {
 	if m != nil {
-		return m.Items
+		return m.MinSeriesCount
 	}
-	return nil
+	return 0
 }
 
-type LabelValueSeriesCount struct {
-	LabelName        string            `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
-	LabelValueSeries map[string]uint64 `protobuf:"bytes,2,rep,name=label_value_series,json=labelValueSeries,proto3" json:"label_value_series,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+func (m *LabelValuesCardinalityRequest) GetAllLabels() bool {
+	if m != nil {
+		return m.AllLabels
+	}
+	return false
 }
 
-func (m *LabelValueSeriesCount) Reset()      { *m = LabelValueSeriesCount{} }
-func (*LabelValueSeriesCount) ProtoMessage() {}
-func (*LabelValueSeriesCount) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{5}
+func (m *LabelValuesCardinalityRequest) GetValueHashSalt() string {
+	if m != nil {
+		return m.ValueHashSalt
+	}
+	return ""
 }
-func (m *LabelValueSeriesCount) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+
+func (m *LabelValuesCardinalityRequest) GetIncludeRatios() bool {
+	if m != nil {
+		return m.IncludeRatios
+	}
+	return false
 }
-func (m *LabelValueSeriesCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_LabelValueSeriesCount.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+
+func (m *LabelValuesCardinalityRequest) GetSampleValues() uint32 {
+	if m != nil {
+		return m.SampleValues
 	}
+	return 0
 }
-func (m *LabelValueSeriesCount) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelValueSeriesCount.Merge(m, src)
+
+func (m *LabelValuesCardinalityRequest) GetSampleSeed() int64 {
+	if m != nil {
+		return m.SampleSeed
+	}
+	return 0
 }
-func (m *LabelValueSeriesCount) XXX_Size() int {
-	return m.Size()
+
+func (m *LabelValuesCardinalityRequest) GetValueGroupRegex() string {
+	if m != nil {
+		return m.ValueGroupRegex
+	}
+	return ""
 }
-func (m *LabelValueSeriesCount) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelValueSeriesCount.DiscardUnknown(m)
+
+func (m *LabelValuesCardinalityRequest) GetCoOccurrenceTopK() uint32 {
+	if m != nil {
+		return m.CoOccurrenceTopK
+	}
+	return 0
 }
 
-var xxx_messageInfo_LabelValueSeriesCount proto.InternalMessageInfo
+func (m *LabelValuesCardinalityRequest) GetMetricNamesTopK() uint32 {
+	if m != nil {
+		return m.MetricNamesTopK
+	}
+	return 0
+}
 
-func (m *LabelValueSeriesCount) GetLabelName() string {
+func (m *LabelValuesCardinalityRequest) GetOrderByLabelSeries() bool {
 	if m != nil {
-		return m.LabelName
+		return m.OrderByLabelSeries
 	}
-	return ""
+	return false
 }
 
-func (m *LabelValueSeriesCount) GetLabelValueSeries() map[string]uint64 {
+func (m *LabelValuesCardinalityRequest) GetSeriesCountPercentiles() []float64 {
 	if m != nil {
-		return m.LabelValueSeries
+		return m.SeriesCountPercentiles
 	}
 	return nil
 }
 
-type ReadRequest struct {
-	Queries               []*QueryRequest            `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
-	AcceptedResponseTypes []ReadRequest_ResponseType `protobuf:"varint,2,rep,packed,name=accepted_response_types,json=acceptedResponseTypes,proto3,enum=cortex.ReadRequest_ResponseType" json:"accepted_response_types,omitempty"`
+func (m *LabelValuesCardinalityRequest) GetContextCheckIntervalSeries() uint32 {
+	if m != nil {
+		return m.ContextCheckIntervalSeries
+	}
+	return 0
 }
 
-func (m *ReadRequest) Reset()      { *m = ReadRequest{} }
-func (*ReadRequest) ProtoMessage() {}
-func (*ReadRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{6}
+func (m *LabelValuesCardinalityRequest) GetSortLabelValues() bool {
+	if m != nil {
+		return m.SortLabelValues
+	}
+	return false
 }
-func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+
+func (m *LabelValuesCardinalityRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
+	}
+	return 0
 }
-func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+
+func (m *LabelValuesCardinalityRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
 	}
+	return 0
 }
-func (m *ReadRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadRequest.Merge(m, src)
+
+func (m *LabelValuesCardinalityRequest) GetBestEffort() bool {
+	if m != nil {
+		return m.BestEffort
+	}
+	return false
 }
-func (m *ReadRequest) XXX_Size() int {
-	return m.Size()
+
+func (m *LabelValuesCardinalityRequest) GetGroupByMagnitude() bool {
+	if m != nil {
+		return m.GroupByMagnitude
+	}
+	return false
 }
-func (m *ReadRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
+
+func (m *LabelValuesCardinalityRequest) GetIncludeSummary() bool {
+	if m != nil {
+		return m.IncludeSummary
+	}
+	return false
 }
 
-var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
+func (m *LabelValuesCardinalityRequest) GetResumeOffset() uint64 {
+	if m != nil {
+		return m.ResumeOffset
+	}
+	return 0
+}
 
-func (m *ReadRequest) GetQueries() []*QueryRequest {
+func (m *LabelValuesCardinalityRequest) GetResumeChecksum() uint32 {
 	if m != nil {
-		return m.Queries
+		return m.ResumeChecksum
 	}
-	return nil
+	return 0
 }
 
-func (m *ReadRequest) GetAcceptedResponseTypes() []ReadRequest_ResponseType {
+func (m *LabelValuesCardinalityRequest) GetCompareStartTimestampMs() int64 {
 	if m != nil {
-		return m.AcceptedResponseTypes
+		return m.CompareStartTimestampMs
 	}
-	return nil
+	return 0
 }
 
-type ReadResponse struct {
-	Results []*QueryResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+func (m *LabelValuesCardinalityRequest) GetCompareEndTimestampMs() int64 {
+	if m != nil {
+		return m.CompareEndTimestampMs
+	}
+	return 0
 }
 
-func (m *ReadResponse) Reset()      { *m = ReadResponse{} }
-func (*ReadResponse) ProtoMessage() {}
-func (*ReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{7}
+func (m *LabelValuesCardinalityRequest) GetCompareGrowthRate() bool {
+	if m != nil {
+		return m.CompareGrowthRate
+	}
+	return false
 }
-func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *LabelValuesCardinalityRequest) GetChangedLabelsOnly() bool {
+	if m != nil {
+		return m.ChangedLabelsOnly
+	}
+	return false
+}
+
+func (m *LabelValuesCardinalityRequest) GetChangedLabelsState() []byte {
+	if m != nil {
+		return m.ChangedLabelsState
+	}
+	return nil
+}
+
+type LabelValuesCardinalityStreamRequest struct {
+	// The request to run. It must be set in the first message, and it's ignored in the following ones.
+	Request *LabelValuesCardinalityRequest `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	// Set by the client to stop the request before it completes.
+	Stop bool `protobuf:"varint,2,opt,name=stop,proto3" json:"stop,omitempty"`
+	// If greater than 0, the request keeps running after the initial counts have been sent, and the changes
+	// of the series counts are sent as deltas at this interval, until the client stops the request.
+	// It must be set in the first message.
+	WatchIntervalMs int64 `protobuf:"varint,3,opt,name=watch_interval_ms,json=watchIntervalMs,proto3" json:"watch_interval_ms,omitempty"`
+	// Set by the client to pause the request: the server stops sending messages and counting series until
+	// the client resumes the request.
+	Pause bool `protobuf:"varint,4,opt,name=pause,proto3" json:"pause,omitempty"`
+	// Set by the client to resume a paused request.
+	Resume bool `protobuf:"varint,5,opt,name=resume,proto3" json:"resume,omitempty"`
+}
+
+func (m *LabelValuesCardinalityStreamRequest) Reset()      { *m = LabelValuesCardinalityStreamRequest{} }
+func (*LabelValuesCardinalityStreamRequest) ProtoMessage() {}
+func (*LabelValuesCardinalityStreamRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{6}
+}
+func (m *LabelValuesCardinalityStreamRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesCardinalityStreamRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesCardinalityStreamRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -463,41 +1166,112 @@ func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *ReadResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ReadResponse.Merge(m, src)
+func (m *LabelValuesCardinalityStreamRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesCardinalityStreamRequest.Merge(m, src)
 }
-func (m *ReadResponse) XXX_Size() int {
+func (m *LabelValuesCardinalityStreamRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *ReadResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
+func (m *LabelValuesCardinalityStreamRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesCardinalityStreamRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesCardinalityStreamRequest proto.InternalMessageInfo
 
-func (m *ReadResponse) GetResults() []*QueryResponse {
+func (m *LabelValuesCardinalityStreamRequest) GetRequest() *LabelValuesCardinalityRequest {
 	if m != nil {
-		return m.Results
+		return m.Request
 	}
 	return nil
 }
 
-type StreamReadResponse struct {
-	ChunkedSeries []*StreamChunkedSeries `protobuf:"bytes,1,rep,name=chunked_series,json=chunkedSeries,proto3" json:"chunked_series,omitempty"`
-	QueryIndex    int64                  `protobuf:"varint,2,opt,name=query_index,json=queryIndex,proto3" json:"query_index,omitempty"`
+func (m *LabelValuesCardinalityStreamRequest) GetStop() bool {
+	if m != nil {
+		return m.Stop
+	}
+	return false
 }
 
-func (m *StreamReadResponse) Reset()      { *m = StreamReadResponse{} }
-func (*StreamReadResponse) ProtoMessage() {}
-func (*StreamReadResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{8}
+func (m *LabelValuesCardinalityStreamRequest) GetWatchIntervalMs() int64 {
+	if m != nil {
+		return m.WatchIntervalMs
+	}
+	return 0
 }
-func (m *StreamReadResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *LabelValuesCardinalityStreamRequest) GetPause() bool {
+	if m != nil {
+		return m.Pause
+	}
+	return false
+}
+
+func (m *LabelValuesCardinalityStreamRequest) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
+}
+
+type LabelValuesCardinalityResponse struct {
+	Items []*LabelValueSeriesCount `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	// Set when the request has consumed most of the series it's allowed to count.
+	// Clients should consider narrowing down the request.
+	BudgetWarning bool `protobuf:"varint,2,opt,name=budget_warning,json=budgetWarning,proto3" json:"budget_warning,omitempty"`
+	// Position of the message in the stream, starting from 0.
+	// It's only populated when the request has include_checksums set.
+	SequenceNumber uint64 `protobuf:"varint,3,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	// CRC32 (IEEE) of the items, as computed by LabelValuesCardinalityResponse.ComputeItemsChecksum().
+	// It's only populated when the request has include_checksums set.
+	ItemsChecksum uint32 `protobuf:"varint,4,opt,name=items_checksum,json=itemsChecksum,proto3" json:"items_checksum,omitempty"`
+	// Set in the last message when the request has been stopped by the client before completing.
+	Stopped bool `protobuf:"varint,5,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	// Timing breakdown of the request. It's only populated in the last message when the request has explain set.
+	Explain *LabelValuesCardinalityExplain `protobuf:"bytes,6,opt,name=explain,proto3" json:"explain,omitempty"`
+	// Progress of the request. Progress messages only carry this field, and they're only sent when the request
+	// has progress_interval_ms set. They're sent at intervals which differ between two runs of the same request, so
+	// they're not part of the checksum chain: they don't carry a sequence number, a byte offset or checksums, and they
+	// don't change the ones of the other messages.
+	Progress *LabelValuesCardinalityProgress `protobuf:"bytes,7,opt,name=progress,proto3" json:"progress,omitempty"`
+	// The seed used to select the sampled label values, so that the sample can be reproduced.
+	// It's only populated when the request has sample_values set.
+	SampleSeed int64 `protobuf:"varint,8,opt,name=sample_seed,json=sampleSeed,proto3" json:"sample_seed,omitempty"`
+	// Number of label values whose series couldn't be counted, and which are omitted from the response.
+	// It's only populated in the last message when the request has best_effort set.
+	FailedLabelValues uint64 `protobuf:"varint,9,opt,name=failed_label_values,json=failedLabelValues,proto3" json:"failed_label_values,omitempty"`
+	// Errors of the first failed label values, at most 10 of them.
+	// It's only populated in the last message when the request has best_effort set.
+	Errors []string `protobuf:"bytes,10,rep,name=errors,proto3" json:"errors,omitempty"`
+	// Summary of each label, in the order in which the labels have been sent.
+	// It's only populated in the last message when the request has include_summary set.
+	Summaries []*LabelValuesCardinalitySummary `protobuf:"bytes,11,rep,name=summaries,proto3" json:"summaries,omitempty"`
+	// Byte offset of the items of the message in the stream, which is the total size of the canonical encoding of the
+	// items of the previous messages, as computed by LabelValuesCardinalityResponse.ComputeChainedItemsChecksums().
+	// It's only populated when the request has include_checksums set.
+	ByteOffset uint64 `protobuf:"varint,12,opt,name=byte_offset,json=byteOffset,proto3" json:"byte_offset,omitempty"`
+	// CRC32 (IEEE) of the items of all the messages up to this one, chained from the running checksum of the previous
+	// message, as computed by LabelValuesCardinalityResponse.ComputeChainedItemsChecksums().
+	// It's only populated when the request has include_checksums set.
+	RunningChecksum uint32 `protobuf:"varint,13,opt,name=running_checksum,json=runningChecksum,proto3" json:"running_checksum,omitempty"`
+	// ID of the ingester instance which has computed the response, so that the responses of the replicas of the same
+	// series can be compared.
+	InstanceId string `protobuf:"bytes,14,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	// State of the series of the labels, to send in changed_labels_state of the next run of the request.
+	// It's only populated in the last message when the request has changed_labels_only set.
+	ChangedLabelsState []byte `protobuf:"bytes,15,opt,name=changed_labels_state,json=changedLabelsState,proto3" json:"changed_labels_state,omitempty"`
+}
+
+func (m *LabelValuesCardinalityResponse) Reset()      { *m = LabelValuesCardinalityResponse{} }
+func (*LabelValuesCardinalityResponse) ProtoMessage() {}
+func (*LabelValuesCardinalityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{7}
+}
+func (m *LabelValuesCardinalityResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *StreamReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesCardinalityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_StreamReadResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesCardinalityResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -507,160 +1281,142 @@ func (m *StreamReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *StreamReadResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StreamReadResponse.Merge(m, src)
+func (m *LabelValuesCardinalityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesCardinalityResponse.Merge(m, src)
 }
-func (m *StreamReadResponse) XXX_Size() int {
+func (m *LabelValuesCardinalityResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *StreamReadResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_StreamReadResponse.DiscardUnknown(m)
+func (m *LabelValuesCardinalityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesCardinalityResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_StreamReadResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesCardinalityResponse proto.InternalMessageInfo
 
-func (m *StreamReadResponse) GetChunkedSeries() []*StreamChunkedSeries {
+func (m *LabelValuesCardinalityResponse) GetItems() []*LabelValueSeriesCount {
 	if m != nil {
-		return m.ChunkedSeries
+		return m.Items
 	}
 	return nil
 }
 
-func (m *StreamReadResponse) GetQueryIndex() int64 {
+func (m *LabelValuesCardinalityResponse) GetBudgetWarning() bool {
 	if m != nil {
-		return m.QueryIndex
+		return m.BudgetWarning
 	}
-	return 0
+	return false
 }
 
-type StreamChunkedSeries struct {
-	Labels []github_com_grafana_mimir_pkg_mimirpb.LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=github.com/grafana/mimir/pkg/mimirpb.LabelAdapter" json:"labels"`
-	Chunks []StreamChunk                                       `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks"`
+func (m *LabelValuesCardinalityResponse) GetSequenceNumber() uint64 {
+	if m != nil {
+		return m.SequenceNumber
+	}
+	return 0
 }
 
-func (m *StreamChunkedSeries) Reset()      { *m = StreamChunkedSeries{} }
-func (*StreamChunkedSeries) ProtoMessage() {}
-func (*StreamChunkedSeries) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{9}
-}
-func (m *StreamChunkedSeries) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *StreamChunkedSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_StreamChunkedSeries.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *LabelValuesCardinalityResponse) GetItemsChecksum() uint32 {
+	if m != nil {
+		return m.ItemsChecksum
 	}
-}
-func (m *StreamChunkedSeries) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StreamChunkedSeries.Merge(m, src)
-}
-func (m *StreamChunkedSeries) XXX_Size() int {
-	return m.Size()
-}
-func (m *StreamChunkedSeries) XXX_DiscardUnknown() {
-	xxx_messageInfo_StreamChunkedSeries.DiscardUnknown(m)
+	return 0
 }
 
-var xxx_messageInfo_StreamChunkedSeries proto.InternalMessageInfo
+func (m *LabelValuesCardinalityResponse) GetStopped() bool {
+	if m != nil {
+		return m.Stopped
+	}
+	return false
+}
 
-func (m *StreamChunkedSeries) GetChunks() []StreamChunk {
+func (m *LabelValuesCardinalityResponse) GetExplain() *LabelValuesCardinalityExplain {
 	if m != nil {
-		return m.Chunks
+		return m.Explain
 	}
 	return nil
 }
 
-type StreamChunk struct {
-	MinTimeMs int64                `protobuf:"varint,1,opt,name=min_time_ms,json=minTimeMs,proto3" json:"min_time_ms,omitempty"`
-	MaxTimeMs int64                `protobuf:"varint,2,opt,name=max_time_ms,json=maxTimeMs,proto3" json:"max_time_ms,omitempty"`
-	Type      StreamChunk_Encoding `protobuf:"varint,3,opt,name=type,proto3,enum=cortex.StreamChunk_Encoding" json:"type,omitempty"`
-	Data      []byte               `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+func (m *LabelValuesCardinalityResponse) GetProgress() *LabelValuesCardinalityProgress {
+	if m != nil {
+		return m.Progress
+	}
+	return nil
 }
 
-func (m *StreamChunk) Reset()      { *m = StreamChunk{} }
-func (*StreamChunk) ProtoMessage() {}
-func (*StreamChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{10}
-}
-func (m *StreamChunk) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
-}
-func (m *StreamChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_StreamChunk.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+func (m *LabelValuesCardinalityResponse) GetSampleSeed() int64 {
+	if m != nil {
+		return m.SampleSeed
 	}
+	return 0
 }
-func (m *StreamChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_StreamChunk.Merge(m, src)
-}
-func (m *StreamChunk) XXX_Size() int {
-	return m.Size()
+
+func (m *LabelValuesCardinalityResponse) GetFailedLabelValues() uint64 {
+	if m != nil {
+		return m.FailedLabelValues
+	}
+	return 0
 }
-func (m *StreamChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_StreamChunk.DiscardUnknown(m)
+
+func (m *LabelValuesCardinalityResponse) GetErrors() []string {
+	if m != nil {
+		return m.Errors
+	}
+	return nil
 }
 
-var xxx_messageInfo_StreamChunk proto.InternalMessageInfo
+func (m *LabelValuesCardinalityResponse) GetSummaries() []*LabelValuesCardinalitySummary {
+	if m != nil {
+		return m.Summaries
+	}
+	return nil
+}
 
-func (m *StreamChunk) GetMinTimeMs() int64 {
+func (m *LabelValuesCardinalityResponse) GetByteOffset() uint64 {
 	if m != nil {
-		return m.MinTimeMs
+		return m.ByteOffset
 	}
 	return 0
 }
 
-func (m *StreamChunk) GetMaxTimeMs() int64 {
+func (m *LabelValuesCardinalityResponse) GetRunningChecksum() uint32 {
 	if m != nil {
-		return m.MaxTimeMs
+		return m.RunningChecksum
 	}
 	return 0
 }
 
-func (m *StreamChunk) GetType() StreamChunk_Encoding {
+func (m *LabelValuesCardinalityResponse) GetInstanceId() string {
 	if m != nil {
-		return m.Type
+		return m.InstanceId
 	}
-	return UNKNOWN
+	return ""
 }
 
-func (m *StreamChunk) GetData() []byte {
+func (m *LabelValuesCardinalityResponse) GetChangedLabelsState() []byte {
 	if m != nil {
-		return m.Data
+		return m.ChangedLabelsState
 	}
 	return nil
 }
 
-type QueryRequest struct {
-	StartTimestampMs int64           `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64           `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         []*LabelMatcher `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+type LabelValuesCardinalitySummary struct {
+	LabelName string `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	// Number of distinct values of the label returned in the items.
+	LabelValuesCount uint64 `protobuf:"varint,2,opt,name=label_values_count,json=labelValuesCount,proto3" json:"label_values_count,omitempty"`
+	// Sum of the series counts of the values of the label returned in the items.
+	SeriesCount uint64 `protobuf:"varint,3,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
 }
 
-func (m *QueryRequest) Reset()      { *m = QueryRequest{} }
-func (*QueryRequest) ProtoMessage() {}
-func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{11}
+func (m *LabelValuesCardinalitySummary) Reset()      { *m = LabelValuesCardinalitySummary{} }
+func (*LabelValuesCardinalitySummary) ProtoMessage() {}
+func (*LabelValuesCardinalitySummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{8}
 }
-func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
+func (m *LabelValuesCardinalitySummary) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesCardinalitySummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesCardinalitySummary.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -670,56 +1426,57 @@ func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *QueryRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryRequest.Merge(m, src)
+func (m *LabelValuesCardinalitySummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesCardinalitySummary.Merge(m, src)
 }
-func (m *QueryRequest) XXX_Size() int {
+func (m *LabelValuesCardinalitySummary) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
+func (m *LabelValuesCardinalitySummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesCardinalitySummary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesCardinalitySummary proto.InternalMessageInfo
 
-func (m *QueryRequest) GetStartTimestampMs() int64 {
+func (m *LabelValuesCardinalitySummary) GetLabelName() string {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.LabelName
 	}
-	return 0
+	return ""
 }
 
-func (m *QueryRequest) GetEndTimestampMs() int64 {
+func (m *LabelValuesCardinalitySummary) GetLabelValuesCount() uint64 {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.LabelValuesCount
 	}
 	return 0
 }
 
-func (m *QueryRequest) GetMatchers() []*LabelMatcher {
+func (m *LabelValuesCardinalitySummary) GetSeriesCount() uint64 {
 	if m != nil {
-		return m.Matchers
+		return m.SeriesCount
 	}
-	return nil
+	return 0
 }
 
-type ExemplarQueryRequest struct {
-	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+type LabelValuesCardinalityProgress struct {
+	// Number of label values whose series have been counted so far.
+	LabelValues uint64 `protobuf:"varint,1,opt,name=label_values,json=labelValues,proto3" json:"label_values,omitempty"`
+	// Number of series counted so far.
+	Series uint64 `protobuf:"varint,2,opt,name=series,proto3" json:"series,omitempty"`
 }
 
-func (m *ExemplarQueryRequest) Reset()      { *m = ExemplarQueryRequest{} }
-func (*ExemplarQueryRequest) ProtoMessage() {}
-func (*ExemplarQueryRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{12}
+func (m *LabelValuesCardinalityProgress) Reset()      { *m = LabelValuesCardinalityProgress{} }
+func (*LabelValuesCardinalityProgress) ProtoMessage() {}
+func (*LabelValuesCardinalityProgress) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{9}
 }
-func (m *ExemplarQueryRequest) XXX_Unmarshal(b []byte) error {
+func (m *LabelValuesCardinalityProgress) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesCardinalityProgress) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_ExemplarQueryRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesCardinalityProgress.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -729,54 +1486,56 @@ func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte
 		return b[:n], nil
 	}
 }
-func (m *ExemplarQueryRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExemplarQueryRequest.Merge(m, src)
+func (m *LabelValuesCardinalityProgress) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesCardinalityProgress.Merge(m, src)
 }
-func (m *ExemplarQueryRequest) XXX_Size() int {
+func (m *LabelValuesCardinalityProgress) XXX_Size() int {
 	return m.Size()
 }
-func (m *ExemplarQueryRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExemplarQueryRequest.DiscardUnknown(m)
+func (m *LabelValuesCardinalityProgress) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesCardinalityProgress.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_ExemplarQueryRequest proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesCardinalityProgress proto.InternalMessageInfo
 
-func (m *ExemplarQueryRequest) GetStartTimestampMs() int64 {
+func (m *LabelValuesCardinalityProgress) GetLabelValues() uint64 {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.LabelValues
 	}
 	return 0
 }
 
-func (m *ExemplarQueryRequest) GetEndTimestampMs() int64 {
+func (m *LabelValuesCardinalityProgress) GetSeries() uint64 {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.Series
 	}
 	return 0
 }
 
-func (m *ExemplarQueryRequest) GetMatchers() []*LabelMatchers {
-	if m != nil {
-		return m.Matchers
-	}
-	return nil
-}
-
-type QueryResponse struct {
-	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
+type LabelValuesCardinalityExplain struct {
+	// Time spent looking up the label values.
+	LabelValuesDurationNs int64 `protobuf:"varint,1,opt,name=label_values_duration_ns,json=labelValuesDurationNs,proto3" json:"label_values_duration_ns,omitempty"`
+	// Time spent counting the series of the label values.
+	CountingDurationNs int64 `protobuf:"varint,2,opt,name=counting_duration_ns,json=countingDurationNs,proto3" json:"counting_duration_ns,omitempty"`
+	// Time spent sending the messages before the last one.
+	SendingDurationNs int64 `protobuf:"varint,3,opt,name=sending_duration_ns,json=sendingDurationNs,proto3" json:"sending_duration_ns,omitempty"`
+	// Maximum number of goroutines used to count the series of the values of a label.
+	CountingGoroutines uint32 `protobuf:"varint,4,opt,name=counting_goroutines,json=countingGoroutines,proto3" json:"counting_goroutines,omitempty"`
+	// Number of goroutines running in the ingester when the request completed.
+	Goroutines uint32 `protobuf:"varint,5,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
 }
 
-func (m *QueryResponse) Reset()      { *m = QueryResponse{} }
-func (*QueryResponse) ProtoMessage() {}
-func (*QueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{13}
+func (m *LabelValuesCardinalityExplain) Reset()      { *m = LabelValuesCardinalityExplain{} }
+func (*LabelValuesCardinalityExplain) ProtoMessage() {}
+func (*LabelValuesCardinalityExplain) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{10}
 }
-func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
+func (m *LabelValuesCardinalityExplain) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesCardinalityExplain) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesCardinalityExplain.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -786,42 +1545,99 @@ func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *QueryResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryResponse.Merge(m, src)
+func (m *LabelValuesCardinalityExplain) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesCardinalityExplain.Merge(m, src)
 }
-func (m *QueryResponse) XXX_Size() int {
+func (m *LabelValuesCardinalityExplain) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryResponse.DiscardUnknown(m)
+func (m *LabelValuesCardinalityExplain) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesCardinalityExplain.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesCardinalityExplain proto.InternalMessageInfo
 
-func (m *QueryResponse) GetTimeseries() []mimirpb.TimeSeries {
+func (m *LabelValuesCardinalityExplain) GetLabelValuesDurationNs() int64 {
 	if m != nil {
-		return m.Timeseries
+		return m.LabelValuesDurationNs
 	}
-	return nil
+	return 0
 }
 
-// QueryStreamResponse contains a batch of timeseries chunks or timeseries. Only one of these series will be populated.
-type QueryStreamResponse struct {
-	Chunkseries []TimeSeriesChunk    `protobuf:"bytes,1,rep,name=chunkseries,proto3" json:"chunkseries"`
-	Timeseries  []mimirpb.TimeSeries `protobuf:"bytes,2,rep,name=timeseries,proto3" json:"timeseries"`
+func (m *LabelValuesCardinalityExplain) GetCountingDurationNs() int64 {
+	if m != nil {
+		return m.CountingDurationNs
+	}
+	return 0
 }
 
-func (m *QueryStreamResponse) Reset()      { *m = QueryStreamResponse{} }
-func (*QueryStreamResponse) ProtoMessage() {}
-func (*QueryStreamResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{14}
+func (m *LabelValuesCardinalityExplain) GetSendingDurationNs() int64 {
+	if m != nil {
+		return m.SendingDurationNs
+	}
+	return 0
 }
-func (m *QueryStreamResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *LabelValuesCardinalityExplain) GetCountingGoroutines() uint32 {
+	if m != nil {
+		return m.CountingGoroutines
+	}
+	return 0
+}
+
+func (m *LabelValuesCardinalityExplain) GetGoroutines() uint32 {
+	if m != nil {
+		return m.Goroutines
+	}
+	return 0
+}
+
+type LabelValueSeriesCount struct {
+	LabelName        string            `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	LabelValueSeries map[string]uint64 `protobuf:"bytes,2,rep,name=label_value_series,json=labelValueSeries,proto3" json:"label_value_series,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Series count of each label value broken down by metric name.
+	// It's only populated when the request has group_by_metric_name set.
+	LabelValueMetricNamesSeries map[string]*MetricNamesSeriesCount `protobuf:"bytes,3,rep,name=label_value_metric_names_series,json=labelValueMetricNamesSeries,proto3" json:"label_value_metric_names_series,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Change of the series count of each label value since the previous update. It's only populated
+	// in the updates sent by LabelValuesCardinalityStream in watch mode, instead of label_value_series.
+	LabelValueSeriesDelta map[string]int64 `protobuf:"bytes,4,rep,name=label_value_series_delta,json=labelValueSeriesDelta,proto3" json:"label_value_series_delta,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Estimated number of distinct series of the label, and the relative standard error of the estimate.
+	// They're only populated when the request has estimate_label_series set, and they're set in all the items of the label.
+	LabelSeriesEstimate              uint64  `protobuf:"varint,5,opt,name=label_series_estimate,json=labelSeriesEstimate,proto3" json:"label_series_estimate,omitempty"`
+	LabelSeriesEstimateRelativeError float64 `protobuf:"fixed64,6,opt,name=label_series_estimate_relative_error,json=labelSeriesEstimateRelativeError,proto3" json:"label_series_estimate_relative_error,omitempty"`
+	// Number of chunks of the series of each label value.
+	// It's only populated when the request has include_chunk_count set.
+	LabelValueChunks map[string]uint64 `protobuf:"bytes,7,rep,name=label_value_chunks,json=labelValueChunks,proto3" json:"label_value_chunks,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// Ratio of the series of each label value to the series of the label, which are all the series counted
+	// for the label, including the ones of the values omitted from the response.
+	// It's only populated when the request has include_ratios set.
+	LabelValueRatios map[string]float64 `protobuf:"bytes,8,rep,name=label_value_ratios,json=labelValueRatios,proto3" json:"label_value_ratios,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Label values most frequently found in the series of each label value, excluding the values of the label itself.
+	// It's only populated for the values with the most series of the label, when the request has co_occurrence_top_k set.
+	LabelValueCoOccurrences map[string]*LabelValueCoOccurrences `protobuf:"bytes,9,rep,name=label_value_co_occurrences,json=labelValueCoOccurrences,proto3" json:"label_value_co_occurrences,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// Change of the series count of each label value between two snapshots, divided by the duration between them,
+	// in series per second. It's only populated when the growth rate is computed, instead of label_value_series.
+	LabelValueSeriesGrowthRate map[string]float64 `protobuf:"bytes,10,rep,name=label_value_series_growth_rate,json=labelValueSeriesGrowthRate,proto3" json:"label_value_series_growth_rate,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	// Approximate percentiles of the distribution of the series count of the values of the label, in the requested
+	// order. They're only populated when the request has series_count_percentiles set, and they're set in all
+	// the items of the label.
+	SeriesCountPercentiles []*SeriesCountPercentile `protobuf:"bytes,11,rep,name=series_count_percentiles,json=seriesCountPercentiles,proto3" json:"series_count_percentiles,omitempty"`
+	// Order of magnitude of the series count of all the label values of the item: 0, 1, 10, 100 or 1000, which
+	// also holds the values with more series. It's only populated when the request has group_by_magnitude set.
+	SeriesCountMagnitude uint64 `protobuf:"varint,12,opt,name=series_count_magnitude,json=seriesCountMagnitude,proto3" json:"series_count_magnitude,omitempty"`
+}
+
+func (m *LabelValueSeriesCount) Reset()      { *m = LabelValueSeriesCount{} }
+func (*LabelValueSeriesCount) ProtoMessage() {}
+func (*LabelValueSeriesCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{11}
+}
+func (m *LabelValueSeriesCount) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *QueryStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValueSeriesCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_QueryStreamResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValueSeriesCount.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -831,93 +1647,119 @@ func (m *QueryStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *QueryStreamResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_QueryStreamResponse.Merge(m, src)
+func (m *LabelValueSeriesCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValueSeriesCount.Merge(m, src)
 }
-func (m *QueryStreamResponse) XXX_Size() int {
+func (m *LabelValueSeriesCount) XXX_Size() int {
 	return m.Size()
 }
-func (m *QueryStreamResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_QueryStreamResponse.DiscardUnknown(m)
+func (m *LabelValueSeriesCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValueSeriesCount.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_QueryStreamResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelValueSeriesCount proto.InternalMessageInfo
 
-func (m *QueryStreamResponse) GetChunkseries() []TimeSeriesChunk {
+func (m *LabelValueSeriesCount) GetLabelName() string {
 	if m != nil {
-		return m.Chunkseries
+		return m.LabelName
+	}
+	return ""
+}
+
+func (m *LabelValueSeriesCount) GetLabelValueSeries() map[string]uint64 {
+	if m != nil {
+		return m.LabelValueSeries
 	}
 	return nil
 }
 
-func (m *QueryStreamResponse) GetTimeseries() []mimirpb.TimeSeries {
+func (m *LabelValueSeriesCount) GetLabelValueMetricNamesSeries() map[string]*MetricNamesSeriesCount {
 	if m != nil {
-		return m.Timeseries
+		return m.LabelValueMetricNamesSeries
 	}
 	return nil
 }
 
-type ExemplarQueryResponse struct {
-	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
+func (m *LabelValueSeriesCount) GetLabelValueSeriesDelta() map[string]int64 {
+	if m != nil {
+		return m.LabelValueSeriesDelta
+	}
+	return nil
 }
 
-func (m *ExemplarQueryResponse) Reset()      { *m = ExemplarQueryResponse{} }
-func (*ExemplarQueryResponse) ProtoMessage() {}
-func (*ExemplarQueryResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{15}
-}
-func (m *ExemplarQueryResponse) XXX_Unmarshal(b []byte) error {
-	return m.Unmarshal(b)
+func (m *LabelValueSeriesCount) GetLabelSeriesEstimate() uint64 {
+	if m != nil {
+		return m.LabelSeriesEstimate
+	}
+	return 0
 }
-func (m *ExemplarQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	if deterministic {
-		return xxx_messageInfo_ExemplarQueryResponse.Marshal(b, m, deterministic)
-	} else {
-		b = b[:cap(b)]
-		n, err := m.MarshalToSizedBuffer(b)
-		if err != nil {
-			return nil, err
-		}
-		return b[:n], nil
+
+func (m *LabelValueSeriesCount) GetLabelSeriesEstimateRelativeError() float64 {
+	if m != nil {
+		return m.LabelSeriesEstimateRelativeError
 	}
+	return 0
 }
-func (m *ExemplarQueryResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ExemplarQueryResponse.Merge(m, src)
+
+func (m *LabelValueSeriesCount) GetLabelValueChunks() map[string]uint64 {
+	if m != nil {
+		return m.LabelValueChunks
+	}
+	return nil
 }
-func (m *ExemplarQueryResponse) XXX_Size() int {
-	return m.Size()
+
+func (m *LabelValueSeriesCount) GetLabelValueRatios() map[string]float64 {
+	if m != nil {
+		return m.LabelValueRatios
+	}
+	return nil
 }
-func (m *ExemplarQueryResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_ExemplarQueryResponse.DiscardUnknown(m)
+
+func (m *LabelValueSeriesCount) GetLabelValueCoOccurrences() map[string]*LabelValueCoOccurrences {
+	if m != nil {
+		return m.LabelValueCoOccurrences
+	}
+	return nil
 }
 
-var xxx_messageInfo_ExemplarQueryResponse proto.InternalMessageInfo
+func (m *LabelValueSeriesCount) GetLabelValueSeriesGrowthRate() map[string]float64 {
+	if m != nil {
+		return m.LabelValueSeriesGrowthRate
+	}
+	return nil
+}
 
-func (m *ExemplarQueryResponse) GetTimeseries() []mimirpb.TimeSeries {
+func (m *LabelValueSeriesCount) GetSeriesCountPercentiles() []*SeriesCountPercentile {
 	if m != nil {
-		return m.Timeseries
+		return m.SeriesCountPercentiles
 	}
 	return nil
 }
 
-type LabelValuesRequest struct {
-	LabelName        string         `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
-	StartTimestampMs int64          `protobuf:"varint,2,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64          `protobuf:"varint,3,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         *LabelMatchers `protobuf:"bytes,4,opt,name=matchers,proto3" json:"matchers,omitempty"`
+func (m *LabelValueSeriesCount) GetSeriesCountMagnitude() uint64 {
+	if m != nil {
+		return m.SeriesCountMagnitude
+	}
+	return 0
 }
 
-func (m *LabelValuesRequest) Reset()      { *m = LabelValuesRequest{} }
-func (*LabelValuesRequest) ProtoMessage() {}
-func (*LabelValuesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{16}
+type SeriesCountPercentile struct {
+	// Percentile between 0 and 1.
+	Percentile  float64 `protobuf:"fixed64,1,opt,name=percentile,proto3" json:"percentile,omitempty"`
+	SeriesCount float64 `protobuf:"fixed64,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
 }
-func (m *LabelValuesRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *SeriesCountPercentile) Reset()      { *m = SeriesCountPercentile{} }
+func (*SeriesCountPercentile) ProtoMessage() {}
+func (*SeriesCountPercentile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{12}
+}
+func (m *SeriesCountPercentile) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelValuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *SeriesCountPercentile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelValuesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_SeriesCountPercentile.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -927,61 +1769,49 @@ func (m *LabelValuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LabelValuesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelValuesRequest.Merge(m, src)
+func (m *SeriesCountPercentile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SeriesCountPercentile.Merge(m, src)
 }
-func (m *LabelValuesRequest) XXX_Size() int {
+func (m *SeriesCountPercentile) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelValuesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelValuesRequest.DiscardUnknown(m)
+func (m *SeriesCountPercentile) XXX_DiscardUnknown() {
+	xxx_messageInfo_SeriesCountPercentile.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelValuesRequest proto.InternalMessageInfo
-
-func (m *LabelValuesRequest) GetLabelName() string {
-	if m != nil {
-		return m.LabelName
-	}
-	return ""
-}
+var xxx_messageInfo_SeriesCountPercentile proto.InternalMessageInfo
 
-func (m *LabelValuesRequest) GetStartTimestampMs() int64 {
+func (m *SeriesCountPercentile) GetPercentile() float64 {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.Percentile
 	}
 	return 0
 }
 
-func (m *LabelValuesRequest) GetEndTimestampMs() int64 {
+func (m *SeriesCountPercentile) GetSeriesCount() float64 {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.SeriesCount
 	}
 	return 0
 }
 
-func (m *LabelValuesRequest) GetMatchers() *LabelMatchers {
-	if m != nil {
-		return m.Matchers
-	}
-	return nil
-}
-
-type LabelValuesResponse struct {
-	LabelValues []string `protobuf:"bytes,1,rep,name=label_values,json=labelValues,proto3" json:"label_values,omitempty"`
+// LabelValueCoOccurrences holds the label values found in the series of a label value, sorted by series count
+// in descending order, and then by label name and value.
+type LabelValueCoOccurrences struct {
+	Items []*LabelValueCoOccurrence `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 }
 
-func (m *LabelValuesResponse) Reset()      { *m = LabelValuesResponse{} }
-func (*LabelValuesResponse) ProtoMessage() {}
-func (*LabelValuesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{17}
+func (m *LabelValueCoOccurrences) Reset()      { *m = LabelValueCoOccurrences{} }
+func (*LabelValueCoOccurrences) ProtoMessage() {}
+func (*LabelValueCoOccurrences) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{13}
 }
-func (m *LabelValuesResponse) XXX_Unmarshal(b []byte) error {
+func (m *LabelValueCoOccurrences) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValueCoOccurrences) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelValuesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValueCoOccurrences.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -991,42 +1821,42 @@ func (m *LabelValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LabelValuesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelValuesResponse.Merge(m, src)
+func (m *LabelValueCoOccurrences) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValueCoOccurrences.Merge(m, src)
 }
-func (m *LabelValuesResponse) XXX_Size() int {
+func (m *LabelValueCoOccurrences) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelValuesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelValuesResponse.DiscardUnknown(m)
+func (m *LabelValueCoOccurrences) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValueCoOccurrences.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelValuesResponse proto.InternalMessageInfo
+var xxx_messageInfo_LabelValueCoOccurrences proto.InternalMessageInfo
 
-func (m *LabelValuesResponse) GetLabelValues() []string {
+func (m *LabelValueCoOccurrences) GetItems() []*LabelValueCoOccurrence {
 	if m != nil {
-		return m.LabelValues
+		return m.Items
 	}
 	return nil
 }
 
-type LabelNamesRequest struct {
-	StartTimestampMs int64          `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64          `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Matchers         *LabelMatchers `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
+type LabelValueCoOccurrence struct {
+	LabelName   string `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	LabelValue  string `protobuf:"bytes,2,opt,name=label_value,json=labelValue,proto3" json:"label_value,omitempty"`
+	SeriesCount uint64 `protobuf:"varint,3,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
 }
 
-func (m *LabelNamesRequest) Reset()      { *m = LabelNamesRequest{} }
-func (*LabelNamesRequest) ProtoMessage() {}
-func (*LabelNamesRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{18}
+func (m *LabelValueCoOccurrence) Reset()      { *m = LabelValueCoOccurrence{} }
+func (*LabelValueCoOccurrence) ProtoMessage() {}
+func (*LabelValueCoOccurrence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{14}
 }
-func (m *LabelNamesRequest) XXX_Unmarshal(b []byte) error {
+func (m *LabelValueCoOccurrence) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValueCoOccurrence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelNamesRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValueCoOccurrence.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1036,54 +1866,55 @@ func (m *LabelNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *LabelNamesRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelNamesRequest.Merge(m, src)
+func (m *LabelValueCoOccurrence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValueCoOccurrence.Merge(m, src)
 }
-func (m *LabelNamesRequest) XXX_Size() int {
+func (m *LabelValueCoOccurrence) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelNamesRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelNamesRequest.DiscardUnknown(m)
+func (m *LabelValueCoOccurrence) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValueCoOccurrence.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelNamesRequest proto.InternalMessageInfo
+var xxx_messageInfo_LabelValueCoOccurrence proto.InternalMessageInfo
 
-func (m *LabelNamesRequest) GetStartTimestampMs() int64 {
+func (m *LabelValueCoOccurrence) GetLabelName() string {
 	if m != nil {
-		return m.StartTimestampMs
+		return m.LabelName
 	}
-	return 0
+	return ""
 }
 
-func (m *LabelNamesRequest) GetEndTimestampMs() int64 {
+func (m *LabelValueCoOccurrence) GetLabelValue() string {
 	if m != nil {
-		return m.EndTimestampMs
+		return m.LabelValue
 	}
-	return 0
+	return ""
 }
 
-func (m *LabelNamesRequest) GetMatchers() *LabelMatchers {
+func (m *LabelValueCoOccurrence) GetSeriesCount() uint64 {
 	if m != nil {
-		return m.Matchers
+		return m.SeriesCount
 	}
-	return nil
+	return 0
 }
 
-type LabelNamesResponse struct {
-	LabelNames []string `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
+// MetricNamesSeriesCount holds the series count per metric name, sorted by metric name.
+type MetricNamesSeriesCount struct {
+	Items []*MetricNameSeriesCount `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 }
 
-func (m *LabelNamesResponse) Reset()      { *m = LabelNamesResponse{} }
-func (*LabelNamesResponse) ProtoMessage() {}
-func (*LabelNamesResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{19}
+func (m *MetricNamesSeriesCount) Reset()      { *m = MetricNamesSeriesCount{} }
+func (*MetricNamesSeriesCount) ProtoMessage() {}
+func (*MetricNamesSeriesCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{15}
 }
-func (m *LabelNamesResponse) XXX_Unmarshal(b []byte) error {
+func (m *MetricNamesSeriesCount) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelNamesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MetricNamesSeriesCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelNamesResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MetricNamesSeriesCount.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1093,39 +1924,41 @@ func (m *LabelNamesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *LabelNamesResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelNamesResponse.Merge(m, src)
+func (m *MetricNamesSeriesCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricNamesSeriesCount.Merge(m, src)
 }
-func (m *LabelNamesResponse) XXX_Size() int {
+func (m *MetricNamesSeriesCount) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelNamesResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelNamesResponse.DiscardUnknown(m)
+func (m *MetricNamesSeriesCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricNamesSeriesCount.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelNamesResponse proto.InternalMessageInfo
+var xxx_messageInfo_MetricNamesSeriesCount proto.InternalMessageInfo
 
-func (m *LabelNamesResponse) GetLabelNames() []string {
+func (m *MetricNamesSeriesCount) GetItems() []*MetricNameSeriesCount {
 	if m != nil {
-		return m.LabelNames
+		return m.Items
 	}
 	return nil
 }
 
-type UserStatsRequest struct {
+type MetricNameSeriesCount struct {
+	MetricName  string `protobuf:"bytes,1,opt,name=metric_name,json=metricName,proto3" json:"metric_name,omitempty"`
+	SeriesCount uint64 `protobuf:"varint,2,opt,name=series_count,json=seriesCount,proto3" json:"series_count,omitempty"`
 }
 
-func (m *UserStatsRequest) Reset()      { *m = UserStatsRequest{} }
-func (*UserStatsRequest) ProtoMessage() {}
-func (*UserStatsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{20}
+func (m *MetricNameSeriesCount) Reset()      { *m = MetricNameSeriesCount{} }
+func (*MetricNameSeriesCount) ProtoMessage() {}
+func (*MetricNameSeriesCount) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{16}
 }
-func (m *UserStatsRequest) XXX_Unmarshal(b []byte) error {
+func (m *MetricNameSeriesCount) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UserStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *MetricNameSeriesCount) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UserStatsRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_MetricNameSeriesCount.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1135,36 +1968,48 @@ func (m *UserStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, er
 		return b[:n], nil
 	}
 }
-func (m *UserStatsRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserStatsRequest.Merge(m, src)
+func (m *MetricNameSeriesCount) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricNameSeriesCount.Merge(m, src)
 }
-func (m *UserStatsRequest) XXX_Size() int {
+func (m *MetricNameSeriesCount) XXX_Size() int {
 	return m.Size()
 }
-func (m *UserStatsRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserStatsRequest.DiscardUnknown(m)
+func (m *MetricNameSeriesCount) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricNameSeriesCount.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserStatsRequest proto.InternalMessageInfo
+var xxx_messageInfo_MetricNameSeriesCount proto.InternalMessageInfo
 
-type UserStatsResponse struct {
-	IngestionRate     float64 `protobuf:"fixed64,1,opt,name=ingestion_rate,json=ingestionRate,proto3" json:"ingestion_rate,omitempty"`
-	NumSeries         uint64  `protobuf:"varint,2,opt,name=num_series,json=numSeries,proto3" json:"num_series,omitempty"`
-	ApiIngestionRate  float64 `protobuf:"fixed64,3,opt,name=api_ingestion_rate,json=apiIngestionRate,proto3" json:"api_ingestion_rate,omitempty"`
-	RuleIngestionRate float64 `protobuf:"fixed64,4,opt,name=rule_ingestion_rate,json=ruleIngestionRate,proto3" json:"rule_ingestion_rate,omitempty"`
+func (m *MetricNameSeriesCount) GetMetricName() string {
+	if m != nil {
+		return m.MetricName
+	}
+	return ""
 }
 
-func (m *UserStatsResponse) Reset()      { *m = UserStatsResponse{} }
-func (*UserStatsResponse) ProtoMessage() {}
-func (*UserStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{21}
+func (m *MetricNameSeriesCount) GetSeriesCount() uint64 {
+	if m != nil {
+		return m.SeriesCount
+	}
+	return 0
 }
-func (m *UserStatsResponse) XXX_Unmarshal(b []byte) error {
+
+type ReadRequest struct {
+	Queries               []*QueryRequest            `protobuf:"bytes,1,rep,name=queries,proto3" json:"queries,omitempty"`
+	AcceptedResponseTypes []ReadRequest_ResponseType `protobuf:"varint,2,rep,packed,name=accepted_response_types,json=acceptedResponseTypes,proto3,enum=cortex.ReadRequest_ResponseType" json:"accepted_response_types,omitempty"`
+}
+
+func (m *ReadRequest) Reset()      { *m = ReadRequest{} }
+func (*ReadRequest) ProtoMessage() {}
+func (*ReadRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{17}
+}
+func (m *ReadRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UserStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReadRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UserStatsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReadRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1174,62 +2019,47 @@ func (m *UserStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, e
 		return b[:n], nil
 	}
 }
-func (m *UserStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserStatsResponse.Merge(m, src)
+func (m *ReadRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadRequest.Merge(m, src)
 }
-func (m *UserStatsResponse) XXX_Size() int {
+func (m *ReadRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *UserStatsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserStatsResponse.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_UserStatsResponse proto.InternalMessageInfo
-
-func (m *UserStatsResponse) GetIngestionRate() float64 {
-	if m != nil {
-		return m.IngestionRate
-	}
-	return 0
+func (m *ReadRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadRequest.DiscardUnknown(m)
 }
 
-func (m *UserStatsResponse) GetNumSeries() uint64 {
-	if m != nil {
-		return m.NumSeries
-	}
-	return 0
-}
+var xxx_messageInfo_ReadRequest proto.InternalMessageInfo
 
-func (m *UserStatsResponse) GetApiIngestionRate() float64 {
+func (m *ReadRequest) GetQueries() []*QueryRequest {
 	if m != nil {
-		return m.ApiIngestionRate
+		return m.Queries
 	}
-	return 0
+	return nil
 }
 
-func (m *UserStatsResponse) GetRuleIngestionRate() float64 {
+func (m *ReadRequest) GetAcceptedResponseTypes() []ReadRequest_ResponseType {
 	if m != nil {
-		return m.RuleIngestionRate
+		return m.AcceptedResponseTypes
 	}
-	return 0
+	return nil
 }
 
-type UserIDStatsResponse struct {
-	UserId string             `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Data   *UserStatsResponse `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+type ReadResponse struct {
+	Results []*QueryResponse `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 }
 
-func (m *UserIDStatsResponse) Reset()      { *m = UserIDStatsResponse{} }
-func (*UserIDStatsResponse) ProtoMessage() {}
-func (*UserIDStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{22}
+func (m *ReadResponse) Reset()      { *m = ReadResponse{} }
+func (*ReadResponse) ProtoMessage() {}
+func (*ReadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{18}
 }
-func (m *UserIDStatsResponse) XXX_Unmarshal(b []byte) error {
+func (m *ReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UserIDStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UserIDStatsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ReadResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1239,47 +2069,41 @@ func (m *UserIDStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *UserIDStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UserIDStatsResponse.Merge(m, src)
+func (m *ReadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReadResponse.Merge(m, src)
 }
-func (m *UserIDStatsResponse) XXX_Size() int {
+func (m *ReadResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *UserIDStatsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UserIDStatsResponse.DiscardUnknown(m)
+func (m *ReadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReadResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UserIDStatsResponse proto.InternalMessageInfo
-
-func (m *UserIDStatsResponse) GetUserId() string {
-	if m != nil {
-		return m.UserId
-	}
-	return ""
-}
+var xxx_messageInfo_ReadResponse proto.InternalMessageInfo
 
-func (m *UserIDStatsResponse) GetData() *UserStatsResponse {
+func (m *ReadResponse) GetResults() []*QueryResponse {
 	if m != nil {
-		return m.Data
+		return m.Results
 	}
 	return nil
 }
 
-type UsersStatsResponse struct {
-	Stats []*UserIDStatsResponse `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
+type StreamReadResponse struct {
+	ChunkedSeries []*StreamChunkedSeries `protobuf:"bytes,1,rep,name=chunked_series,json=chunkedSeries,proto3" json:"chunked_series,omitempty"`
+	QueryIndex    int64                  `protobuf:"varint,2,opt,name=query_index,json=queryIndex,proto3" json:"query_index,omitempty"`
 }
 
-func (m *UsersStatsResponse) Reset()      { *m = UsersStatsResponse{} }
-func (*UsersStatsResponse) ProtoMessage() {}
-func (*UsersStatsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{23}
+func (m *StreamReadResponse) Reset()      { *m = StreamReadResponse{} }
+func (*StreamReadResponse) ProtoMessage() {}
+func (*StreamReadResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{19}
 }
-func (m *UsersStatsResponse) XXX_Unmarshal(b []byte) error {
+func (m *StreamReadResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *UsersStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StreamReadResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_UsersStatsResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StreamReadResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1289,42 +2113,48 @@ func (m *UsersStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte,
 		return b[:n], nil
 	}
 }
-func (m *UsersStatsResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_UsersStatsResponse.Merge(m, src)
+func (m *StreamReadResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamReadResponse.Merge(m, src)
 }
-func (m *UsersStatsResponse) XXX_Size() int {
+func (m *StreamReadResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *UsersStatsResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_UsersStatsResponse.DiscardUnknown(m)
+func (m *StreamReadResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamReadResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_UsersStatsResponse proto.InternalMessageInfo
+var xxx_messageInfo_StreamReadResponse proto.InternalMessageInfo
 
-func (m *UsersStatsResponse) GetStats() []*UserIDStatsResponse {
+func (m *StreamReadResponse) GetChunkedSeries() []*StreamChunkedSeries {
 	if m != nil {
-		return m.Stats
+		return m.ChunkedSeries
 	}
 	return nil
 }
 
-type MetricsForLabelMatchersRequest struct {
-	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	MatchersSet      []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers_set,json=matchersSet,proto3" json:"matchers_set,omitempty"`
+func (m *StreamReadResponse) GetQueryIndex() int64 {
+	if m != nil {
+		return m.QueryIndex
+	}
+	return 0
 }
 
-func (m *MetricsForLabelMatchersRequest) Reset()      { *m = MetricsForLabelMatchersRequest{} }
-func (*MetricsForLabelMatchersRequest) ProtoMessage() {}
-func (*MetricsForLabelMatchersRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{24}
+type StreamChunkedSeries struct {
+	Labels []github_com_grafana_mimir_pkg_mimirpb.LabelAdapter `protobuf:"bytes,1,rep,name=labels,proto3,customtype=github.com/grafana/mimir/pkg/mimirpb.LabelAdapter" json:"labels"`
+	Chunks []StreamChunk                                       `protobuf:"bytes,2,rep,name=chunks,proto3" json:"chunks"`
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Unmarshal(b []byte) error {
+
+func (m *StreamChunkedSeries) Reset()      { *m = StreamChunkedSeries{} }
+func (*StreamChunkedSeries) ProtoMessage() {}
+func (*StreamChunkedSeries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{20}
+}
+func (m *StreamChunkedSeries) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StreamChunkedSeries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsForLabelMatchersRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StreamChunkedSeries.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1334,54 +2164,43 @@ func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic boo
 		return b[:n], nil
 	}
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsForLabelMatchersRequest.Merge(m, src)
+func (m *StreamChunkedSeries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamChunkedSeries.Merge(m, src)
 }
-func (m *MetricsForLabelMatchersRequest) XXX_Size() int {
+func (m *StreamChunkedSeries) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsForLabelMatchersRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsForLabelMatchersRequest.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_MetricsForLabelMatchersRequest proto.InternalMessageInfo
-
-func (m *MetricsForLabelMatchersRequest) GetStartTimestampMs() int64 {
-	if m != nil {
-		return m.StartTimestampMs
-	}
-	return 0
+func (m *StreamChunkedSeries) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamChunkedSeries.DiscardUnknown(m)
 }
 
-func (m *MetricsForLabelMatchersRequest) GetEndTimestampMs() int64 {
-	if m != nil {
-		return m.EndTimestampMs
-	}
-	return 0
-}
+var xxx_messageInfo_StreamChunkedSeries proto.InternalMessageInfo
 
-func (m *MetricsForLabelMatchersRequest) GetMatchersSet() []*LabelMatchers {
+func (m *StreamChunkedSeries) GetChunks() []StreamChunk {
 	if m != nil {
-		return m.MatchersSet
+		return m.Chunks
 	}
 	return nil
 }
 
-type MetricsForLabelMatchersResponse struct {
-	Metric []*mimirpb.Metric `protobuf:"bytes,1,rep,name=metric,proto3" json:"metric,omitempty"`
+type StreamChunk struct {
+	MinTimeMs int64                `protobuf:"varint,1,opt,name=min_time_ms,json=minTimeMs,proto3" json:"min_time_ms,omitempty"`
+	MaxTimeMs int64                `protobuf:"varint,2,opt,name=max_time_ms,json=maxTimeMs,proto3" json:"max_time_ms,omitempty"`
+	Type      StreamChunk_Encoding `protobuf:"varint,3,opt,name=type,proto3,enum=cortex.StreamChunk_Encoding" json:"type,omitempty"`
+	Data      []byte               `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
 }
 
-func (m *MetricsForLabelMatchersResponse) Reset()      { *m = MetricsForLabelMatchersResponse{} }
-func (*MetricsForLabelMatchersResponse) ProtoMessage() {}
-func (*MetricsForLabelMatchersResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{25}
+func (m *StreamChunk) Reset()      { *m = StreamChunk{} }
+func (*StreamChunk) ProtoMessage() {}
+func (*StreamChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{21}
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Unmarshal(b []byte) error {
+func (m *StreamChunk) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *StreamChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsForLabelMatchersResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_StreamChunk.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1391,39 +2210,63 @@ func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bo
 		return b[:n], nil
 	}
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsForLabelMatchersResponse.Merge(m, src)
+func (m *StreamChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StreamChunk.Merge(m, src)
 }
-func (m *MetricsForLabelMatchersResponse) XXX_Size() int {
+func (m *StreamChunk) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsForLabelMatchersResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsForLabelMatchersResponse.DiscardUnknown(m)
+func (m *StreamChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_StreamChunk.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsForLabelMatchersResponse proto.InternalMessageInfo
+var xxx_messageInfo_StreamChunk proto.InternalMessageInfo
 
-func (m *MetricsForLabelMatchersResponse) GetMetric() []*mimirpb.Metric {
+func (m *StreamChunk) GetMinTimeMs() int64 {
 	if m != nil {
-		return m.Metric
+		return m.MinTimeMs
+	}
+	return 0
+}
+
+func (m *StreamChunk) GetMaxTimeMs() int64 {
+	if m != nil {
+		return m.MaxTimeMs
+	}
+	return 0
+}
+
+func (m *StreamChunk) GetType() StreamChunk_Encoding {
+	if m != nil {
+		return m.Type
+	}
+	return UNKNOWN
+}
+
+func (m *StreamChunk) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
 	return nil
 }
 
-type MetricsMetadataRequest struct {
+type QueryRequest struct {
+	StartTimestampMs int64           `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64           `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         []*LabelMatcher `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
-func (m *MetricsMetadataRequest) Reset()      { *m = MetricsMetadataRequest{} }
-func (*MetricsMetadataRequest) ProtoMessage() {}
-func (*MetricsMetadataRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{26}
+func (m *QueryRequest) Reset()      { *m = QueryRequest{} }
+func (*QueryRequest) ProtoMessage() {}
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{22}
 }
-func (m *MetricsMetadataRequest) XXX_Unmarshal(b []byte) error {
+func (m *QueryRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsMetadataRequest.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1433,33 +2276,56 @@ func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]by
 		return b[:n], nil
 	}
 }
-func (m *MetricsMetadataRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsMetadataRequest.Merge(m, src)
+func (m *QueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryRequest.Merge(m, src)
 }
-func (m *MetricsMetadataRequest) XXX_Size() int {
+func (m *QueryRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsMetadataRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsMetadataRequest.DiscardUnknown(m)
+func (m *QueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsMetadataRequest proto.InternalMessageInfo
+var xxx_messageInfo_QueryRequest proto.InternalMessageInfo
 
-type MetricsMetadataResponse struct {
-	Metadata []*mimirpb.MetricMetadata `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
+func (m *QueryRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
+	}
+	return 0
 }
 
-func (m *MetricsMetadataResponse) Reset()      { *m = MetricsMetadataResponse{} }
-func (*MetricsMetadataResponse) ProtoMessage() {}
-func (*MetricsMetadataResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{27}
+func (m *QueryRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
 }
-func (m *MetricsMetadataResponse) XXX_Unmarshal(b []byte) error {
+
+func (m *QueryRequest) GetMatchers() []*LabelMatcher {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
+}
+
+type ExemplarQueryRequest struct {
+	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *ExemplarQueryRequest) Reset()      { *m = ExemplarQueryRequest{} }
+func (*ExemplarQueryRequest) ProtoMessage() {}
+func (*ExemplarQueryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{23}
+}
+func (m *ExemplarQueryRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ExemplarQueryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_MetricsMetadataResponse.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ExemplarQueryRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1469,43 +2335,54 @@ func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]b
 		return b[:n], nil
 	}
 }
-func (m *MetricsMetadataResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_MetricsMetadataResponse.Merge(m, src)
+func (m *ExemplarQueryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryRequest.Merge(m, src)
 }
-func (m *MetricsMetadataResponse) XXX_Size() int {
+func (m *ExemplarQueryRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *MetricsMetadataResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_MetricsMetadataResponse.DiscardUnknown(m)
+func (m *ExemplarQueryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_MetricsMetadataResponse proto.InternalMessageInfo
+var xxx_messageInfo_ExemplarQueryRequest proto.InternalMessageInfo
 
-func (m *MetricsMetadataResponse) GetMetadata() []*mimirpb.MetricMetadata {
+func (m *ExemplarQueryRequest) GetStartTimestampMs() int64 {
 	if m != nil {
-		return m.Metadata
+		return m.StartTimestampMs
+	}
+	return 0
+}
+
+func (m *ExemplarQueryRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
+	}
+	return 0
+}
+
+func (m *ExemplarQueryRequest) GetMatchers() []*LabelMatchers {
+	if m != nil {
+		return m.Matchers
 	}
 	return nil
 }
 
-type TimeSeriesChunk struct {
-	FromIngesterId string                                              `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
-	UserId         string                                              `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Labels         []github_com_grafana_mimir_pkg_mimirpb.LabelAdapter `protobuf:"bytes,3,rep,name=labels,proto3,customtype=github.com/grafana/mimir/pkg/mimirpb.LabelAdapter" json:"labels"`
-	Chunks         []Chunk                                             `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks"`
+type QueryResponse struct {
+	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
 }
 
-func (m *TimeSeriesChunk) Reset()      { *m = TimeSeriesChunk{} }
-func (*TimeSeriesChunk) ProtoMessage() {}
-func (*TimeSeriesChunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{28}
+func (m *QueryResponse) Reset()      { *m = QueryResponse{} }
+func (*QueryResponse) ProtoMessage() {}
+func (*QueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{24}
 }
-func (m *TimeSeriesChunk) XXX_Unmarshal(b []byte) error {
+func (m *QueryResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TimeSeriesChunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1515,57 +2392,42 @@ func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, err
 		return b[:n], nil
 	}
 }
-func (m *TimeSeriesChunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TimeSeriesChunk.Merge(m, src)
+func (m *QueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryResponse.Merge(m, src)
 }
-func (m *TimeSeriesChunk) XXX_Size() int {
+func (m *QueryResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *TimeSeriesChunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_TimeSeriesChunk.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_TimeSeriesChunk proto.InternalMessageInfo
-
-func (m *TimeSeriesChunk) GetFromIngesterId() string {
-	if m != nil {
-		return m.FromIngesterId
-	}
-	return ""
+func (m *QueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryResponse.DiscardUnknown(m)
 }
 
-func (m *TimeSeriesChunk) GetUserId() string {
-	if m != nil {
-		return m.UserId
-	}
-	return ""
-}
+var xxx_messageInfo_QueryResponse proto.InternalMessageInfo
 
-func (m *TimeSeriesChunk) GetChunks() []Chunk {
+func (m *QueryResponse) GetTimeseries() []mimirpb.TimeSeries {
 	if m != nil {
-		return m.Chunks
+		return m.Timeseries
 	}
 	return nil
 }
 
-type Chunk struct {
-	StartTimestampMs int64  `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
-	EndTimestampMs   int64  `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
-	Encoding         int32  `protobuf:"varint,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
-	Data             []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+// QueryStreamResponse contains a batch of timeseries chunks or timeseries. Only one of these series will be populated.
+type QueryStreamResponse struct {
+	Chunkseries []TimeSeriesChunk    `protobuf:"bytes,1,rep,name=chunkseries,proto3" json:"chunkseries"`
+	Timeseries  []mimirpb.TimeSeries `protobuf:"bytes,2,rep,name=timeseries,proto3" json:"timeseries"`
 }
 
-func (m *Chunk) Reset()      { *m = Chunk{} }
-func (*Chunk) ProtoMessage() {}
-func (*Chunk) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{29}
+func (m *QueryStreamResponse) Reset()      { *m = QueryStreamResponse{} }
+func (*QueryStreamResponse) ProtoMessage() {}
+func (*QueryStreamResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{25}
 }
-func (m *Chunk) XXX_Unmarshal(b []byte) error {
+func (m *QueryStreamResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *QueryStreamResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
+		return xxx_messageInfo_QueryStreamResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1575,61 +2437,47 @@ func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 		return b[:n], nil
 	}
 }
-func (m *Chunk) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Chunk.Merge(m, src)
+func (m *QueryStreamResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QueryStreamResponse.Merge(m, src)
 }
-func (m *Chunk) XXX_Size() int {
+func (m *QueryStreamResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *Chunk) XXX_DiscardUnknown() {
-	xxx_messageInfo_Chunk.DiscardUnknown(m)
-}
-
-var xxx_messageInfo_Chunk proto.InternalMessageInfo
-
-func (m *Chunk) GetStartTimestampMs() int64 {
-	if m != nil {
-		return m.StartTimestampMs
-	}
-	return 0
+func (m *QueryStreamResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_QueryStreamResponse.DiscardUnknown(m)
 }
 
-func (m *Chunk) GetEndTimestampMs() int64 {
-	if m != nil {
-		return m.EndTimestampMs
-	}
-	return 0
-}
+var xxx_messageInfo_QueryStreamResponse proto.InternalMessageInfo
 
-func (m *Chunk) GetEncoding() int32 {
+func (m *QueryStreamResponse) GetChunkseries() []TimeSeriesChunk {
 	if m != nil {
-		return m.Encoding
+		return m.Chunkseries
 	}
-	return 0
+	return nil
 }
 
-func (m *Chunk) GetData() []byte {
+func (m *QueryStreamResponse) GetTimeseries() []mimirpb.TimeSeries {
 	if m != nil {
-		return m.Data
+		return m.Timeseries
 	}
 	return nil
 }
 
-type LabelMatchers struct {
-	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
+type ExemplarQueryResponse struct {
+	Timeseries []mimirpb.TimeSeries `protobuf:"bytes,1,rep,name=timeseries,proto3" json:"timeseries"`
 }
 
-func (m *LabelMatchers) Reset()      { *m = LabelMatchers{} }
-func (*LabelMatchers) ProtoMessage() {}
-func (*LabelMatchers) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{30}
+func (m *ExemplarQueryResponse) Reset()      { *m = ExemplarQueryResponse{} }
+func (*ExemplarQueryResponse) ProtoMessage() {}
+func (*ExemplarQueryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{26}
 }
-func (m *LabelMatchers) XXX_Unmarshal(b []byte) error {
+func (m *ExemplarQueryResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *ExemplarQueryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelMatchers.Marshal(b, m, deterministic)
+		return xxx_messageInfo_ExemplarQueryResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1639,42 +2487,43 @@ func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error
 		return b[:n], nil
 	}
 }
-func (m *LabelMatchers) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelMatchers.Merge(m, src)
+func (m *ExemplarQueryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ExemplarQueryResponse.Merge(m, src)
 }
-func (m *LabelMatchers) XXX_Size() int {
+func (m *ExemplarQueryResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelMatchers) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelMatchers.DiscardUnknown(m)
+func (m *ExemplarQueryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ExemplarQueryResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelMatchers proto.InternalMessageInfo
+var xxx_messageInfo_ExemplarQueryResponse proto.InternalMessageInfo
 
-func (m *LabelMatchers) GetMatchers() []*LabelMatcher {
+func (m *ExemplarQueryResponse) GetTimeseries() []mimirpb.TimeSeries {
 	if m != nil {
-		return m.Matchers
+		return m.Timeseries
 	}
 	return nil
 }
 
-type LabelMatcher struct {
-	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MatchType" json:"type,omitempty"`
-	Name  string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Value string    `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+type LabelValuesRequest struct {
+	LabelName        string         `protobuf:"bytes,1,opt,name=label_name,json=labelName,proto3" json:"label_name,omitempty"`
+	StartTimestampMs int64          `protobuf:"varint,2,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64          `protobuf:"varint,3,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         *LabelMatchers `protobuf:"bytes,4,opt,name=matchers,proto3" json:"matchers,omitempty"`
 }
 
-func (m *LabelMatcher) Reset()      { *m = LabelMatcher{} }
-func (*LabelMatcher) ProtoMessage() {}
-func (*LabelMatcher) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{31}
+func (m *LabelValuesRequest) Reset()      { *m = LabelValuesRequest{} }
+func (*LabelValuesRequest) ProtoMessage() {}
+func (*LabelValuesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{27}
 }
-func (m *LabelMatcher) XXX_Unmarshal(b []byte) error {
+func (m *LabelValuesRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_LabelMatcher.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesRequest.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1684,57 +2533,61 @@ func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error)
 		return b[:n], nil
 	}
 }
-func (m *LabelMatcher) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_LabelMatcher.Merge(m, src)
+func (m *LabelValuesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesRequest.Merge(m, src)
 }
-func (m *LabelMatcher) XXX_Size() int {
+func (m *LabelValuesRequest) XXX_Size() int {
 	return m.Size()
 }
-func (m *LabelMatcher) XXX_DiscardUnknown() {
-	xxx_messageInfo_LabelMatcher.DiscardUnknown(m)
+func (m *LabelValuesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_LabelMatcher proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesRequest proto.InternalMessageInfo
 
-func (m *LabelMatcher) GetType() MatchType {
+func (m *LabelValuesRequest) GetLabelName() string {
 	if m != nil {
-		return m.Type
+		return m.LabelName
 	}
-	return EQUAL
+	return ""
 }
 
-func (m *LabelMatcher) GetName() string {
+func (m *LabelValuesRequest) GetStartTimestampMs() int64 {
 	if m != nil {
-		return m.Name
+		return m.StartTimestampMs
 	}
-	return ""
+	return 0
 }
 
-func (m *LabelMatcher) GetValue() string {
+func (m *LabelValuesRequest) GetEndTimestampMs() int64 {
 	if m != nil {
-		return m.Value
+		return m.EndTimestampMs
 	}
-	return ""
+	return 0
 }
 
-type TimeSeriesFile struct {
-	FromIngesterId string `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
-	UserId         string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Filename       string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
-	Data           []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+func (m *LabelValuesRequest) GetMatchers() *LabelMatchers {
+	if m != nil {
+		return m.Matchers
+	}
+	return nil
 }
 
-func (m *TimeSeriesFile) Reset()      { *m = TimeSeriesFile{} }
-func (*TimeSeriesFile) ProtoMessage() {}
-func (*TimeSeriesFile) Descriptor() ([]byte, []int) {
-	return fileDescriptor_60f6df4f3586b478, []int{32}
+type LabelValuesResponse struct {
+	LabelValues []string `protobuf:"bytes,1,rep,name=label_values,json=labelValues,proto3" json:"label_values,omitempty"`
 }
-func (m *TimeSeriesFile) XXX_Unmarshal(b []byte) error {
+
+func (m *LabelValuesResponse) Reset()      { *m = LabelValuesResponse{} }
+func (*LabelValuesResponse) ProtoMessage() {}
+func (*LabelValuesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{28}
+}
+func (m *LabelValuesResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
 }
-func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+func (m *LabelValuesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
 	if deterministic {
-		return xxx_messageInfo_TimeSeriesFile.Marshal(b, m, deterministic)
+		return xxx_messageInfo_LabelValuesResponse.Marshal(b, m, deterministic)
 	} else {
 		b = b[:cap(b)]
 		n, err := m.MarshalToSizedBuffer(b)
@@ -1744,887 +2597,1149 @@ func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, erro
 		return b[:n], nil
 	}
 }
-func (m *TimeSeriesFile) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_TimeSeriesFile.Merge(m, src)
+func (m *LabelValuesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelValuesResponse.Merge(m, src)
 }
-func (m *TimeSeriesFile) XXX_Size() int {
+func (m *LabelValuesResponse) XXX_Size() int {
 	return m.Size()
 }
-func (m *TimeSeriesFile) XXX_DiscardUnknown() {
-	xxx_messageInfo_TimeSeriesFile.DiscardUnknown(m)
+func (m *LabelValuesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelValuesResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_TimeSeriesFile proto.InternalMessageInfo
+var xxx_messageInfo_LabelValuesResponse proto.InternalMessageInfo
 
-func (m *TimeSeriesFile) GetFromIngesterId() string {
+func (m *LabelValuesResponse) GetLabelValues() []string {
 	if m != nil {
-		return m.FromIngesterId
+		return m.LabelValues
 	}
-	return ""
+	return nil
 }
 
-func (m *TimeSeriesFile) GetUserId() string {
+type LabelNamesRequest struct {
+	StartTimestampMs int64          `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64          `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Matchers         *LabelMatchers `protobuf:"bytes,3,opt,name=matchers,proto3" json:"matchers,omitempty"`
+}
+
+func (m *LabelNamesRequest) Reset()      { *m = LabelNamesRequest{} }
+func (*LabelNamesRequest) ProtoMessage() {}
+func (*LabelNamesRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{29}
+}
+func (m *LabelNamesRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelNamesRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelNamesRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *LabelNamesRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNamesRequest.Merge(m, src)
+}
+func (m *LabelNamesRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelNamesRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNamesRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelNamesRequest proto.InternalMessageInfo
+
+func (m *LabelNamesRequest) GetStartTimestampMs() int64 {
 	if m != nil {
-		return m.UserId
+		return m.StartTimestampMs
 	}
-	return ""
+	return 0
 }
 
-func (m *TimeSeriesFile) GetFilename() string {
+func (m *LabelNamesRequest) GetEndTimestampMs() int64 {
 	if m != nil {
-		return m.Filename
+		return m.EndTimestampMs
 	}
-	return ""
+	return 0
 }
 
-func (m *TimeSeriesFile) GetData() []byte {
+func (m *LabelNamesRequest) GetMatchers() *LabelMatchers {
 	if m != nil {
-		return m.Data
+		return m.Matchers
 	}
 	return nil
 }
 
-func init() {
-	proto.RegisterEnum("cortex.MatchType", MatchType_name, MatchType_value)
-	proto.RegisterEnum("cortex.ReadRequest_ResponseType", ReadRequest_ResponseType_name, ReadRequest_ResponseType_value)
-	proto.RegisterEnum("cortex.StreamChunk_Encoding", StreamChunk_Encoding_name, StreamChunk_Encoding_value)
-	proto.RegisterType((*LabelNamesAndValuesRequest)(nil), "cortex.LabelNamesAndValuesRequest")
-	proto.RegisterType((*LabelNamesAndValuesResponse)(nil), "cortex.LabelNamesAndValuesResponse")
-	proto.RegisterType((*LabelValues)(nil), "cortex.LabelValues")
-	proto.RegisterType((*LabelValuesCardinalityRequest)(nil), "cortex.LabelValuesCardinalityRequest")
-	proto.RegisterType((*LabelValuesCardinalityResponse)(nil), "cortex.LabelValuesCardinalityResponse")
-	proto.RegisterType((*LabelValueSeriesCount)(nil), "cortex.LabelValueSeriesCount")
-	proto.RegisterMapType((map[string]uint64)(nil), "cortex.LabelValueSeriesCount.LabelValueSeriesEntry")
-	proto.RegisterType((*ReadRequest)(nil), "cortex.ReadRequest")
-	proto.RegisterType((*ReadResponse)(nil), "cortex.ReadResponse")
-	proto.RegisterType((*StreamReadResponse)(nil), "cortex.StreamReadResponse")
-	proto.RegisterType((*StreamChunkedSeries)(nil), "cortex.StreamChunkedSeries")
-	proto.RegisterType((*StreamChunk)(nil), "cortex.StreamChunk")
-	proto.RegisterType((*QueryRequest)(nil), "cortex.QueryRequest")
-	proto.RegisterType((*ExemplarQueryRequest)(nil), "cortex.ExemplarQueryRequest")
-	proto.RegisterType((*QueryResponse)(nil), "cortex.QueryResponse")
-	proto.RegisterType((*QueryStreamResponse)(nil), "cortex.QueryStreamResponse")
-	proto.RegisterType((*ExemplarQueryResponse)(nil), "cortex.ExemplarQueryResponse")
-	proto.RegisterType((*LabelValuesRequest)(nil), "cortex.LabelValuesRequest")
-	proto.RegisterType((*LabelValuesResponse)(nil), "cortex.LabelValuesResponse")
-	proto.RegisterType((*LabelNamesRequest)(nil), "cortex.LabelNamesRequest")
-	proto.RegisterType((*LabelNamesResponse)(nil), "cortex.LabelNamesResponse")
-	proto.RegisterType((*UserStatsRequest)(nil), "cortex.UserStatsRequest")
-	proto.RegisterType((*UserStatsResponse)(nil), "cortex.UserStatsResponse")
-	proto.RegisterType((*UserIDStatsResponse)(nil), "cortex.UserIDStatsResponse")
-	proto.RegisterType((*UsersStatsResponse)(nil), "cortex.UsersStatsResponse")
-	proto.RegisterType((*MetricsForLabelMatchersRequest)(nil), "cortex.MetricsForLabelMatchersRequest")
-	proto.RegisterType((*MetricsForLabelMatchersResponse)(nil), "cortex.MetricsForLabelMatchersResponse")
-	proto.RegisterType((*MetricsMetadataRequest)(nil), "cortex.MetricsMetadataRequest")
-	proto.RegisterType((*MetricsMetadataResponse)(nil), "cortex.MetricsMetadataResponse")
-	proto.RegisterType((*TimeSeriesChunk)(nil), "cortex.TimeSeriesChunk")
-	proto.RegisterType((*Chunk)(nil), "cortex.Chunk")
-	proto.RegisterType((*LabelMatchers)(nil), "cortex.LabelMatchers")
-	proto.RegisterType((*LabelMatcher)(nil), "cortex.LabelMatcher")
-	proto.RegisterType((*TimeSeriesFile)(nil), "cortex.TimeSeriesFile")
+type LabelNamesResponse struct {
+	LabelNames []string `protobuf:"bytes,1,rep,name=label_names,json=labelNames,proto3" json:"label_names,omitempty"`
 }
 
-func init() { proto.RegisterFile("ingester.proto", fileDescriptor_60f6df4f3586b478) }
-
-var fileDescriptor_60f6df4f3586b478 = []byte{
-	// 1640 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x58, 0xcd, 0x6f, 0xdb, 0x46,
-	0x16, 0xd7, 0x48, 0xb2, 0x6c, 0x3d, 0xd9, 0x8a, 0x3c, 0x8a, 0x6d, 0x85, 0x59, 0xd3, 0x5a, 0x2e,
-	0x92, 0xd5, 0xee, 0x26, 0xf2, 0x47, 0xb2, 0x40, 0x12, 0x14, 0x08, 0x64, 0x5b, 0x89, 0x5d, 0x47,
-	0x72, 0x42, 0xd9, 0x8d, 0x51, 0xa0, 0x20, 0x28, 0x69, 0x6c, 0x13, 0x16, 0x29, 0x85, 0xa4, 0x0a,
-	0xfb, 0x56, 0xa0, 0xf7, 0xb6, 0xe8, 0xa9, 0xa7, 0x02, 0xbd, 0xf5, 0x58, 0x14, 0x28, 0x7a, 0xeb,
-	0x39, 0x97, 0x02, 0x39, 0x06, 0x3d, 0x04, 0x8d, 0x73, 0x69, 0x6f, 0xf9, 0x13, 0x0a, 0xce, 0x0c,
-	0x29, 0x92, 0xa2, 0x3f, 0x52, 0x24, 0x39, 0x49, 0xf3, 0xde, 0x6f, 0x7e, 0xf3, 0xbe, 0x38, 0xef,
-	0x91, 0x90, 0xd5, 0x8c, 0x3d, 0x62, 0xd9, 0xc4, 0x2c, 0xf7, 0xcc, 0xae, 0xdd, 0xc5, 0xa9, 0x56,
-	0xd7, 0xb4, 0xc9, 0xa1, 0x70, 0x7d, 0x4f, 0xb3, 0xf7, 0xfb, 0xcd, 0x72, 0xab, 0xab, 0xcf, 0xef,
-	0x75, 0xf7, 0xba, 0xf3, 0x54, 0xdd, 0xec, 0xef, 0xd2, 0x15, 0x5d, 0xd0, 0x7f, 0x6c, 0x9b, 0xb0,
-	0xe0, 0x87, 0x9b, 0xea, 0xae, 0x6a, 0xa8, 0xf3, 0xba, 0xa6, 0x6b, 0xe6, 0x7c, 0xef, 0x60, 0x8f,
-	0xfd, 0xeb, 0x35, 0xd9, 0x2f, 0xdb, 0x21, 0xd5, 0x41, 0x78, 0xa0, 0x36, 0x49, 0xa7, 0xae, 0xea,
-	0xc4, 0xaa, 0x18, 0xed, 0x8f, 0xd4, 0x4e, 0x9f, 0x58, 0x32, 0x79, 0xd2, 0x27, 0x96, 0x8d, 0x17,
-	0x60, 0x4c, 0x57, 0xed, 0xd6, 0x3e, 0x31, 0xad, 0x02, 0x2a, 0x26, 0x4a, 0x99, 0xa5, 0x8b, 0x65,
-	0x66, 0x59, 0x99, 0xee, 0xaa, 0x31, 0xa5, 0xec, 0xa1, 0xa4, 0x35, 0xb8, 0x1c, 0xc9, 0x67, 0xf5,
-	0xba, 0x86, 0x45, 0xf0, 0x7f, 0x60, 0x44, 0xb3, 0x89, 0xee, 0xb2, 0xe5, 0x03, 0x6c, 0x1c, 0xcb,
-	0x10, 0xd2, 0x2a, 0x64, 0x7c, 0x52, 0x3c, 0x0b, 0xd0, 0x71, 0x96, 0x8a, 0xa1, 0xea, 0xa4, 0x80,
-	0x8a, 0xa8, 0x94, 0x96, 0xd3, 0x1d, 0xf7, 0x28, 0x3c, 0x0d, 0xa9, 0x4f, 0x29, 0xb0, 0x10, 0x2f,
-	0x26, 0x4a, 0x69, 0x99, 0xaf, 0x24, 0x13, 0x66, 0x7d, 0x2c, 0x2b, 0xaa, 0xd9, 0xd6, 0x0c, 0xb5,
-	0xa3, 0xd9, 0x47, 0xae, 0x8b, 0x73, 0x90, 0x19, 0xf0, 0x32, 0xbb, 0xd2, 0x32, 0x78, 0xc4, 0x56,
-	0x20, 0x06, 0xf1, 0x73, 0xc5, 0x60, 0x1b, 0xc4, 0x93, 0xce, 0xe4, 0x61, 0xb8, 0x11, 0x0c, 0xc3,
-	0xec, 0x70, 0x18, 0x1a, 0xc4, 0xd4, 0x88, 0xb5, 0xd2, 0xed, 0x1b, 0xb6, 0x1b, 0x90, 0x17, 0x08,
-	0xa6, 0x22, 0x01, 0x67, 0xc5, 0x46, 0x05, 0xcc, 0xd4, 0x34, 0x26, 0x8a, 0x45, 0x77, 0x72, 0x5f,
-	0x6e, 0x9c, 0x7a, 0xf4, 0x90, 0xb4, 0x6a, 0xd8, 0xe6, 0x91, 0x9c, 0xeb, 0x84, 0xc4, 0xc2, 0xca,
-	0xb0, 0x69, 0x14, 0x8a, 0x73, 0x90, 0x38, 0x20, 0x47, 0xdc, 0x26, 0xe7, 0x2f, 0xbe, 0x08, 0x23,
-	0xd4, 0x8e, 0x42, 0xbc, 0x88, 0x4a, 0x49, 0x99, 0x2d, 0xee, 0xc4, 0x6f, 0x21, 0xe9, 0x57, 0x04,
-	0x19, 0x99, 0xa8, 0x6d, 0x37, 0x35, 0x65, 0x18, 0x7d, 0xd2, 0x67, 0xc6, 0x86, 0x8a, 0xef, 0x51,
-	0x9f, 0x98, 0x6e, 0x06, 0x65, 0x17, 0x84, 0x77, 0x60, 0x46, 0x6d, 0xb5, 0x48, 0xcf, 0x26, 0x6d,
-	0xc5, 0xe4, 0xa1, 0x56, 0xec, 0xa3, 0x1e, 0x77, 0x36, 0xbb, 0x54, 0x74, 0xf7, 0xfb, 0x4e, 0x29,
-	0xbb, 0x49, 0xd9, 0x3a, 0xea, 0x11, 0x79, 0xca, 0x25, 0xf0, 0x4b, 0x2d, 0xe9, 0x26, 0x8c, 0xfb,
-	0x05, 0x38, 0x03, 0xa3, 0x8d, 0x4a, 0xed, 0xe1, 0x83, 0x6a, 0x23, 0x17, 0xc3, 0x33, 0x90, 0x6f,
-	0x6c, 0xc9, 0xd5, 0x4a, 0xad, 0xba, 0xaa, 0xec, 0x6c, 0xca, 0xca, 0xca, 0xda, 0x76, 0x7d, 0xa3,
-	0x91, 0x43, 0xd2, 0x5d, 0x67, 0x97, 0xea, 0x51, 0xe1, 0x79, 0x18, 0x35, 0x89, 0xd5, 0xef, 0xd8,
-	0xae, 0x3f, 0x53, 0x21, 0x7f, 0x18, 0x4e, 0x76, 0x51, 0xd2, 0x11, 0xe0, 0x86, 0x6d, 0x12, 0x55,
-	0x0f, 0xd0, 0x2c, 0x43, 0xb6, 0xb5, 0xdf, 0x37, 0x0e, 0x48, 0xdb, 0x4d, 0x25, 0x63, 0xbb, 0xec,
-	0xb2, 0xb1, 0x3d, 0x2b, 0x0c, 0xc3, 0x92, 0x21, 0x4f, 0xb4, 0xfc, 0x4b, 0xa7, 0xea, 0x9d, 0xa8,
-	0x1d, 0x29, 0x9a, 0xd1, 0x26, 0x87, 0x34, 0x15, 0x09, 0x19, 0xa8, 0x68, 0xdd, 0x91, 0x48, 0x3f,
-	0x20, 0xc8, 0x47, 0xf0, 0xe0, 0x5d, 0x48, 0xd1, 0xe4, 0x87, 0x9f, 0xe0, 0x5e, 0x93, 0xd5, 0xca,
-	0x43, 0x55, 0x33, 0x97, 0x6f, 0x3f, 0x7d, 0x31, 0x17, 0xfb, 0xed, 0xc5, 0xdc, 0xe2, 0x79, 0xae,
-	0x23, 0xb6, 0xaf, 0xd2, 0x56, 0x7b, 0x36, 0x31, 0x65, 0xce, 0x8e, 0x17, 0x21, 0x45, 0x2d, 0x76,
-	0xeb, 0x34, 0x1f, 0xe1, 0xdc, 0x72, 0xd2, 0x39, 0x47, 0xe6, 0x40, 0xe9, 0x27, 0x04, 0x19, 0x9f,
-	0x16, 0x8b, 0x90, 0xd1, 0x35, 0x43, 0xb1, 0x35, 0x9d, 0x28, 0xf4, 0x51, 0x73, 0x7c, 0x4c, 0xeb,
-	0x9a, 0xb1, 0xa5, 0xe9, 0xa4, 0x66, 0x51, 0xbd, 0x7a, 0xe8, 0xe9, 0xe3, 0x5c, 0xaf, 0x1e, 0x72,
-	0xfd, 0x02, 0x24, 0x9d, 0xe2, 0x29, 0x24, 0x8a, 0xa8, 0x94, 0x5d, 0xfa, 0x47, 0x84, 0x01, 0xe5,
-	0xaa, 0xd1, 0xea, 0xb6, 0x35, 0x63, 0x4f, 0xa6, 0x48, 0x8c, 0x21, 0xd9, 0x56, 0x6d, 0xb5, 0x90,
-	0x2c, 0xa2, 0xd2, 0xb8, 0x4c, 0xff, 0x4b, 0x45, 0x18, 0x73, 0x51, 0x4e, 0xd9, 0x6c, 0xd7, 0x37,
-	0xea, 0x9b, 0x8f, 0xeb, 0xb9, 0x18, 0x1e, 0x85, 0xc4, 0xce, 0xa6, 0x9c, 0x43, 0xd2, 0x37, 0x08,
-	0xc6, 0xfd, 0x05, 0x8d, 0xaf, 0x01, 0xb6, 0x6c, 0xd5, 0xb4, 0xa9, 0x69, 0x96, 0xad, 0xea, 0xbd,
-	0x81, 0xfd, 0x39, 0xaa, 0xd9, 0x72, 0x15, 0x35, 0x0b, 0x97, 0x20, 0x47, 0x8c, 0x76, 0x10, 0xcb,
-	0x7c, 0xc9, 0x12, 0xa3, 0xed, 0x47, 0xfa, 0x6f, 0xb2, 0xc4, 0xb9, 0x6e, 0xb2, 0xef, 0x10, 0x5c,
-	0xac, 0x1e, 0x12, 0xbd, 0xd7, 0x51, 0xcd, 0xf7, 0x62, 0xe2, 0xe2, 0x90, 0x89, 0x53, 0x51, 0x26,
-	0x5a, 0x3e, 0x1b, 0x37, 0x60, 0x22, 0xf0, 0xf8, 0xe0, 0x3b, 0x00, 0xf4, 0xa4, 0xa8, 0x9b, 0xa3,
-	0xd7, 0x2c, 0x3b, 0xc7, 0xb1, 0x62, 0xe6, 0xf5, 0xe3, 0x43, 0x4b, 0x5f, 0x23, 0xc8, 0x53, 0x36,
-	0xf7, 0xb9, 0xe3, 0x9c, 0x77, 0x21, 0xc3, 0xaa, 0xcc, 0x4f, 0x3a, 0xe3, 0x9a, 0x36, 0xa0, 0xf4,
-	0xd7, 0xa5, 0x7f, 0x47, 0xc8, 0xa8, 0xf8, 0x1b, 0x19, 0xd5, 0x80, 0xa9, 0x50, 0x12, 0xde, 0x82,
-	0xa7, 0xbf, 0x20, 0xc0, 0xfe, 0xae, 0xcb, 0x13, 0x7b, 0x46, 0x2b, 0x89, 0xce, 0x7b, 0xfc, 0x0d,
-	0xf2, 0x9e, 0x38, 0x33, 0xef, 0xce, 0xd3, 0x73, 0x8e, 0xbc, 0xdf, 0x82, 0x7c, 0xc0, 0x7e, 0x1e,
-	0x93, 0x7f, 0xc2, 0xb8, 0xaf, 0xd9, 0xb9, 0x0d, 0x3d, 0x33, 0xe8, 0x58, 0x96, 0xf4, 0x2d, 0x82,
-	0xc9, 0xc1, 0x90, 0xf2, 0x7e, 0x4b, 0xfa, 0x5c, 0xae, 0xfd, 0x9f, 0xa7, 0x86, 0xdb, 0xc7, 0x3d,
-	0x3b, 0x6b, 0x52, 0x91, 0x30, 0xe4, 0xb6, 0x2d, 0x62, 0x36, 0x6c, 0xd5, 0x76, 0xbd, 0x92, 0x7e,
-	0x46, 0x30, 0xe9, 0x13, 0x72, 0xaa, 0x2b, 0xee, 0xc0, 0xa9, 0x75, 0x0d, 0xc5, 0x54, 0x6d, 0x96,
-	0x69, 0x24, 0x4f, 0x78, 0x52, 0x59, 0xb5, 0x89, 0x53, 0x0c, 0x46, 0x5f, 0x1f, 0x0c, 0x0c, 0x4e,
-	0xbf, 0x4e, 0x1b, 0x7d, 0x9d, 0xf7, 0x82, 0x6b, 0x80, 0xd5, 0x9e, 0xa6, 0x84, 0x98, 0x12, 0x94,
-	0x29, 0xa7, 0xf6, 0xb4, 0xf5, 0x00, 0x59, 0x19, 0xf2, 0x66, 0xbf, 0x43, 0xc2, 0xf0, 0x24, 0x85,
-	0x4f, 0x3a, 0xaa, 0x00, 0x5e, 0xfa, 0x04, 0xf2, 0x8e, 0xe1, 0xeb, 0xab, 0x41, 0xd3, 0x67, 0x60,
-	0xb4, 0x6f, 0x11, 0x53, 0xd1, 0xda, 0xbc, 0x3a, 0x53, 0xce, 0x72, 0xbd, 0x8d, 0xaf, 0xf3, 0xcb,
-	0x37, 0x4e, 0x63, 0x7c, 0xc9, 0x8d, 0xf1, 0x90, 0xf3, 0xfc, 0x5e, 0xbe, 0x0f, 0xd8, 0x51, 0x59,
-	0x41, 0xf6, 0x45, 0x18, 0xb1, 0x1c, 0x41, 0xb8, 0xa5, 0x46, 0x58, 0x22, 0x33, 0xa4, 0xf4, 0x23,
-	0x02, 0xb1, 0x46, 0x6c, 0x53, 0x6b, 0x59, 0xf7, 0xba, 0x66, 0x30, 0xa5, 0xef, 0xb8, 0xb4, 0x6e,
-	0xc1, 0xb8, 0x5b, 0x33, 0x8a, 0x45, 0xec, 0xd3, 0x6f, 0xcc, 0x8c, 0x0b, 0x6d, 0x10, 0x5b, 0xda,
-	0x80, 0xb9, 0x13, 0x6d, 0xe6, 0xa1, 0x28, 0x41, 0x4a, 0xa7, 0x10, 0x1e, 0x8b, 0xdc, 0xe0, 0x62,
-	0x61, 0x5b, 0x65, 0xae, 0x97, 0x0a, 0x30, 0xcd, 0xc9, 0x6a, 0xc4, 0x56, 0x9d, 0xe8, 0xba, 0xd5,
-	0xb7, 0x09, 0x33, 0x43, 0x1a, 0x4e, 0x7f, 0x13, 0xc6, 0x74, 0x2e, 0xe3, 0x07, 0x14, 0xc2, 0x07,
-	0x78, 0x7b, 0x3c, 0xa4, 0xf4, 0x27, 0x82, 0x0b, 0xa1, 0xdb, 0xd6, 0x89, 0xd7, 0xae, 0xd9, 0xd5,
-	0x15, 0xf7, 0x15, 0x6a, 0x50, 0x1a, 0x59, 0x47, 0xbe, 0xce, 0xc5, 0xeb, 0x6d, 0x7f, 0xed, 0xc4,
-	0x03, 0xb5, 0x33, 0x98, 0x6a, 0x12, 0xef, 0x74, 0xaa, 0xf9, 0x9f, 0x37, 0xd5, 0x24, 0xe9, 0x39,
-	0x13, 0x6e, 0xaa, 0xa2, 0xe6, 0x99, 0x2f, 0x11, 0x8c, 0x30, 0x0f, 0xdf, 0x55, 0xfd, 0x08, 0x30,
-	0x46, 0xf8, 0x6c, 0x42, 0x1f, 0xdb, 0x11, 0xd9, 0x5b, 0x47, 0xce, 0x32, 0x15, 0x98, 0x08, 0xd4,
-	0xca, 0xdf, 0x78, 0x3f, 0x54, 0x60, 0xdc, 0xaf, 0xc1, 0x57, 0xf8, 0x90, 0x85, 0xe8, 0x90, 0x35,
-	0xe9, 0xee, 0xa6, 0x6a, 0x3a, 0x91, 0x7b, 0x93, 0x15, 0x6d, 0x48, 0x2c, 0x6d, 0xf4, 0xff, 0xe0,
-	0x45, 0x22, 0x41, 0x85, 0x6c, 0x21, 0x7d, 0x8e, 0x20, 0x3b, 0xa8, 0x90, 0x7b, 0x5a, 0x87, 0xbc,
-	0x8d, 0x02, 0x11, 0x60, 0x6c, 0x57, 0xeb, 0x10, 0x6a, 0x03, 0x3b, 0xce, 0x5b, 0x47, 0x45, 0xea,
-	0xbf, 0x1f, 0x42, 0xda, 0x73, 0x01, 0xa7, 0x61, 0xa4, 0xfa, 0x68, 0xbb, 0xf2, 0x20, 0x17, 0xc3,
-	0x13, 0x90, 0xae, 0x6f, 0x6e, 0x29, 0x6c, 0x89, 0xf0, 0x05, 0xc8, 0xc8, 0xd5, 0xfb, 0xd5, 0x1d,
-	0xa5, 0x56, 0xd9, 0x5a, 0x59, 0xcb, 0xc5, 0x31, 0x86, 0x2c, 0x13, 0xd4, 0x37, 0xb9, 0x2c, 0xb1,
-	0xf4, 0xc5, 0x28, 0x8c, 0xb9, 0x36, 0xe2, 0xdb, 0x90, 0x7c, 0xd8, 0xb7, 0xf6, 0xf1, 0xf4, 0xa0,
-	0x42, 0x1f, 0x9b, 0x9a, 0x4d, 0xf8, 0x13, 0x27, 0xcc, 0x0c, 0xc9, 0xd9, 0xf3, 0x26, 0xc5, 0xf0,
-	0x2a, 0x64, 0x7c, 0xa3, 0x0d, 0x8e, 0x7c, 0x99, 0x12, 0x2e, 0x07, 0xa4, 0xc1, 0x29, 0x48, 0x8a,
-	0x2d, 0x20, 0xbc, 0x09, 0x59, 0xaa, 0x72, 0x27, 0x12, 0x0b, 0x7b, 0x93, 0x71, 0xd4, 0xa4, 0x28,
-	0xcc, 0x9e, 0xa0, 0xf5, 0xcc, 0x5a, 0x0b, 0xbe, 0xe7, 0x0b, 0x51, 0x9f, 0x04, 0xc2, 0xc6, 0x45,
-	0x34, 0x7e, 0x29, 0x86, 0xab, 0x00, 0x83, 0xb6, 0x89, 0x2f, 0x05, 0xc0, 0xfe, 0x56, 0x2f, 0x08,
-	0x51, 0x2a, 0x8f, 0x66, 0x19, 0xd2, 0x5e, 0xd3, 0xc0, 0x85, 0x88, 0x3e, 0xc2, 0x48, 0x4e, 0xee,
-	0x30, 0x52, 0x0c, 0xdf, 0x83, 0xf1, 0x4a, 0xa7, 0x73, 0x1e, 0x1a, 0xc1, 0xaf, 0xb1, 0xc2, 0x3c,
-	0x1d, 0xef, 0x02, 0x0d, 0xdf, 0xd3, 0xf8, 0xaa, 0xf7, 0xac, 0x9c, 0xda, 0x7c, 0x84, 0x7f, 0x9f,
-	0x89, 0xf3, 0x4e, 0xdb, 0x82, 0x0b, 0xa1, 0xeb, 0x1a, 0x8b, 0xa1, 0xdd, 0xa1, 0x1b, 0x5e, 0x98,
-	0x3b, 0x51, 0xef, 0xb1, 0x36, 0xf9, 0xa0, 0x16, 0xfc, 0x24, 0x84, 0xa5, 0xe1, 0x24, 0x84, 0xbf,
-	0x3f, 0x09, 0xff, 0x3a, 0x15, 0xe3, 0xab, 0xca, 0x03, 0x98, 0x8e, 0xfe, 0xe4, 0x82, 0xaf, 0x44,
-	0xd4, 0xcc, 0xf0, 0x67, 0x20, 0xe1, 0xea, 0x59, 0xb0, 0xc1, 0x61, 0xcb, 0x1f, 0x3c, 0x7b, 0x29,
-	0xc6, 0x9e, 0xbf, 0x14, 0x63, 0xaf, 0x5f, 0x8a, 0xe8, 0xb3, 0x63, 0x11, 0x7d, 0x7f, 0x2c, 0xa2,
-	0xa7, 0xc7, 0x22, 0x7a, 0x76, 0x2c, 0xa2, 0xdf, 0x8f, 0x45, 0xf4, 0xc7, 0xb1, 0x18, 0x7b, 0x7d,
-	0x2c, 0xa2, 0xaf, 0x5e, 0x89, 0xb1, 0x67, 0xaf, 0xc4, 0xd8, 0xf3, 0x57, 0x62, 0xec, 0xe3, 0x54,
-	0xab, 0xa3, 0x11, 0xc3, 0x6e, 0xa6, 0xe8, 0x87, 0xb7, 0x1b, 0x7f, 0x05, 0x00, 0x00, 0xff, 0xff,
-	0x2f, 0xe4, 0x86, 0xaf, 0xf3, 0x13, 0x00, 0x00,
+func (m *LabelNamesResponse) Reset()      { *m = LabelNamesResponse{} }
+func (*LabelNamesResponse) ProtoMessage() {}
+func (*LabelNamesResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{30}
 }
-
-func (x MatchType) String() string {
-	s, ok := MatchType_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *LabelNamesResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
 }
-func (x ReadRequest_ResponseType) String() string {
-	s, ok := ReadRequest_ResponseType_name[int32(x)]
-	if ok {
-		return s
+func (m *LabelNamesResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelNamesResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
 	}
-	return strconv.Itoa(int(x))
 }
-func (x StreamChunk_Encoding) String() string {
-	s, ok := StreamChunk_Encoding_name[int32(x)]
-	if ok {
-		return s
-	}
-	return strconv.Itoa(int(x))
+func (m *LabelNamesResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelNamesResponse.Merge(m, src)
+}
+func (m *LabelNamesResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelNamesResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelNamesResponse.DiscardUnknown(m)
 }
-func (this *LabelNamesAndValuesRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelNamesAndValuesRequest)
-	if !ok {
-		that2, ok := that.(LabelNamesAndValuesRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
-	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
-		}
+var xxx_messageInfo_LabelNamesResponse proto.InternalMessageInfo
+
+func (m *LabelNamesResponse) GetLabelNames() []string {
+	if m != nil {
+		return m.LabelNames
 	}
-	return true
+	return nil
 }
-func (this *LabelNamesAndValuesResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelNamesAndValuesResponse)
-	if !ok {
-		that2, ok := that.(LabelNamesAndValuesResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Items) != len(that1.Items) {
-		return false
-	}
-	for i := range this.Items {
-		if !this.Items[i].Equal(that1.Items[i]) {
-			return false
-		}
-	}
-	return true
+type UserStatsRequest struct {
 }
-func (this *LabelValues) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelValues)
-	if !ok {
-		that2, ok := that.(LabelValues)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
-	if len(this.Values) != len(that1.Values) {
-		return false
-	}
-	for i := range this.Values {
-		if this.Values[i] != that1.Values[i] {
-			return false
+func (m *UserStatsRequest) Reset()      { *m = UserStatsRequest{} }
+func (*UserStatsRequest) ProtoMessage() {}
+func (*UserStatsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{31}
+}
+func (m *UserStatsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UserStatsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UserStatsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *LabelValuesCardinalityRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *UserStatsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserStatsRequest.Merge(m, src)
+}
+func (m *UserStatsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *UserStatsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserStatsRequest.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*LabelValuesCardinalityRequest)
-	if !ok {
-		that2, ok := that.(LabelValuesCardinalityRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.LabelNames) != len(that1.LabelNames) {
-		return false
-	}
-	for i := range this.LabelNames {
-		if this.LabelNames[i] != that1.LabelNames[i] {
-			return false
-		}
-	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
-	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
-		}
-	}
-	return true
+var xxx_messageInfo_UserStatsRequest proto.InternalMessageInfo
+
+type UserStatsResponse struct {
+	IngestionRate     float64 `protobuf:"fixed64,1,opt,name=ingestion_rate,json=ingestionRate,proto3" json:"ingestion_rate,omitempty"`
+	NumSeries         uint64  `protobuf:"varint,2,opt,name=num_series,json=numSeries,proto3" json:"num_series,omitempty"`
+	ApiIngestionRate  float64 `protobuf:"fixed64,3,opt,name=api_ingestion_rate,json=apiIngestionRate,proto3" json:"api_ingestion_rate,omitempty"`
+	RuleIngestionRate float64 `protobuf:"fixed64,4,opt,name=rule_ingestion_rate,json=ruleIngestionRate,proto3" json:"rule_ingestion_rate,omitempty"`
 }
-func (this *LabelValuesCardinalityResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelValuesCardinalityResponse)
-	if !ok {
-		that2, ok := that.(LabelValuesCardinalityResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Items) != len(that1.Items) {
-		return false
-	}
-	for i := range this.Items {
-		if !this.Items[i].Equal(that1.Items[i]) {
-			return false
+func (m *UserStatsResponse) Reset()      { *m = UserStatsResponse{} }
+func (*UserStatsResponse) ProtoMessage() {}
+func (*UserStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{32}
+}
+func (m *UserStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UserStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UserStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *LabelValueSeriesCount) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *UserStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserStatsResponse.Merge(m, src)
+}
+func (m *UserStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *UserStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserStatsResponse.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*LabelValueSeriesCount)
-	if !ok {
-		that2, ok := that.(LabelValueSeriesCount)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
-	if len(this.LabelValueSeries) != len(that1.LabelValueSeries) {
-		return false
-	}
-	for i := range this.LabelValueSeries {
-		if this.LabelValueSeries[i] != that1.LabelValueSeries[i] {
-			return false
-		}
+var xxx_messageInfo_UserStatsResponse proto.InternalMessageInfo
+
+func (m *UserStatsResponse) GetIngestionRate() float64 {
+	if m != nil {
+		return m.IngestionRate
 	}
-	return true
+	return 0
 }
-func (this *ReadRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*ReadRequest)
-	if !ok {
-		that2, ok := that.(ReadRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Queries) != len(that1.Queries) {
-		return false
-	}
-	for i := range this.Queries {
-		if !this.Queries[i].Equal(that1.Queries[i]) {
-			return false
-		}
-	}
-	if len(this.AcceptedResponseTypes) != len(that1.AcceptedResponseTypes) {
-		return false
+func (m *UserStatsResponse) GetNumSeries() uint64 {
+	if m != nil {
+		return m.NumSeries
 	}
-	for i := range this.AcceptedResponseTypes {
-		if this.AcceptedResponseTypes[i] != that1.AcceptedResponseTypes[i] {
-			return false
-		}
+	return 0
+}
+
+func (m *UserStatsResponse) GetApiIngestionRate() float64 {
+	if m != nil {
+		return m.ApiIngestionRate
 	}
-	return true
+	return 0
 }
-func (this *ReadResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *UserStatsResponse) GetRuleIngestionRate() float64 {
+	if m != nil {
+		return m.RuleIngestionRate
 	}
+	return 0
+}
 
-	that1, ok := that.(*ReadResponse)
-	if !ok {
-		that2, ok := that.(ReadResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type UserIDStatsResponse struct {
+	UserId string             `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Data   *UserStatsResponse `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *UserIDStatsResponse) Reset()      { *m = UserIDStatsResponse{} }
+func (*UserIDStatsResponse) ProtoMessage() {}
+func (*UserIDStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{33}
+}
+func (m *UserIDStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UserIDStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UserIDStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Results) != len(that1.Results) {
-		return false
-	}
-	for i := range this.Results {
-		if !this.Results[i].Equal(that1.Results[i]) {
-			return false
-		}
+}
+func (m *UserIDStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserIDStatsResponse.Merge(m, src)
+}
+func (m *UserIDStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *UserIDStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserIDStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserIDStatsResponse proto.InternalMessageInfo
+
+func (m *UserIDStatsResponse) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	return true
+	return ""
 }
-func (this *StreamReadResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *UserIDStatsResponse) GetData() *UserStatsResponse {
+	if m != nil {
+		return m.Data
 	}
+	return nil
+}
 
-	that1, ok := that.(*StreamReadResponse)
-	if !ok {
-		that2, ok := that.(StreamReadResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.ChunkedSeries) != len(that1.ChunkedSeries) {
-		return false
-	}
-	for i := range this.ChunkedSeries {
-		if !this.ChunkedSeries[i].Equal(that1.ChunkedSeries[i]) {
-			return false
-		}
-	}
-	if this.QueryIndex != that1.QueryIndex {
-		return false
-	}
-	return true
+type UsersStatsResponse struct {
+	Stats []*UserIDStatsResponse `protobuf:"bytes,1,rep,name=stats,proto3" json:"stats,omitempty"`
 }
-func (this *StreamChunkedSeries) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*StreamChunkedSeries)
-	if !ok {
-		that2, ok := that.(StreamChunkedSeries)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Labels) != len(that1.Labels) {
-		return false
-	}
-	for i := range this.Labels {
-		if !this.Labels[i].Equal(that1.Labels[i]) {
-			return false
-		}
-	}
-	if len(this.Chunks) != len(that1.Chunks) {
-		return false
-	}
-	for i := range this.Chunks {
-		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
-			return false
+func (m *UsersStatsResponse) Reset()      { *m = UsersStatsResponse{} }
+func (*UsersStatsResponse) ProtoMessage() {}
+func (*UsersStatsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{34}
+}
+func (m *UsersStatsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *UsersStatsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_UsersStatsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *StreamChunk) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *UsersStatsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsersStatsResponse.Merge(m, src)
+}
+func (m *UsersStatsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *UsersStatsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsersStatsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsersStatsResponse proto.InternalMessageInfo
+
+func (m *UsersStatsResponse) GetStats() []*UserIDStatsResponse {
+	if m != nil {
+		return m.Stats
 	}
+	return nil
+}
 
-	that1, ok := that.(*StreamChunk)
-	if !ok {
-		that2, ok := that.(StreamChunk)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type MetricsForLabelMatchersRequest struct {
+	StartTimestampMs int64            `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64            `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	MatchersSet      []*LabelMatchers `protobuf:"bytes,3,rep,name=matchers_set,json=matchersSet,proto3" json:"matchers_set,omitempty"`
+}
+
+func (m *MetricsForLabelMatchersRequest) Reset()      { *m = MetricsForLabelMatchersRequest{} }
+func (*MetricsForLabelMatchersRequest) ProtoMessage() {}
+func (*MetricsForLabelMatchersRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{35}
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsForLabelMatchersRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.MinTimeMs != that1.MinTimeMs {
-		return false
-	}
-	if this.MaxTimeMs != that1.MaxTimeMs {
-		return false
-	}
-	if this.Type != that1.Type {
-		return false
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsForLabelMatchersRequest.Merge(m, src)
+}
+func (m *MetricsForLabelMatchersRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsForLabelMatchersRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsForLabelMatchersRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsForLabelMatchersRequest proto.InternalMessageInfo
+
+func (m *MetricsForLabelMatchersRequest) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
-		return false
+	return 0
+}
+
+func (m *MetricsForLabelMatchersRequest) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
 	}
-	return true
+	return 0
 }
-func (this *QueryRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *MetricsForLabelMatchersRequest) GetMatchersSet() []*LabelMatchers {
+	if m != nil {
+		return m.MatchersSet
 	}
+	return nil
+}
 
-	that1, ok := that.(*QueryRequest)
-	if !ok {
-		that2, ok := that.(QueryRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type MetricsForLabelMatchersResponse struct {
+	Metric []*mimirpb.Metric `protobuf:"bytes,1,rep,name=metric,proto3" json:"metric,omitempty"`
+}
+
+func (m *MetricsForLabelMatchersResponse) Reset()      { *m = MetricsForLabelMatchersResponse{} }
+func (*MetricsForLabelMatchersResponse) ProtoMessage() {}
+func (*MetricsForLabelMatchersResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{36}
+}
+func (m *MetricsForLabelMatchersResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsForLabelMatchersResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsForLabelMatchersResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
-	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
+}
+func (m *MetricsForLabelMatchersResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsForLabelMatchersResponse.Merge(m, src)
+}
+func (m *MetricsForLabelMatchersResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsForLabelMatchersResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsForLabelMatchersResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsForLabelMatchersResponse proto.InternalMessageInfo
+
+func (m *MetricsForLabelMatchersResponse) GetMetric() []*mimirpb.Metric {
+	if m != nil {
+		return m.Metric
 	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
+	return nil
+}
+
+type MetricsMetadataRequest struct {
+}
+
+func (m *MetricsMetadataRequest) Reset()      { *m = MetricsMetadataRequest{} }
+func (*MetricsMetadataRequest) ProtoMessage() {}
+func (*MetricsMetadataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{37}
+}
+func (m *MetricsMetadataRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsMetadataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsMetadataRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
+func (m *MetricsMetadataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsMetadataRequest.Merge(m, src)
+}
+func (m *MetricsMetadataRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsMetadataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsMetadataRequest.DiscardUnknown(m)
+}
 
-	that1, ok := that.(*ExemplarQueryRequest)
-	if !ok {
-		that2, ok := that.(ExemplarQueryRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
-	}
-	if len(this.Matchers) != len(that1.Matchers) {
-		return false
-	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
-			return false
-		}
-	}
-	return true
+var xxx_messageInfo_MetricsMetadataRequest proto.InternalMessageInfo
+
+type MetricsMetadataResponse struct {
+	Metadata []*mimirpb.MetricMetadata `protobuf:"bytes,1,rep,name=metadata,proto3" json:"metadata,omitempty"`
 }
-func (this *QueryResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*QueryResponse)
-	if !ok {
-		that2, ok := that.(QueryResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
-		return false
-	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
-			return false
+func (m *MetricsMetadataResponse) Reset()      { *m = MetricsMetadataResponse{} }
+func (*MetricsMetadataResponse) ProtoMessage() {}
+func (*MetricsMetadataResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{38}
+}
+func (m *MetricsMetadataResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *MetricsMetadataResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_MetricsMetadataResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *QueryStreamResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *MetricsMetadataResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MetricsMetadataResponse.Merge(m, src)
+}
+func (m *MetricsMetadataResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *MetricsMetadataResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_MetricsMetadataResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MetricsMetadataResponse proto.InternalMessageInfo
+
+func (m *MetricsMetadataResponse) GetMetadata() []*mimirpb.MetricMetadata {
+	if m != nil {
+		return m.Metadata
 	}
+	return nil
+}
 
-	that1, ok := that.(*QueryStreamResponse)
-	if !ok {
-		that2, ok := that.(QueryStreamResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type TimeSeriesChunk struct {
+	FromIngesterId string                                              `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
+	UserId         string                                              `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Labels         []github_com_grafana_mimir_pkg_mimirpb.LabelAdapter `protobuf:"bytes,3,rep,name=labels,proto3,customtype=github.com/grafana/mimir/pkg/mimirpb.LabelAdapter" json:"labels"`
+	Chunks         []Chunk                                             `protobuf:"bytes,4,rep,name=chunks,proto3" json:"chunks"`
+}
+
+func (m *TimeSeriesChunk) Reset()      { *m = TimeSeriesChunk{} }
+func (*TimeSeriesChunk) ProtoMessage() {}
+func (*TimeSeriesChunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{39}
+}
+func (m *TimeSeriesChunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeSeriesChunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeSeriesChunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.Chunkseries) != len(that1.Chunkseries) {
-		return false
+}
+func (m *TimeSeriesChunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSeriesChunk.Merge(m, src)
+}
+func (m *TimeSeriesChunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeSeriesChunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSeriesChunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeSeriesChunk proto.InternalMessageInfo
+
+func (m *TimeSeriesChunk) GetFromIngesterId() string {
+	if m != nil {
+		return m.FromIngesterId
 	}
-	for i := range this.Chunkseries {
-		if !this.Chunkseries[i].Equal(&that1.Chunkseries[i]) {
-			return false
-		}
+	return ""
+}
+
+func (m *TimeSeriesChunk) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
-		return false
+	return ""
+}
+
+func (m *TimeSeriesChunk) GetChunks() []Chunk {
+	if m != nil {
+		return m.Chunks
 	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
-			return false
+	return nil
+}
+
+type Chunk struct {
+	StartTimestampMs int64  `protobuf:"varint,1,opt,name=start_timestamp_ms,json=startTimestampMs,proto3" json:"start_timestamp_ms,omitempty"`
+	EndTimestampMs   int64  `protobuf:"varint,2,opt,name=end_timestamp_ms,json=endTimestampMs,proto3" json:"end_timestamp_ms,omitempty"`
+	Encoding         int32  `protobuf:"varint,3,opt,name=encoding,proto3" json:"encoding,omitempty"`
+	Data             []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *Chunk) Reset()      { *m = Chunk{} }
+func (*Chunk) ProtoMessage() {}
+func (*Chunk) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{40}
+}
+func (m *Chunk) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Chunk) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Chunk.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *ExemplarQueryResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *Chunk) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Chunk.Merge(m, src)
+}
+func (m *Chunk) XXX_Size() int {
+	return m.Size()
+}
+func (m *Chunk) XXX_DiscardUnknown() {
+	xxx_messageInfo_Chunk.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Chunk proto.InternalMessageInfo
+
+func (m *Chunk) GetStartTimestampMs() int64 {
+	if m != nil {
+		return m.StartTimestampMs
 	}
+	return 0
+}
 
-	that1, ok := that.(*ExemplarQueryResponse)
-	if !ok {
-		that2, ok := that.(ExemplarQueryResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func (m *Chunk) GetEndTimestampMs() int64 {
+	if m != nil {
+		return m.EndTimestampMs
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return 0
+}
+
+func (m *Chunk) GetEncoding() int32 {
+	if m != nil {
+		return m.Encoding
 	}
-	if len(this.Timeseries) != len(that1.Timeseries) {
-		return false
+	return 0
+}
+
+func (m *Chunk) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
-	for i := range this.Timeseries {
-		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
-			return false
-		}
-	}
-	return true
+	return nil
 }
-func (this *LabelValuesRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelValuesRequest)
-	if !ok {
-		that2, ok := that.(LabelValuesRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.LabelName != that1.LabelName {
-		return false
-	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
-	}
-	if !this.Matchers.Equal(that1.Matchers) {
-		return false
-	}
-	return true
+type LabelMatchers struct {
+	Matchers []*LabelMatcher `protobuf:"bytes,1,rep,name=matchers,proto3" json:"matchers,omitempty"`
 }
-func (this *LabelValuesResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
-	}
 
-	that1, ok := that.(*LabelValuesResponse)
-	if !ok {
-		that2, ok := that.(LabelValuesResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
-	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if len(this.LabelValues) != len(that1.LabelValues) {
-		return false
-	}
-	for i := range this.LabelValues {
-		if this.LabelValues[i] != that1.LabelValues[i] {
-			return false
+func (m *LabelMatchers) Reset()      { *m = LabelMatchers{} }
+func (*LabelMatchers) ProtoMessage() {}
+func (*LabelMatchers) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{41}
+}
+func (m *LabelMatchers) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelMatchers) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelMatchers.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	return true
 }
-func (this *LabelNamesRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+func (m *LabelMatchers) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelMatchers.Merge(m, src)
+}
+func (m *LabelMatchers) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelMatchers) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelMatchers.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelMatchers proto.InternalMessageInfo
+
+func (m *LabelMatchers) GetMatchers() []*LabelMatcher {
+	if m != nil {
+		return m.Matchers
 	}
+	return nil
+}
 
-	that1, ok := that.(*LabelNamesRequest)
-	if !ok {
-		that2, ok := that.(LabelNamesRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type LabelMatcher struct {
+	Type  MatchType `protobuf:"varint,1,opt,name=type,proto3,enum=cortex.MatchType" json:"type,omitempty"`
+	Name  string    `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Value string    `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (m *LabelMatcher) Reset()      { *m = LabelMatcher{} }
+func (*LabelMatcher) ProtoMessage() {}
+func (*LabelMatcher) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{42}
+}
+func (m *LabelMatcher) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *LabelMatcher) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_LabelMatcher.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
-	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
-		return false
-	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
-		return false
+}
+func (m *LabelMatcher) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LabelMatcher.Merge(m, src)
+}
+func (m *LabelMatcher) XXX_Size() int {
+	return m.Size()
+}
+func (m *LabelMatcher) XXX_DiscardUnknown() {
+	xxx_messageInfo_LabelMatcher.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LabelMatcher proto.InternalMessageInfo
+
+func (m *LabelMatcher) GetType() MatchType {
+	if m != nil {
+		return m.Type
 	}
-	if !this.Matchers.Equal(that1.Matchers) {
-		return false
+	return EQUAL
+}
+
+func (m *LabelMatcher) GetName() string {
+	if m != nil {
+		return m.Name
 	}
-	return true
+	return ""
 }
-func (this *LabelNamesResponse) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *LabelMatcher) GetValue() string {
+	if m != nil {
+		return m.Value
 	}
+	return ""
+}
 
-	that1, ok := that.(*LabelNamesResponse)
-	if !ok {
-		that2, ok := that.(LabelNamesResponse)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
+type TimeSeriesFile struct {
+	FromIngesterId string `protobuf:"bytes,1,opt,name=from_ingester_id,json=fromIngesterId,proto3" json:"from_ingester_id,omitempty"`
+	UserId         string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Filename       string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"`
+	Data           []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *TimeSeriesFile) Reset()      { *m = TimeSeriesFile{} }
+func (*TimeSeriesFile) ProtoMessage() {}
+func (*TimeSeriesFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_60f6df4f3586b478, []int{43}
+}
+func (m *TimeSeriesFile) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TimeSeriesFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TimeSeriesFile.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
 		}
+		return b[:n], nil
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+}
+func (m *TimeSeriesFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeSeriesFile.Merge(m, src)
+}
+func (m *TimeSeriesFile) XXX_Size() int {
+	return m.Size()
+}
+func (m *TimeSeriesFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeSeriesFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeSeriesFile proto.InternalMessageInfo
+
+func (m *TimeSeriesFile) GetFromIngesterId() string {
+	if m != nil {
+		return m.FromIngesterId
 	}
-	if len(this.LabelNames) != len(that1.LabelNames) {
-		return false
+	return ""
+}
+
+func (m *TimeSeriesFile) GetUserId() string {
+	if m != nil {
+		return m.UserId
 	}
-	for i := range this.LabelNames {
-		if this.LabelNames[i] != that1.LabelNames[i] {
-			return false
-		}
+	return ""
+}
+
+func (m *TimeSeriesFile) GetFilename() string {
+	if m != nil {
+		return m.Filename
 	}
-	return true
+	return ""
 }
-func (this *UserStatsRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+
+func (m *TimeSeriesFile) GetData() []byte {
+	if m != nil {
+		return m.Data
 	}
+	return nil
+}
 
-	that1, ok := that.(*UserStatsRequest)
-	if !ok {
-		that2, ok := that.(UserStatsRequest)
-		if ok {
-			that1 = &that2
-		} else {
-			return false
-		}
+func init() {
+	proto.RegisterEnum("cortex.LabelValuePresence", LabelValuePresence_name, LabelValuePresence_value)
+	proto.RegisterEnum("cortex.LabelValueRelabelOutcome", LabelValueRelabelOutcome_name, LabelValueRelabelOutcome_value)
+	proto.RegisterEnum("cortex.MatchType", MatchType_name, MatchType_value)
+	proto.RegisterEnum("cortex.ReadRequest_ResponseType", ReadRequest_ResponseType_name, ReadRequest_ResponseType_value)
+	proto.RegisterEnum("cortex.StreamChunk_Encoding", StreamChunk_Encoding_name, StreamChunk_Encoding_value)
+	proto.RegisterType((*LabelNamesAndValuesRequest)(nil), "cortex.LabelNamesAndValuesRequest")
+	proto.RegisterType((*LabelValuesBloomFilter)(nil), "cortex.LabelValuesBloomFilter")
+	proto.RegisterType((*LabelNamesAndValuesResponse)(nil), "cortex.LabelNamesAndValuesResponse")
+	proto.RegisterType((*LabelValues)(nil), "cortex.LabelValues")
+	proto.RegisterType((*LongLabelValues)(nil), "cortex.LongLabelValues")
+	proto.RegisterType((*LabelValuesCardinalityRequest)(nil), "cortex.LabelValuesCardinalityRequest")
+	proto.RegisterType((*LabelValuesCardinalityStreamRequest)(nil), "cortex.LabelValuesCardinalityStreamRequest")
+	proto.RegisterType((*LabelValuesCardinalityResponse)(nil), "cortex.LabelValuesCardinalityResponse")
+	proto.RegisterType((*LabelValuesCardinalitySummary)(nil), "cortex.LabelValuesCardinalitySummary")
+	proto.RegisterType((*LabelValuesCardinalityProgress)(nil), "cortex.LabelValuesCardinalityProgress")
+	proto.RegisterType((*LabelValuesCardinalityExplain)(nil), "cortex.LabelValuesCardinalityExplain")
+	proto.RegisterType((*LabelValueSeriesCount)(nil), "cortex.LabelValueSeriesCount")
+	proto.RegisterMapType((map[string]uint64)(nil), "cortex.LabelValueSeriesCount.LabelValueChunksEntry")
+	proto.RegisterMapType((map[string]*LabelValueCoOccurrences)(nil), "cortex.LabelValueSeriesCount.LabelValueCoOccurrencesEntry")
+	proto.RegisterMapType((map[string]*MetricNamesSeriesCount)(nil), "cortex.LabelValueSeriesCount.LabelValueMetricNamesSeriesEntry")
+	proto.RegisterMapType((map[string]float64)(nil), "cortex.LabelValueSeriesCount.LabelValueRatiosEntry")
+	proto.RegisterMapType((map[string]int64)(nil), "cortex.LabelValueSeriesCount.LabelValueSeriesDeltaEntry")
+	proto.RegisterMapType((map[string]uint64)(nil), "cortex.LabelValueSeriesCount.LabelValueSeriesEntry")
+	proto.RegisterMapType((map[string]float64)(nil), "cortex.LabelValueSeriesCount.LabelValueSeriesGrowthRateEntry")
+	proto.RegisterType((*SeriesCountPercentile)(nil), "cortex.SeriesCountPercentile")
+	proto.RegisterType((*LabelValueCoOccurrences)(nil), "cortex.LabelValueCoOccurrences")
+	proto.RegisterType((*LabelValueCoOccurrence)(nil), "cortex.LabelValueCoOccurrence")
+	proto.RegisterType((*MetricNamesSeriesCount)(nil), "cortex.MetricNamesSeriesCount")
+	proto.RegisterType((*MetricNameSeriesCount)(nil), "cortex.MetricNameSeriesCount")
+	proto.RegisterType((*ReadRequest)(nil), "cortex.ReadRequest")
+	proto.RegisterType((*ReadResponse)(nil), "cortex.ReadResponse")
+	proto.RegisterType((*StreamReadResponse)(nil), "cortex.StreamReadResponse")
+	proto.RegisterType((*StreamChunkedSeries)(nil), "cortex.StreamChunkedSeries")
+	proto.RegisterType((*StreamChunk)(nil), "cortex.StreamChunk")
+	proto.RegisterType((*QueryRequest)(nil), "cortex.QueryRequest")
+	proto.RegisterType((*ExemplarQueryRequest)(nil), "cortex.ExemplarQueryRequest")
+	proto.RegisterType((*QueryResponse)(nil), "cortex.QueryResponse")
+	proto.RegisterType((*QueryStreamResponse)(nil), "cortex.QueryStreamResponse")
+	proto.RegisterType((*ExemplarQueryResponse)(nil), "cortex.ExemplarQueryResponse")
+	proto.RegisterType((*LabelValuesRequest)(nil), "cortex.LabelValuesRequest")
+	proto.RegisterType((*LabelValuesResponse)(nil), "cortex.LabelValuesResponse")
+	proto.RegisterType((*LabelNamesRequest)(nil), "cortex.LabelNamesRequest")
+	proto.RegisterType((*LabelNamesResponse)(nil), "cortex.LabelNamesResponse")
+	proto.RegisterType((*UserStatsRequest)(nil), "cortex.UserStatsRequest")
+	proto.RegisterType((*UserStatsResponse)(nil), "cortex.UserStatsResponse")
+	proto.RegisterType((*UserIDStatsResponse)(nil), "cortex.UserIDStatsResponse")
+	proto.RegisterType((*UsersStatsResponse)(nil), "cortex.UsersStatsResponse")
+	proto.RegisterType((*MetricsForLabelMatchersRequest)(nil), "cortex.MetricsForLabelMatchersRequest")
+	proto.RegisterType((*MetricsForLabelMatchersResponse)(nil), "cortex.MetricsForLabelMatchersResponse")
+	proto.RegisterType((*MetricsMetadataRequest)(nil), "cortex.MetricsMetadataRequest")
+	proto.RegisterType((*MetricsMetadataResponse)(nil), "cortex.MetricsMetadataResponse")
+	proto.RegisterType((*TimeSeriesChunk)(nil), "cortex.TimeSeriesChunk")
+	proto.RegisterType((*Chunk)(nil), "cortex.Chunk")
+	proto.RegisterType((*LabelMatchers)(nil), "cortex.LabelMatchers")
+	proto.RegisterType((*LabelMatcher)(nil), "cortex.LabelMatcher")
+	proto.RegisterType((*TimeSeriesFile)(nil), "cortex.TimeSeriesFile")
+}
+
+func init() { proto.RegisterFile("ingester.proto", fileDescriptor_60f6df4f3586b478) }
+
+var fileDescriptor_60f6df4f3586b478 = []byte{
+	// 3857 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xbc, 0x3a, 0x49, 0x73, 0xe4, 0xd6,
+	0x79, 0x04, 0x9b, 0x4b, 0xf7, 0xd7, 0x6c, 0xb2, 0xf9, 0x9a, 0x0b, 0xd4, 0x33, 0x6c, 0xb6, 0x21,
+	0x8f, 0x4c, 0xcd, 0x48, 0x9c, 0x19, 0x4a, 0x4e, 0xc6, 0x4a, 0x1c, 0x15, 0x97, 0x9e, 0x19, 0x8a,
+	0xc3, 0xc5, 0x20, 0x95, 0x99, 0xd8, 0x95, 0x42, 0x81, 0x8d, 0x47, 0x12, 0x26, 0x96, 0x16, 0x1e,
+	0x30, 0xc3, 0x76, 0x2e, 0x49, 0x25, 0x97, 0x54, 0x0e, 0x4e, 0xe5, 0x94, 0x53, 0xaa, 0x72, 0xcb,
+	0xd1, 0x95, 0x38, 0x95, 0x9c, 0x72, 0xf6, 0x25, 0x55, 0x3a, 0xe4, 0xe0, 0xca, 0x41, 0x15, 0x8d,
+	0x2e, 0xc9, 0xcd, 0x3f, 0x21, 0xf5, 0x36, 0xe0, 0xa1, 0x1b, 0xdc, 0xaa, 0x2c, 0x9f, 0xba, 0xf1,
+	0x7d, 0xdf, 0xfb, 0xbe, 0xf7, 0x2d, 0xef, 0x5b, 0x1e, 0x00, 0xd3, 0x6e, 0x70, 0x8a, 0x49, 0x8c,
+	0xa3, 0xd5, 0x5e, 0x14, 0xc6, 0x21, 0x9a, 0xe8, 0x86, 0x51, 0x8c, 0x2f, 0x9a, 0x1f, 0x9e, 0xba,
+	0xf1, 0x59, 0x72, 0xbc, 0xda, 0x0d, 0xfd, 0x87, 0xa7, 0xe1, 0x69, 0xf8, 0x90, 0xa1, 0x8f, 0x93,
+	0x13, 0xf6, 0xc4, 0x1e, 0xd8, 0x3f, 0xbe, 0xac, 0xf9, 0x48, 0x25, 0x8f, 0xec, 0x13, 0x3b, 0xb0,
+	0x1f, 0xfa, 0xae, 0xef, 0x46, 0x0f, 0x7b, 0xe7, 0xa7, 0xfc, 0x5f, 0xef, 0x98, 0xff, 0xf2, 0x15,
+	0xc6, 0x57, 0x93, 0xd0, 0x7c, 0x61, 0x1f, 0x63, 0x6f, 0xcf, 0xf6, 0x31, 0x59, 0x0f, 0x9c, 0x3f,
+	0xb6, 0xbd, 0x04, 0x13, 0x13, 0x7f, 0x91, 0x60, 0x12, 0xa3, 0x47, 0x50, 0xf6, 0xed, 0xb8, 0x7b,
+	0x86, 0x23, 0xa2, 0x6b, 0xed, 0xd2, 0x4a, 0x75, 0x6d, 0x6e, 0x95, 0x6f, 0x6d, 0x95, 0xad, 0xda,
+	0xe5, 0x48, 0x33, 0xa5, 0x42, 0x8f, 0x60, 0xce, 0x0d, 0xba, 0x5e, 0xe2, 0x60, 0x8b, 0xe0, 0xc8,
+	0xc5, 0xc4, 0xea, 0x86, 0x49, 0x10, 0xeb, 0xa3, 0x6d, 0x6d, 0xa5, 0x6c, 0x22, 0x81, 0x3b, 0x64,
+	0xa8, 0x4d, 0x8a, 0x41, 0x0b, 0x30, 0x71, 0xe2, 0x62, 0xcf, 0x21, 0x7a, 0xa9, 0x5d, 0x5a, 0xa9,
+	0x98, 0xe2, 0x09, 0xfd, 0x10, 0xee, 0x78, 0x61, 0x70, 0x6a, 0xbd, 0xa6, 0x3b, 0xb2, 0x3c, 0x1c,
+	0x9c, 0xc6, 0x67, 0x56, 0x7c, 0x16, 0x61, 0x72, 0x16, 0x7a, 0x8e, 0x3e, 0xd6, 0xd6, 0x56, 0x6a,
+	0xa6, 0x4e, 0x49, 0xd8, 0x9e, 0x5f, 0x30, 0x82, 0x23, 0x89, 0x47, 0x9f, 0xc2, 0xdd, 0x9e, 0x1d,
+	0xc5, 0x6e, 0xec, 0x86, 0x81, 0x75, 0xdc, 0xb7, 0x4e, 0xdc, 0x88, 0xc4, 0x56, 0xf7, 0xcc, 0x8e,
+	0xec, 0x6e, 0x8c, 0x23, 0x7d, 0x9c, 0x6d, 0xe8, 0x9d, 0x94, 0x66, 0xa3, 0xff, 0x94, 0x52, 0x6c,
+	0x4a, 0x02, 0xf4, 0x3e, 0xd4, 0xa5, 0x26, 0xbd, 0x08, 0x13, 0x1c, 0x74, 0xb1, 0x3e, 0xc1, 0x16,
+	0xcd, 0x08, 0xf8, 0x81, 0x00, 0xa3, 0x3d, 0x68, 0xb0, 0x5d, 0x12, 0xeb, 0xd8, 0x0b, 0x43, 0xdf,
+	0x3a, 0x71, 0x3d, 0x2a, 0x62, 0xb2, 0xad, 0xad, 0x54, 0xd7, 0x5a, 0x39, 0x8b, 0x71, 0xfb, 0x6e,
+	0x50, 0xb2, 0xa7, 0x8c, 0xca, 0x9c, 0x7d, 0x3d, 0x08, 0x42, 0xab, 0xd0, 0xf0, 0xed, 0x0b, 0xcb,
+	0x71, 0x49, 0xec, 0x06, 0xdd, 0x98, 0x9b, 0x80, 0xe8, 0x65, 0xa6, 0xf2, 0xac, 0x6f, 0x5f, 0x6c,
+	0x09, 0x0c, 0xe7, 0x86, 0x0c, 0xa8, 0x25, 0x04, 0x0b, 0x4b, 0xb9, 0x0e, 0xd1, 0x2b, 0x6c, 0x9f,
+	0xd5, 0x84, 0x60, 0x46, 0xb1, 0xed, 0x10, 0xaa, 0x4e, 0xf7, 0x0c, 0x77, 0xcf, 0x7b, 0xa1, 0x1b,
+	0xc4, 0x56, 0x1c, 0x9e, 0xe3, 0x40, 0x87, 0xb6, 0xb6, 0x52, 0x31, 0x67, 0x32, 0xf8, 0x11, 0x05,
+	0x53, 0xf1, 0x42, 0x9d, 0x5e, 0x84, 0x5f, 0xbb, 0xf8, 0x8d, 0x45, 0xdc, 0x9f, 0x61, 0xbd, 0xca,
+	0xc5, 0x73, 0xd4, 0x01, 0xc7, 0x1c, 0xba, 0x3f, 0xc3, 0x68, 0x03, 0x96, 0x04, 0x7d, 0x37, 0xf4,
+	0xa9, 0xad, 0x08, 0xb5, 0xb9, 0xe3, 0x76, 0xa9, 0x5d, 0xed, 0xa8, 0xaf, 0x4f, 0xb5, 0xb5, 0x95,
+	0x29, 0xf3, 0x0e, 0x27, 0xda, 0xcc, 0x68, 0xb6, 0x52, 0x12, 0x2a, 0x53, 0x5a, 0x9b, 0xab, 0xc1,
+	0xc3, 0xa6, 0xc6, 0x14, 0x99, 0x15, 0x28, 0xa6, 0x0c, 0x8f, 0x9a, 0x25, 0x00, 0x6a, 0x22, 0x61,
+	0x99, 0x69, 0xb6, 0xb5, 0x8a, 0x6f, 0x5f, 0x08, 0x8b, 0xdc, 0x83, 0x69, 0xb1, 0x86, 0xba, 0xa4,
+	0x7b, 0x4e, 0xf4, 0x19, 0xc6, 0xa9, 0x26, 0xa0, 0x1b, 0x0c, 0x88, 0xbe, 0x03, 0x53, 0x0e, 0x76,
+	0x92, 0x9e, 0xe4, 0x53, 0xe7, 0x76, 0x63, 0x30, 0xc1, 0xe9, 0x09, 0xe8, 0x92, 0x53, 0x84, 0x3d,
+	0xea, 0x42, 0x2b, 0x4c, 0xe2, 0x6e, 0xe8, 0x63, 0xa2, 0xcf, 0x32, 0xf2, 0x05, 0x81, 0x37, 0x39,
+	0x7a, 0x5f, 0x60, 0xd1, 0x32, 0x54, 0xc9, 0x99, 0x1d, 0x39, 0x96, 0x1b, 0x38, 0xf8, 0x42, 0x47,
+	0x6d, 0x6d, 0x65, 0xcc, 0x04, 0x06, 0xda, 0xa6, 0x90, 0x8c, 0x80, 0xeb, 0xda, 0x50, 0x08, 0xb8,
+	0x92, 0xef, 0x43, 0x3d, 0x35, 0xac, 0xe7, 0xd9, 0xd4, 0x56, 0xfa, 0x1c, 0xf7, 0x99, 0xb4, 0xa5,
+	0x00, 0xd3, 0x53, 0x84, 0x2f, 0x7a, 0x61, 0x14, 0xeb, 0xf3, 0x6c, 0x53, 0xe2, 0xc9, 0xd8, 0x81,
+	0x85, 0xe2, 0xb8, 0x43, 0x08, 0xc6, 0x8e, 0xdd, 0x98, 0x9e, 0x6b, 0xea, 0x1c, 0xf6, 0x9f, 0x5a,
+	0xf5, 0xcc, 0x26, 0x67, 0xca, 0x99, 0xad, 0x99, 0x15, 0x0a, 0x61, 0xfb, 0x31, 0xfe, 0xa5, 0x04,
+	0x77, 0x0a, 0xb3, 0x05, 0xe9, 0x85, 0x01, 0xc1, 0xe8, 0x7d, 0x18, 0x77, 0x63, 0xec, 0xcb, 0x5c,
+	0xd1, 0x28, 0x88, 0x7c, 0x93, 0x53, 0x50, 0xcb, 0x0f, 0xe5, 0x87, 0x31, 0xb3, 0x4a, 0x94, 0xc4,
+	0xf0, 0x04, 0xaa, 0x59, 0x02, 0xe0, 0xd9, 0xa1, 0xba, 0xb6, 0x98, 0xf2, 0x0c, 0x83, 0x53, 0x95,
+	0x2f, 0xa4, 0x99, 0x80, 0xa0, 0x77, 0xa1, 0x96, 0x9d, 0xfd, 0x73, 0xdc, 0x67, 0xc9, 0xa2, 0x62,
+	0x4e, 0xa5, 0xc0, 0x1d, 0xdc, 0x47, 0x2d, 0x00, 0x25, 0x44, 0xc7, 0x59, 0xee, 0x51, 0x20, 0xe8,
+	0x19, 0xb4, 0xaf, 0x8c, 0x6a, 0xcb, 0x75, 0x58, 0x3e, 0xa8, 0x99, 0x4b, 0x57, 0x04, 0xf6, 0xb6,
+	0x83, 0xee, 0x42, 0x25, 0x8e, 0x92, 0xa0, 0x6b, 0xc7, 0xd8, 0x61, 0x39, 0xa1, 0x6c, 0x66, 0x00,
+	0xb4, 0x06, 0xf3, 0x3c, 0xaa, 0x02, 0x6a, 0x53, 0x2b, 0xa3, 0x2c, 0x33, 0xca, 0x86, 0x97, 0xda,
+	0xfb, 0x28, 0x5d, 0xf3, 0x01, 0x20, 0xee, 0x5e, 0x2b, 0x3c, 0xfe, 0x29, 0xee, 0xc6, 0x6c, 0x2d,
+	0x3b, 0xf4, 0x15, 0xb3, 0xce, 0x31, 0xfb, 0x0c, 0x41, 0xd7, 0x19, 0xbf, 0x1c, 0x85, 0xaa, 0x62,
+	0x29, 0xea, 0xe4, 0x4c, 0x22, 0x73, 0x7f, 0xc5, 0xac, 0xa4, 0x62, 0x68, 0x24, 0x09, 0x8b, 0x8f,
+	0xf2, 0x7c, 0xcc, 0x9f, 0xd0, 0xef, 0x41, 0x39, 0xcd, 0x83, 0xd4, 0x17, 0xd3, 0x6b, 0xcd, 0x61,
+	0xff, 0xca, 0x94, 0x68, 0xa6, 0xb4, 0xe8, 0x0e, 0x54, 0xb2, 0xc4, 0x34, 0xd6, 0x2e, 0xad, 0xd4,
+	0xcc, 0xf2, 0x6b, 0x99, 0x95, 0x1e, 0xc0, 0xac, 0xb4, 0x2e, 0x76, 0xa4, 0xa7, 0xc7, 0x59, 0x44,
+	0xd6, 0x33, 0x84, 0xd8, 0xf8, 0x32, 0x54, 0xd5, 0xdc, 0x30, 0xc1, 0xcf, 0xcb, 0xeb, 0x2c, 0x29,
+	0xec, 0x40, 0x7d, 0xe8, 0x8c, 0x4e, 0xb2, 0xad, 0xb6, 0x87, 0xb7, 0x9a, 0x3f, 0xae, 0xe6, 0x4c,
+	0x94, 0x7b, 0x26, 0x86, 0x03, 0x33, 0x03, 0x31, 0x76, 0x9d, 0xe5, 0xe6, 0x60, 0x5c, 0x0d, 0x66,
+	0xfe, 0x40, 0xdd, 0x8f, 0x2f, 0xb0, 0xdf, 0xf3, 0xec, 0x48, 0x96, 0xb8, 0x0c, 0x60, 0xfc, 0x62,
+	0x0a, 0x96, 0x14, 0x11, 0x9b, 0x76, 0xe4, 0xb8, 0x81, 0xed, 0xb9, 0x71, 0x5f, 0xd6, 0xe0, 0x65,
+	0xa8, 0x66, 0x42, 0xf9, 0xd1, 0xaa, 0x98, 0x90, 0x85, 0x45, 0xae, 0x48, 0x8f, 0xde, 0xa8, 0x48,
+	0x3f, 0x84, 0xb9, 0xd3, 0x28, 0x4c, 0x7a, 0xb4, 0x2e, 0xfa, 0x38, 0x8e, 0xdc, 0x2e, 0xd7, 0xa8,
+	0xc4, 0xb3, 0x2d, 0xc3, 0x6d, 0xf4, 0x77, 0x19, 0x86, 0x69, 0xf6, 0x00, 0x64, 0x0a, 0xb6, 0x58,
+	0xb1, 0x20, 0x89, 0x4f, 0xd8, 0xa1, 0x2a, 0x9b, 0xb2, 0x48, 0x6e, 0x4a, 0xf8, 0x60, 0xde, 0x1b,
+	0xbf, 0x2e, 0xef, 0x4d, 0x0c, 0xe5, 0xbd, 0x35, 0x98, 0xc7, 0x24, 0x76, 0x7d, 0x3b, 0xc6, 0x16,
+	0xd7, 0x9d, 0xe7, 0x05, 0x71, 0x7a, 0x1a, 0x12, 0xc9, 0xd4, 0xe3, 0xbd, 0x84, 0x5a, 0x40, 0xba,
+	0x67, 0x49, 0x70, 0x2e, 0x98, 0x97, 0x73, 0x05, 0x64, 0x93, 0x62, 0xb8, 0x0c, 0x1d, 0x26, 0xf1,
+	0x45, 0xcf, 0xb3, 0xdd, 0x40, 0x54, 0x4b, 0xf9, 0x48, 0x5b, 0x98, 0x5e, 0x14, 0x9e, 0xd2, 0xd0,
+	0xb3, 0xdc, 0x20, 0xc6, 0xd1, 0x6b, 0xdb, 0xb3, 0x7c, 0xc2, 0xaa, 0x65, 0xc9, 0x44, 0x12, 0xb7,
+	0x2d, 0x50, 0xbb, 0x04, 0xad, 0x40, 0xdd, 0x77, 0x83, 0x7c, 0xc3, 0x53, 0x65, 0x5a, 0x4d, 0xfb,
+	0x6e, 0xa0, 0x36, 0x3b, 0x4b, 0x00, 0xb6, 0xe7, 0x71, 0xa5, 0x08, 0xab, 0x8b, 0x65, 0xb3, 0x62,
+	0x7b, 0x1e, 0xd3, 0x84, 0xa0, 0xf7, 0x80, 0x27, 0x76, 0x8b, 0x65, 0x61, 0x62, 0x7b, 0xbc, 0x02,
+	0x56, 0xcc, 0x1a, 0x03, 0x3f, 0xb7, 0xc9, 0xd9, 0xa1, 0xed, 0xc5, 0x6a, 0x79, 0x8b, 0x68, 0xfe,
+	0xe7, 0x15, 0x30, 0x2b, 0x6f, 0x26, 0x03, 0xd2, 0x3c, 0x48, 0x6c, 0xbf, 0xe7, 0x61, 0x79, 0xb2,
+	0x66, 0x58, 0xbe, 0x9a, 0xe2, 0xc0, 0xec, 0x54, 0x09, 0x22, 0x82, 0xb1, 0xc3, 0x4a, 0x60, 0xc9,
+	0x04, 0x0e, 0x3a, 0xc4, 0xd8, 0x41, 0xf7, 0x81, 0xd7, 0x7c, 0x8b, 0xc7, 0x4c, 0x84, 0x4f, 0xf1,
+	0x85, 0x3e, 0xab, 0x94, 0xa1, 0x67, 0x14, 0x6e, 0x52, 0x30, 0xfa, 0x10, 0x1a, 0xdd, 0xd0, 0x0a,
+	0xbb, 0xdd, 0x24, 0x8a, 0xe8, 0xe9, 0xb7, 0xe2, 0xb0, 0x67, 0x9d, 0xb3, 0xda, 0x57, 0xa3, 0x27,
+	0x7a, 0x3f, 0xc5, 0x1c, 0x85, 0xbd, 0x1d, 0xf4, 0x00, 0x90, 0x12, 0x7f, 0x44, 0x50, 0x37, 0x18,
+	0xf5, 0x8c, 0x9f, 0xc6, 0x1f, 0x61, 0xc4, 0x8f, 0x61, 0x3e, 0x8c, 0x1c, 0x1c, 0xd1, 0xa8, 0xcd,
+	0x45, 0xc5, 0x1c, 0xef, 0x2d, 0x19, 0x72, 0xa3, 0xaf, 0x06, 0xc5, 0x13, 0xd0, 0x55, 0xa7, 0x58,
+	0x3d, 0x1c, 0x75, 0x71, 0x10, 0xbb, 0x1e, 0x26, 0xfa, 0x7c, 0xbb, 0xb4, 0xa2, 0x99, 0x0b, 0x4a,
+	0xc5, 0x39, 0xc8, 0xb0, 0x68, 0x1d, 0x96, 0xba, 0x61, 0x10, 0xe3, 0x8b, 0x98, 0x47, 0x7c, 0x16,
+	0x09, 0x42, 0xe8, 0x02, 0xdb, 0x64, 0x53, 0x10, 0xb1, 0xe8, 0x97, 0x11, 0x21, 0x84, 0xdf, 0x87,
+	0x59, 0x42, 0x73, 0x34, 0xdf, 0xab, 0xf0, 0xc0, 0x22, 0xef, 0x20, 0x29, 0x42, 0xcd, 0x2c, 0x1f,
+	0x00, 0x22, 0xb1, 0x1d, 0xc5, 0x56, 0xec, 0xfa, 0x98, 0xc4, 0xb6, 0xdf, 0xa3, 0x11, 0xa7, 0x33,
+	0x5f, 0xd4, 0x19, 0xe6, 0x48, 0x22, 0x78, 0xbc, 0xe1, 0xc0, 0xc9, 0xd3, 0xbe, 0xc3, 0x68, 0xa7,
+	0x71, 0xe0, 0xa8, 0x94, 0xcb, 0x50, 0x3d, 0xc6, 0x24, 0xb6, 0xf0, 0xc9, 0x09, 0xed, 0x0d, 0x9a,
+	0x4c, 0x3a, 0x50, 0x50, 0x87, 0x41, 0xa8, 0xe0, 0x2c, 0x15, 0xd8, 0xa7, 0x81, 0x1b, 0x27, 0x0e,
+	0xd6, 0xef, 0xf0, 0xa3, 0x2d, 0x13, 0x81, 0x84, 0xa3, 0xef, 0xc1, 0x4c, 0xda, 0xdd, 0x27, 0xbe,
+	0x4f, 0x0b, 0xe7, 0x5d, 0x46, 0x2a, 0xc3, 0xf1, 0x90, 0x43, 0x69, 0xe4, 0x45, 0x98, 0x24, 0x3e,
+	0xb6, 0xc2, 0x93, 0x13, 0x82, 0x63, 0x7d, 0x89, 0x1d, 0x87, 0x29, 0x0e, 0xdc, 0x67, 0x30, 0xca,
+	0x4d, 0x10, 0xc9, 0xa4, 0xa2, 0xb7, 0x98, 0x55, 0xa7, 0x39, 0x58, 0xa6, 0x14, 0xf4, 0x07, 0xd0,
+	0xa4, 0xc5, 0xc0, 0x8e, 0xb0, 0x55, 0x60, 0xa5, 0x36, 0xd3, 0x7c, 0x51, 0x50, 0x1c, 0x0e, 0x1a,
+	0xeb, 0xf7, 0x41, 0x97, 0x8b, 0x87, 0x8c, 0xf6, 0x1d, 0xb6, 0x74, 0x5e, 0xe0, 0x3b, 0x79, 0xdb,
+	0xad, 0x42, 0x43, 0x20, 0x68, 0xe4, 0xbf, 0x89, 0xcf, 0xe8, 0x59, 0xc3, 0xba, 0xc1, 0x33, 0x8a,
+	0x40, 0x3d, 0x63, 0x18, 0xd3, 0x8e, 0x31, 0xa3, 0x3f, 0xb3, 0x83, 0x53, 0xec, 0x88, 0xf3, 0x6d,
+	0x85, 0x81, 0xd7, 0xd7, 0xdf, 0x15, 0xf4, 0x1c, 0xc5, 0x0f, 0xfa, 0x7e, 0xe0, 0xf5, 0x69, 0x9e,
+	0x19, 0xa0, 0x27, 0x31, 0x15, 0xf0, 0x5d, 0x56, 0xfe, 0x50, 0x6e, 0xc1, 0x21, 0xc5, 0x7c, 0x36,
+	0x56, 0x5e, 0xae, 0xb7, 0x8d, 0xff, 0xd2, 0xe0, 0xdd, 0xe2, 0x92, 0x71, 0x18, 0x47, 0xd8, 0xf6,
+	0x65, 0xe1, 0xf8, 0x14, 0x26, 0x23, 0xfe, 0x97, 0x95, 0xaa, 0xea, 0xda, 0xbd, 0x82, 0x7e, 0x6c,
+	0xb8, 0xe0, 0x98, 0x72, 0x15, 0xed, 0x10, 0x49, 0x1c, 0xf6, 0xc4, 0xec, 0xc6, 0xfe, 0xd3, 0xa0,
+	0x7e, 0x43, 0xcb, 0x48, 0x2e, 0x33, 0x96, 0x98, 0x19, 0x67, 0x18, 0x42, 0x49, 0x8b, 0x73, 0x30,
+	0xde, 0xb3, 0x13, 0x82, 0x45, 0xa5, 0xe0, 0x0f, 0xb4, 0xbf, 0xe0, 0xee, 0x15, 0x23, 0x98, 0x78,
+	0x32, 0xfe, 0x7d, 0x1c, 0x5a, 0x97, 0x6d, 0x4c, 0xf4, 0x97, 0x1f, 0xe5, 0xfb, 0xcb, 0xa5, 0x61,
+	0x7d, 0x94, 0x5c, 0x2b, 0x3b, 0xcd, 0x7b, 0x30, 0x7d, 0x9c, 0x38, 0xa7, 0x38, 0xb6, 0xde, 0xd8,
+	0x51, 0xe0, 0x06, 0xa7, 0x42, 0x9f, 0x1a, 0x87, 0xbe, 0xe4, 0x40, 0x1a, 0x8c, 0x84, 0xea, 0x4d,
+	0x93, 0x56, 0x90, 0xf8, 0xc7, 0x38, 0x62, 0x6a, 0x8d, 0x99, 0xd3, 0x12, 0xbc, 0xc7, 0xa0, 0x2c,
+	0xf7, 0x52, 0xc6, 0x59, 0xd0, 0xf2, 0x51, 0xb4, 0xc6, 0xa0, 0x69, 0xcc, 0xea, 0x30, 0x49, 0x0d,
+	0xd6, 0xc3, 0x8e, 0xd0, 0x53, 0x3e, 0x52, 0xbf, 0xc8, 0xca, 0x33, 0x71, 0x13, 0xbf, 0x74, 0x38,
+	0x71, 0x56, 0xa0, 0x36, 0xa0, 0x2c, 0x8b, 0x90, 0x98, 0x31, 0xdf, 0xbb, 0x9a, 0xc3, 0x81, 0xa0,
+	0x36, 0xd3, 0x75, 0x83, 0x59, 0xbf, 0x3c, 0x94, 0xf5, 0x57, 0xa1, 0x71, 0x62, 0xbb, 0x1e, 0x76,
+	0xf2, 0xf9, 0xab, 0xc2, 0x6c, 0x32, 0xcb, 0x51, 0x6a, 0x06, 0xa3, 0x03, 0x48, 0x14, 0x85, 0x11,
+	0xad, 0x93, 0xac, 0x6d, 0xe4, 0x4f, 0x68, 0x13, 0x2a, 0x3c, 0x55, 0xd0, 0xa4, 0x59, 0x6d, 0x97,
+	0xae, 0xd7, 0x57, 0xe4, 0x10, 0x33, 0x5b, 0xc7, 0xd2, 0x58, 0x3f, 0x4e, 0x93, 0xc9, 0x14, 0xef,
+	0x18, 0x28, 0x48, 0xa4, 0x92, 0xf7, 0xa1, 0x1e, 0x25, 0x01, 0x75, 0x64, 0xe6, 0x96, 0x1a, 0x2f,
+	0x23, 0x02, 0x9e, 0x3a, 0x66, 0x19, 0xaa, 0x6e, 0x40, 0x62, 0x9b, 0x3a, 0xda, 0x75, 0x58, 0xe1,
+	0xac, 0x98, 0x20, 0x41, 0xdb, 0xce, 0xa5, 0xe7, 0x72, 0xe6, 0xb2, 0x73, 0x69, 0xfc, 0x5c, 0x83,
+	0xa5, 0x2b, 0x75, 0xb9, 0xae, 0x73, 0xfc, 0x00, 0x90, 0x6a, 0xe5, 0xdc, 0x4c, 0x54, 0xf7, 0x14,
+	0xce, 0x14, 0x3e, 0x34, 0x3b, 0x95, 0x86, 0x66, 0x27, 0xe3, 0x27, 0xd0, 0xba, 0x3a, 0x14, 0x28,
+	0x93, 0x9c, 0x63, 0x35, 0xce, 0xc4, 0xcb, 0xbb, 0x54, 0x14, 0x3b, 0xbe, 0x13, 0xf1, 0x64, 0xfc,
+	0xcd, 0x28, 0x2c, 0x5d, 0x19, 0xaa, 0x34, 0xe7, 0xe6, 0xf4, 0x71, 0x12, 0xd6, 0xa6, 0x04, 0x56,
+	0xc0, 0x05, 0x95, 0xcc, 0x79, 0x45, 0xd0, 0x96, 0xc0, 0xee, 0xb1, 0xeb, 0x23, 0xa6, 0x13, 0x75,
+	0xa4, 0xba, 0x68, 0x94, 0x2d, 0x42, 0x12, 0xa7, 0xac, 0x58, 0x85, 0x06, 0xc1, 0x81, 0x33, 0xb8,
+	0x80, 0xa7, 0xa4, 0x59, 0x81, 0x52, 0xe8, 0x1f, 0x42, 0x43, 0x72, 0xb1, 0x4e, 0xc3, 0x28, 0x4c,
+	0x62, 0x37, 0xc0, 0x44, 0x9c, 0xe1, 0x54, 0xc0, 0xb3, 0x14, 0x43, 0xe7, 0x44, 0x85, 0x6e, 0x9c,
+	0xd1, 0x29, 0x10, 0xe3, 0x97, 0x35, 0x98, 0x2f, 0x4c, 0x40, 0xd7, 0x39, 0xdd, 0xce, 0x39, 0xdd,
+	0x4a, 0x4d, 0x4d, 0x8f, 0xc8, 0x47, 0x57, 0xa6, 0xb6, 0x21, 0x68, 0x27, 0x88, 0xa3, 0xbe, 0x1a,
+	0x29, 0x1c, 0x8c, 0xfe, 0x4a, 0x83, 0x65, 0x55, 0x46, 0xae, 0xd9, 0x12, 0x02, 0xf9, 0x5c, 0xfd,
+	0x47, 0x37, 0x15, 0x98, 0x4d, 0x05, 0x44, 0x95, 0x7d, 0xc7, 0xbb, 0x9c, 0x02, 0x7d, 0x91, 0x0b,
+	0x07, 0xd9, 0x27, 0x3b, 0xd8, 0x8b, 0x6d, 0x36, 0x11, 0x56, 0xd7, 0x9e, 0xdc, 0x4e, 0xdf, 0x2d,
+	0xba, 0x94, 0x0b, 0x9e, 0xf7, 0x8a, 0x70, 0xd9, 0x58, 0x2d, 0x84, 0xc9, 0x91, 0x41, 0x8c, 0x23,
+	0x7c, 0xac, 0x16, 0x0a, 0x08, 0x14, 0xda, 0x83, 0xef, 0x16, 0xae, 0x61, 0x17, 0x3f, 0xb1, 0xfb,
+	0x1a, 0x5b, 0x2c, 0xa7, 0xb1, 0xac, 0xad, 0x99, 0xed, 0x02, 0x16, 0xa6, 0x20, 0xec, 0x50, 0xba,
+	0x41, 0x07, 0xb3, 0xb1, 0x84, 0x0f, 0xa4, 0xb7, 0x70, 0x30, 0x1b, 0x59, 0x86, 0x1d, 0xcc, 0xc1,
+	0x83, 0x22, 0xc4, 0x30, 0x50, 0xbe, 0x9d, 0x08, 0x3e, 0x2d, 0x0c, 0x89, 0xe0, 0x60, 0xf4, 0x06,
+	0x9a, 0x39, 0x2d, 0xd4, 0xf6, 0x9e, 0xd6, 0x03, 0x2a, 0xea, 0x93, 0x1b, 0x6b, 0xa3, 0x4c, 0x00,
+	0x42, 0xe2, 0xa2, 0x57, 0x8c, 0x45, 0x7f, 0xa1, 0x41, 0xab, 0x20, 0x6c, 0xd4, 0x5e, 0x0c, 0x98,
+	0xf4, 0x1f, 0xde, 0x2e, 0x78, 0xb2, 0x96, 0x8d, 0x6f, 0xa0, 0xe9, 0x5d, 0x4a, 0x80, 0x5e, 0x5e,
+	0x31, 0x40, 0x54, 0xf3, 0x4d, 0xc8, 0x61, 0xd1, 0x20, 0x71, 0xe9, 0x7c, 0xf1, 0x31, 0x2c, 0xe4,
+	0x18, 0x67, 0xbd, 0x37, 0x2f, 0x6e, 0x73, 0xca, 0xba, 0xb4, 0xff, 0x6e, 0x6e, 0x0e, 0xa7, 0x1a,
+	0xa6, 0x03, 0xaa, 0x43, 0x89, 0xde, 0x73, 0xf1, 0x1c, 0x43, 0xff, 0xd2, 0xe6, 0x8b, 0x99, 0x4d,
+	0x5e, 0x46, 0xb0, 0x87, 0x4f, 0x46, 0x9f, 0x68, 0xcd, 0x00, 0xda, 0xd7, 0x1d, 0xe7, 0x02, 0x7e,
+	0x1f, 0xab, 0xfc, 0x94, 0x5b, 0xed, 0x21, 0x06, 0xa2, 0xf9, 0xca, 0xe4, 0x3d, 0x87, 0x66, 0x26,
+	0x6f, 0xf0, 0xfc, 0x5e, 0xb7, 0xf3, 0x92, 0xca, 0x29, 0xa7, 0xbe, 0x72, 0x30, 0x6e, 0xa5, 0x7e,
+	0x8e, 0x89, 0x12, 0xfa, 0xd7, 0x31, 0xd1, 0x54, 0x26, 0xe7, 0x70, 0xf7, 0xaa, 0xa0, 0x2e, 0xe0,
+	0xf5, 0xfd, 0xbc, 0xfd, 0x96, 0x87, 0x63, 0x36, 0xc7, 0x46, 0x15, 0xb6, 0x0b, 0xcb, 0xd7, 0xc4,
+	0xf0, 0x6d, 0xf6, 0xfe, 0xd9, 0x58, 0xb9, 0x56, 0x9f, 0x36, 0x7e, 0x0c, 0xf3, 0x85, 0x11, 0x4b,
+	0xeb, 0x5d, 0x16, 0xe5, 0x8c, 0xa3, 0x66, 0x2a, 0x90, 0xc2, 0x9b, 0x5b, 0x2d, 0xdf, 0x7d, 0xec,
+	0xc3, 0xe2, 0x25, 0x6a, 0xd1, 0x30, 0x52, 0x5b, 0xf8, 0xd6, 0xd5, 0x66, 0x10, 0x3d, 0xbc, 0xf1,
+	0x67, 0xb0, 0x50, 0x4c, 0x70, 0x5d, 0x8d, 0x4d, 0x2f, 0xcf, 0x32, 0x5b, 0xc8, 0xcb, 0x33, 0xc6,
+	0xeb, 0x26, 0xbd, 0xd4, 0x2e, 0x2c, 0x14, 0x07, 0xf9, 0xa5, 0xf3, 0x48, 0x46, 0x3e, 0x3c, 0x8f,
+	0x18, 0x3f, 0x81, 0xf9, 0x42, 0x3c, 0xdd, 0xab, 0x7a, 0x19, 0xc7, 0x75, 0x81, 0xec, 0x16, 0xe4,
+	0x06, 0x77, 0xe6, 0xc6, 0x7f, 0x6a, 0x50, 0x35, 0xb1, 0xed, 0xc8, 0x19, 0x70, 0x15, 0x26, 0xbf,
+	0x48, 0x78, 0x9d, 0x1f, 0x78, 0x7f, 0xf7, 0xa3, 0x04, 0x47, 0xd9, 0xc8, 0x27, 0x88, 0xd0, 0x2b,
+	0x58, 0xb4, 0xbb, 0x5d, 0xdc, 0x8b, 0xb1, 0x63, 0x45, 0x62, 0xec, 0xb2, 0xe2, 0x7e, 0x4f, 0x34,
+	0x26, 0xca, 0x45, 0xaa, 0x22, 0x65, 0x55, 0x0e, 0x68, 0x47, 0xfd, 0x1e, 0x36, 0xe7, 0x25, 0x03,
+	0x15, 0x4a, 0x8c, 0x8f, 0x61, 0x4a, 0x05, 0xa0, 0x2a, 0x4c, 0x1e, 0xae, 0xef, 0x1e, 0xbc, 0xe8,
+	0x1c, 0xd6, 0x47, 0xd0, 0x22, 0x34, 0x0e, 0x8f, 0xcc, 0xce, 0xfa, 0x6e, 0x67, 0xcb, 0x7a, 0xb5,
+	0x6f, 0x5a, 0x9b, 0xcf, 0x3f, 0xdf, 0xdb, 0x39, 0xac, 0x6b, 0xc6, 0xa7, 0x30, 0xc5, 0x05, 0xf1,
+	0x95, 0xe8, 0x21, 0x9d, 0x69, 0x49, 0xe2, 0xc5, 0x52, 0x9f, 0xf9, 0x01, 0x7d, 0x38, 0x9d, 0x29,
+	0xa9, 0x8c, 0x3e, 0x20, 0x39, 0x15, 0x2b, 0x6c, 0x36, 0x60, 0x9a, 0x55, 0x63, 0xec, 0xc8, 0x2e,
+	0x88, 0x73, 0xbb, 0x93, 0x26, 0x73, 0xb6, 0x66, 0x93, 0xd3, 0x70, 0x27, 0x99, 0xb5, 0xae, 0xfa,
+	0x48, 0xdd, 0x45, 0xad, 0xd6, 0x17, 0xd7, 0x9c, 0x3c, 0x59, 0x01, 0x03, 0xb1, 0x6b, 0x4e, 0xe3,
+	0x17, 0x1a, 0x34, 0x0a, 0xf8, 0xa0, 0x13, 0x98, 0x10, 0xf7, 0x7f, 0xf9, 0xd7, 0x24, 0xbd, 0x63,
+	0x7e, 0x0a, 0x0e, 0x6c, 0x37, 0xda, 0xf8, 0xc1, 0xaf, 0xbe, 0x5a, 0x1e, 0xf9, 0xef, 0xaf, 0x96,
+	0x1f, 0xdf, 0xe4, 0x95, 0x2e, 0x5f, 0xb7, 0xee, 0xd8, 0xbd, 0x18, 0x47, 0xa6, 0xe0, 0x8e, 0x1e,
+	0xc3, 0x84, 0x68, 0x39, 0x46, 0x73, 0x72, 0x54, 0xe5, 0x36, 0xc6, 0xa8, 0x1c, 0x53, 0x10, 0x1a,
+	0xff, 0xaa, 0x41, 0x55, 0xc1, 0xa2, 0x16, 0x54, 0xe9, 0xc5, 0x66, 0xec, 0xfa, 0xd8, 0xf2, 0x65,
+	0xeb, 0x5e, 0xf1, 0xdd, 0x80, 0xde, 0x93, 0xec, 0x12, 0x86, 0xb7, 0x2f, 0x52, 0xfc, 0xa8, 0xc0,
+	0xdb, 0x17, 0x02, 0xff, 0x08, 0xc6, 0x68, 0xf0, 0xb0, 0x53, 0x35, 0xbd, 0x76, 0xb7, 0x60, 0x03,
+	0xab, 0x9d, 0xa0, 0x1b, 0xd2, 0x16, 0xdd, 0x64, 0x94, 0xf4, 0xce, 0xc1, 0xb1, 0x59, 0x5b, 0xc8,
+	0xde, 0x4a, 0xd1, 0xff, 0x46, 0x1b, 0xca, 0x92, 0x8a, 0x86, 0xcd, 0xe7, 0x7b, 0x3b, 0x7b, 0xfb,
+	0x2f, 0xf7, 0xea, 0x23, 0x68, 0x12, 0x4a, 0xaf, 0xf6, 0xcd, 0xba, 0x66, 0xfc, 0xbd, 0x06, 0x53,
+	0x6a, 0x40, 0x5f, 0x72, 0x9f, 0xa6, 0xdd, 0xe2, 0x3e, 0x6d, 0xb4, 0xf0, 0x3e, 0x4d, 0xbd, 0x6b,
+	0x2f, 0xdd, 0xe4, 0xae, 0xdd, 0xf8, 0x47, 0x0d, 0xe6, 0x3a, 0xe2, 0xba, 0xff, 0x77, 0xb2, 0xc5,
+	0xc7, 0x43, 0x5b, 0x9c, 0x2f, 0xda, 0x22, 0x51, 0xf6, 0xb8, 0x03, 0xb5, 0xdc, 0xf1, 0x41, 0x9f,
+	0x00, 0x30, 0x49, 0x45, 0x99, 0xa3, 0x77, 0xbc, 0x4a, 0xc5, 0xf1, 0x60, 0x16, 0xf1, 0xa3, 0x50,
+	0x1b, 0x7f, 0xa7, 0x41, 0x83, 0x71, 0x93, 0xe7, 0x4e, 0xf0, 0xfc, 0x14, 0xaa, 0x3c, 0xca, 0x54,
+	0xa6, 0xe9, 0xeb, 0xbc, 0x8c, 0xa5, 0x1a, 0x97, 0xea, 0x8a, 0x81, 0x4d, 0x8d, 0xde, 0x6a, 0x53,
+	0x87, 0x30, 0x3f, 0xe0, 0x84, 0xdf, 0x82, 0xa6, 0xff, 0xa1, 0x01, 0x52, 0x5f, 0x41, 0x0a, 0xc7,
+	0x5e, 0x3f, 0xeb, 0x17, 0xf8, 0x7d, 0xf4, 0x16, 0x7e, 0x2f, 0x5d, 0xeb, 0xf7, 0xb1, 0xb6, 0x76,
+	0x13, 0xbf, 0x3f, 0x81, 0x46, 0x6e, 0xff, 0xc2, 0x26, 0xc3, 0x57, 0x03, 0xf4, 0x42, 0x47, 0xbd,
+	0x1a, 0x30, 0xfe, 0x41, 0x83, 0xd9, 0xec, 0x4d, 0xf0, 0xef, 0x36, 0xa4, 0x6f, 0xa4, 0xda, 0xf7,
+	0x01, 0xa9, 0xfb, 0x13, 0x9a, 0x5d, 0xf7, 0x2e, 0xcd, 0x40, 0x50, 0xff, 0x9c, 0xe0, 0x88, 0x5e,
+	0xeb, 0x48, 0xad, 0x8c, 0x7f, 0xd3, 0x60, 0x56, 0x01, 0x0a, 0x56, 0xf7, 0xe4, 0x47, 0x3b, 0xf4,
+	0xc2, 0x81, 0x0d, 0x23, 0xbc, 0x55, 0xaa, 0xa5, 0x50, 0x36, 0x40, 0x2c, 0x01, 0x04, 0x89, 0x6f,
+	0xe5, 0xee, 0x51, 0x2a, 0x41, 0xe2, 0x8b, 0x5a, 0xf0, 0x01, 0x20, 0xbb, 0xe7, 0x5a, 0x03, 0x9c,
+	0x4a, 0x8c, 0x53, 0xdd, 0xee, 0xb9, 0xdb, 0x39, 0x66, 0xab, 0xd0, 0x88, 0x12, 0x0f, 0x0f, 0x92,
+	0x8f, 0x31, 0xf2, 0x59, 0x8a, 0xca, 0xd1, 0x1b, 0x7f, 0x0a, 0x0d, 0xba, 0xf1, 0xed, 0xad, 0xfc,
+	0xd6, 0x17, 0x61, 0x32, 0x21, 0x38, 0xa2, 0xb7, 0x5f, 0x3c, 0x3a, 0x27, 0xe8, 0xe3, 0xb6, 0x83,
+	0x3e, 0x14, 0xc9, 0x97, 0xb7, 0xa8, 0xef, 0x48, 0x1b, 0x0f, 0x29, 0x2f, 0xf2, 0xf2, 0x33, 0x40,
+	0x14, 0x45, 0xf2, 0xdc, 0x1f, 0xc3, 0x38, 0xa1, 0x80, 0xc1, 0x92, 0x5a, 0xb0, 0x13, 0x93, 0x53,
+	0x1a, 0xff, 0xac, 0x41, 0x8b, 0xf7, 0x44, 0xe4, 0x69, 0x18, 0xe5, 0x5d, 0xfa, 0x2d, 0x87, 0xd6,
+	0x13, 0x98, 0x92, 0x31, 0x63, 0x11, 0x1c, 0x5f, 0x9d, 0x31, 0xab, 0x92, 0xf4, 0x10, 0xd3, 0x2f,
+	0x2b, 0x96, 0x2f, 0xdd, 0xb3, 0x30, 0xc5, 0x0a, 0x4c, 0xf0, 0xf6, 0x4d, 0xd8, 0xa2, 0x9e, 0x25,
+	0x16, 0xbe, 0xd4, 0x14, 0x78, 0x43, 0x97, 0x3d, 0x26, 0xd9, 0xc5, 0xb1, 0x4d, 0xad, 0x2b, 0xa3,
+	0x6f, 0x1f, 0x16, 0x87, 0x30, 0x82, 0xfd, 0xc7, 0x50, 0xf6, 0x05, 0x4c, 0x08, 0xd0, 0x07, 0x05,
+	0xa4, 0x6b, 0x52, 0x4a, 0xe3, 0xff, 0x34, 0x98, 0x19, 0xc8, 0xb6, 0xd4, 0x5e, 0x27, 0x51, 0xe8,
+	0x5b, 0xf2, 0x33, 0xb4, 0x2c, 0x34, 0xa6, 0x29, 0x7c, 0x5b, 0x80, 0xb7, 0x1d, 0x35, 0x76, 0x46,
+	0x73, 0xb1, 0x93, 0x75, 0x35, 0xa5, 0x6f, 0xb5, 0xab, 0x79, 0x90, 0x76, 0x35, 0xfc, 0xe6, 0xa8,
+	0x26, 0x5d, 0x55, 0xd4, 0xcf, 0xfc, 0x5c, 0x83, 0x71, 0xae, 0xe1, 0xb7, 0x15, 0x3f, 0x4d, 0x28,
+	0x63, 0xd1, 0x9b, 0xb0, 0x63, 0x3b, 0x6e, 0xa6, 0xcf, 0x85, 0xbd, 0xcc, 0x3a, 0xd4, 0x72, 0xb1,
+	0x72, 0xfb, 0x4f, 0xec, 0x0c, 0x0b, 0xa6, 0x54, 0x0c, 0xba, 0x27, 0x9a, 0x2c, 0x8d, 0x35, 0x59,
+	0xb3, 0xe9, 0x10, 0x42, 0xd1, 0xac, 0x23, 0x4f, 0x3b, 0x2b, 0x56, 0x90, 0xb8, 0xdb, 0xd8, 0xff,
+	0x6c, 0x48, 0x2c, 0x31, 0x20, 0x7f, 0x30, 0xfe, 0x52, 0x83, 0xe9, 0x2c, 0x42, 0x9e, 0xd2, 0xa1,
+	0xef, 0xb7, 0x10, 0x20, 0x4d, 0x28, 0x9f, 0xb8, 0x1e, 0x4e, 0x3f, 0x34, 0xa8, 0x98, 0xe9, 0x73,
+	0x91, 0xa5, 0xee, 0xff, 0x14, 0xd0, 0xf0, 0x77, 0x25, 0xa8, 0x05, 0xcd, 0x03, 0xb3, 0x73, 0xd8,
+	0xd9, 0x3b, 0xb2, 0xb6, 0xf7, 0xac, 0xe7, 0x9d, 0xf5, 0x2d, 0x6b, 0x7d, 0x6f, 0xcb, 0xda, 0x78,
+	0xb1, 0xbf, 0xb9, 0x43, 0x27, 0x09, 0x1d, 0xe6, 0x06, 0xf1, 0xfb, 0x7b, 0x2f, 0xfe, 0xa4, 0xae,
+	0xa1, 0x26, 0x2c, 0x28, 0x18, 0xbe, 0x80, 0xe3, 0x46, 0xef, 0xbf, 0x02, 0xfd, 0xb2, 0x0f, 0x43,
+	0x50, 0x1d, 0xa6, 0xcc, 0xce, 0x8b, 0xf5, 0x8d, 0xce, 0x0b, 0x6b, 0xa7, 0x73, 0x70, 0x54, 0x1f,
+	0x41, 0x0d, 0x98, 0x91, 0x90, 0x2d, 0x73, 0xff, 0xe0, 0xa0, 0xb3, 0x55, 0xd7, 0xd0, 0x3c, 0xcc,
+	0x4a, 0xa0, 0xd9, 0x79, 0x69, 0x6e, 0x1f, 0x1d, 0x75, 0xf6, 0xea, 0xa3, 0xf7, 0x3f, 0x83, 0x4a,
+	0xea, 0x08, 0x54, 0x81, 0xf1, 0xce, 0x8f, 0x3e, 0x5f, 0x7f, 0x51, 0x1f, 0x41, 0x35, 0xa8, 0xec,
+	0xed, 0x1f, 0x59, 0xfc, 0x51, 0x43, 0x33, 0x50, 0x35, 0x3b, 0xcf, 0x3a, 0xaf, 0xac, 0xdd, 0xf5,
+	0xa3, 0xcd, 0xe7, 0xf5, 0x51, 0x84, 0x60, 0x9a, 0x03, 0xf6, 0xf6, 0x05, 0xac, 0xb4, 0xf6, 0xd7,
+	0x65, 0x28, 0x4b, 0x4b, 0xa3, 0x1f, 0xc0, 0xd8, 0x41, 0x42, 0xce, 0xd0, 0x42, 0x76, 0xce, 0x5e,
+	0x46, 0x6e, 0x8c, 0x45, 0xde, 0x68, 0x2e, 0x0e, 0xc1, 0x79, 0xd6, 0x30, 0x46, 0xd0, 0x16, 0x54,
+	0x95, 0x06, 0x0d, 0x15, 0x8e, 0x84, 0xcd, 0x3b, 0x39, 0x68, 0xbe, 0x97, 0x33, 0x46, 0x1e, 0x69,
+	0x68, 0x1f, 0xa6, 0x19, 0x4a, 0xf6, 0x55, 0x04, 0xa5, 0xfd, 0x7d, 0x51, 0xbf, 0xdb, 0x5c, 0xba,
+	0x04, 0x9b, 0x6e, 0xeb, 0x79, 0xfe, 0x33, 0xa5, 0x66, 0xd1, 0xd7, 0x63, 0x83, 0x9b, 0x2b, 0x68,
+	0x5f, 0x8c, 0x11, 0xd4, 0x01, 0xc8, 0x8a, 0x3f, 0x7a, 0x27, 0x47, 0xac, 0x36, 0x2c, 0xcd, 0x66,
+	0x11, 0x2a, 0x65, 0xb3, 0x01, 0x95, 0xb4, 0xf4, 0x21, 0xbd, 0xa0, 0x1a, 0x72, 0x26, 0x97, 0xd7,
+	0x49, 0x63, 0x04, 0x3d, 0x85, 0xa9, 0x75, 0xcf, 0xbb, 0x09, 0x9b, 0xa6, 0x8a, 0x21, 0x83, 0x7c,
+	0x3c, 0x58, 0xbc, 0xa4, 0xda, 0xa0, 0xf7, 0xf2, 0xd7, 0x0e, 0x97, 0x95, 0xd0, 0xe6, 0xf7, 0xae,
+	0xa5, 0x4b, 0xa5, 0x1d, 0xc1, 0xcc, 0x40, 0xd1, 0x41, 0x03, 0x17, 0x7e, 0x83, 0x75, 0xaa, 0xb9,
+	0x7c, 0x29, 0x3e, 0xe5, 0x7a, 0x0c, 0x8d, 0xcc, 0xce, 0xe9, 0xd7, 0x83, 0xc8, 0x18, 0x76, 0xc2,
+	0xe0, 0x87, 0xc8, 0xcd, 0x77, 0xaf, 0xa4, 0x51, 0xa2, 0xf2, 0x1c, 0x16, 0x8a, 0x5f, 0x4d, 0xa1,
+	0x9b, 0xbd, 0xfd, 0x6e, 0xbe, 0x77, 0x1d, 0x99, 0x22, 0xac, 0x0f, 0x77, 0x8b, 0xa9, 0xc4, 0xc9,
+	0x7a, 0x70, 0xcd, 0x8b, 0x4e, 0xf5, 0x75, 0xfd, 0xcd, 0x05, 0xaf, 0x68, 0x8f, 0xb4, 0x8d, 0x3f,
+	0xfc, 0xf2, 0xeb, 0xd6, 0xc8, 0xaf, 0xbf, 0x6e, 0x8d, 0xfc, 0xe6, 0xeb, 0x96, 0xf6, 0xe7, 0x6f,
+	0x5b, 0xda, 0x3f, 0xbd, 0x6d, 0x69, 0xbf, 0x7a, 0xdb, 0xd2, 0xbe, 0x7c, 0xdb, 0xd2, 0xfe, 0xe7,
+	0x6d, 0x4b, 0xfb, 0xdf, 0xb7, 0xad, 0x91, 0xdf, 0xbc, 0x6d, 0x69, 0x7f, 0xfb, 0x4d, 0x6b, 0xe4,
+	0xcb, 0x6f, 0x5a, 0x23, 0xbf, 0xfe, 0xa6, 0x35, 0xf2, 0xe3, 0x89, 0xae, 0xe7, 0xe2, 0x20, 0x3e,
+	0x9e, 0x60, 0x5f, 0x7f, 0x7f, 0xf4, 0xff, 0x03, 0x00, 0x64, 0x5c, 0xc1, 0x56, 0x78, 0x2e, 0x00,
+	0x00,
+}
+
+func (x LabelValuePresence) String() string {
+	s, ok := LabelValuePresence_name[int32(x)]
+	if ok {
+		return s
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
-		return false
+	return strconv.Itoa(int(x))
+}
+func (x LabelValueRelabelOutcome) String() string {
+	s, ok := LabelValueRelabelOutcome_name[int32(x)]
+	if ok {
+		return s
 	}
-	return true
+	return strconv.Itoa(int(x))
 }
-func (this *UserStatsResponse) Equal(that interface{}) bool {
+func (x MatchType) String() string {
+	s, ok := MatchType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x ReadRequest_ResponseType) String() string {
+	s, ok := ReadRequest_ResponseType_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (x StreamChunk_Encoding) String() string {
+	s, ok := StreamChunk_Encoding_name[int32(x)]
+	if ok {
+		return s
+	}
+	return strconv.Itoa(int(x))
+}
+func (this *LabelNamesAndValuesRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserStatsResponse)
+	that1, ok := that.(*LabelNamesAndValuesRequest)
 	if !ok {
-		that2, ok := that.(UserStatsResponse)
+		that2, ok := that.(LabelNamesAndValuesRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2636,28 +3751,89 @@ func (this *UserStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.IngestionRate != that1.IngestionRate {
+	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
-	if this.NumSeries != that1.NumSeries {
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
+	}
+	if this.IncludeSeriesCount != that1.IncludeSeriesCount {
 		return false
 	}
-	if this.ApiIngestionRate != that1.ApiIngestionRate {
+	if len(this.Fields) != len(that1.Fields) {
 		return false
 	}
-	if this.RuleIngestionRate != that1.RuleIngestionRate {
+	for i := range this.Fields {
+		if this.Fields[i] != that1.Fields[i] {
+			return false
+		}
+	}
+	if this.LongValueLengthThreshold != that1.LongValueLengthThreshold {
+		return false
+	}
+	if this.PartitionByFirstCharacter != that1.PartitionByFirstCharacter {
+		return false
+	}
+	if this.IncludePresence != that1.IncludePresence {
+		return false
+	}
+	if !this.ValuesBloomFilter.Equal(that1.ValuesBloomFilter) {
+		return false
+	}
+	if this.MaxDistinctValues != that1.MaxDistinctValues {
+		return false
+	}
+	if this.UseValueIds != that1.UseValueIds {
+		return false
+	}
+	if this.CheckpointToken != that1.CheckpointToken {
+		return false
+	}
+	if this.ValuesPreviewSize != that1.ValuesPreviewSize {
+		return false
+	}
+	if !bytes.Equal(this.ValuesCompressionDictionary, that1.ValuesCompressionDictionary) {
+		return false
+	}
+	if this.IncludeValueCount != that1.IncludeValueCount {
+		return false
+	}
+	if this.MaxValues != that1.MaxValues {
+		return false
+	}
+	if this.IncludeBlocks != that1.IncludeBlocks {
+		return false
+	}
+	if this.DedupValues != that1.DedupValues {
+		return false
+	}
+	if this.IncludeRelabelOutcomes != that1.IncludeRelabelOutcomes {
+		return false
+	}
+	if this.ShardIndex != that1.ShardIndex {
+		return false
+	}
+	if this.ShardCount != that1.ShardCount {
+		return false
+	}
+	if this.ValuesCollation != that1.ValuesCollation {
+		return false
+	}
+	if this.Export != that1.Export {
 		return false
 	}
 	return true
 }
-func (this *UserIDStatsResponse) Equal(that interface{}) bool {
+func (this *LabelValuesBloomFilter) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UserIDStatsResponse)
+	that1, ok := that.(*LabelValuesBloomFilter)
 	if !ok {
-		that2, ok := that.(UserIDStatsResponse)
+		that2, ok := that.(LabelValuesBloomFilter)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2669,22 +3845,22 @@ func (this *UserIDStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.UserId != that1.UserId {
+	if !bytes.Equal(this.Bits, that1.Bits) {
 		return false
 	}
-	if !this.Data.Equal(that1.Data) {
+	if this.HashCount != that1.HashCount {
 		return false
 	}
 	return true
 }
-func (this *UsersStatsResponse) Equal(that interface{}) bool {
+func (this *LabelNamesAndValuesResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*UsersStatsResponse)
+	that1, ok := that.(*LabelNamesAndValuesResponse)
 	if !ok {
-		that2, ok := that.(UsersStatsResponse)
+		that2, ok := that.(LabelNamesAndValuesResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2696,59 +3872,58 @@ func (this *UsersStatsResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Stats) != len(that1.Stats) {
+	if len(this.Items) != len(that1.Items) {
 		return false
 	}
-	for i := range this.Stats {
-		if !this.Stats[i].Equal(that1.Stats[i]) {
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
 			return false
 		}
 	}
-	return true
-}
-func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+	if this.SeriesCount != that1.SeriesCount {
+		return false
 	}
-
-	that1, ok := that.(*MetricsForLabelMatchersRequest)
-	if !ok {
-		that2, ok := that.(MetricsForLabelMatchersRequest)
-		if ok {
-			that1 = &that2
-		} else {
+	if len(this.LongValues) != len(that1.LongValues) {
+		return false
+	}
+	for i := range this.LongValues {
+		if !this.LongValues[i].Equal(that1.LongValues[i]) {
 			return false
 		}
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
+	if this.PartitionKey != that1.PartitionKey {
 		return false
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
+	if len(this.Dictionary) != len(that1.Dictionary) {
 		return false
 	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
+	for i := range this.Dictionary {
+		if this.Dictionary[i] != that1.Dictionary[i] {
+			return false
+		}
+	}
+	if this.ValuesCompressionDictionaryId != that1.ValuesCompressionDictionaryId {
 		return false
 	}
-	if len(this.MatchersSet) != len(that1.MatchersSet) {
+	if this.Truncated != that1.Truncated {
 		return false
 	}
-	for i := range this.MatchersSet {
-		if !this.MatchersSet[i].Equal(that1.MatchersSet[i]) {
-			return false
-		}
+	if this.LabelNamesTruncated != that1.LabelNamesTruncated {
+		return false
+	}
+	if this.ExportObjectName != that1.ExportObjectName {
+		return false
 	}
 	return true
 }
-func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
+func (this *LabelValues) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsForLabelMatchersResponse)
+	that1, ok := that.(*LabelValues)
 	if !ok {
-		that2, ok := that.(MetricsForLabelMatchersResponse)
+		that2, ok := that.(LabelValues)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2760,45 +3935,57 @@ func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Metric) != len(that1.Metric) {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	for i := range this.Metric {
-		if !this.Metric[i].Equal(that1.Metric[i]) {
+	if len(this.Values) != len(that1.Values) {
+		return false
+	}
+	for i := range this.Values {
+		if this.Values[i] != that1.Values[i] {
 			return false
 		}
 	}
-	return true
-}
-func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
-	if that == nil {
-		return this == nil
+	if len(this.Presence) != len(that1.Presence) {
+		return false
 	}
-
-	that1, ok := that.(*MetricsMetadataRequest)
-	if !ok {
-		that2, ok := that.(MetricsMetadataRequest)
-		if ok {
-			that1 = &that2
-		} else {
+	for i := range this.Presence {
+		if this.Presence[i] != that1.Presence[i] {
 			return false
 		}
 	}
-	if that1 == nil {
-		return this == nil
-	} else if this == nil {
+	if len(this.ValueIds) != len(that1.ValueIds) {
+		return false
+	}
+	for i := range this.ValueIds {
+		if this.ValueIds[i] != that1.ValueIds[i] {
+			return false
+		}
+	}
+	if !bytes.Equal(this.CompressedValues, that1.CompressedValues) {
+		return false
+	}
+	if this.ValueCount != that1.ValueCount {
+		return false
+	}
+	if len(this.RelabelOutcomes) != len(that1.RelabelOutcomes) {
 		return false
 	}
+	for i := range this.RelabelOutcomes {
+		if this.RelabelOutcomes[i] != that1.RelabelOutcomes[i] {
+			return false
+		}
+	}
 	return true
 }
-func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
+func (this *LongLabelValues) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*MetricsMetadataResponse)
+	that1, ok := that.(*LongLabelValues)
 	if !ok {
-		that2, ok := that.(MetricsMetadataResponse)
+		that2, ok := that.(LongLabelValues)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2810,24 +3997,30 @@ func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Metadata) != len(that1.Metadata) {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	for i := range this.Metadata {
-		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+	if this.Count != that1.Count {
+		return false
+	}
+	if len(this.Exemplars) != len(that1.Exemplars) {
+		return false
+	}
+	for i := range this.Exemplars {
+		if this.Exemplars[i] != that1.Exemplars[i] {
 			return false
 		}
 	}
 	return true
 }
-func (this *TimeSeriesChunk) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TimeSeriesChunk)
+	that1, ok := that.(*LabelValuesCardinalityRequest)
 	if !ok {
-		that2, ok := that.(TimeSeriesChunk)
+		that2, ok := that.(LabelValuesCardinalityRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2839,38 +4032,136 @@ func (this *TimeSeriesChunk) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.FromIngesterId != that1.FromIngesterId {
-		return false
-	}
-	if this.UserId != that1.UserId {
-		return false
-	}
-	if len(this.Labels) != len(that1.Labels) {
+	if len(this.LabelNames) != len(that1.LabelNames) {
 		return false
 	}
-	for i := range this.Labels {
-		if !this.Labels[i].Equal(that1.Labels[i]) {
+	for i := range this.LabelNames {
+		if this.LabelNames[i] != that1.LabelNames[i] {
 			return false
 		}
 	}
-	if len(this.Chunks) != len(that1.Chunks) {
+	if len(this.Matchers) != len(that1.Matchers) {
 		return false
 	}
-	for i := range this.Chunks {
-		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
+	}
+	if this.GroupByMetricName != that1.GroupByMetricName {
+		return false
+	}
+	if this.IncludeChecksums != that1.IncludeChecksums {
+		return false
+	}
+	if this.ShardIndex != that1.ShardIndex {
+		return false
+	}
+	if this.ShardCount != that1.ShardCount {
+		return false
+	}
+	if this.EstimateLabelSeries != that1.EstimateLabelSeries {
+		return false
+	}
+	if this.IncludeChunkCount != that1.IncludeChunkCount {
+		return false
+	}
+	if this.Explain != that1.Explain {
+		return false
+	}
+	if this.ProgressIntervalMs != that1.ProgressIntervalMs {
+		return false
+	}
+	if this.MinSeriesCount != that1.MinSeriesCount {
+		return false
+	}
+	if this.AllLabels != that1.AllLabels {
+		return false
+	}
+	if this.ValueHashSalt != that1.ValueHashSalt {
+		return false
+	}
+	if this.IncludeRatios != that1.IncludeRatios {
+		return false
+	}
+	if this.SampleValues != that1.SampleValues {
+		return false
+	}
+	if this.SampleSeed != that1.SampleSeed {
+		return false
+	}
+	if this.ValueGroupRegex != that1.ValueGroupRegex {
+		return false
+	}
+	if this.CoOccurrenceTopK != that1.CoOccurrenceTopK {
+		return false
+	}
+	if this.MetricNamesTopK != that1.MetricNamesTopK {
+		return false
+	}
+	if this.OrderByLabelSeries != that1.OrderByLabelSeries {
+		return false
+	}
+	if len(this.SeriesCountPercentiles) != len(that1.SeriesCountPercentiles) {
+		return false
+	}
+	for i := range this.SeriesCountPercentiles {
+		if this.SeriesCountPercentiles[i] != that1.SeriesCountPercentiles[i] {
 			return false
 		}
 	}
+	if this.ContextCheckIntervalSeries != that1.ContextCheckIntervalSeries {
+		return false
+	}
+	if this.SortLabelValues != that1.SortLabelValues {
+		return false
+	}
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
+	}
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
+	}
+	if this.BestEffort != that1.BestEffort {
+		return false
+	}
+	if this.GroupByMagnitude != that1.GroupByMagnitude {
+		return false
+	}
+	if this.IncludeSummary != that1.IncludeSummary {
+		return false
+	}
+	if this.ResumeOffset != that1.ResumeOffset {
+		return false
+	}
+	if this.ResumeChecksum != that1.ResumeChecksum {
+		return false
+	}
+	if this.CompareStartTimestampMs != that1.CompareStartTimestampMs {
+		return false
+	}
+	if this.CompareEndTimestampMs != that1.CompareEndTimestampMs {
+		return false
+	}
+	if this.CompareGrowthRate != that1.CompareGrowthRate {
+		return false
+	}
+	if this.ChangedLabelsOnly != that1.ChangedLabelsOnly {
+		return false
+	}
+	if !bytes.Equal(this.ChangedLabelsState, that1.ChangedLabelsState) {
+		return false
+	}
 	return true
 }
-func (this *Chunk) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityStreamRequest) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*Chunk)
+	that1, ok := that.(*LabelValuesCardinalityStreamRequest)
 	if !ok {
-		that2, ok := that.(Chunk)
+		that2, ok := that.(LabelValuesCardinalityStreamRequest)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2882,28 +4173,31 @@ func (this *Chunk) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.StartTimestampMs != that1.StartTimestampMs {
+	if !this.Request.Equal(that1.Request) {
 		return false
 	}
-	if this.EndTimestampMs != that1.EndTimestampMs {
+	if this.Stop != that1.Stop {
 		return false
 	}
-	if this.Encoding != that1.Encoding {
+	if this.WatchIntervalMs != that1.WatchIntervalMs {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
+	if this.Pause != that1.Pause {
+		return false
+	}
+	if this.Resume != that1.Resume {
 		return false
 	}
 	return true
 }
-func (this *LabelMatchers) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityResponse) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelMatchers)
+	that1, ok := that.(*LabelValuesCardinalityResponse)
 	if !ok {
-		that2, ok := that.(LabelMatchers)
+		that2, ok := that.(LabelValuesCardinalityResponse)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2915,24 +4209,76 @@ func (this *LabelMatchers) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if len(this.Matchers) != len(that1.Matchers) {
+	if len(this.Items) != len(that1.Items) {
 		return false
 	}
-	for i := range this.Matchers {
-		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
+			return false
+		}
+	}
+	if this.BudgetWarning != that1.BudgetWarning {
+		return false
+	}
+	if this.SequenceNumber != that1.SequenceNumber {
+		return false
+	}
+	if this.ItemsChecksum != that1.ItemsChecksum {
+		return false
+	}
+	if this.Stopped != that1.Stopped {
+		return false
+	}
+	if !this.Explain.Equal(that1.Explain) {
+		return false
+	}
+	if !this.Progress.Equal(that1.Progress) {
+		return false
+	}
+	if this.SampleSeed != that1.SampleSeed {
+		return false
+	}
+	if this.FailedLabelValues != that1.FailedLabelValues {
+		return false
+	}
+	if len(this.Errors) != len(that1.Errors) {
+		return false
+	}
+	for i := range this.Errors {
+		if this.Errors[i] != that1.Errors[i] {
+			return false
+		}
+	}
+	if len(this.Summaries) != len(that1.Summaries) {
+		return false
+	}
+	for i := range this.Summaries {
+		if !this.Summaries[i].Equal(that1.Summaries[i]) {
 			return false
 		}
 	}
+	if this.ByteOffset != that1.ByteOffset {
+		return false
+	}
+	if this.RunningChecksum != that1.RunningChecksum {
+		return false
+	}
+	if this.InstanceId != that1.InstanceId {
+		return false
+	}
+	if !bytes.Equal(this.ChangedLabelsState, that1.ChangedLabelsState) {
+		return false
+	}
 	return true
 }
-func (this *LabelMatcher) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalitySummary) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*LabelMatcher)
+	that1, ok := that.(*LabelValuesCardinalitySummary)
 	if !ok {
-		that2, ok := that.(LabelMatcher)
+		that2, ok := that.(LabelValuesCardinalitySummary)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2944,25 +4290,25 @@ func (this *LabelMatcher) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.Type != that1.Type {
+	if this.LabelName != that1.LabelName {
 		return false
 	}
-	if this.Name != that1.Name {
+	if this.LabelValuesCount != that1.LabelValuesCount {
 		return false
 	}
-	if this.Value != that1.Value {
+	if this.SeriesCount != that1.SeriesCount {
 		return false
 	}
 	return true
 }
-func (this *TimeSeriesFile) Equal(that interface{}) bool {
+func (this *LabelValuesCardinalityProgress) Equal(that interface{}) bool {
 	if that == nil {
 		return this == nil
 	}
 
-	that1, ok := that.(*TimeSeriesFile)
+	that1, ok := that.(*LabelValuesCardinalityProgress)
 	if !ok {
-		that2, ok := that.(TimeSeriesFile)
+		that2, ok := that.(LabelValuesCardinalityProgress)
 		if ok {
 			that1 = &that2
 		} else {
@@ -2974,3525 +4320,11343 @@ func (this *TimeSeriesFile) Equal(that interface{}) bool {
 	} else if this == nil {
 		return false
 	}
-	if this.FromIngesterId != that1.FromIngesterId {
+	if this.LabelValues != that1.LabelValues {
 		return false
 	}
-	if this.UserId != that1.UserId {
+	if this.Series != that1.Series {
 		return false
 	}
-	if this.Filename != that1.Filename {
+	return true
+}
+func (this *LabelValuesCardinalityExplain) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
+
+	that1, ok := that.(*LabelValuesCardinalityExplain)
+	if !ok {
+		that2, ok := that.(LabelValuesCardinalityExplain)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
 		return false
 	}
-	if !bytes.Equal(this.Data, that1.Data) {
+	if this.LabelValuesDurationNs != that1.LabelValuesDurationNs {
 		return false
 	}
-	return true
-}
-func (this *LabelNamesAndValuesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.CountingDurationNs != that1.CountingDurationNs {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelNamesAndValuesRequest{")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if this.SendingDurationNs != that1.SendingDurationNs {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesAndValuesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.CountingGoroutines != that1.CountingGoroutines {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelNamesAndValuesResponse{")
-	if this.Items != nil {
-		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	if this.Goroutines != that1.Goroutines {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelValues) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *LabelValueSeriesCount) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelValues{")
-	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
-	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesCardinalityRequest) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*LabelValueSeriesCount)
+	if !ok {
+		that2, ok := that.(LabelValueSeriesCount)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelValuesCardinalityRequest{")
-	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesCardinalityResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.LabelName != that1.LabelName {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelValuesCardinalityResponse{")
-	if this.Items != nil {
-		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	if len(this.LabelValueSeries) != len(that1.LabelValueSeries) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValueSeriesCount) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.LabelValueSeries {
+		if this.LabelValueSeries[i] != that1.LabelValueSeries[i] {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.LabelValueSeriesCount{")
-	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
-	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
-	for k, _ := range this.LabelValueSeries {
-		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
+	if len(this.LabelValueMetricNamesSeries) != len(that1.LabelValueMetricNamesSeries) {
+		return false
 	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
-	mapStringForLabelValueSeries := "map[string]uint64{"
-	for _, k := range keysForLabelValueSeries {
-		mapStringForLabelValueSeries += fmt.Sprintf("%#v: %#v,", k, this.LabelValueSeries[k])
+	for i := range this.LabelValueMetricNamesSeries {
+		if !this.LabelValueMetricNamesSeries[i].Equal(that1.LabelValueMetricNamesSeries[i]) {
+			return false
+		}
 	}
-	mapStringForLabelValueSeries += "}"
-	if this.LabelValueSeries != nil {
-		s = append(s, "LabelValueSeries: "+mapStringForLabelValueSeries+",\n")
+	if len(this.LabelValueSeriesDelta) != len(that1.LabelValueSeriesDelta) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ReadRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.LabelValueSeriesDelta {
+		if this.LabelValueSeriesDelta[i] != that1.LabelValueSeriesDelta[i] {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.ReadRequest{")
-	if this.Queries != nil {
-		s = append(s, "Queries: "+fmt.Sprintf("%#v", this.Queries)+",\n")
+	if this.LabelSeriesEstimate != that1.LabelSeriesEstimate {
+		return false
 	}
-	s = append(s, "AcceptedResponseTypes: "+fmt.Sprintf("%#v", this.AcceptedResponseTypes)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ReadResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.LabelSeriesEstimateRelativeError != that1.LabelSeriesEstimateRelativeError {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.ReadResponse{")
-	if this.Results != nil {
-		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	if len(this.LabelValueChunks) != len(that1.LabelValueChunks) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *StreamReadResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.LabelValueChunks {
+		if this.LabelValueChunks[i] != that1.LabelValueChunks[i] {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.StreamReadResponse{")
-	if this.ChunkedSeries != nil {
-		s = append(s, "ChunkedSeries: "+fmt.Sprintf("%#v", this.ChunkedSeries)+",\n")
+	if len(this.LabelValueRatios) != len(that1.LabelValueRatios) {
+		return false
 	}
-	s = append(s, "QueryIndex: "+fmt.Sprintf("%#v", this.QueryIndex)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *StreamChunkedSeries) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.LabelValueRatios {
+		if this.LabelValueRatios[i] != that1.LabelValueRatios[i] {
+			return false
+		}
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.StreamChunkedSeries{")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	if this.Chunks != nil {
-		vs := make([]*StreamChunk, len(this.Chunks))
-		for i := range vs {
-			vs[i] = &this.Chunks[i]
+	if len(this.LabelValueCoOccurrences) != len(that1.LabelValueCoOccurrences) {
+		return false
+	}
+	for i := range this.LabelValueCoOccurrences {
+		if !this.LabelValueCoOccurrences[i].Equal(that1.LabelValueCoOccurrences[i]) {
+			return false
 		}
-		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *StreamChunk) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.LabelValueSeriesGrowthRate) != len(that1.LabelValueSeriesGrowthRate) {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.StreamChunk{")
-	s = append(s, "MinTimeMs: "+fmt.Sprintf("%#v", this.MinTimeMs)+",\n")
-	s = append(s, "MaxTimeMs: "+fmt.Sprintf("%#v", this.MaxTimeMs)+",\n")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *QueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.LabelValueSeriesGrowthRate {
+		if this.LabelValueSeriesGrowthRate[i] != that1.LabelValueSeriesGrowthRate[i] {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.QueryRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if len(this.SeriesCountPercentiles) != len(that1.SeriesCountPercentiles) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ExemplarQueryRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.SeriesCountPercentiles {
+		if !this.SeriesCountPercentiles[i].Equal(that1.SeriesCountPercentiles[i]) {
+			return false
+		}
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.ExemplarQueryRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if this.SeriesCountMagnitude != that1.SeriesCountMagnitude {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *QueryResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *SeriesCountPercentile) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.QueryResponse{")
-	if this.Timeseries != nil {
-		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
+
+	that1, ok := that.(*SeriesCountPercentile)
+	if !ok {
+		that2, ok := that.(SeriesCountPercentile)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.Percentile != that1.Percentile {
+		return false
+	}
+	if this.SeriesCount != that1.SeriesCount {
+		return false
+	}
+	return true
 }
-func (this *QueryStreamResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *LabelValueCoOccurrences) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.QueryStreamResponse{")
-	if this.Chunkseries != nil {
-		vs := make([]*TimeSeriesChunk, len(this.Chunkseries))
-		for i := range vs {
-			vs[i] = &this.Chunkseries[i]
+
+	that1, ok := that.(*LabelValueCoOccurrences)
+	if !ok {
+		that2, ok := that.(LabelValueCoOccurrences)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
-		s = append(s, "Chunkseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	if this.Timeseries != nil {
-		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
-		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *ExemplarQueryResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Items) != len(that1.Items) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.ExemplarQueryResponse{")
-	if this.Timeseries != nil {
-		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
-		for i := range vs {
-			vs[i] = &this.Timeseries[i]
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
+			return false
 		}
-		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *LabelValuesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *LabelValueCoOccurrence) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.LabelValuesRequest{")
-	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+
+	that1, ok := that.(*LabelValueCoOccurrence)
+	if !ok {
+		that2, ok := that.(LabelValueCoOccurrence)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelValuesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelValuesResponse{")
-	s = append(s, "LabelValues: "+fmt.Sprintf("%#v", this.LabelValues)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.LabelName != that1.LabelName {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.LabelNamesRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if this.LabelValue != that1.LabelValue {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelNamesResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if this.SeriesCount != that1.SeriesCount {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelNamesResponse{")
-	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *UserStatsRequest) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *MetricNamesSeriesCount) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&client.UserStatsRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *UserStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*MetricNamesSeriesCount)
+	if !ok {
+		that2, ok := that.(MetricNamesSeriesCount)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.UserStatsResponse{")
-	s = append(s, "IngestionRate: "+fmt.Sprintf("%#v", this.IngestionRate)+",\n")
-	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
-	s = append(s, "ApiIngestionRate: "+fmt.Sprintf("%#v", this.ApiIngestionRate)+",\n")
-	s = append(s, "RuleIngestionRate: "+fmt.Sprintf("%#v", this.RuleIngestionRate)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *UserIDStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 6)
-	s = append(s, "&client.UserIDStatsResponse{")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	if this.Data != nil {
-		s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	if len(this.Items) != len(that1.Items) {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	for i := range this.Items {
+		if !this.Items[i].Equal(that1.Items[i]) {
+			return false
+		}
+	}
+	return true
 }
-func (this *UsersStatsResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *MetricNameSeriesCount) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.UsersStatsResponse{")
-	if this.Stats != nil {
-		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+
+	that1, ok := that.(*MetricNameSeriesCount)
+	if !ok {
+		that2, ok := that.(MetricNameSeriesCount)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsForLabelMatchersRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.MetricsForLabelMatchersRequest{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	if this.MatchersSet != nil {
-		s = append(s, "MatchersSet: "+fmt.Sprintf("%#v", this.MatchersSet)+",\n")
+	if this.MetricName != that1.MetricName {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	if this.SeriesCount != that1.SeriesCount {
+		return false
+	}
+	return true
 }
-func (this *MetricsForLabelMatchersResponse) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ReadRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.MetricsForLabelMatchersResponse{")
-	if this.Metric != nil {
-		s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
+
+	that1, ok := that.(*ReadRequest)
+	if !ok {
+		that2, ok := that.(ReadRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsMetadataRequest) GoString() string {
-	if this == nil {
-		return "nil"
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s := make([]string, 0, 4)
-	s = append(s, "&client.MetricsMetadataRequest{")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *MetricsMetadataResponse) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Queries) != len(that1.Queries) {
+		return false
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.MetricsMetadataResponse{")
-	if this.Metadata != nil {
-		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	for i := range this.Queries {
+		if !this.Queries[i].Equal(that1.Queries[i]) {
+			return false
+		}
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *TimeSeriesChunk) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.AcceptedResponseTypes) != len(that1.AcceptedResponseTypes) {
+		return false
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.TimeSeriesChunk{")
-	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
-	if this.Chunks != nil {
-		vs := make([]*Chunk, len(this.Chunks))
-		for i := range vs {
-			vs[i] = &this.Chunks[i]
+	for i := range this.AcceptedResponseTypes {
+		if this.AcceptedResponseTypes[i] != that1.AcceptedResponseTypes[i] {
+			return false
 		}
-		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func (this *Chunk) GoString() string {
-	if this == nil {
-		return "nil"
+func (this *ReadResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.Chunk{")
-	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
-	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
-	s = append(s, "Encoding: "+fmt.Sprintf("%#v", this.Encoding)+",\n")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelMatchers) GoString() string {
-	if this == nil {
-		return "nil"
+
+	that1, ok := that.(*ReadResponse)
+	if !ok {
+		that2, ok := that.(ReadResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	s := make([]string, 0, 5)
-	s = append(s, "&client.LabelMatchers{")
-	if this.Matchers != nil {
-		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *LabelMatcher) GoString() string {
-	if this == nil {
-		return "nil"
+	if len(this.Results) != len(that1.Results) {
+		return false
 	}
-	s := make([]string, 0, 7)
-	s = append(s, "&client.LabelMatcher{")
-	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
-	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
-	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
-}
-func (this *TimeSeriesFile) GoString() string {
-	if this == nil {
-		return "nil"
+	for i := range this.Results {
+		if !this.Results[i].Equal(that1.Results[i]) {
+			return false
+		}
 	}
-	s := make([]string, 0, 8)
-	s = append(s, "&client.TimeSeriesFile{")
-	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
-	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
-	s = append(s, "Filename: "+fmt.Sprintf("%#v", this.Filename)+",\n")
-	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
-	s = append(s, "}")
-	return strings.Join(s, "")
+	return true
 }
-func valueToGoStringIngester(v interface{}, typ string) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+func (this *StreamReadResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
-}
-
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
 
-// IngesterClient is the client API for Ingester service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type IngesterClient interface {
-	Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error)
-	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error)
-	QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error)
-	LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error)
-	LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error)
-	UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
-	AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error)
-	MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error)
-	MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error)
-	// LabelNamesAndValues provides all values for each label that matches the matchers.
-	// The order of the labels and values is not guaranteed.
-	LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error)
-	// LabelValuesCardinality returns all values and series total count for label_names labels
-	// that match the matchers.
-	// The listing order of the labels is not guaranteed.
-	LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error)
-}
-
-type ingesterClient struct {
-	cc *grpc.ClientConn
-}
-
-func NewIngesterClient(cc *grpc.ClientConn) IngesterClient {
-	return &ingesterClient{cc}
-}
-
-func (c *ingesterClient) Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error) {
-	out := new(mimirpb.WriteResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/Push", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*StreamReadResponse)
+	if !ok {
+		that2, ok := that.(StreamReadResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[0], "/cortex.Ingester/QueryStream", opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	x := &ingesterQueryStreamClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if len(this.ChunkedSeries) != len(that1.ChunkedSeries) {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	for i := range this.ChunkedSeries {
+		if !this.ChunkedSeries[i].Equal(that1.ChunkedSeries[i]) {
+			return false
+		}
 	}
-	return x, nil
-}
-
-type Ingester_QueryStreamClient interface {
-	Recv() (*QueryStreamResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterQueryStreamClient struct {
-	grpc.ClientStream
-}
-
-func (x *ingesterQueryStreamClient) Recv() (*QueryStreamResponse, error) {
-	m := new(QueryStreamResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if this.QueryIndex != that1.QueryIndex {
+		return false
 	}
-	return m, nil
+	return true
 }
-
-func (c *ingesterClient) QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error) {
-	out := new(ExemplarQueryResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/QueryExemplars", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (this *StreamChunkedSeries) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return out, nil
-}
 
-func (c *ingesterClient) LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error) {
-	out := new(LabelValuesResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValues", in, out, opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*StreamChunkedSeries)
+	if !ok {
+		that2, ok := that.(StreamChunkedSeries)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error) {
-	out := new(LabelNamesResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelNames", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
-	out := new(UserStatsResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/UserStats", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if len(this.Labels) != len(that1.Labels) {
+		return false
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error) {
-	out := new(UsersStatsResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/AllUserStats", in, out, opts...)
-	if err != nil {
-		return nil, err
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
+		}
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error) {
-	out := new(MetricsForLabelMatchersResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsForLabelMatchers", in, out, opts...)
-	if err != nil {
-		return nil, err
+	if len(this.Chunks) != len(that1.Chunks) {
+		return false
 	}
-	return out, nil
-}
-
-func (c *ingesterClient) MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error) {
-	out := new(MetricsMetadataResponse)
-	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsMetadata", in, out, opts...)
-	if err != nil {
-		return nil, err
+	for i := range this.Chunks {
+		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
+			return false
+		}
 	}
-	return out, nil
+	return true
 }
+func (this *StreamChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *ingesterClient) LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[1], "/cortex.Ingester/LabelNamesAndValues", opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*StreamChunk)
+	if !ok {
+		that2, ok := that.(StreamChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	x := &ingesterLabelNamesAndValuesClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if this.MinTimeMs != that1.MinTimeMs {
+		return false
 	}
-	return x, nil
-}
-
-type Ingester_LabelNamesAndValuesClient interface {
-	Recv() (*LabelNamesAndValuesResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterLabelNamesAndValuesClient struct {
-	grpc.ClientStream
-}
-
-func (x *ingesterLabelNamesAndValuesClient) Recv() (*LabelNamesAndValuesResponse, error) {
-	m := new(LabelNamesAndValuesResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	if this.MaxTimeMs != that1.MaxTimeMs {
+		return false
 	}
-	return m, nil
+	if this.Type != that1.Type {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
 }
+func (this *QueryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (c *ingesterClient) LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[2], "/cortex.Ingester/LabelValuesCardinality", opts...)
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*QueryRequest)
+	if !ok {
+		that2, ok := that.(QueryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	x := &ingesterLabelValuesCardinalityClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	return x, nil
-}
-
-type Ingester_LabelValuesCardinalityClient interface {
-	Recv() (*LabelValuesCardinalityResponse, error)
-	grpc.ClientStream
-}
-
-type ingesterLabelValuesCardinalityClient struct {
-	grpc.ClientStream
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
+	}
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
+	}
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *ExemplarQueryRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (x *ingesterLabelValuesCardinalityClient) Recv() (*LabelValuesCardinalityResponse, error) {
-	m := new(LabelValuesCardinalityResponse)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
+	that1, ok := that.(*ExemplarQueryRequest)
+	if !ok {
+		that2, ok := that.(ExemplarQueryRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return m, nil
-}
-
-// IngesterServer is the server API for Ingester service.
-type IngesterServer interface {
-	Push(context.Context, *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error)
-	QueryStream(*QueryRequest, Ingester_QueryStreamServer) error
-	QueryExemplars(context.Context, *ExemplarQueryRequest) (*ExemplarQueryResponse, error)
-	LabelValues(context.Context, *LabelValuesRequest) (*LabelValuesResponse, error)
-	LabelNames(context.Context, *LabelNamesRequest) (*LabelNamesResponse, error)
-	UserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
-	AllUserStats(context.Context, *UserStatsRequest) (*UsersStatsResponse, error)
-	MetricsForLabelMatchers(context.Context, *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error)
-	MetricsMetadata(context.Context, *MetricsMetadataRequest) (*MetricsMetadataResponse, error)
-	// LabelNamesAndValues provides all values for each label that matches the matchers.
-	// The order of the labels and values is not guaranteed.
-	LabelNamesAndValues(*LabelNamesAndValuesRequest, Ingester_LabelNamesAndValuesServer) error
-	// LabelValuesCardinality returns all values and series total count for label_names labels
-	// that match the matchers.
-	// The listing order of the labels is not guaranteed.
-	LabelValuesCardinality(*LabelValuesCardinalityRequest, Ingester_LabelValuesCardinalityServer) error
-}
-
-// UnimplementedIngesterServer can be embedded to have forward compatible implementations.
-type UnimplementedIngesterServer struct {
-}
-
-func (*UnimplementedIngesterServer) Push(ctx context.Context, req *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
-}
-func (*UnimplementedIngesterServer) QueryStream(req *QueryRequest, srv Ingester_QueryStreamServer) error {
-	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
-}
-func (*UnimplementedIngesterServer) QueryExemplars(ctx context.Context, req *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method QueryExemplars not implemented")
-}
-func (*UnimplementedIngesterServer) LabelValues(ctx context.Context, req *LabelValuesRequest) (*LabelValuesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LabelValues not implemented")
-}
-func (*UnimplementedIngesterServer) LabelNames(ctx context.Context, req *LabelNamesRequest) (*LabelNamesResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LabelNames not implemented")
-}
-func (*UnimplementedIngesterServer) UserStats(ctx context.Context, req *UserStatsRequest) (*UserStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UserStats not implemented")
-}
-func (*UnimplementedIngesterServer) AllUserStats(ctx context.Context, req *UserStatsRequest) (*UsersStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AllUserStats not implemented")
-}
-func (*UnimplementedIngesterServer) MetricsForLabelMatchers(ctx context.Context, req *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MetricsForLabelMatchers not implemented")
-}
-func (*UnimplementedIngesterServer) MetricsMetadata(ctx context.Context, req *MetricsMetadataRequest) (*MetricsMetadataResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method MetricsMetadata not implemented")
-}
-func (*UnimplementedIngesterServer) LabelNamesAndValues(req *LabelNamesAndValuesRequest, srv Ingester_LabelNamesAndValuesServer) error {
-	return status.Errorf(codes.Unimplemented, "method LabelNamesAndValues not implemented")
-}
-func (*UnimplementedIngesterServer) LabelValuesCardinality(req *LabelValuesCardinalityRequest, srv Ingester_LabelValuesCardinalityServer) error {
-	return status.Errorf(codes.Unimplemented, "method LabelValuesCardinality not implemented")
-}
-
-func RegisterIngesterServer(s *grpc.Server, srv IngesterServer) {
-	s.RegisterService(&_Ingester_serviceDesc, srv)
-}
-
-func _Ingester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(mimirpb.WriteRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).Push(ctx, in)
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/Push",
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).Push(ctx, req.(*mimirpb.WriteRequest))
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Ingester_QueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(QueryRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
+		}
 	}
-	return srv.(IngesterServer).QueryStream(m, &ingesterQueryStreamServer{stream})
-}
-
-type Ingester_QueryStreamServer interface {
-	Send(*QueryStreamResponse) error
-	grpc.ServerStream
-}
-
-type ingesterQueryStreamServer struct {
-	grpc.ServerStream
-}
-
-func (x *ingesterQueryStreamServer) Send(m *QueryStreamResponse) error {
-	return x.ServerStream.SendMsg(m)
+	return true
 }
+func (this *QueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_QueryExemplars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ExemplarQueryRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*QueryResponse)
+	if !ok {
+		that2, ok := that.(QueryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).QueryExemplars(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/QueryExemplars",
+	if len(this.Timeseries) != len(that1.Timeseries) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).QueryExemplars(ctx, req.(*ExemplarQueryRequest))
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
-
-func _Ingester_LabelValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabelValuesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(IngesterServer).LabelValues(ctx, in)
+func (this *QueryStreamResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/LabelValues",
+
+	that1, ok := that.(*QueryStreamResponse)
+	if !ok {
+		that2, ok := that.(QueryStreamResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).LabelValues(ctx, req.(*LabelValuesRequest))
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
-}
-
-func _Ingester_LabelNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LabelNamesRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	if len(this.Chunkseries) != len(that1.Chunkseries) {
+		return false
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).LabelNames(ctx, in)
+	for i := range this.Chunkseries {
+		if !this.Chunkseries[i].Equal(&that1.Chunkseries[i]) {
+			return false
+		}
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/LabelNames",
+	if len(this.Timeseries) != len(that1.Timeseries) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).LabelNames(ctx, req.(*LabelNamesRequest))
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *ExemplarQueryResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_UserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*ExemplarQueryResponse)
+	if !ok {
+		that2, ok := that.(ExemplarQueryResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).UserStats(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/UserStats",
+	if len(this.Timeseries) != len(that1.Timeseries) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).UserStats(ctx, req.(*UserStatsRequest))
+	for i := range this.Timeseries {
+		if !this.Timeseries[i].Equal(&that1.Timeseries[i]) {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *LabelValuesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_AllUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UserStatsRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*LabelValuesRequest)
+	if !ok {
+		that2, ok := that.(LabelValuesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).AllUserStats(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/AllUserStats",
+	if this.LabelName != that1.LabelName {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).AllUserStats(ctx, req.(*UserStatsRequest))
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
+	}
+	if !this.Matchers.Equal(that1.Matchers) {
+		return false
+	}
+	return true
 }
+func (this *LabelValuesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_MetricsForLabelMatchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetricsForLabelMatchersRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*LabelValuesResponse)
+	if !ok {
+		that2, ok := that.(LabelValuesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/MetricsForLabelMatchers",
+	if len(this.LabelValues) != len(that1.LabelValues) {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, req.(*MetricsForLabelMatchersRequest))
+	for i := range this.LabelValues {
+		if this.LabelValues[i] != that1.LabelValues[i] {
+			return false
+		}
 	}
-	return interceptor(ctx, in, info, handler)
+	return true
 }
+func (this *LabelNamesRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_MetricsMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(MetricsMetadataRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+	that1, ok := that.(*LabelNamesRequest)
+	if !ok {
+		that2, ok := that.(LabelNamesRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if interceptor == nil {
-		return srv.(IngesterServer).MetricsMetadata(ctx, in)
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: "/cortex.Ingester/MetricsMetadata",
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
 	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IngesterServer).MetricsMetadata(ctx, req.(*MetricsMetadataRequest))
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
 	}
-	return interceptor(ctx, in, info, handler)
+	if !this.Matchers.Equal(that1.Matchers) {
+		return false
+	}
+	return true
 }
+func (this *LabelNamesResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_LabelNamesAndValues_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(LabelNamesAndValuesRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	that1, ok := that.(*LabelNamesResponse)
+	if !ok {
+		that2, ok := that.(LabelNamesResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return srv.(IngesterServer).LabelNamesAndValues(m, &ingesterLabelNamesAndValuesServer{stream})
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.LabelNames) != len(that1.LabelNames) {
+		return false
+	}
+	for i := range this.LabelNames {
+		if this.LabelNames[i] != that1.LabelNames[i] {
+			return false
+		}
+	}
+	return true
 }
+func (this *UserStatsRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-type Ingester_LabelNamesAndValuesServer interface {
-	Send(*LabelNamesAndValuesResponse) error
-	grpc.ServerStream
+	that1, ok := that.(*UserStatsRequest)
+	if !ok {
+		that2, ok := that.(UserStatsRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	return true
 }
+func (this *UserStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-type ingesterLabelNamesAndValuesServer struct {
-	grpc.ServerStream
+	that1, ok := that.(*UserStatsResponse)
+	if !ok {
+		that2, ok := that.(UserStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.IngestionRate != that1.IngestionRate {
+		return false
+	}
+	if this.NumSeries != that1.NumSeries {
+		return false
+	}
+	if this.ApiIngestionRate != that1.ApiIngestionRate {
+		return false
+	}
+	if this.RuleIngestionRate != that1.RuleIngestionRate {
+		return false
+	}
+	return true
 }
+func (this *UserIDStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (x *ingesterLabelNamesAndValuesServer) Send(m *LabelNamesAndValuesResponse) error {
-	return x.ServerStream.SendMsg(m)
+	that1, ok := that.(*UserIDStatsResponse)
+	if !ok {
+		that2, ok := that.(UserIDStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
+	}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.UserId != that1.UserId {
+		return false
+	}
+	if !this.Data.Equal(that1.Data) {
+		return false
+	}
+	return true
 }
+func (this *UsersStatsResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func _Ingester_LabelValuesCardinality_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(LabelValuesCardinalityRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
+	that1, ok := that.(*UsersStatsResponse)
+	if !ok {
+		that2, ok := that.(UsersStatsResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return srv.(IngesterServer).LabelValuesCardinality(m, &ingesterLabelValuesCardinalityServer{stream})
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Stats) != len(that1.Stats) {
+		return false
+	}
+	for i := range this.Stats {
+		if !this.Stats[i].Equal(that1.Stats[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *MetricsForLabelMatchersRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-type Ingester_LabelValuesCardinalityServer interface {
-	Send(*LabelValuesCardinalityResponse) error
-	grpc.ServerStream
-}
-
-type ingesterLabelValuesCardinalityServer struct {
-	grpc.ServerStream
-}
-
-func (x *ingesterLabelValuesCardinalityServer) Send(m *LabelValuesCardinalityResponse) error {
-	return x.ServerStream.SendMsg(m)
-}
-
-var _Ingester_serviceDesc = grpc.ServiceDesc{
-	ServiceName: "cortex.Ingester",
-	HandlerType: (*IngesterServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "Push",
-			Handler:    _Ingester_Push_Handler,
-		},
-		{
-			MethodName: "QueryExemplars",
-			Handler:    _Ingester_QueryExemplars_Handler,
-		},
-		{
-			MethodName: "LabelValues",
-			Handler:    _Ingester_LabelValues_Handler,
-		},
-		{
-			MethodName: "LabelNames",
-			Handler:    _Ingester_LabelNames_Handler,
-		},
-		{
-			MethodName: "UserStats",
-			Handler:    _Ingester_UserStats_Handler,
-		},
-		{
-			MethodName: "AllUserStats",
-			Handler:    _Ingester_AllUserStats_Handler,
-		},
-		{
-			MethodName: "MetricsForLabelMatchers",
-			Handler:    _Ingester_MetricsForLabelMatchers_Handler,
-		},
-		{
-			MethodName: "MetricsMetadata",
-			Handler:    _Ingester_MetricsMetadata_Handler,
-		},
-	},
-	Streams: []grpc.StreamDesc{
-		{
-			StreamName:    "QueryStream",
-			Handler:       _Ingester_QueryStream_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "LabelNamesAndValues",
-			Handler:       _Ingester_LabelNamesAndValues_Handler,
-			ServerStreams: true,
-		},
-		{
-			StreamName:    "LabelValuesCardinality",
-			Handler:       _Ingester_LabelValuesCardinality_Handler,
-			ServerStreams: true,
-		},
-	},
-	Metadata: "ingester.proto",
-}
-
-func (m *LabelNamesAndValuesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	that1, ok := that.(*MetricsForLabelMatchersRequest)
+	if !ok {
+		that2, ok := that.(MetricsForLabelMatchersRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelNamesAndValuesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *LabelNamesAndValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
+	}
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
+	}
+	if len(this.MatchersSet) != len(that1.MatchersSet) {
+		return false
+	}
+	for i := range this.MatchersSet {
+		if !this.MatchersSet[i].Equal(that1.MatchersSet[i]) {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *LabelNamesAndValuesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *MetricsForLabelMatchersResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelNamesAndValuesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *LabelNamesAndValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	that1, ok := that.(*MetricsForLabelMatchersResponse)
+	if !ok {
+		that2, ok := that.(MetricsForLabelMatchersResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *LabelValues) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelValues) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if len(this.Metric) != len(that1.Metric) {
+		return false
+	}
+	for i := range this.Metric {
+		if !this.Metric[i].Equal(that1.Metric[i]) {
+			return false
+		}
+	}
+	return true
 }
+func (this *MetricsMetadataRequest) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *LabelValues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Values) > 0 {
-		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.Values[iNdEx])
-			copy(dAtA[i:], m.Values[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.Values[iNdEx])))
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*MetricsMetadataRequest)
+	if !ok {
+		that2, ok := that.(MetricsMetadataRequest)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.LabelName) > 0 {
-		i -= len(m.LabelName)
-		copy(dAtA[i:], m.LabelName)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *LabelValuesCardinalityRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *MetricsMetadataResponse) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelValuesCardinalityRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *LabelValuesCardinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*MetricsMetadataResponse)
+	if !ok {
+		that2, ok := that.(MetricsMetadataResponse)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.LabelNames) > 0 {
-		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LabelNames[iNdEx])
-			copy(dAtA[i:], m.LabelNames[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
-			i--
-			dAtA[i] = 0xa
-		}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *LabelValuesCardinalityResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if len(this.Metadata) != len(that1.Metadata) {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelValuesCardinalityResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *LabelValuesCardinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	for i := range this.Metadata {
+		if !this.Metadata[i].Equal(that1.Metadata[i]) {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *LabelValueSeriesCount) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *TimeSeriesChunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelValueSeriesCount) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *LabelValueSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelValueSeries) > 0 {
-		for k := range m.LabelValueSeries {
-			v := m.LabelValueSeries[k]
-			baseI := i
-			i = encodeVarintIngester(dAtA, i, uint64(v))
-			i--
-			dAtA[i] = 0x10
-			i -= len(k)
-			copy(dAtA[i:], k)
-			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
-			i--
-			dAtA[i] = 0xa
-			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*TimeSeriesChunk)
+	if !ok {
+		that2, ok := that.(TimeSeriesChunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.LabelName) > 0 {
-		i -= len(m.LabelName)
-		copy(dAtA[i:], m.LabelName)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
-		i--
-		dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.FromIngesterId != that1.FromIngesterId {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ReadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.AcceptedResponseTypes) > 0 {
-		dAtA2 := make([]byte, len(m.AcceptedResponseTypes)*10)
-		var j1 int
-		for _, num := range m.AcceptedResponseTypes {
-			for num >= 1<<7 {
-				dAtA2[j1] = uint8(uint64(num)&0x7f | 0x80)
-				num >>= 7
-				j1++
-			}
-			dAtA2[j1] = uint8(num)
-			j1++
+	if this.UserId != that1.UserId {
+		return false
+	}
+	if len(this.Labels) != len(that1.Labels) {
+		return false
+	}
+	for i := range this.Labels {
+		if !this.Labels[i].Equal(that1.Labels[i]) {
+			return false
 		}
-		i -= j1
-		copy(dAtA[i:], dAtA2[:j1])
-		i = encodeVarintIngester(dAtA, i, uint64(j1))
-		i--
-		dAtA[i] = 0x12
 	}
-	if len(m.Queries) > 0 {
-		for iNdEx := len(m.Queries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Queries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	if len(this.Chunks) != len(that1.Chunks) {
+		return false
+	}
+	for i := range this.Chunks {
+		if !this.Chunks[i].Equal(&that1.Chunks[i]) {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *ReadResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *Chunk) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
-
-func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
 
-func (m *ReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Results) > 0 {
-		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	that1, ok := that.(*Chunk)
+	if !ok {
+		that2, ok := that.(Chunk)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *StreamReadResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return dAtA[:n], nil
+	if this.StartTimestampMs != that1.StartTimestampMs {
+		return false
+	}
+	if this.EndTimestampMs != that1.EndTimestampMs {
+		return false
+	}
+	if this.Encoding != that1.Encoding {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
 }
+func (this *LabelMatchers) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *StreamReadResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *StreamReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.QueryIndex != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.QueryIndex))
-		i--
-		dAtA[i] = 0x10
+	that1, ok := that.(*LabelMatchers)
+	if !ok {
+		that2, ok := that.(LabelMatchers)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if len(m.ChunkedSeries) > 0 {
-		for iNdEx := len(m.ChunkedSeries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.ChunkedSeries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
+	}
+	if len(this.Matchers) != len(that1.Matchers) {
+		return false
+	}
+	for i := range this.Matchers {
+		if !this.Matchers[i].Equal(that1.Matchers[i]) {
+			return false
 		}
 	}
-	return len(dAtA) - i, nil
+	return true
 }
-
-func (m *StreamChunkedSeries) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelMatcher) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
 	}
-	return dAtA[:n], nil
-}
 
-func (m *StreamChunkedSeries) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *StreamChunkedSeries) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Chunks) > 0 {
-		for iNdEx := len(m.Chunks) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Chunks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
+	that1, ok := that.(*LabelMatcher)
+	if !ok {
+		that2, ok := that.(LabelMatcher)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
 		}
 	}
-	if len(m.Labels) > 0 {
-		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size := m.Labels[iNdEx].Size()
-				i -= size
-				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
-					return 0, err
-				}
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *StreamChunk) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	if this.Type != that1.Type {
+		return false
 	}
-	return dAtA[:n], nil
-}
-
-func (m *StreamChunk) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	if this.Name != that1.Name {
+		return false
+	}
+	if this.Value != that1.Value {
+		return false
+	}
+	return true
 }
+func (this *TimeSeriesFile) Equal(that interface{}) bool {
+	if that == nil {
+		return this == nil
+	}
 
-func (m *StreamChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x22
+	that1, ok := that.(*TimeSeriesFile)
+	if !ok {
+		that2, ok := that.(TimeSeriesFile)
+		if ok {
+			that1 = &that2
+		} else {
+			return false
+		}
 	}
-	if m.Type != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x18
+	if that1 == nil {
+		return this == nil
+	} else if this == nil {
+		return false
 	}
-	if m.MaxTimeMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.MaxTimeMs))
-		i--
-		dAtA[i] = 0x10
+	if this.FromIngesterId != that1.FromIngesterId {
+		return false
 	}
-	if m.MinTimeMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.MinTimeMs))
-		i--
-		dAtA[i] = 0x8
+	if this.UserId != that1.UserId {
+		return false
 	}
-	return len(dAtA) - i, nil
+	if this.Filename != that1.Filename {
+		return false
+	}
+	if !bytes.Equal(this.Data, that1.Data) {
+		return false
+	}
+	return true
 }
-
-func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelNamesAndValuesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 25)
+	s = append(s, "&client.LabelNamesAndValuesRequest{")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "IncludeSeriesCount: "+fmt.Sprintf("%#v", this.IncludeSeriesCount)+",\n")
+	s = append(s, "Fields: "+fmt.Sprintf("%#v", this.Fields)+",\n")
+	s = append(s, "LongValueLengthThreshold: "+fmt.Sprintf("%#v", this.LongValueLengthThreshold)+",\n")
+	s = append(s, "PartitionByFirstCharacter: "+fmt.Sprintf("%#v", this.PartitionByFirstCharacter)+",\n")
+	s = append(s, "IncludePresence: "+fmt.Sprintf("%#v", this.IncludePresence)+",\n")
+	if this.ValuesBloomFilter != nil {
+		s = append(s, "ValuesBloomFilter: "+fmt.Sprintf("%#v", this.ValuesBloomFilter)+",\n")
+	}
+	s = append(s, "MaxDistinctValues: "+fmt.Sprintf("%#v", this.MaxDistinctValues)+",\n")
+	s = append(s, "UseValueIds: "+fmt.Sprintf("%#v", this.UseValueIds)+",\n")
+	s = append(s, "CheckpointToken: "+fmt.Sprintf("%#v", this.CheckpointToken)+",\n")
+	s = append(s, "ValuesPreviewSize: "+fmt.Sprintf("%#v", this.ValuesPreviewSize)+",\n")
+	s = append(s, "ValuesCompressionDictionary: "+fmt.Sprintf("%#v", this.ValuesCompressionDictionary)+",\n")
+	s = append(s, "IncludeValueCount: "+fmt.Sprintf("%#v", this.IncludeValueCount)+",\n")
+	s = append(s, "MaxValues: "+fmt.Sprintf("%#v", this.MaxValues)+",\n")
+	s = append(s, "IncludeBlocks: "+fmt.Sprintf("%#v", this.IncludeBlocks)+",\n")
+	s = append(s, "DedupValues: "+fmt.Sprintf("%#v", this.DedupValues)+",\n")
+	s = append(s, "IncludeRelabelOutcomes: "+fmt.Sprintf("%#v", this.IncludeRelabelOutcomes)+",\n")
+	s = append(s, "ShardIndex: "+fmt.Sprintf("%#v", this.ShardIndex)+",\n")
+	s = append(s, "ShardCount: "+fmt.Sprintf("%#v", this.ShardCount)+",\n")
+	s = append(s, "ValuesCollation: "+fmt.Sprintf("%#v", this.ValuesCollation)+",\n")
+	s = append(s, "Export: "+fmt.Sprintf("%#v", this.Export)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *LabelValuesBloomFilter) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValuesBloomFilter{")
+	s = append(s, "Bits: "+fmt.Sprintf("%#v", this.Bits)+",\n")
+	s = append(s, "HashCount: "+fmt.Sprintf("%#v", this.HashCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+func (this *LabelNamesAndValuesResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 13)
+	s = append(s, "&client.LabelNamesAndValuesResponse{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	if this.LongValues != nil {
+		s = append(s, "LongValues: "+fmt.Sprintf("%#v", this.LongValues)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "PartitionKey: "+fmt.Sprintf("%#v", this.PartitionKey)+",\n")
+	s = append(s, "Dictionary: "+fmt.Sprintf("%#v", this.Dictionary)+",\n")
+	s = append(s, "ValuesCompressionDictionaryId: "+fmt.Sprintf("%#v", this.ValuesCompressionDictionaryId)+",\n")
+	s = append(s, "Truncated: "+fmt.Sprintf("%#v", this.Truncated)+",\n")
+	s = append(s, "LabelNamesTruncated: "+fmt.Sprintf("%#v", this.LabelNamesTruncated)+",\n")
+	s = append(s, "ExportObjectName: "+fmt.Sprintf("%#v", this.ExportObjectName)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ExemplarQueryRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelValues) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 11)
+	s = append(s, "&client.LabelValues{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "Values: "+fmt.Sprintf("%#v", this.Values)+",\n")
+	s = append(s, "Presence: "+fmt.Sprintf("%#v", this.Presence)+",\n")
+	s = append(s, "ValueIds: "+fmt.Sprintf("%#v", this.ValueIds)+",\n")
+	s = append(s, "CompressedValues: "+fmt.Sprintf("%#v", this.CompressedValues)+",\n")
+	s = append(s, "ValueCount: "+fmt.Sprintf("%#v", this.ValueCount)+",\n")
+	s = append(s, "RelabelOutcomes: "+fmt.Sprintf("%#v", this.RelabelOutcomes)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ExemplarQueryRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
-}
-
-func (m *ExemplarQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
+func (this *LongLabelValues) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LongLabelValues{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "Count: "+fmt.Sprintf("%#v", this.Count)+",\n")
+	s = append(s, "Exemplars: "+fmt.Sprintf("%#v", this.Exemplars)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelValuesCardinalityRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	s := make([]string, 0, 39)
+	s = append(s, "&client.LabelValuesCardinalityRequest{")
+	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "GroupByMetricName: "+fmt.Sprintf("%#v", this.GroupByMetricName)+",\n")
+	s = append(s, "IncludeChecksums: "+fmt.Sprintf("%#v", this.IncludeChecksums)+",\n")
+	s = append(s, "ShardIndex: "+fmt.Sprintf("%#v", this.ShardIndex)+",\n")
+	s = append(s, "ShardCount: "+fmt.Sprintf("%#v", this.ShardCount)+",\n")
+	s = append(s, "EstimateLabelSeries: "+fmt.Sprintf("%#v", this.EstimateLabelSeries)+",\n")
+	s = append(s, "IncludeChunkCount: "+fmt.Sprintf("%#v", this.IncludeChunkCount)+",\n")
+	s = append(s, "Explain: "+fmt.Sprintf("%#v", this.Explain)+",\n")
+	s = append(s, "ProgressIntervalMs: "+fmt.Sprintf("%#v", this.ProgressIntervalMs)+",\n")
+	s = append(s, "MinSeriesCount: "+fmt.Sprintf("%#v", this.MinSeriesCount)+",\n")
+	s = append(s, "AllLabels: "+fmt.Sprintf("%#v", this.AllLabels)+",\n")
+	s = append(s, "ValueHashSalt: "+fmt.Sprintf("%#v", this.ValueHashSalt)+",\n")
+	s = append(s, "IncludeRatios: "+fmt.Sprintf("%#v", this.IncludeRatios)+",\n")
+	s = append(s, "SampleValues: "+fmt.Sprintf("%#v", this.SampleValues)+",\n")
+	s = append(s, "SampleSeed: "+fmt.Sprintf("%#v", this.SampleSeed)+",\n")
+	s = append(s, "ValueGroupRegex: "+fmt.Sprintf("%#v", this.ValueGroupRegex)+",\n")
+	s = append(s, "CoOccurrenceTopK: "+fmt.Sprintf("%#v", this.CoOccurrenceTopK)+",\n")
+	s = append(s, "MetricNamesTopK: "+fmt.Sprintf("%#v", this.MetricNamesTopK)+",\n")
+	s = append(s, "OrderByLabelSeries: "+fmt.Sprintf("%#v", this.OrderByLabelSeries)+",\n")
+	s = append(s, "SeriesCountPercentiles: "+fmt.Sprintf("%#v", this.SeriesCountPercentiles)+",\n")
+	s = append(s, "ContextCheckIntervalSeries: "+fmt.Sprintf("%#v", this.ContextCheckIntervalSeries)+",\n")
+	s = append(s, "SortLabelValues: "+fmt.Sprintf("%#v", this.SortLabelValues)+",\n")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	s = append(s, "BestEffort: "+fmt.Sprintf("%#v", this.BestEffort)+",\n")
+	s = append(s, "GroupByMagnitude: "+fmt.Sprintf("%#v", this.GroupByMagnitude)+",\n")
+	s = append(s, "IncludeSummary: "+fmt.Sprintf("%#v", this.IncludeSummary)+",\n")
+	s = append(s, "ResumeOffset: "+fmt.Sprintf("%#v", this.ResumeOffset)+",\n")
+	s = append(s, "ResumeChecksum: "+fmt.Sprintf("%#v", this.ResumeChecksum)+",\n")
+	s = append(s, "CompareStartTimestampMs: "+fmt.Sprintf("%#v", this.CompareStartTimestampMs)+",\n")
+	s = append(s, "CompareEndTimestampMs: "+fmt.Sprintf("%#v", this.CompareEndTimestampMs)+",\n")
+	s = append(s, "CompareGrowthRate: "+fmt.Sprintf("%#v", this.CompareGrowthRate)+",\n")
+	s = append(s, "ChangedLabelsOnly: "+fmt.Sprintf("%#v", this.ChangedLabelsOnly)+",\n")
+	s = append(s, "ChangedLabelsState: "+fmt.Sprintf("%#v", this.ChangedLabelsState)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelValuesCardinalityStreamRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 9)
+	s = append(s, "&client.LabelValuesCardinalityStreamRequest{")
+	if this.Request != nil {
+		s = append(s, "Request: "+fmt.Sprintf("%#v", this.Request)+",\n")
+	}
+	s = append(s, "Stop: "+fmt.Sprintf("%#v", this.Stop)+",\n")
+	s = append(s, "WatchIntervalMs: "+fmt.Sprintf("%#v", this.WatchIntervalMs)+",\n")
+	s = append(s, "Pause: "+fmt.Sprintf("%#v", this.Pause)+",\n")
+	s = append(s, "Resume: "+fmt.Sprintf("%#v", this.Resume)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *LabelValuesCardinalityResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 19)
+	s = append(s, "&client.LabelValuesCardinalityResponse{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	}
+	s = append(s, "BudgetWarning: "+fmt.Sprintf("%#v", this.BudgetWarning)+",\n")
+	s = append(s, "SequenceNumber: "+fmt.Sprintf("%#v", this.SequenceNumber)+",\n")
+	s = append(s, "ItemsChecksum: "+fmt.Sprintf("%#v", this.ItemsChecksum)+",\n")
+	s = append(s, "Stopped: "+fmt.Sprintf("%#v", this.Stopped)+",\n")
+	if this.Explain != nil {
+		s = append(s, "Explain: "+fmt.Sprintf("%#v", this.Explain)+",\n")
+	}
+	if this.Progress != nil {
+		s = append(s, "Progress: "+fmt.Sprintf("%#v", this.Progress)+",\n")
+	}
+	s = append(s, "SampleSeed: "+fmt.Sprintf("%#v", this.SampleSeed)+",\n")
+	s = append(s, "FailedLabelValues: "+fmt.Sprintf("%#v", this.FailedLabelValues)+",\n")
+	s = append(s, "Errors: "+fmt.Sprintf("%#v", this.Errors)+",\n")
+	if this.Summaries != nil {
+		s = append(s, "Summaries: "+fmt.Sprintf("%#v", this.Summaries)+",\n")
+	}
+	s = append(s, "ByteOffset: "+fmt.Sprintf("%#v", this.ByteOffset)+",\n")
+	s = append(s, "RunningChecksum: "+fmt.Sprintf("%#v", this.RunningChecksum)+",\n")
+	s = append(s, "InstanceId: "+fmt.Sprintf("%#v", this.InstanceId)+",\n")
+	s = append(s, "ChangedLabelsState: "+fmt.Sprintf("%#v", this.ChangedLabelsState)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *LabelValuesCardinalitySummary) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelValuesCardinalitySummary{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "LabelValuesCount: "+fmt.Sprintf("%#v", this.LabelValuesCount)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryStreamResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelValuesCardinalityProgress) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 6)
+	s = append(s, "&client.LabelValuesCardinalityProgress{")
+	s = append(s, "LabelValues: "+fmt.Sprintf("%#v", this.LabelValues)+",\n")
+	s = append(s, "Series: "+fmt.Sprintf("%#v", this.Series)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryStreamResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *LabelValuesCardinalityExplain) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 9)
+	s = append(s, "&client.LabelValuesCardinalityExplain{")
+	s = append(s, "LabelValuesDurationNs: "+fmt.Sprintf("%#v", this.LabelValuesDurationNs)+",\n")
+	s = append(s, "CountingDurationNs: "+fmt.Sprintf("%#v", this.CountingDurationNs)+",\n")
+	s = append(s, "SendingDurationNs: "+fmt.Sprintf("%#v", this.SendingDurationNs)+",\n")
+	s = append(s, "CountingGoroutines: "+fmt.Sprintf("%#v", this.CountingGoroutines)+",\n")
+	s = append(s, "Goroutines: "+fmt.Sprintf("%#v", this.Goroutines)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x12
-		}
+func (this *LabelValueSeriesCount) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.Chunkseries) > 0 {
-		for iNdEx := len(m.Chunkseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Chunkseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+	s := make([]string, 0, 16)
+	s = append(s, "&client.LabelValueSeriesCount{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
+	for k, _ := range this.LabelValueSeries {
+		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
 	}
-	return len(dAtA) - i, nil
-}
-
-func (m *ExemplarQueryResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
+	mapStringForLabelValueSeries := "map[string]uint64{"
+	for _, k := range keysForLabelValueSeries {
+		mapStringForLabelValueSeries += fmt.Sprintf("%#v: %#v,", k, this.LabelValueSeries[k])
 	}
-	return dAtA[:n], nil
+	mapStringForLabelValueSeries += "}"
+	if this.LabelValueSeries != nil {
+		s = append(s, "LabelValueSeries: "+mapStringForLabelValueSeries+",\n")
+	}
+	keysForLabelValueMetricNamesSeries := make([]string, 0, len(this.LabelValueMetricNamesSeries))
+	for k, _ := range this.LabelValueMetricNamesSeries {
+		keysForLabelValueMetricNamesSeries = append(keysForLabelValueMetricNamesSeries, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueMetricNamesSeries)
+	mapStringForLabelValueMetricNamesSeries := "map[string]*MetricNamesSeriesCount{"
+	for _, k := range keysForLabelValueMetricNamesSeries {
+		mapStringForLabelValueMetricNamesSeries += fmt.Sprintf("%#v: %#v,", k, this.LabelValueMetricNamesSeries[k])
+	}
+	mapStringForLabelValueMetricNamesSeries += "}"
+	if this.LabelValueMetricNamesSeries != nil {
+		s = append(s, "LabelValueMetricNamesSeries: "+mapStringForLabelValueMetricNamesSeries+",\n")
+	}
+	keysForLabelValueSeriesDelta := make([]string, 0, len(this.LabelValueSeriesDelta))
+	for k, _ := range this.LabelValueSeriesDelta {
+		keysForLabelValueSeriesDelta = append(keysForLabelValueSeriesDelta, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeriesDelta)
+	mapStringForLabelValueSeriesDelta := "map[string]int64{"
+	for _, k := range keysForLabelValueSeriesDelta {
+		mapStringForLabelValueSeriesDelta += fmt.Sprintf("%#v: %#v,", k, this.LabelValueSeriesDelta[k])
+	}
+	mapStringForLabelValueSeriesDelta += "}"
+	if this.LabelValueSeriesDelta != nil {
+		s = append(s, "LabelValueSeriesDelta: "+mapStringForLabelValueSeriesDelta+",\n")
+	}
+	s = append(s, "LabelSeriesEstimate: "+fmt.Sprintf("%#v", this.LabelSeriesEstimate)+",\n")
+	s = append(s, "LabelSeriesEstimateRelativeError: "+fmt.Sprintf("%#v", this.LabelSeriesEstimateRelativeError)+",\n")
+	keysForLabelValueChunks := make([]string, 0, len(this.LabelValueChunks))
+	for k, _ := range this.LabelValueChunks {
+		keysForLabelValueChunks = append(keysForLabelValueChunks, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueChunks)
+	mapStringForLabelValueChunks := "map[string]uint64{"
+	for _, k := range keysForLabelValueChunks {
+		mapStringForLabelValueChunks += fmt.Sprintf("%#v: %#v,", k, this.LabelValueChunks[k])
+	}
+	mapStringForLabelValueChunks += "}"
+	if this.LabelValueChunks != nil {
+		s = append(s, "LabelValueChunks: "+mapStringForLabelValueChunks+",\n")
+	}
+	keysForLabelValueRatios := make([]string, 0, len(this.LabelValueRatios))
+	for k, _ := range this.LabelValueRatios {
+		keysForLabelValueRatios = append(keysForLabelValueRatios, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueRatios)
+	mapStringForLabelValueRatios := "map[string]float64{"
+	for _, k := range keysForLabelValueRatios {
+		mapStringForLabelValueRatios += fmt.Sprintf("%#v: %#v,", k, this.LabelValueRatios[k])
+	}
+	mapStringForLabelValueRatios += "}"
+	if this.LabelValueRatios != nil {
+		s = append(s, "LabelValueRatios: "+mapStringForLabelValueRatios+",\n")
+	}
+	keysForLabelValueCoOccurrences := make([]string, 0, len(this.LabelValueCoOccurrences))
+	for k, _ := range this.LabelValueCoOccurrences {
+		keysForLabelValueCoOccurrences = append(keysForLabelValueCoOccurrences, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueCoOccurrences)
+	mapStringForLabelValueCoOccurrences := "map[string]*LabelValueCoOccurrences{"
+	for _, k := range keysForLabelValueCoOccurrences {
+		mapStringForLabelValueCoOccurrences += fmt.Sprintf("%#v: %#v,", k, this.LabelValueCoOccurrences[k])
+	}
+	mapStringForLabelValueCoOccurrences += "}"
+	if this.LabelValueCoOccurrences != nil {
+		s = append(s, "LabelValueCoOccurrences: "+mapStringForLabelValueCoOccurrences+",\n")
+	}
+	keysForLabelValueSeriesGrowthRate := make([]string, 0, len(this.LabelValueSeriesGrowthRate))
+	for k, _ := range this.LabelValueSeriesGrowthRate {
+		keysForLabelValueSeriesGrowthRate = append(keysForLabelValueSeriesGrowthRate, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeriesGrowthRate)
+	mapStringForLabelValueSeriesGrowthRate := "map[string]float64{"
+	for _, k := range keysForLabelValueSeriesGrowthRate {
+		mapStringForLabelValueSeriesGrowthRate += fmt.Sprintf("%#v: %#v,", k, this.LabelValueSeriesGrowthRate[k])
+	}
+	mapStringForLabelValueSeriesGrowthRate += "}"
+	if this.LabelValueSeriesGrowthRate != nil {
+		s = append(s, "LabelValueSeriesGrowthRate: "+mapStringForLabelValueSeriesGrowthRate+",\n")
+	}
+	if this.SeriesCountPercentiles != nil {
+		s = append(s, "SeriesCountPercentiles: "+fmt.Sprintf("%#v", this.SeriesCountPercentiles)+",\n")
+	}
+	s = append(s, "SeriesCountMagnitude: "+fmt.Sprintf("%#v", this.SeriesCountMagnitude)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ExemplarQueryResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *SeriesCountPercentile) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&client.SeriesCountPercentile{")
+	s = append(s, "Percentile: "+fmt.Sprintf("%#v", this.Percentile)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *ExemplarQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *LabelValueCoOccurrences) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelValueCoOccurrences{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelValueCoOccurrence) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelValueCoOccurrence{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "LabelValue: "+fmt.Sprintf("%#v", this.LabelValue)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *MetricNamesSeriesCount) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.MetricNamesSeriesCount{")
+	if this.Items != nil {
+		s = append(s, "Items: "+fmt.Sprintf("%#v", this.Items)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Matchers != nil {
-		{
-			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIngester(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x22
-	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x18
+func (this *MetricNameSeriesCount) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 6)
+	s = append(s, "&client.MetricNameSeriesCount{")
+	s = append(s, "MetricName: "+fmt.Sprintf("%#v", this.MetricName)+",\n")
+	s = append(s, "SeriesCount: "+fmt.Sprintf("%#v", this.SeriesCount)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *ReadRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.LabelName) > 0 {
-		i -= len(m.LabelName)
-		copy(dAtA[i:], m.LabelName)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 6)
+	s = append(s, "&client.ReadRequest{")
+	if this.Queries != nil {
+		s = append(s, "Queries: "+fmt.Sprintf("%#v", this.Queries)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "AcceptedResponseTypes: "+fmt.Sprintf("%#v", this.AcceptedResponseTypes)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ReadResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ReadResponse{")
+	if this.Results != nil {
+		s = append(s, "Results: "+fmt.Sprintf("%#v", this.Results)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *StreamReadResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&client.StreamReadResponse{")
+	if this.ChunkedSeries != nil {
+		s = append(s, "ChunkedSeries: "+fmt.Sprintf("%#v", this.ChunkedSeries)+",\n")
+	}
+	s = append(s, "QueryIndex: "+fmt.Sprintf("%#v", this.QueryIndex)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelValues) > 0 {
-		for iNdEx := len(m.LabelValues) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LabelValues[iNdEx])
-			copy(dAtA[i:], m.LabelValues[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelValues[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (this *StreamChunkedSeries) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 6)
+	s = append(s, "&client.StreamChunkedSeries{")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if this.Chunks != nil {
+		vs := make([]*StreamChunk, len(this.Chunks))
+		for i := range vs {
+			vs[i] = &this.Chunks[i]
 		}
+		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelNamesRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *StreamChunk) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelNamesRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 8)
+	s = append(s, "&client.StreamChunk{")
+	s = append(s, "MinTimeMs: "+fmt.Sprintf("%#v", this.MinTimeMs)+",\n")
+	s = append(s, "MaxTimeMs: "+fmt.Sprintf("%#v", this.MaxTimeMs)+",\n")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelNamesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Matchers != nil {
-		{
-			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIngester(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x1a
-	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+func (this *QueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	s := make([]string, 0, 7)
+	s = append(s, "&client.QueryRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelNamesResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ExemplarQueryRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *LabelNamesResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 7)
+	s = append(s, "&client.ExemplarQueryRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *LabelNamesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.LabelNames) > 0 {
-		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
-			i -= len(m.LabelNames[iNdEx])
-			copy(dAtA[i:], m.LabelNames[iNdEx])
-			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
-			i--
-			dAtA[i] = 0xa
+func (this *QueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.QueryResponse{")
+	if this.Timeseries != nil {
+		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
 		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UserStatsRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *QueryStreamResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UserStatsRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 6)
+	s = append(s, "&client.QueryStreamResponse{")
+	if this.Chunkseries != nil {
+		vs := make([]*TimeSeriesChunk, len(this.Chunkseries))
+		for i := range vs {
+			vs[i] = &this.Chunkseries[i]
+		}
+		s = append(s, "Chunkseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	if this.Timeseries != nil {
+		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
+		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UserStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
-}
-
-func (m *UserStatsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *ExemplarQueryResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.ExemplarQueryResponse{")
+	if this.Timeseries != nil {
+		vs := make([]*mimirpb.TimeSeries, len(this.Timeseries))
+		for i := range vs {
+			vs[i] = &this.Timeseries[i]
+		}
+		s = append(s, "Timeseries: "+fmt.Sprintf("%#v", vs)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UserStatsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *LabelValuesRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&client.LabelValuesRequest{")
+	s = append(s, "LabelName: "+fmt.Sprintf("%#v", this.LabelName)+",\n")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UserStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.RuleIngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RuleIngestionRate))))
-		i--
-		dAtA[i] = 0x21
+func (this *LabelValuesResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.ApiIngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ApiIngestionRate))))
-		i--
-		dAtA[i] = 0x19
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelValuesResponse{")
+	s = append(s, "LabelValues: "+fmt.Sprintf("%#v", this.LabelValues)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelNamesRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.NumSeries != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.NumSeries))
-		i--
-		dAtA[i] = 0x10
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelNamesRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
 	}
-	if m.IngestionRate != 0 {
-		i -= 8
-		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.IngestionRate))))
-		i--
-		dAtA[i] = 0x9
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelNamesResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelNamesResponse{")
+	s = append(s, "LabelNames: "+fmt.Sprintf("%#v", this.LabelNames)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UserIDStatsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *UserStatsRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 4)
+	s = append(s, "&client.UserStatsRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UserIDStatsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *UserStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&client.UserStatsResponse{")
+	s = append(s, "IngestionRate: "+fmt.Sprintf("%#v", this.IngestionRate)+",\n")
+	s = append(s, "NumSeries: "+fmt.Sprintf("%#v", this.NumSeries)+",\n")
+	s = append(s, "ApiIngestionRate: "+fmt.Sprintf("%#v", this.ApiIngestionRate)+",\n")
+	s = append(s, "RuleIngestionRate: "+fmt.Sprintf("%#v", this.RuleIngestionRate)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UserIDStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if m.Data != nil {
-		{
-			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
-			if err != nil {
-				return 0, err
-			}
-			i -= size
-			i = encodeVarintIngester(dAtA, i, uint64(size))
-		}
-		i--
-		dAtA[i] = 0x12
+func (this *UserIDStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0xa
+	s := make([]string, 0, 6)
+	s = append(s, "&client.UserIDStatsResponse{")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	if this.Data != nil {
+		s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
 	}
-	return len(dAtA) - i, nil
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UsersStatsResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *UsersStatsResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
-}
-
-func (m *UsersStatsResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	s := make([]string, 0, 5)
+	s = append(s, "&client.UsersStatsResponse{")
+	if this.Stats != nil {
+		s = append(s, "Stats: "+fmt.Sprintf("%#v", this.Stats)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Stats) > 0 {
-		for iNdEx := len(m.Stats) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Stats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (this *MetricsForLabelMatchersRequest) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.MetricsForLabelMatchersRequest{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	if this.MatchersSet != nil {
+		s = append(s, "MatchersSet: "+fmt.Sprintf("%#v", this.MatchersSet)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *MetricsForLabelMatchersResponse) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.MetricsForLabelMatchersResponse{")
+	if this.Metric != nil {
+		s = append(s, "Metric: "+fmt.Sprintf("%#v", this.Metric)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (this *MetricsMetadataRequest) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 4)
+	s = append(s, "&client.MetricsMetadataRequest{")
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.MatchersSet) > 0 {
-		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.MatchersSet[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
+func (this *MetricsMetadataResponse) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 5)
+	s = append(s, "&client.MetricsMetadataResponse{")
+	if this.Metadata != nil {
+		s = append(s, "Metadata: "+fmt.Sprintf("%#v", this.Metadata)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TimeSeriesChunk) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&client.TimeSeriesChunk{")
+	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	s = append(s, "Labels: "+fmt.Sprintf("%#v", this.Labels)+",\n")
+	if this.Chunks != nil {
+		vs := make([]*Chunk, len(this.Chunks))
+		for i := range vs {
+			vs[i] = &this.Chunks[i]
 		}
+		s = append(s, "Chunks: "+fmt.Sprintf("%#v", vs)+",\n")
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *Chunk) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	s := make([]string, 0, 8)
+	s = append(s, "&client.Chunk{")
+	s = append(s, "StartTimestampMs: "+fmt.Sprintf("%#v", this.StartTimestampMs)+",\n")
+	s = append(s, "EndTimestampMs: "+fmt.Sprintf("%#v", this.EndTimestampMs)+",\n")
+	s = append(s, "Encoding: "+fmt.Sprintf("%#v", this.Encoding)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *LabelMatchers) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return len(dAtA) - i, nil
+	s := make([]string, 0, 5)
+	s = append(s, "&client.LabelMatchers{")
+	if this.Matchers != nil {
+		s = append(s, "Matchers: "+fmt.Sprintf("%#v", this.Matchers)+",\n")
+	}
+	s = append(s, "}")
+	return strings.Join(s, "")
 }
-
-func (m *MetricsForLabelMatchersResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
-		return nil, err
+func (this *LabelMatcher) GoString() string {
+	if this == nil {
+		return "nil"
 	}
-	return dAtA[:n], nil
+	s := make([]string, 0, 7)
+	s = append(s, "&client.LabelMatcher{")
+	s = append(s, "Type: "+fmt.Sprintf("%#v", this.Type)+",\n")
+	s = append(s, "Name: "+fmt.Sprintf("%#v", this.Name)+",\n")
+	s = append(s, "Value: "+fmt.Sprintf("%#v", this.Value)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func (this *TimeSeriesFile) GoString() string {
+	if this == nil {
+		return "nil"
+	}
+	s := make([]string, 0, 8)
+	s = append(s, "&client.TimeSeriesFile{")
+	s = append(s, "FromIngesterId: "+fmt.Sprintf("%#v", this.FromIngesterId)+",\n")
+	s = append(s, "UserId: "+fmt.Sprintf("%#v", this.UserId)+",\n")
+	s = append(s, "Filename: "+fmt.Sprintf("%#v", this.Filename)+",\n")
+	s = append(s, "Data: "+fmt.Sprintf("%#v", this.Data)+",\n")
+	s = append(s, "}")
+	return strings.Join(s, "")
+}
+func valueToGoStringIngester(v interface{}, typ string) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("func(v %v) *%v { return &v } ( %#v )", typ, typ, pv)
 }
 
-func (m *MetricsForLabelMatchersResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// IngesterClient is the client API for Ingester service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IngesterClient interface {
+	Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error)
+	QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error)
+	QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error)
+	LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error)
+	LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error)
+	UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error)
+	AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error)
+	MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error)
+	MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error)
+	// LabelNamesAndValues provides all values for each label that matches the matchers.
+	// The order of the labels and values is not guaranteed.
+	LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error)
+	// LabelValuesCardinality returns all values and series total count for label_names labels
+	// that match the matchers.
+	// The listing order of the labels is not guaranteed.
+	LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error)
+	// LabelValuesCardinalityStream works like LabelValuesCardinality, but it allows the client to stop
+	// the request by sending a stop message. The server then sends the pending items and ends the stream.
+	// The client can also pause and resume the request, for example to throttle the messages it receives.
+	LabelValuesCardinalityStream(ctx context.Context, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityStreamClient, error)
 }
 
-func (m *MetricsForLabelMatchersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metric) > 0 {
-		for iNdEx := len(m.Metric) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metric[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+type ingesterClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewIngesterClient(cc *grpc.ClientConn) IngesterClient {
+	return &ingesterClient{cc}
+}
+
+func (c *ingesterClient) Push(ctx context.Context, in *mimirpb.WriteRequest, opts ...grpc.CallOption) (*mimirpb.WriteResponse, error) {
+	out := new(mimirpb.WriteResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/Push", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *MetricsMetadataRequest) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) QueryStream(ctx context.Context, in *QueryRequest, opts ...grpc.CallOption) (Ingester_QueryStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[0], "/cortex.Ingester/QueryStream", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &ingesterQueryStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *MetricsMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Ingester_QueryStreamClient interface {
+	Recv() (*QueryStreamResponse, error)
+	grpc.ClientStream
 }
 
-func (m *MetricsMetadataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	return len(dAtA) - i, nil
+type ingesterQueryStreamClient struct {
+	grpc.ClientStream
 }
 
-func (m *MetricsMetadataResponse) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *ingesterQueryStreamClient) Recv() (*QueryStreamResponse, error) {
+	m := new(QueryStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
-}
-
-func (m *MetricsMetadataResponse) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+	return m, nil
 }
 
-func (m *MetricsMetadataResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Metadata) > 0 {
-		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+func (c *ingesterClient) QueryExemplars(ctx context.Context, in *ExemplarQueryRequest, opts ...grpc.CallOption) (*ExemplarQueryResponse, error) {
+	out := new(ExemplarQueryResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/QueryExemplars", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *TimeSeriesChunk) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) LabelValues(ctx context.Context, in *LabelValuesRequest, opts ...grpc.CallOption) (*LabelValuesResponse, error) {
+	out := new(LabelValuesResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelValues", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *TimeSeriesChunk) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) LabelNames(ctx context.Context, in *LabelNamesRequest, opts ...grpc.CallOption) (*LabelNamesResponse, error) {
+	out := new(LabelNamesResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/LabelNames", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *TimeSeriesChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Chunks) > 0 {
-		for iNdEx := len(m.Chunks) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Chunks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x22
-		}
-	}
-	if len(m.Labels) > 0 {
-		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size := m.Labels[iNdEx].Size()
-				i -= size
-				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
-					return 0, err
-				}
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0x1a
-		}
-	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0x12
+func (c *ingesterClient) UserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UserStatsResponse, error) {
+	out := new(UserStatsResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/UserStats", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	if len(m.FromIngesterId) > 0 {
-		i -= len(m.FromIngesterId)
-		copy(dAtA[i:], m.FromIngesterId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
-		i--
-		dAtA[i] = 0xa
+	return out, nil
+}
+
+func (c *ingesterClient) AllUserStats(ctx context.Context, in *UserStatsRequest, opts ...grpc.CallOption) (*UsersStatsResponse, error) {
+	out := new(UsersStatsResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/AllUserStats", in, out, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return out, nil
 }
 
-func (m *Chunk) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) MetricsForLabelMatchers(ctx context.Context, in *MetricsForLabelMatchersRequest, opts ...grpc.CallOption) (*MetricsForLabelMatchersResponse, error) {
+	out := new(MetricsForLabelMatchersResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsForLabelMatchers", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return out, nil
 }
 
-func (m *Chunk) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) MetricsMetadata(ctx context.Context, in *MetricsMetadataRequest, opts ...grpc.CallOption) (*MetricsMetadataResponse, error) {
+	out := new(MetricsMetadataResponse)
+	err := c.cc.Invoke(ctx, "/cortex.Ingester/MetricsMetadata", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (m *Chunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x22
+func (c *ingesterClient) LabelNamesAndValues(ctx context.Context, in *LabelNamesAndValuesRequest, opts ...grpc.CallOption) (Ingester_LabelNamesAndValuesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[1], "/cortex.Ingester/LabelNamesAndValues", opts...)
+	if err != nil {
+		return nil, err
 	}
-	if m.Encoding != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.Encoding))
-		i--
-		dAtA[i] = 0x18
+	x := &ingesterLabelNamesAndValuesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
 	}
-	if m.EndTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
-		i--
-		dAtA[i] = 0x10
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
 	}
-	if m.StartTimestampMs != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
-		i--
-		dAtA[i] = 0x8
+	return x, nil
+}
+
+type Ingester_LabelNamesAndValuesClient interface {
+	Recv() (*LabelNamesAndValuesResponse, error)
+	grpc.ClientStream
+}
+
+type ingesterLabelNamesAndValuesClient struct {
+	grpc.ClientStream
+}
+
+func (x *ingesterLabelNamesAndValuesClient) Recv() (*LabelNamesAndValuesResponse, error) {
+	m := new(LabelNamesAndValuesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *LabelMatchers) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) LabelValuesCardinality(ctx context.Context, in *LabelValuesCardinalityRequest, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[2], "/cortex.Ingester/LabelValuesCardinality", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &ingesterLabelValuesCardinalityClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
 }
 
-func (m *LabelMatchers) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Ingester_LabelValuesCardinalityClient interface {
+	Recv() (*LabelValuesCardinalityResponse, error)
+	grpc.ClientStream
 }
 
-func (m *LabelMatchers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintIngester(dAtA, i, uint64(size))
-			}
-			i--
-			dAtA[i] = 0xa
-		}
+type ingesterLabelValuesCardinalityClient struct {
+	grpc.ClientStream
+}
+
+func (x *ingesterLabelValuesCardinalityClient) Recv() (*LabelValuesCardinalityResponse, error) {
+	m := new(LabelValuesCardinalityResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return len(dAtA) - i, nil
+	return m, nil
 }
 
-func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+func (c *ingesterClient) LabelValuesCardinalityStream(ctx context.Context, opts ...grpc.CallOption) (Ingester_LabelValuesCardinalityStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Ingester_serviceDesc.Streams[3], "/cortex.Ingester/LabelValuesCardinalityStream", opts...)
 	if err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	x := &ingesterLabelValuesCardinalityStreamClient{stream}
+	return x, nil
 }
 
-func (m *LabelMatcher) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+type Ingester_LabelValuesCardinalityStreamClient interface {
+	Send(*LabelValuesCardinalityStreamRequest) error
+	Recv() (*LabelValuesCardinalityResponse, error)
+	grpc.ClientStream
 }
 
-func (m *LabelMatcher) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Value) > 0 {
-		i -= len(m.Value)
-		copy(dAtA[i:], m.Value)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Value)))
-		i--
-		dAtA[i] = 0x1a
-	}
-	if len(m.Name) > 0 {
-		i -= len(m.Name)
-		copy(dAtA[i:], m.Name)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Name)))
-		i--
-		dAtA[i] = 0x12
-	}
-	if m.Type != 0 {
-		i = encodeVarintIngester(dAtA, i, uint64(m.Type))
-		i--
-		dAtA[i] = 0x8
-	}
-	return len(dAtA) - i, nil
+type ingesterLabelValuesCardinalityStreamClient struct {
+	grpc.ClientStream
 }
 
-func (m *TimeSeriesFile) Marshal() (dAtA []byte, err error) {
-	size := m.Size()
-	dAtA = make([]byte, size)
-	n, err := m.MarshalToSizedBuffer(dAtA[:size])
-	if err != nil {
+func (x *ingesterLabelValuesCardinalityStreamClient) Send(m *LabelValuesCardinalityStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *ingesterLabelValuesCardinalityStreamClient) Recv() (*LabelValuesCardinalityResponse, error) {
+	m := new(LabelValuesCardinalityResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
 		return nil, err
 	}
-	return dAtA[:n], nil
+	return m, nil
 }
 
-func (m *TimeSeriesFile) MarshalTo(dAtA []byte) (int, error) {
-	size := m.Size()
-	return m.MarshalToSizedBuffer(dAtA[:size])
+// IngesterServer is the server API for Ingester service.
+type IngesterServer interface {
+	Push(context.Context, *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error)
+	QueryStream(*QueryRequest, Ingester_QueryStreamServer) error
+	QueryExemplars(context.Context, *ExemplarQueryRequest) (*ExemplarQueryResponse, error)
+	LabelValues(context.Context, *LabelValuesRequest) (*LabelValuesResponse, error)
+	LabelNames(context.Context, *LabelNamesRequest) (*LabelNamesResponse, error)
+	UserStats(context.Context, *UserStatsRequest) (*UserStatsResponse, error)
+	AllUserStats(context.Context, *UserStatsRequest) (*UsersStatsResponse, error)
+	MetricsForLabelMatchers(context.Context, *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error)
+	MetricsMetadata(context.Context, *MetricsMetadataRequest) (*MetricsMetadataResponse, error)
+	// LabelNamesAndValues provides all values for each label that matches the matchers.
+	// The order of the labels and values is not guaranteed.
+	LabelNamesAndValues(*LabelNamesAndValuesRequest, Ingester_LabelNamesAndValuesServer) error
+	// LabelValuesCardinality returns all values and series total count for label_names labels
+	// that match the matchers.
+	// The listing order of the labels is not guaranteed.
+	LabelValuesCardinality(*LabelValuesCardinalityRequest, Ingester_LabelValuesCardinalityServer) error
+	// LabelValuesCardinalityStream works like LabelValuesCardinality, but it allows the client to stop
+	// the request by sending a stop message. The server then sends the pending items and ends the stream.
+	// The client can also pause and resume the request, for example to throttle the messages it receives.
+	LabelValuesCardinalityStream(Ingester_LabelValuesCardinalityStreamServer) error
 }
 
-func (m *TimeSeriesFile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
-	i := len(dAtA)
-	_ = i
-	var l int
-	_ = l
-	if len(m.Data) > 0 {
-		i -= len(m.Data)
-		copy(dAtA[i:], m.Data)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
-		i--
-		dAtA[i] = 0x22
+// UnimplementedIngesterServer can be embedded to have forward compatible implementations.
+type UnimplementedIngesterServer struct {
+}
+
+func (*UnimplementedIngesterServer) Push(ctx context.Context, req *mimirpb.WriteRequest) (*mimirpb.WriteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Push not implemented")
+}
+func (*UnimplementedIngesterServer) QueryStream(req *QueryRequest, srv Ingester_QueryStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method QueryStream not implemented")
+}
+func (*UnimplementedIngesterServer) QueryExemplars(ctx context.Context, req *ExemplarQueryRequest) (*ExemplarQueryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryExemplars not implemented")
+}
+func (*UnimplementedIngesterServer) LabelValues(ctx context.Context, req *LabelValuesRequest) (*LabelValuesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelValues not implemented")
+}
+func (*UnimplementedIngesterServer) LabelNames(ctx context.Context, req *LabelNamesRequest) (*LabelNamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LabelNames not implemented")
+}
+func (*UnimplementedIngesterServer) UserStats(ctx context.Context, req *UserStatsRequest) (*UserStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UserStats not implemented")
+}
+func (*UnimplementedIngesterServer) AllUserStats(ctx context.Context, req *UserStatsRequest) (*UsersStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AllUserStats not implemented")
+}
+func (*UnimplementedIngesterServer) MetricsForLabelMatchers(ctx context.Context, req *MetricsForLabelMatchersRequest) (*MetricsForLabelMatchersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetricsForLabelMatchers not implemented")
+}
+func (*UnimplementedIngesterServer) MetricsMetadata(ctx context.Context, req *MetricsMetadataRequest) (*MetricsMetadataResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MetricsMetadata not implemented")
+}
+func (*UnimplementedIngesterServer) LabelNamesAndValues(req *LabelNamesAndValuesRequest, srv Ingester_LabelNamesAndValuesServer) error {
+	return status.Errorf(codes.Unimplemented, "method LabelNamesAndValues not implemented")
+}
+func (*UnimplementedIngesterServer) LabelValuesCardinality(req *LabelValuesCardinalityRequest, srv Ingester_LabelValuesCardinalityServer) error {
+	return status.Errorf(codes.Unimplemented, "method LabelValuesCardinality not implemented")
+}
+func (*UnimplementedIngesterServer) LabelValuesCardinalityStream(srv Ingester_LabelValuesCardinalityStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method LabelValuesCardinalityStream not implemented")
+}
+
+func RegisterIngesterServer(s *grpc.Server, srv IngesterServer) {
+	s.RegisterService(&_Ingester_serviceDesc, srv)
+}
+
+func _Ingester_Push_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(mimirpb.WriteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if len(m.Filename) > 0 {
-		i -= len(m.Filename)
-		copy(dAtA[i:], m.Filename)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.Filename)))
-		i--
-		dAtA[i] = 0x1a
+	if interceptor == nil {
+		return srv.(IngesterServer).Push(ctx, in)
 	}
-	if len(m.UserId) > 0 {
-		i -= len(m.UserId)
-		copy(dAtA[i:], m.UserId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
-		i--
-		dAtA[i] = 0x12
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/Push",
 	}
-	if len(m.FromIngesterId) > 0 {
-		i -= len(m.FromIngesterId)
-		copy(dAtA[i:], m.FromIngesterId)
-		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
-		i--
-		dAtA[i] = 0xa
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).Push(ctx, req.(*mimirpb.WriteRequest))
 	}
-	return len(dAtA) - i, nil
+	return interceptor(ctx, in, info, handler)
 }
 
-func encodeVarintIngester(dAtA []byte, offset int, v uint64) int {
-	offset -= sovIngester(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func _Ingester_QueryStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return srv.(IngesterServer).QueryStream(m, &ingesterQueryStreamServer{stream})
 }
-func (m *LabelNamesAndValuesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+
+type Ingester_QueryStreamServer interface {
+	Send(*QueryStreamResponse) error
+	grpc.ServerStream
 }
 
-func (m *LabelNamesAndValuesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+type ingesterQueryStreamServer struct {
+	grpc.ServerStream
 }
 
-func (m *LabelValues) Size() (n int) {
-	if m == nil {
-		return 0
+func (x *ingesterQueryStreamServer) Send(m *QueryStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Ingester_QueryExemplars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExemplarQueryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.LabelName)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if interceptor == nil {
+		return srv.(IngesterServer).QueryExemplars(ctx, in)
 	}
-	if len(m.Values) > 0 {
-		for _, s := range m.Values {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/QueryExemplars",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).QueryExemplars(ctx, req.(*ExemplarQueryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LabelValuesCardinalityRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _Ingester_LabelValues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelValuesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.LabelNames) > 0 {
-		for _, s := range m.LabelNames {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelValues(ctx, in)
 	}
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelValues",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelValues(ctx, req.(*LabelValuesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LabelValuesCardinalityResponse) Size() (n int) {
-	if m == nil {
-		return 0
+func _Ingester_LabelNames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LabelNamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Items) > 0 {
-		for _, e := range m.Items {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	if interceptor == nil {
+		return srv.(IngesterServer).LabelNames(ctx, in)
 	}
-	return n
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/LabelNames",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).LabelNames(ctx, req.(*LabelNamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *LabelValueSeriesCount) Size() (n int) {
-	if m == nil {
-		return 0
+func _Ingester_UserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	l = len(m.LabelName)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if interceptor == nil {
+		return srv.(IngesterServer).UserStats(ctx, in)
 	}
-	if len(m.LabelValueSeries) > 0 {
-		for k, v := range m.LabelValueSeries {
-			_ = k
-			_ = v
-			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + sovIngester(uint64(v))
-			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/UserStats",
 	}
-	return n
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).UserStats(ctx, req.(*UserStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *ReadRequest) Size() (n int) {
-	if m == nil {
-		return 0
+func _Ingester_AllUserStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UserStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	var l int
-	_ = l
-	if len(m.Queries) > 0 {
-		for _, e := range m.Queries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	if len(m.AcceptedResponseTypes) > 0 {
-		l = 0
-		for _, e := range m.AcceptedResponseTypes {
-			l += sovIngester(uint64(e))
-		}
-		n += 1 + sovIngester(uint64(l)) + l
+	if interceptor == nil {
+		return srv.(IngesterServer).AllUserStats(ctx, in)
 	}
-	return n
-}
-
-func (m *ReadResponse) Size() (n int) {
-	if m == nil {
-		return 0
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/AllUserStats",
 	}
-	var l int
-	_ = l
-	if len(m.Results) > 0 {
-		for _, e := range m.Results {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).AllUserStats(ctx, req.(*UserStatsRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *StreamReadResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.ChunkedSeries) > 0 {
-		for _, e := range m.ChunkedSeries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	if m.QueryIndex != 0 {
-		n += 1 + sovIngester(uint64(m.QueryIndex))
+func _Ingester_MetricsForLabelMatchers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsForLabelMatchersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	return n
-}
-
-func (m *StreamChunkedSeries) Size() (n int) {
-	if m == nil {
-		return 0
+	if interceptor == nil {
+		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, in)
 	}
-	var l int
-	_ = l
-	if len(m.Labels) > 0 {
-		for _, e := range m.Labels {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/MetricsForLabelMatchers",
 	}
-	if len(m.Chunks) > 0 {
-		for _, e := range m.Chunks {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).MetricsForLabelMatchers(ctx, req.(*MetricsForLabelMatchersRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *StreamChunk) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.MinTimeMs != 0 {
-		n += 1 + sovIngester(uint64(m.MinTimeMs))
+func _Ingester_MetricsMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MetricsMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
 	}
-	if m.MaxTimeMs != 0 {
-		n += 1 + sovIngester(uint64(m.MaxTimeMs))
+	if interceptor == nil {
+		return srv.(IngesterServer).MetricsMetadata(ctx, in)
 	}
-	if m.Type != 0 {
-		n += 1 + sovIngester(uint64(m.Type))
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cortex.Ingester/MetricsMetadata",
 	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IngesterServer).MetricsMetadata(ctx, req.(*MetricsMetadataRequest))
 	}
-	return n
+	return interceptor(ctx, in, info, handler)
 }
 
-func (m *QueryRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func _Ingester_LabelNamesAndValues_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LabelNamesAndValuesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return n
+	return srv.(IngesterServer).LabelNamesAndValues(m, &ingesterLabelNamesAndValuesServer{stream})
 }
 
-func (m *ExemplarQueryRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+type Ingester_LabelNamesAndValuesServer interface {
+	Send(*LabelNamesAndValuesResponse) error
+	grpc.ServerStream
 }
 
-func (m *QueryResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for _, e := range m.Timeseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+type ingesterLabelNamesAndValuesServer struct {
+	grpc.ServerStream
 }
 
-func (m *QueryStreamResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Chunkseries) > 0 {
-		for _, e := range m.Chunkseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	if len(m.Timeseries) > 0 {
-		for _, e := range m.Timeseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+func (x *ingesterLabelNamesAndValuesServer) Send(m *LabelNamesAndValuesResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *ExemplarQueryResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Timeseries) > 0 {
-		for _, e := range m.Timeseries {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func _Ingester_LabelValuesCardinality_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LabelValuesCardinalityRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
 	}
-	return n
+	return srv.(IngesterServer).LabelValuesCardinality(m, &ingesterLabelValuesCardinalityServer{stream})
 }
 
-func (m *LabelValuesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.LabelName)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if m.Matchers != nil {
-		l = m.Matchers.Size()
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	return n
+type Ingester_LabelValuesCardinalityServer interface {
+	Send(*LabelValuesCardinalityResponse) error
+	grpc.ServerStream
 }
 
-func (m *LabelValuesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.LabelValues) > 0 {
-		for _, s := range m.LabelValues {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+type ingesterLabelValuesCardinalityServer struct {
+	grpc.ServerStream
 }
 
-func (m *LabelNamesRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if m.Matchers != nil {
-		l = m.Matchers.Size()
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	return n
+func (x *ingesterLabelValuesCardinalityServer) Send(m *LabelValuesCardinalityResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *LabelNamesResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.LabelNames) > 0 {
-		for _, s := range m.LabelNames {
-			l = len(s)
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+func _Ingester_LabelValuesCardinalityStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(IngesterServer).LabelValuesCardinalityStream(&ingesterLabelValuesCardinalityStreamServer{stream})
 }
 
-func (m *UserStatsRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	return n
+type Ingester_LabelValuesCardinalityStreamServer interface {
+	Send(*LabelValuesCardinalityResponse) error
+	Recv() (*LabelValuesCardinalityStreamRequest, error)
+	grpc.ServerStream
 }
 
-func (m *UserStatsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.IngestionRate != 0 {
-		n += 9
-	}
-	if m.NumSeries != 0 {
-		n += 1 + sovIngester(uint64(m.NumSeries))
-	}
-	if m.ApiIngestionRate != 0 {
-		n += 9
-	}
-	if m.RuleIngestionRate != 0 {
-		n += 9
-	}
-	return n
+type ingesterLabelValuesCardinalityStreamServer struct {
+	grpc.ServerStream
 }
 
-func (m *UserIDStatsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	if m.Data != nil {
-		l = m.Data.Size()
-		n += 1 + l + sovIngester(uint64(l))
-	}
-	return n
+func (x *ingesterLabelValuesCardinalityStreamServer) Send(m *LabelValuesCardinalityResponse) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func (m *UsersStatsResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Stats) > 0 {
-		for _, e := range m.Stats {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func (x *ingesterLabelValuesCardinalityStreamServer) Recv() (*LabelValuesCardinalityStreamRequest, error) {
+	m := new(LabelValuesCardinalityStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
 	}
-	return n
+	return m, nil
 }
 
-func (m *MetricsForLabelMatchersRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
-	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
-	}
-	if len(m.MatchersSet) > 0 {
-		for _, e := range m.MatchersSet {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
-	}
-	return n
+var _Ingester_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cortex.Ingester",
+	HandlerType: (*IngesterServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Push",
+			Handler:    _Ingester_Push_Handler,
+		},
+		{
+			MethodName: "QueryExemplars",
+			Handler:    _Ingester_QueryExemplars_Handler,
+		},
+		{
+			MethodName: "LabelValues",
+			Handler:    _Ingester_LabelValues_Handler,
+		},
+		{
+			MethodName: "LabelNames",
+			Handler:    _Ingester_LabelNames_Handler,
+		},
+		{
+			MethodName: "UserStats",
+			Handler:    _Ingester_UserStats_Handler,
+		},
+		{
+			MethodName: "AllUserStats",
+			Handler:    _Ingester_AllUserStats_Handler,
+		},
+		{
+			MethodName: "MetricsForLabelMatchers",
+			Handler:    _Ingester_MetricsForLabelMatchers_Handler,
+		},
+		{
+			MethodName: "MetricsMetadata",
+			Handler:    _Ingester_MetricsMetadata_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "QueryStream",
+			Handler:       _Ingester_QueryStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LabelNamesAndValues",
+			Handler:       _Ingester_LabelNamesAndValues_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LabelValuesCardinality",
+			Handler:       _Ingester_LabelValuesCardinality_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "LabelValuesCardinalityStream",
+			Handler:       _Ingester_LabelValuesCardinalityStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "ingester.proto",
 }
 
-func (m *MetricsForLabelMatchersResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if len(m.Metric) > 0 {
-		for _, e := range m.Metric {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
-		}
+func (m *LabelNamesAndValuesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	return n
+	return dAtA[:n], nil
 }
 
-func (m *MetricsMetadataRequest) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	return n
+func (m *LabelNamesAndValuesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *MetricsMetadataResponse) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+func (m *LabelNamesAndValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if len(m.Metadata) > 0 {
-		for _, e := range m.Metadata {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if m.Export {
+		i--
+		if m.Export {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa8
 	}
-	return n
-}
-
-func (m *TimeSeriesChunk) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.ValuesCollation) > 0 {
+		i -= len(m.ValuesCollation)
+		copy(dAtA[i:], m.ValuesCollation)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.ValuesCollation)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
 	}
-	var l int
-	_ = l
-	l = len(m.FromIngesterId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.ShardCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ShardCount))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
 	}
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.ShardIndex != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ShardIndex))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
 	}
-	if len(m.Labels) > 0 {
-		for _, e := range m.Labels {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if m.IncludeRelabelOutcomes {
+		i--
+		if m.IncludeRelabelOutcomes {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
 	}
-	if len(m.Chunks) > 0 {
-		for _, e := range m.Chunks {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if m.DedupValues {
+		i--
+		if m.DedupValues {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
 	}
-	return n
-}
-
-func (m *Chunk) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.IncludeBlocks {
+		i--
+		if m.IncludeBlocks {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x78
 	}
-	var l int
-	_ = l
-	if m.StartTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	if m.MaxValues != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MaxValues))
+		i--
+		dAtA[i] = 0x70
 	}
-	if m.EndTimestampMs != 0 {
-		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	if m.IncludeValueCount {
+		i--
+		if m.IncludeValueCount {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x68
 	}
-	if m.Encoding != 0 {
-		n += 1 + sovIngester(uint64(m.Encoding))
+	if len(m.ValuesCompressionDictionary) > 0 {
+		i -= len(m.ValuesCompressionDictionary)
+		copy(dAtA[i:], m.ValuesCompressionDictionary)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.ValuesCompressionDictionary)))
+		i--
+		dAtA[i] = 0x62
 	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.ValuesPreviewSize != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ValuesPreviewSize))
+		i--
+		dAtA[i] = 0x58
 	}
-	return n
-}
-
-func (m *LabelMatchers) Size() (n int) {
-	if m == nil {
-		return 0
+	if len(m.CheckpointToken) > 0 {
+		i -= len(m.CheckpointToken)
+		copy(dAtA[i:], m.CheckpointToken)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.CheckpointToken)))
+		i--
+		dAtA[i] = 0x52
 	}
-	var l int
-	_ = l
-	if len(m.Matchers) > 0 {
-		for _, e := range m.Matchers {
-			l = e.Size()
-			n += 1 + l + sovIngester(uint64(l))
+	if m.UseValueIds {
+		i--
+		if m.UseValueIds {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
+		i--
+		dAtA[i] = 0x48
 	}
-	return n
-}
-
-func (m *LabelMatcher) Size() (n int) {
-	if m == nil {
-		return 0
-	}
-	var l int
-	_ = l
-	if m.Type != 0 {
-		n += 1 + sovIngester(uint64(m.Type))
+	if m.MaxDistinctValues != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MaxDistinctValues))
+		i--
+		dAtA[i] = 0x40
 	}
-	l = len(m.Name)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.ValuesBloomFilter != nil {
+		{
+			size, err := m.ValuesBloomFilter.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x3a
 	}
-	l = len(m.Value)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.IncludePresence {
+		i--
+		if m.IncludePresence {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x30
 	}
-	return n
-}
-
-func (m *TimeSeriesFile) Size() (n int) {
-	if m == nil {
-		return 0
+	if m.PartitionByFirstCharacter {
+		i--
+		if m.PartitionByFirstCharacter {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
 	}
-	var l int
-	_ = l
-	l = len(m.FromIngesterId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.LongValueLengthThreshold != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.LongValueLengthThreshold))
+		i--
+		dAtA[i] = 0x20
 	}
-	l = len(m.UserId)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if len(m.Fields) > 0 {
+		for iNdEx := len(m.Fields) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Fields[iNdEx])
+			copy(dAtA[i:], m.Fields[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.Fields[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	l = len(m.Filename)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if m.IncludeSeriesCount {
+		i--
+		if m.IncludeSeriesCount {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
 	}
-	l = len(m.Data)
-	if l > 0 {
-		n += 1 + l + sovIngester(uint64(l))
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func sovIngester(x uint64) (n int) {
-	return (math_bits.Len64(x|1) + 6) / 7
+func (m *LabelValuesBloomFilter) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
 }
-func sozIngester(x uint64) (n int) {
-	return sovIngester(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+
+func (m *LabelValuesBloomFilter) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *LabelNamesAndValuesRequest) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelValuesBloomFilter) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.HashCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.HashCount))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	if len(m.Bits) > 0 {
+		i -= len(m.Bits)
+		copy(dAtA[i:], m.Bits)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Bits)))
+		i--
+		dAtA[i] = 0xa
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&LabelNamesAndValuesRequest{`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *LabelNamesAndValuesResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForItems := "[]*LabelValues{"
-	for _, f := range this.Items {
-		repeatedStringForItems += strings.Replace(f.String(), "LabelValues", "LabelValues", 1) + ","
+
+func (m *LabelNamesAndValuesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForItems += "}"
-	s := strings.Join([]string{`&LabelNamesAndValuesResponse{`,
-		`Items:` + repeatedStringForItems + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *LabelValues) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&LabelValues{`,
-		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
-		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *LabelNamesAndValuesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *LabelValuesCardinalityRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+
+func (m *LabelNamesAndValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ExportObjectName) > 0 {
+		i -= len(m.ExportObjectName)
+		copy(dAtA[i:], m.ExportObjectName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.ExportObjectName)))
+		i--
+		dAtA[i] = 0x4a
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&LabelValuesCardinalityRequest{`,
-		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelValuesCardinalityResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.LabelNamesTruncated {
+		i--
+		if m.LabelNamesTruncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
 	}
-	repeatedStringForItems := "[]*LabelValueSeriesCount{"
-	for _, f := range this.Items {
-		repeatedStringForItems += strings.Replace(f.String(), "LabelValueSeriesCount", "LabelValueSeriesCount", 1) + ","
+	if m.Truncated {
+		i--
+		if m.Truncated {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
 	}
-	repeatedStringForItems += "}"
-	s := strings.Join([]string{`&LabelValuesCardinalityResponse{`,
-		`Items:` + repeatedStringForItems + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelValueSeriesCount) String() string {
-	if this == nil {
-		return "nil"
+	if m.ValuesCompressionDictionaryId != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ValuesCompressionDictionaryId))
+		i--
+		dAtA[i] = 0x30
 	}
-	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
-	for k, _ := range this.LabelValueSeries {
-		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
+	if len(m.Dictionary) > 0 {
+		for iNdEx := len(m.Dictionary) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Dictionary[iNdEx])
+			copy(dAtA[i:], m.Dictionary[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.Dictionary[iNdEx])))
+			i--
+			dAtA[i] = 0x2a
+		}
 	}
-	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
-	mapStringForLabelValueSeries := "map[string]uint64{"
-	for _, k := range keysForLabelValueSeries {
-		mapStringForLabelValueSeries += fmt.Sprintf("%v: %v,", k, this.LabelValueSeries[k])
+	if len(m.PartitionKey) > 0 {
+		i -= len(m.PartitionKey)
+		copy(dAtA[i:], m.PartitionKey)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.PartitionKey)))
+		i--
+		dAtA[i] = 0x22
 	}
-	mapStringForLabelValueSeries += "}"
-	s := strings.Join([]string{`&LabelValueSeriesCount{`,
-		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
-		`LabelValueSeries:` + mapStringForLabelValueSeries + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ReadRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.LongValues) > 0 {
+		for iNdEx := len(m.LongValues) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.LongValues[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	repeatedStringForQueries := "[]*QueryRequest{"
-	for _, f := range this.Queries {
-		repeatedStringForQueries += strings.Replace(f.String(), "QueryRequest", "QueryRequest", 1) + ","
+	if m.SeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForQueries += "}"
-	s := strings.Join([]string{`&ReadRequest{`,
-		`Queries:` + repeatedStringForQueries + `,`,
-		`AcceptedResponseTypes:` + fmt.Sprintf("%v", this.AcceptedResponseTypes) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ReadResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForResults := "[]*QueryResponse{"
-	for _, f := range this.Results {
-		repeatedStringForResults += strings.Replace(f.String(), "QueryResponse", "QueryResponse", 1) + ","
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	repeatedStringForResults += "}"
-	s := strings.Join([]string{`&ReadResponse{`,
-		`Results:` + repeatedStringForResults + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *StreamReadResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForChunkedSeries := "[]*StreamChunkedSeries{"
-	for _, f := range this.ChunkedSeries {
-		repeatedStringForChunkedSeries += strings.Replace(f.String(), "StreamChunkedSeries", "StreamChunkedSeries", 1) + ","
+
+func (m *LabelValues) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForChunkedSeries += "}"
-	s := strings.Join([]string{`&StreamReadResponse{`,
-		`ChunkedSeries:` + repeatedStringForChunkedSeries + `,`,
-		`QueryIndex:` + fmt.Sprintf("%v", this.QueryIndex) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *StreamChunkedSeries) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForChunks := "[]StreamChunk{"
-	for _, f := range this.Chunks {
-		repeatedStringForChunks += strings.Replace(strings.Replace(f.String(), "StreamChunk", "StreamChunk", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForChunks += "}"
-	s := strings.Join([]string{`&StreamChunkedSeries{`,
-		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
-		`Chunks:` + repeatedStringForChunks + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *LabelValues) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *StreamChunk) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelValues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.RelabelOutcomes) > 0 {
+		dAtA3 := make([]byte, len(m.RelabelOutcomes)*10)
+		var j2 int
+		for _, num := range m.RelabelOutcomes {
+			for num >= 1<<7 {
+				dAtA3[j2] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j2++
+			}
+			dAtA3[j2] = uint8(num)
+			j2++
+		}
+		i -= j2
+		copy(dAtA[i:], dAtA3[:j2])
+		i = encodeVarintIngester(dAtA, i, uint64(j2))
+		i--
+		dAtA[i] = 0x3a
 	}
-	s := strings.Join([]string{`&StreamChunk{`,
-		`MinTimeMs:` + fmt.Sprintf("%v", this.MinTimeMs) + `,`,
-		`MaxTimeMs:` + fmt.Sprintf("%v", this.MaxTimeMs) + `,`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *QueryRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.ValueCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ValueCount))
+		i--
+		dAtA[i] = 0x30
 	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	if len(m.CompressedValues) > 0 {
+		i -= len(m.CompressedValues)
+		copy(dAtA[i:], m.CompressedValues)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.CompressedValues)))
+		i--
+		dAtA[i] = 0x2a
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&QueryRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *ExemplarQueryRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.ValueIds) > 0 {
+		dAtA5 := make([]byte, len(m.ValueIds)*10)
+		var j4 int
+		for _, num := range m.ValueIds {
+			for num >= 1<<7 {
+				dAtA5[j4] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j4++
+			}
+			dAtA5[j4] = uint8(num)
+			j4++
+		}
+		i -= j4
+		copy(dAtA[i:], dAtA5[:j4])
+		i = encodeVarintIngester(dAtA, i, uint64(j4))
+		i--
+		dAtA[i] = 0x22
 	}
-	repeatedStringForMatchers := "[]*LabelMatchers{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	if len(m.Presence) > 0 {
+		dAtA7 := make([]byte, len(m.Presence)*10)
+		var j6 int
+		for _, num := range m.Presence {
+			for num >= 1<<7 {
+				dAtA7[j6] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j6++
+			}
+			dAtA7[j6] = uint8(num)
+			j6++
+		}
+		i -= j6
+		copy(dAtA[i:], dAtA7[:j6])
+		i = encodeVarintIngester(dAtA, i, uint64(j6))
+		i--
+		dAtA[i] = 0x1a
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&ExemplarQueryRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *QueryResponse) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.Values) > 0 {
+		for iNdEx := len(m.Values) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Values[iNdEx])
+			copy(dAtA[i:], m.Values[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.Values[iNdEx])))
+			i--
+			dAtA[i] = 0x12
+		}
 	}
-	repeatedStringForTimeseries := "[]TimeSeries{"
-	for _, f := range this.Timeseries {
-		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
 	}
-	repeatedStringForTimeseries += "}"
-	s := strings.Join([]string{`&QueryResponse{`,
-		`Timeseries:` + repeatedStringForTimeseries + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *QueryStreamResponse) String() string {
-	if this == nil {
-		return "nil"
-	}
-	repeatedStringForChunkseries := "[]TimeSeriesChunk{"
-	for _, f := range this.Chunkseries {
-		repeatedStringForChunkseries += strings.Replace(strings.Replace(f.String(), "TimeSeriesChunk", "TimeSeriesChunk", 1), `&`, ``, 1) + ","
-	}
-	repeatedStringForChunkseries += "}"
-	repeatedStringForTimeseries := "[]TimeSeries{"
-	for _, f := range this.Timeseries {
-		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+
+func (m *LongLabelValues) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	repeatedStringForTimeseries += "}"
-	s := strings.Join([]string{`&QueryStreamResponse{`,
-		`Chunkseries:` + repeatedStringForChunkseries + `,`,
-		`Timeseries:` + repeatedStringForTimeseries + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *ExemplarQueryResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LongLabelValues) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LongLabelValues) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Exemplars) > 0 {
+		for iNdEx := len(m.Exemplars) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Exemplars[iNdEx])
+			copy(dAtA[i:], m.Exemplars[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.Exemplars[iNdEx])))
+			i--
+			dAtA[i] = 0x1a
+		}
 	}
-	repeatedStringForTimeseries := "[]TimeSeries{"
-	for _, f := range this.Timeseries {
-		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	if m.Count != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Count))
+		i--
+		dAtA[i] = 0x10
 	}
-	repeatedStringForTimeseries += "}"
-	s := strings.Join([]string{`&ExemplarQueryResponse{`,
-		`Timeseries:` + repeatedStringForTimeseries + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelValuesRequest) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
 	}
-	s := strings.Join([]string{`&LabelValuesRequest{`,
-		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+	return len(dAtA) - i, nil
 }
-func (this *LabelValuesResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelValuesCardinalityRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	s := strings.Join([]string{`&LabelValuesResponse{`,
-		`LabelValues:` + fmt.Sprintf("%v", this.LabelValues) + `,`,
-		`}`,
-	}, "")
-	return s
+	return dAtA[:n], nil
 }
-func (this *LabelNamesRequest) String() string {
-	if this == nil {
-		return "nil"
-	}
-	s := strings.Join([]string{`&LabelNamesRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
-		`}`,
-	}, "")
-	return s
+
+func (m *LabelValuesCardinalityRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
 }
-func (this *LabelNamesResponse) String() string {
-	if this == nil {
-		return "nil"
+
+func (m *LabelValuesCardinalityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChangedLabelsState) > 0 {
+		i -= len(m.ChangedLabelsState)
+		copy(dAtA[i:], m.ChangedLabelsState)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.ChangedLabelsState)))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0xa2
 	}
-	s := strings.Join([]string{`&LabelNamesResponse{`,
-		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UserStatsRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.ChangedLabelsOnly {
+		i--
+		if m.ChangedLabelsOnly {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x98
 	}
-	s := strings.Join([]string{`&UserStatsRequest{`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UserStatsResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.CompareGrowthRate {
+		i--
+		if m.CompareGrowthRate {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x90
 	}
-	s := strings.Join([]string{`&UserStatsResponse{`,
-		`IngestionRate:` + fmt.Sprintf("%v", this.IngestionRate) + `,`,
-		`NumSeries:` + fmt.Sprintf("%v", this.NumSeries) + `,`,
-		`ApiIngestionRate:` + fmt.Sprintf("%v", this.ApiIngestionRate) + `,`,
-		`RuleIngestionRate:` + fmt.Sprintf("%v", this.RuleIngestionRate) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UserIDStatsResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.CompareEndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.CompareEndTimestampMs))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x88
 	}
-	s := strings.Join([]string{`&UserIDStatsResponse{`,
-		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
-		`Data:` + strings.Replace(this.Data.String(), "UserStatsResponse", "UserStatsResponse", 1) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *UsersStatsResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.CompareStartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.CompareStartTimestampMs))
+		i--
+		dAtA[i] = 0x2
+		i--
+		dAtA[i] = 0x80
 	}
-	repeatedStringForStats := "[]*UserIDStatsResponse{"
-	for _, f := range this.Stats {
-		repeatedStringForStats += strings.Replace(f.String(), "UserIDStatsResponse", "UserIDStatsResponse", 1) + ","
+	if m.ResumeChecksum != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ResumeChecksum))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xf0
 	}
-	repeatedStringForStats += "}"
-	s := strings.Join([]string{`&UsersStatsResponse{`,
-		`Stats:` + repeatedStringForStats + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MetricsForLabelMatchersRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.ResumeOffset != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ResumeOffset))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe8
 	}
-	repeatedStringForMatchersSet := "[]*LabelMatchers{"
-	for _, f := range this.MatchersSet {
-		repeatedStringForMatchersSet += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	if m.IncludeSummary {
+		i--
+		if m.IncludeSummary {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xe0
 	}
-	repeatedStringForMatchersSet += "}"
-	s := strings.Join([]string{`&MetricsForLabelMatchersRequest{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`MatchersSet:` + repeatedStringForMatchersSet + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MetricsForLabelMatchersResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.GroupByMagnitude {
+		i--
+		if m.GroupByMagnitude {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd8
 	}
-	repeatedStringForMetric := "[]*Metric{"
-	for _, f := range this.Metric {
-		repeatedStringForMetric += strings.Replace(fmt.Sprintf("%v", f), "Metric", "mimirpb.Metric", 1) + ","
+	if m.BestEffort {
+		i--
+		if m.BestEffort {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xd0
 	}
-	repeatedStringForMetric += "}"
-	s := strings.Join([]string{`&MetricsForLabelMatchersResponse{`,
-		`Metric:` + repeatedStringForMetric + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MetricsMetadataRequest) String() string {
-	if this == nil {
-		return "nil"
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc8
 	}
-	s := strings.Join([]string{`&MetricsMetadataRequest{`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *MetricsMetadataResponse) String() string {
-	if this == nil {
-		return "nil"
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xc0
 	}
-	repeatedStringForMetadata := "[]*MetricMetadata{"
-	for _, f := range this.Metadata {
-		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "MetricMetadata", "mimirpb.MetricMetadata", 1) + ","
+	if m.SortLabelValues {
+		i--
+		if m.SortLabelValues {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb8
 	}
-	repeatedStringForMetadata += "}"
-	s := strings.Join([]string{`&MetricsMetadataResponse{`,
-		`Metadata:` + repeatedStringForMetadata + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *TimeSeriesChunk) String() string {
-	if this == nil {
-		return "nil"
+	if m.ContextCheckIntervalSeries != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ContextCheckIntervalSeries))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xb0
 	}
-	repeatedStringForChunks := "[]Chunk{"
-	for _, f := range this.Chunks {
-		repeatedStringForChunks += strings.Replace(strings.Replace(f.String(), "Chunk", "Chunk", 1), `&`, ``, 1) + ","
+	if len(m.SeriesCountPercentiles) > 0 {
+		for iNdEx := len(m.SeriesCountPercentiles) - 1; iNdEx >= 0; iNdEx-- {
+			f8 := math.Float64bits(float64(m.SeriesCountPercentiles[iNdEx]))
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f8))
+		}
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.SeriesCountPercentiles)*8))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xaa
 	}
-	repeatedStringForChunks += "}"
-	s := strings.Join([]string{`&TimeSeriesChunk{`,
-		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
-		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
-		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
-		`Chunks:` + repeatedStringForChunks + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *Chunk) String() string {
-	if this == nil {
-		return "nil"
+	if m.OrderByLabelSeries {
+		i--
+		if m.OrderByLabelSeries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa0
 	}
-	s := strings.Join([]string{`&Chunk{`,
-		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
-		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
-		`Encoding:` + fmt.Sprintf("%v", this.Encoding) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelMatchers) String() string {
-	if this == nil {
-		return "nil"
+	if m.MetricNamesTopK != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MetricNamesTopK))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x98
 	}
-	repeatedStringForMatchers := "[]*LabelMatcher{"
-	for _, f := range this.Matchers {
-		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	if m.CoOccurrenceTopK != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.CoOccurrenceTopK))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
 	}
-	repeatedStringForMatchers += "}"
-	s := strings.Join([]string{`&LabelMatchers{`,
-		`Matchers:` + repeatedStringForMatchers + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *LabelMatcher) String() string {
-	if this == nil {
-		return "nil"
+	if len(m.ValueGroupRegex) > 0 {
+		i -= len(m.ValueGroupRegex)
+		copy(dAtA[i:], m.ValueGroupRegex)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.ValueGroupRegex)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x8a
 	}
-	s := strings.Join([]string{`&LabelMatcher{`,
-		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
-		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
-		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func (this *TimeSeriesFile) String() string {
-	if this == nil {
-		return "nil"
+	if m.SampleSeed != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SampleSeed))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x80
 	}
-	s := strings.Join([]string{`&TimeSeriesFile{`,
-		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
-		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
-		`Filename:` + fmt.Sprintf("%v", this.Filename) + `,`,
-		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
-		`}`,
-	}, "")
-	return s
-}
-func valueToStringIngester(v interface{}) string {
-	rv := reflect.ValueOf(v)
-	if rv.IsNil() {
-		return "nil"
+	if m.SampleValues != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SampleValues))
+		i--
+		dAtA[i] = 0x78
 	}
-	pv := reflect.Indirect(rv).Interface()
-	return fmt.Sprintf("*%v", pv)
+	if m.IncludeRatios {
+		i--
+		if m.IncludeRatios {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x70
+	}
+	if len(m.ValueHashSalt) > 0 {
+		i -= len(m.ValueHashSalt)
+		copy(dAtA[i:], m.ValueHashSalt)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.ValueHashSalt)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.AllLabels {
+		i--
+		if m.AllLabels {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x60
+	}
+	if m.MinSeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MinSeriesCount))
+		i--
+		dAtA[i] = 0x58
+	}
+	if m.ProgressIntervalMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ProgressIntervalMs))
+		i--
+		dAtA[i] = 0x50
+	}
+	if m.Explain {
+		i--
+		if m.Explain {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.IncludeChunkCount {
+		i--
+		if m.IncludeChunkCount {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.EstimateLabelSeries {
+		i--
+		if m.EstimateLabelSeries {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x38
+	}
+	if m.ShardCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ShardCount))
+		i--
+		dAtA[i] = 0x30
+	}
+	if m.ShardIndex != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ShardIndex))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.IncludeChecksums {
+		i--
+		if m.IncludeChecksums {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.GroupByMetricName {
+		i--
+		if m.GroupByMetricName {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelNames[iNdEx])
+			copy(dAtA[i:], m.LabelNames[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
 }
-func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
-	l := len(dAtA)
-	iNdEx := 0
-	for iNdEx < l {
-		preIndex := iNdEx
-		var wire uint64
-		for shift := uint(0); ; shift += 7 {
-			if shift >= 64 {
-				return ErrIntOverflowIngester
+
+func (m *LabelValuesCardinalityStreamRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalityStreamRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityStreamRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Resume {
+		i--
+		if m.Resume {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.Pause {
+		i--
+		if m.Pause {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.WatchIntervalMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.WatchIntervalMs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Stop {
+		i--
+		if m.Stop {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Request != nil {
+		{
+			size, err := m.Request.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
-			if iNdEx >= l {
-				return io.ErrUnexpectedEOF
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesCardinalityResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalityResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.ChangedLabelsState) > 0 {
+		i -= len(m.ChangedLabelsState)
+		copy(dAtA[i:], m.ChangedLabelsState)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.ChangedLabelsState)))
+		i--
+		dAtA[i] = 0x7a
+	}
+	if len(m.InstanceId) > 0 {
+		i -= len(m.InstanceId)
+		copy(dAtA[i:], m.InstanceId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.InstanceId)))
+		i--
+		dAtA[i] = 0x72
+	}
+	if m.RunningChecksum != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.RunningChecksum))
+		i--
+		dAtA[i] = 0x68
+	}
+	if m.ByteOffset != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ByteOffset))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.Summaries) > 0 {
+		for iNdEx := len(m.Summaries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Summaries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.Errors) > 0 {
+		for iNdEx := len(m.Errors) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Errors[iNdEx])
+			copy(dAtA[i:], m.Errors[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.Errors[iNdEx])))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if m.FailedLabelValues != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.FailedLabelValues))
+		i--
+		dAtA[i] = 0x48
+	}
+	if m.SampleSeed != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SampleSeed))
+		i--
+		dAtA[i] = 0x40
+	}
+	if m.Progress != nil {
+		{
+			size, err := m.Progress.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
 			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
 		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesAndValuesRequest: wiretype end group for non-group")
+		i--
+		dAtA[i] = 0x3a
+	}
+	if m.Explain != nil {
+		{
+			size, err := m.Explain.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
 		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesAndValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		i--
+		dAtA[i] = 0x32
+	}
+	if m.Stopped {
+		i--
+		if m.Stopped {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
 		}
-		switch fieldNum {
-		case 1:
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.ItemsChecksum != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.ItemsChecksum))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SequenceNumber != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SequenceNumber))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.BudgetWarning {
+		i--
+		if m.BudgetWarning {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesCardinalitySummary) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalitySummary) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalitySummary) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.LabelValuesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.LabelValuesCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesCardinalityProgress) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalityProgress) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityProgress) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Series != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Series))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.LabelValues != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.LabelValues))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesCardinalityExplain) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesCardinalityExplain) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesCardinalityExplain) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Goroutines != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Goroutines))
+		i--
+		dAtA[i] = 0x28
+	}
+	if m.CountingGoroutines != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.CountingGoroutines))
+		i--
+		dAtA[i] = 0x20
+	}
+	if m.SendingDurationNs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SendingDurationNs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.CountingDurationNs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.CountingDurationNs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.LabelValuesDurationNs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.LabelValuesDurationNs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValueSeriesCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValueSeriesCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValueSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SeriesCountMagnitude != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCountMagnitude))
+		i--
+		dAtA[i] = 0x60
+	}
+	if len(m.SeriesCountPercentiles) > 0 {
+		for iNdEx := len(m.SeriesCountPercentiles) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.SeriesCountPercentiles[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x5a
+		}
+	}
+	if len(m.LabelValueSeriesGrowthRate) > 0 {
+		for k := range m.LabelValueSeriesGrowthRate {
+			v := m.LabelValueSeriesGrowthRate[k]
+			baseI := i
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(v))))
+			i--
+			dAtA[i] = 0x11
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x52
+		}
+	}
+	if len(m.LabelValueCoOccurrences) > 0 {
+		for k := range m.LabelValueCoOccurrences {
+			v := m.LabelValueCoOccurrences[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintIngester(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x4a
+		}
+	}
+	if len(m.LabelValueRatios) > 0 {
+		for k := range m.LabelValueRatios {
+			v := m.LabelValueRatios[k]
+			baseI := i
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(v))))
+			i--
+			dAtA[i] = 0x11
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x42
+		}
+	}
+	if len(m.LabelValueChunks) > 0 {
+		for k := range m.LabelValueChunks {
+			v := m.LabelValueChunks[k]
+			baseI := i
+			i = encodeVarintIngester(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x3a
+		}
+	}
+	if m.LabelSeriesEstimateRelativeError != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.LabelSeriesEstimateRelativeError))))
+		i--
+		dAtA[i] = 0x31
+	}
+	if m.LabelSeriesEstimate != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.LabelSeriesEstimate))
+		i--
+		dAtA[i] = 0x28
+	}
+	if len(m.LabelValueSeriesDelta) > 0 {
+		for k := range m.LabelValueSeriesDelta {
+			v := m.LabelValueSeriesDelta[k]
+			baseI := i
+			i = encodeVarintIngester(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.LabelValueMetricNamesSeries) > 0 {
+		for k := range m.LabelValueMetricNamesSeries {
+			v := m.LabelValueMetricNamesSeries[k]
+			baseI := i
+			if v != nil {
+				{
+					size, err := v.MarshalToSizedBuffer(dAtA[:i])
+					if err != nil {
+						return 0, err
+					}
+					i -= size
+					i = encodeVarintIngester(dAtA, i, uint64(size))
+				}
+				i--
+				dAtA[i] = 0x12
+			}
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.LabelValueSeries) > 0 {
+		for k := range m.LabelValueSeries {
+			v := m.LabelValueSeries[k]
+			baseI := i
+			i = encodeVarintIngester(dAtA, i, uint64(v))
+			i--
+			dAtA[i] = 0x10
+			i -= len(k)
+			copy(dAtA[i:], k)
+			i = encodeVarintIngester(dAtA, i, uint64(len(k)))
+			i--
+			dAtA[i] = 0xa
+			i = encodeVarintIngester(dAtA, i, uint64(baseI-i))
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *SeriesCountPercentile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SeriesCountPercentile) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SeriesCountPercentile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SeriesCount != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.SeriesCount))))
+		i--
+		dAtA[i] = 0x11
+	}
+	if m.Percentile != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Percentile))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValueCoOccurrences) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValueCoOccurrences) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValueCoOccurrences) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValueCoOccurrence) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValueCoOccurrence) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValueCoOccurrence) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.LabelValue) > 0 {
+		i -= len(m.LabelValue)
+		copy(dAtA[i:], m.LabelValue)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelValue)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricNamesSeriesCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricNamesSeriesCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricNamesSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for iNdEx := len(m.Items) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Items[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricNameSeriesCount) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricNameSeriesCount) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricNameSeriesCount) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.SeriesCount != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.SeriesCount))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.MetricName) > 0 {
+		i -= len(m.MetricName)
+		copy(dAtA[i:], m.MetricName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.MetricName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReadRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.AcceptedResponseTypes) > 0 {
+		dAtA15 := make([]byte, len(m.AcceptedResponseTypes)*10)
+		var j14 int
+		for _, num := range m.AcceptedResponseTypes {
+			for num >= 1<<7 {
+				dAtA15[j14] = uint8(uint64(num)&0x7f | 0x80)
+				num >>= 7
+				j14++
+			}
+			dAtA15[j14] = uint8(num)
+			j14++
+		}
+		i -= j14
+		copy(dAtA[i:], dAtA15[:j14])
+		i = encodeVarintIngester(dAtA, i, uint64(j14))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.Queries) > 0 {
+		for iNdEx := len(m.Queries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Queries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ReadResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ReadResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for iNdEx := len(m.Results) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Results[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamReadResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamReadResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamReadResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.QueryIndex != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.QueryIndex))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.ChunkedSeries) > 0 {
+		for iNdEx := len(m.ChunkedSeries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.ChunkedSeries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamChunkedSeries) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamChunkedSeries) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamChunkedSeries) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chunks) > 0 {
+		for iNdEx := len(m.Chunks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Chunks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *StreamChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *StreamChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *StreamChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Type != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.MaxTimeMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MaxTimeMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.MinTimeMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.MinTimeMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExemplarQueryRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExemplarQueryRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExemplarQueryRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *QueryStreamResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *QueryStreamResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *QueryStreamResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x12
+		}
+	}
+	if len(m.Chunkseries) > 0 {
+		for iNdEx := len(m.Chunkseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Chunkseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *ExemplarQueryResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *ExemplarQueryResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *ExemplarQueryResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for iNdEx := len(m.Timeseries) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Timeseries[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Matchers != nil {
+		{
+			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if len(m.LabelName) > 0 {
+		i -= len(m.LabelName)
+		copy(dAtA[i:], m.LabelName)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelName)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelValuesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelValuesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelValuesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LabelValues) > 0 {
+		for iNdEx := len(m.LabelValues) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelValues[iNdEx])
+			copy(dAtA[i:], m.LabelValues[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelValues[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelNamesRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelNamesRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelNamesRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Matchers != nil {
+		{
+			size, err := m.Matchers.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelNamesResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelNamesResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelNamesResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for iNdEx := len(m.LabelNames) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.LabelNames[iNdEx])
+			copy(dAtA[i:], m.LabelNames[iNdEx])
+			i = encodeVarintIngester(dAtA, i, uint64(len(m.LabelNames[iNdEx])))
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UserStatsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserStatsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserStatsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *UserStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.RuleIngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.RuleIngestionRate))))
+		i--
+		dAtA[i] = 0x21
+	}
+	if m.ApiIngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.ApiIngestionRate))))
+		i--
+		dAtA[i] = 0x19
+	}
+	if m.NumSeries != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.NumSeries))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.IngestionRate != 0 {
+		i -= 8
+		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.IngestionRate))))
+		i--
+		dAtA[i] = 0x9
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UserIDStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UserIDStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UserIDStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.Data != nil {
+		{
+			size, err := m.Data.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintIngester(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *UsersStatsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *UsersStatsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *UsersStatsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Stats) > 0 {
+		for iNdEx := len(m.Stats) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Stats[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsForLabelMatchersRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsForLabelMatchersRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsForLabelMatchersRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.MatchersSet) > 0 {
+		for iNdEx := len(m.MatchersSet) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.MatchersSet[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsForLabelMatchersResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsForLabelMatchersResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsForLabelMatchersResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metric) > 0 {
+		for iNdEx := len(m.Metric) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metric[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsMetadataRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsMetadataRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsMetadataRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *MetricsMetadataResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *MetricsMetadataResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *MetricsMetadataResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		for iNdEx := len(m.Metadata) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Metadata[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimeSeriesChunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeSeriesChunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeSeriesChunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Chunks) > 0 {
+		for iNdEx := len(m.Chunks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Chunks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x22
+		}
+	}
+	if len(m.Labels) > 0 {
+		for iNdEx := len(m.Labels) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size := m.Labels[iNdEx].Size()
+				i -= size
+				if _, err := m.Labels[iNdEx].MarshalTo(dAtA[i:]); err != nil {
+					return 0, err
+				}
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0x1a
+		}
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.FromIngesterId) > 0 {
+		i -= len(m.FromIngesterId)
+		copy(dAtA[i:], m.FromIngesterId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *Chunk) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *Chunk) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Chunk) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.Encoding != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Encoding))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.EndTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.EndTimestampMs))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.StartTimestampMs != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.StartTimestampMs))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelMatchers) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelMatchers) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelMatchers) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for iNdEx := len(m.Matchers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Matchers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintIngester(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *LabelMatcher) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *LabelMatcher) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *LabelMatcher) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Value) > 0 {
+		i -= len(m.Value)
+		copy(dAtA[i:], m.Value)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Value)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Name) > 0 {
+		i -= len(m.Name)
+		copy(dAtA[i:], m.Name)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Name)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Type != 0 {
+		i = encodeVarintIngester(dAtA, i, uint64(m.Type))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TimeSeriesFile) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TimeSeriesFile) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TimeSeriesFile) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if len(m.Filename) > 0 {
+		i -= len(m.Filename)
+		copy(dAtA[i:], m.Filename)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.Filename)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.UserId) > 0 {
+		i -= len(m.UserId)
+		copy(dAtA[i:], m.UserId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.UserId)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if len(m.FromIngesterId) > 0 {
+		i -= len(m.FromIngesterId)
+		copy(dAtA[i:], m.FromIngesterId)
+		i = encodeVarintIngester(dAtA, i, uint64(len(m.FromIngesterId)))
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintIngester(dAtA []byte, offset int, v uint64) int {
+	offset -= sovIngester(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *LabelNamesAndValuesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.IncludeSeriesCount {
+		n += 2
+	}
+	if len(m.Fields) > 0 {
+		for _, s := range m.Fields {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.LongValueLengthThreshold != 0 {
+		n += 1 + sovIngester(uint64(m.LongValueLengthThreshold))
+	}
+	if m.PartitionByFirstCharacter {
+		n += 2
+	}
+	if m.IncludePresence {
+		n += 2
+	}
+	if m.ValuesBloomFilter != nil {
+		l = m.ValuesBloomFilter.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.MaxDistinctValues != 0 {
+		n += 1 + sovIngester(uint64(m.MaxDistinctValues))
+	}
+	if m.UseValueIds {
+		n += 2
+	}
+	l = len(m.CheckpointToken)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.ValuesPreviewSize != 0 {
+		n += 1 + sovIngester(uint64(m.ValuesPreviewSize))
+	}
+	l = len(m.ValuesCompressionDictionary)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.IncludeValueCount {
+		n += 2
+	}
+	if m.MaxValues != 0 {
+		n += 1 + sovIngester(uint64(m.MaxValues))
+	}
+	if m.IncludeBlocks {
+		n += 2
+	}
+	if m.DedupValues {
+		n += 3
+	}
+	if m.IncludeRelabelOutcomes {
+		n += 3
+	}
+	if m.ShardIndex != 0 {
+		n += 2 + sovIngester(uint64(m.ShardIndex))
+	}
+	if m.ShardCount != 0 {
+		n += 2 + sovIngester(uint64(m.ShardCount))
+	}
+	l = len(m.ValuesCollation)
+	if l > 0 {
+		n += 2 + l + sovIngester(uint64(l))
+	}
+	if m.Export {
+		n += 3
+	}
+	return n
+}
+
+func (m *LabelValuesBloomFilter) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.Bits)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.HashCount != 0 {
+		n += 1 + sovIngester(uint64(m.HashCount))
+	}
+	return n
+}
+
+func (m *LabelNamesAndValuesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCount))
+	}
+	if len(m.LongValues) > 0 {
+		for _, e := range m.LongValues {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	l = len(m.PartitionKey)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.Dictionary) > 0 {
+		for _, s := range m.Dictionary {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.ValuesCompressionDictionaryId != 0 {
+		n += 1 + sovIngester(uint64(m.ValuesCompressionDictionaryId))
+	}
+	if m.Truncated {
+		n += 2
+	}
+	if m.LabelNamesTruncated {
+		n += 2
+	}
+	l = len(m.ExportObjectName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelValues) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.Values) > 0 {
+		for _, s := range m.Values {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Presence) > 0 {
+		l = 0
+		for _, e := range m.Presence {
+			l += sovIngester(uint64(e))
+		}
+		n += 1 + sovIngester(uint64(l)) + l
+	}
+	if len(m.ValueIds) > 0 {
+		l = 0
+		for _, e := range m.ValueIds {
+			l += sovIngester(uint64(e))
+		}
+		n += 1 + sovIngester(uint64(l)) + l
+	}
+	l = len(m.CompressedValues)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.ValueCount != 0 {
+		n += 1 + sovIngester(uint64(m.ValueCount))
+	}
+	if len(m.RelabelOutcomes) > 0 {
+		l = 0
+		for _, e := range m.RelabelOutcomes {
+			l += sovIngester(uint64(e))
+		}
+		n += 1 + sovIngester(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *LongLabelValues) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.Count != 0 {
+		n += 1 + sovIngester(uint64(m.Count))
+	}
+	if len(m.Exemplars) > 0 {
+		for _, s := range m.Exemplars {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for _, s := range m.LabelNames {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.GroupByMetricName {
+		n += 2
+	}
+	if m.IncludeChecksums {
+		n += 2
+	}
+	if m.ShardIndex != 0 {
+		n += 1 + sovIngester(uint64(m.ShardIndex))
+	}
+	if m.ShardCount != 0 {
+		n += 1 + sovIngester(uint64(m.ShardCount))
+	}
+	if m.EstimateLabelSeries {
+		n += 2
+	}
+	if m.IncludeChunkCount {
+		n += 2
+	}
+	if m.Explain {
+		n += 2
+	}
+	if m.ProgressIntervalMs != 0 {
+		n += 1 + sovIngester(uint64(m.ProgressIntervalMs))
+	}
+	if m.MinSeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.MinSeriesCount))
+	}
+	if m.AllLabels {
+		n += 2
+	}
+	l = len(m.ValueHashSalt)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.IncludeRatios {
+		n += 2
+	}
+	if m.SampleValues != 0 {
+		n += 1 + sovIngester(uint64(m.SampleValues))
+	}
+	if m.SampleSeed != 0 {
+		n += 2 + sovIngester(uint64(m.SampleSeed))
+	}
+	l = len(m.ValueGroupRegex)
+	if l > 0 {
+		n += 2 + l + sovIngester(uint64(l))
+	}
+	if m.CoOccurrenceTopK != 0 {
+		n += 2 + sovIngester(uint64(m.CoOccurrenceTopK))
+	}
+	if m.MetricNamesTopK != 0 {
+		n += 2 + sovIngester(uint64(m.MetricNamesTopK))
+	}
+	if m.OrderByLabelSeries {
+		n += 3
+	}
+	if len(m.SeriesCountPercentiles) > 0 {
+		n += 2 + sovIngester(uint64(len(m.SeriesCountPercentiles)*8)) + len(m.SeriesCountPercentiles)*8
+	}
+	if m.ContextCheckIntervalSeries != 0 {
+		n += 2 + sovIngester(uint64(m.ContextCheckIntervalSeries))
+	}
+	if m.SortLabelValues {
+		n += 3
+	}
+	if m.StartTimestampMs != 0 {
+		n += 2 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 2 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.BestEffort {
+		n += 3
+	}
+	if m.GroupByMagnitude {
+		n += 3
+	}
+	if m.IncludeSummary {
+		n += 3
+	}
+	if m.ResumeOffset != 0 {
+		n += 2 + sovIngester(uint64(m.ResumeOffset))
+	}
+	if m.ResumeChecksum != 0 {
+		n += 2 + sovIngester(uint64(m.ResumeChecksum))
+	}
+	if m.CompareStartTimestampMs != 0 {
+		n += 2 + sovIngester(uint64(m.CompareStartTimestampMs))
+	}
+	if m.CompareEndTimestampMs != 0 {
+		n += 2 + sovIngester(uint64(m.CompareEndTimestampMs))
+	}
+	if m.CompareGrowthRate {
+		n += 3
+	}
+	if m.ChangedLabelsOnly {
+		n += 3
+	}
+	l = len(m.ChangedLabelsState)
+	if l > 0 {
+		n += 2 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityStreamRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Request != nil {
+		l = m.Request.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.Stop {
+		n += 2
+	}
+	if m.WatchIntervalMs != 0 {
+		n += 1 + sovIngester(uint64(m.WatchIntervalMs))
+	}
+	if m.Pause {
+		n += 2
+	}
+	if m.Resume {
+		n += 2
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.BudgetWarning {
+		n += 2
+	}
+	if m.SequenceNumber != 0 {
+		n += 1 + sovIngester(uint64(m.SequenceNumber))
+	}
+	if m.ItemsChecksum != 0 {
+		n += 1 + sovIngester(uint64(m.ItemsChecksum))
+	}
+	if m.Stopped {
+		n += 2
+	}
+	if m.Explain != nil {
+		l = m.Explain.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.Progress != nil {
+		l = m.Progress.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.SampleSeed != 0 {
+		n += 1 + sovIngester(uint64(m.SampleSeed))
+	}
+	if m.FailedLabelValues != 0 {
+		n += 1 + sovIngester(uint64(m.FailedLabelValues))
+	}
+	if len(m.Errors) > 0 {
+		for _, s := range m.Errors {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Summaries) > 0 {
+		for _, e := range m.Summaries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.ByteOffset != 0 {
+		n += 1 + sovIngester(uint64(m.ByteOffset))
+	}
+	if m.RunningChecksum != 0 {
+		n += 1 + sovIngester(uint64(m.RunningChecksum))
+	}
+	l = len(m.InstanceId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.ChangedLabelsState)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalitySummary) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.LabelValuesCount != 0 {
+		n += 1 + sovIngester(uint64(m.LabelValuesCount))
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCount))
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityProgress) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LabelValues != 0 {
+		n += 1 + sovIngester(uint64(m.LabelValues))
+	}
+	if m.Series != 0 {
+		n += 1 + sovIngester(uint64(m.Series))
+	}
+	return n
+}
+
+func (m *LabelValuesCardinalityExplain) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.LabelValuesDurationNs != 0 {
+		n += 1 + sovIngester(uint64(m.LabelValuesDurationNs))
+	}
+	if m.CountingDurationNs != 0 {
+		n += 1 + sovIngester(uint64(m.CountingDurationNs))
+	}
+	if m.SendingDurationNs != 0 {
+		n += 1 + sovIngester(uint64(m.SendingDurationNs))
+	}
+	if m.CountingGoroutines != 0 {
+		n += 1 + sovIngester(uint64(m.CountingGoroutines))
+	}
+	if m.Goroutines != 0 {
+		n += 1 + sovIngester(uint64(m.Goroutines))
+	}
+	return n
+}
+
+func (m *LabelValueSeriesCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.LabelValueSeries) > 0 {
+		for k, v := range m.LabelValueSeries {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + sovIngester(uint64(v))
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	if len(m.LabelValueMetricNamesSeries) > 0 {
+		for k, v := range m.LabelValueMetricNamesSeries {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovIngester(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	if len(m.LabelValueSeriesDelta) > 0 {
+		for k, v := range m.LabelValueSeriesDelta {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + sovIngester(uint64(v))
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	if m.LabelSeriesEstimate != 0 {
+		n += 1 + sovIngester(uint64(m.LabelSeriesEstimate))
+	}
+	if m.LabelSeriesEstimateRelativeError != 0 {
+		n += 9
+	}
+	if len(m.LabelValueChunks) > 0 {
+		for k, v := range m.LabelValueChunks {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + sovIngester(uint64(v))
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	if len(m.LabelValueRatios) > 0 {
+		for k, v := range m.LabelValueRatios {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + 8
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	if len(m.LabelValueCoOccurrences) > 0 {
+		for k, v := range m.LabelValueCoOccurrences {
+			_ = k
+			_ = v
+			l = 0
+			if v != nil {
+				l = v.Size()
+				l += 1 + sovIngester(uint64(l))
+			}
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + l
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	if len(m.LabelValueSeriesGrowthRate) > 0 {
+		for k, v := range m.LabelValueSeriesGrowthRate {
+			_ = k
+			_ = v
+			mapEntrySize := 1 + len(k) + sovIngester(uint64(len(k))) + 1 + 8
+			n += mapEntrySize + 1 + sovIngester(uint64(mapEntrySize))
+		}
+	}
+	if len(m.SeriesCountPercentiles) > 0 {
+		for _, e := range m.SeriesCountPercentiles {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.SeriesCountMagnitude != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCountMagnitude))
+	}
+	return n
+}
+
+func (m *SeriesCountPercentile) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Percentile != 0 {
+		n += 9
+	}
+	if m.SeriesCount != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *LabelValueCoOccurrences) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValueCoOccurrence) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.LabelValue)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCount))
+	}
+	return n
+}
+
+func (m *MetricNamesSeriesCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Items) > 0 {
+		for _, e := range m.Items {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricNameSeriesCount) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.MetricName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.SeriesCount != 0 {
+		n += 1 + sovIngester(uint64(m.SeriesCount))
+	}
+	return n
+}
+
+func (m *ReadRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Queries) > 0 {
+		for _, e := range m.Queries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.AcceptedResponseTypes) > 0 {
+		l = 0
+		for _, e := range m.AcceptedResponseTypes {
+			l += sovIngester(uint64(e))
+		}
+		n += 1 + sovIngester(uint64(l)) + l
+	}
+	return n
+}
+
+func (m *ReadResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Results) > 0 {
+		for _, e := range m.Results {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StreamReadResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.ChunkedSeries) > 0 {
+		for _, e := range m.ChunkedSeries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if m.QueryIndex != 0 {
+		n += 1 + sovIngester(uint64(m.QueryIndex))
+	}
+	return n
+}
+
+func (m *StreamChunkedSeries) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Chunks) > 0 {
+		for _, e := range m.Chunks {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *StreamChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.MinTimeMs != 0 {
+		n += 1 + sovIngester(uint64(m.MinTimeMs))
+	}
+	if m.MaxTimeMs != 0 {
+		n += 1 + sovIngester(uint64(m.MaxTimeMs))
+	}
+	if m.Type != 0 {
+		n += 1 + sovIngester(uint64(m.Type))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *QueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExemplarQueryRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *QueryStreamResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Chunkseries) > 0 {
+		for _, e := range m.Chunkseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *ExemplarQueryResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Timeseries) > 0 {
+		for _, e := range m.Timeseries {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelValuesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.LabelName)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.Matchers != nil {
+		l = m.Matchers.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelValuesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelValues) > 0 {
+		for _, s := range m.LabelValues {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelNamesRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.Matchers != nil {
+		l = m.Matchers.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelNamesResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.LabelNames) > 0 {
+		for _, s := range m.LabelNames {
+			l = len(s)
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *UserStatsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *UserStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.IngestionRate != 0 {
+		n += 9
+	}
+	if m.NumSeries != 0 {
+		n += 1 + sovIngester(uint64(m.NumSeries))
+	}
+	if m.ApiIngestionRate != 0 {
+		n += 9
+	}
+	if m.RuleIngestionRate != 0 {
+		n += 9
+	}
+	return n
+}
+
+func (m *UserIDStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if m.Data != nil {
+		l = m.Data.Size()
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *UsersStatsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Stats) > 0 {
+		for _, e := range m.Stats {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricsForLabelMatchersRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if len(m.MatchersSet) > 0 {
+		for _, e := range m.MatchersSet {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricsForLabelMatchersResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Metric) > 0 {
+		for _, e := range m.Metric {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *MetricsMetadataRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *MetricsMetadataResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Metadata) > 0 {
+		for _, e := range m.Metadata {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *TimeSeriesChunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FromIngesterId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	if len(m.Labels) > 0 {
+		for _, e := range m.Labels {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	if len(m.Chunks) > 0 {
+		for _, e := range m.Chunks {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *Chunk) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.StartTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.StartTimestampMs))
+	}
+	if m.EndTimestampMs != 0 {
+		n += 1 + sovIngester(uint64(m.EndTimestampMs))
+	}
+	if m.Encoding != 0 {
+		n += 1 + sovIngester(uint64(m.Encoding))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *LabelMatchers) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Matchers) > 0 {
+		for _, e := range m.Matchers {
+			l = e.Size()
+			n += 1 + l + sovIngester(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *LabelMatcher) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Type != 0 {
+		n += 1 + sovIngester(uint64(m.Type))
+	}
+	l = len(m.Name)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.Value)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func (m *TimeSeriesFile) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.FromIngesterId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.UserId)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.Filename)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 1 + l + sovIngester(uint64(l))
+	}
+	return n
+}
+
+func sovIngester(x uint64) (n int) {
+	return (math_bits.Len64(x|1) + 6) / 7
+}
+func sozIngester(x uint64) (n int) {
+	return sovIngester(uint64((x << 1) ^ uint64((int64(x) >> 63))))
+}
+func (this *LabelNamesAndValuesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&LabelNamesAndValuesRequest{`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`IncludeSeriesCount:` + fmt.Sprintf("%v", this.IncludeSeriesCount) + `,`,
+		`Fields:` + fmt.Sprintf("%v", this.Fields) + `,`,
+		`LongValueLengthThreshold:` + fmt.Sprintf("%v", this.LongValueLengthThreshold) + `,`,
+		`PartitionByFirstCharacter:` + fmt.Sprintf("%v", this.PartitionByFirstCharacter) + `,`,
+		`IncludePresence:` + fmt.Sprintf("%v", this.IncludePresence) + `,`,
+		`ValuesBloomFilter:` + strings.Replace(this.ValuesBloomFilter.String(), "LabelValuesBloomFilter", "LabelValuesBloomFilter", 1) + `,`,
+		`MaxDistinctValues:` + fmt.Sprintf("%v", this.MaxDistinctValues) + `,`,
+		`UseValueIds:` + fmt.Sprintf("%v", this.UseValueIds) + `,`,
+		`CheckpointToken:` + fmt.Sprintf("%v", this.CheckpointToken) + `,`,
+		`ValuesPreviewSize:` + fmt.Sprintf("%v", this.ValuesPreviewSize) + `,`,
+		`ValuesCompressionDictionary:` + fmt.Sprintf("%v", this.ValuesCompressionDictionary) + `,`,
+		`IncludeValueCount:` + fmt.Sprintf("%v", this.IncludeValueCount) + `,`,
+		`MaxValues:` + fmt.Sprintf("%v", this.MaxValues) + `,`,
+		`IncludeBlocks:` + fmt.Sprintf("%v", this.IncludeBlocks) + `,`,
+		`DedupValues:` + fmt.Sprintf("%v", this.DedupValues) + `,`,
+		`IncludeRelabelOutcomes:` + fmt.Sprintf("%v", this.IncludeRelabelOutcomes) + `,`,
+		`ShardIndex:` + fmt.Sprintf("%v", this.ShardIndex) + `,`,
+		`ShardCount:` + fmt.Sprintf("%v", this.ShardCount) + `,`,
+		`ValuesCollation:` + fmt.Sprintf("%v", this.ValuesCollation) + `,`,
+		`Export:` + fmt.Sprintf("%v", this.Export) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesBloomFilter) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesBloomFilter{`,
+		`Bits:` + fmt.Sprintf("%v", this.Bits) + `,`,
+		`HashCount:` + fmt.Sprintf("%v", this.HashCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelNamesAndValuesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*LabelValues{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "LabelValues", "LabelValues", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	repeatedStringForLongValues := "[]*LongLabelValues{"
+	for _, f := range this.LongValues {
+		repeatedStringForLongValues += strings.Replace(f.String(), "LongLabelValues", "LongLabelValues", 1) + ","
+	}
+	repeatedStringForLongValues += "}"
+	s := strings.Join([]string{`&LabelNamesAndValuesResponse{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`LongValues:` + repeatedStringForLongValues + `,`,
+		`PartitionKey:` + fmt.Sprintf("%v", this.PartitionKey) + `,`,
+		`Dictionary:` + fmt.Sprintf("%v", this.Dictionary) + `,`,
+		`ValuesCompressionDictionaryId:` + fmt.Sprintf("%v", this.ValuesCompressionDictionaryId) + `,`,
+		`Truncated:` + fmt.Sprintf("%v", this.Truncated) + `,`,
+		`LabelNamesTruncated:` + fmt.Sprintf("%v", this.LabelNamesTruncated) + `,`,
+		`ExportObjectName:` + fmt.Sprintf("%v", this.ExportObjectName) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValues) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValues{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`Values:` + fmt.Sprintf("%v", this.Values) + `,`,
+		`Presence:` + fmt.Sprintf("%v", this.Presence) + `,`,
+		`ValueIds:` + fmt.Sprintf("%v", this.ValueIds) + `,`,
+		`CompressedValues:` + fmt.Sprintf("%v", this.CompressedValues) + `,`,
+		`ValueCount:` + fmt.Sprintf("%v", this.ValueCount) + `,`,
+		`RelabelOutcomes:` + fmt.Sprintf("%v", this.RelabelOutcomes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LongLabelValues) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LongLabelValues{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`Count:` + fmt.Sprintf("%v", this.Count) + `,`,
+		`Exemplars:` + fmt.Sprintf("%v", this.Exemplars) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&LabelValuesCardinalityRequest{`,
+		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`GroupByMetricName:` + fmt.Sprintf("%v", this.GroupByMetricName) + `,`,
+		`IncludeChecksums:` + fmt.Sprintf("%v", this.IncludeChecksums) + `,`,
+		`ShardIndex:` + fmt.Sprintf("%v", this.ShardIndex) + `,`,
+		`ShardCount:` + fmt.Sprintf("%v", this.ShardCount) + `,`,
+		`EstimateLabelSeries:` + fmt.Sprintf("%v", this.EstimateLabelSeries) + `,`,
+		`IncludeChunkCount:` + fmt.Sprintf("%v", this.IncludeChunkCount) + `,`,
+		`Explain:` + fmt.Sprintf("%v", this.Explain) + `,`,
+		`ProgressIntervalMs:` + fmt.Sprintf("%v", this.ProgressIntervalMs) + `,`,
+		`MinSeriesCount:` + fmt.Sprintf("%v", this.MinSeriesCount) + `,`,
+		`AllLabels:` + fmt.Sprintf("%v", this.AllLabels) + `,`,
+		`ValueHashSalt:` + fmt.Sprintf("%v", this.ValueHashSalt) + `,`,
+		`IncludeRatios:` + fmt.Sprintf("%v", this.IncludeRatios) + `,`,
+		`SampleValues:` + fmt.Sprintf("%v", this.SampleValues) + `,`,
+		`SampleSeed:` + fmt.Sprintf("%v", this.SampleSeed) + `,`,
+		`ValueGroupRegex:` + fmt.Sprintf("%v", this.ValueGroupRegex) + `,`,
+		`CoOccurrenceTopK:` + fmt.Sprintf("%v", this.CoOccurrenceTopK) + `,`,
+		`MetricNamesTopK:` + fmt.Sprintf("%v", this.MetricNamesTopK) + `,`,
+		`OrderByLabelSeries:` + fmt.Sprintf("%v", this.OrderByLabelSeries) + `,`,
+		`SeriesCountPercentiles:` + fmt.Sprintf("%v", this.SeriesCountPercentiles) + `,`,
+		`ContextCheckIntervalSeries:` + fmt.Sprintf("%v", this.ContextCheckIntervalSeries) + `,`,
+		`SortLabelValues:` + fmt.Sprintf("%v", this.SortLabelValues) + `,`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`BestEffort:` + fmt.Sprintf("%v", this.BestEffort) + `,`,
+		`GroupByMagnitude:` + fmt.Sprintf("%v", this.GroupByMagnitude) + `,`,
+		`IncludeSummary:` + fmt.Sprintf("%v", this.IncludeSummary) + `,`,
+		`ResumeOffset:` + fmt.Sprintf("%v", this.ResumeOffset) + `,`,
+		`ResumeChecksum:` + fmt.Sprintf("%v", this.ResumeChecksum) + `,`,
+		`CompareStartTimestampMs:` + fmt.Sprintf("%v", this.CompareStartTimestampMs) + `,`,
+		`CompareEndTimestampMs:` + fmt.Sprintf("%v", this.CompareEndTimestampMs) + `,`,
+		`CompareGrowthRate:` + fmt.Sprintf("%v", this.CompareGrowthRate) + `,`,
+		`ChangedLabelsOnly:` + fmt.Sprintf("%v", this.ChangedLabelsOnly) + `,`,
+		`ChangedLabelsState:` + fmt.Sprintf("%v", this.ChangedLabelsState) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityStreamRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesCardinalityStreamRequest{`,
+		`Request:` + strings.Replace(this.Request.String(), "LabelValuesCardinalityRequest", "LabelValuesCardinalityRequest", 1) + `,`,
+		`Stop:` + fmt.Sprintf("%v", this.Stop) + `,`,
+		`WatchIntervalMs:` + fmt.Sprintf("%v", this.WatchIntervalMs) + `,`,
+		`Pause:` + fmt.Sprintf("%v", this.Pause) + `,`,
+		`Resume:` + fmt.Sprintf("%v", this.Resume) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*LabelValueSeriesCount{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "LabelValueSeriesCount", "LabelValueSeriesCount", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	repeatedStringForSummaries := "[]*LabelValuesCardinalitySummary{"
+	for _, f := range this.Summaries {
+		repeatedStringForSummaries += strings.Replace(f.String(), "LabelValuesCardinalitySummary", "LabelValuesCardinalitySummary", 1) + ","
+	}
+	repeatedStringForSummaries += "}"
+	s := strings.Join([]string{`&LabelValuesCardinalityResponse{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`BudgetWarning:` + fmt.Sprintf("%v", this.BudgetWarning) + `,`,
+		`SequenceNumber:` + fmt.Sprintf("%v", this.SequenceNumber) + `,`,
+		`ItemsChecksum:` + fmt.Sprintf("%v", this.ItemsChecksum) + `,`,
+		`Stopped:` + fmt.Sprintf("%v", this.Stopped) + `,`,
+		`Explain:` + strings.Replace(this.Explain.String(), "LabelValuesCardinalityExplain", "LabelValuesCardinalityExplain", 1) + `,`,
+		`Progress:` + strings.Replace(this.Progress.String(), "LabelValuesCardinalityProgress", "LabelValuesCardinalityProgress", 1) + `,`,
+		`SampleSeed:` + fmt.Sprintf("%v", this.SampleSeed) + `,`,
+		`FailedLabelValues:` + fmt.Sprintf("%v", this.FailedLabelValues) + `,`,
+		`Errors:` + fmt.Sprintf("%v", this.Errors) + `,`,
+		`Summaries:` + repeatedStringForSummaries + `,`,
+		`ByteOffset:` + fmt.Sprintf("%v", this.ByteOffset) + `,`,
+		`RunningChecksum:` + fmt.Sprintf("%v", this.RunningChecksum) + `,`,
+		`InstanceId:` + fmt.Sprintf("%v", this.InstanceId) + `,`,
+		`ChangedLabelsState:` + fmt.Sprintf("%v", this.ChangedLabelsState) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalitySummary) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesCardinalitySummary{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`LabelValuesCount:` + fmt.Sprintf("%v", this.LabelValuesCount) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityProgress) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesCardinalityProgress{`,
+		`LabelValues:` + fmt.Sprintf("%v", this.LabelValues) + `,`,
+		`Series:` + fmt.Sprintf("%v", this.Series) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesCardinalityExplain) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesCardinalityExplain{`,
+		`LabelValuesDurationNs:` + fmt.Sprintf("%v", this.LabelValuesDurationNs) + `,`,
+		`CountingDurationNs:` + fmt.Sprintf("%v", this.CountingDurationNs) + `,`,
+		`SendingDurationNs:` + fmt.Sprintf("%v", this.SendingDurationNs) + `,`,
+		`CountingGoroutines:` + fmt.Sprintf("%v", this.CountingGoroutines) + `,`,
+		`Goroutines:` + fmt.Sprintf("%v", this.Goroutines) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValueSeriesCount) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForSeriesCountPercentiles := "[]*SeriesCountPercentile{"
+	for _, f := range this.SeriesCountPercentiles {
+		repeatedStringForSeriesCountPercentiles += strings.Replace(f.String(), "SeriesCountPercentile", "SeriesCountPercentile", 1) + ","
+	}
+	repeatedStringForSeriesCountPercentiles += "}"
+	keysForLabelValueSeries := make([]string, 0, len(this.LabelValueSeries))
+	for k, _ := range this.LabelValueSeries {
+		keysForLabelValueSeries = append(keysForLabelValueSeries, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeries)
+	mapStringForLabelValueSeries := "map[string]uint64{"
+	for _, k := range keysForLabelValueSeries {
+		mapStringForLabelValueSeries += fmt.Sprintf("%v: %v,", k, this.LabelValueSeries[k])
+	}
+	mapStringForLabelValueSeries += "}"
+	keysForLabelValueMetricNamesSeries := make([]string, 0, len(this.LabelValueMetricNamesSeries))
+	for k, _ := range this.LabelValueMetricNamesSeries {
+		keysForLabelValueMetricNamesSeries = append(keysForLabelValueMetricNamesSeries, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueMetricNamesSeries)
+	mapStringForLabelValueMetricNamesSeries := "map[string]*MetricNamesSeriesCount{"
+	for _, k := range keysForLabelValueMetricNamesSeries {
+		mapStringForLabelValueMetricNamesSeries += fmt.Sprintf("%v: %v,", k, this.LabelValueMetricNamesSeries[k])
+	}
+	mapStringForLabelValueMetricNamesSeries += "}"
+	keysForLabelValueSeriesDelta := make([]string, 0, len(this.LabelValueSeriesDelta))
+	for k, _ := range this.LabelValueSeriesDelta {
+		keysForLabelValueSeriesDelta = append(keysForLabelValueSeriesDelta, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeriesDelta)
+	mapStringForLabelValueSeriesDelta := "map[string]int64{"
+	for _, k := range keysForLabelValueSeriesDelta {
+		mapStringForLabelValueSeriesDelta += fmt.Sprintf("%v: %v,", k, this.LabelValueSeriesDelta[k])
+	}
+	mapStringForLabelValueSeriesDelta += "}"
+	keysForLabelValueChunks := make([]string, 0, len(this.LabelValueChunks))
+	for k, _ := range this.LabelValueChunks {
+		keysForLabelValueChunks = append(keysForLabelValueChunks, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueChunks)
+	mapStringForLabelValueChunks := "map[string]uint64{"
+	for _, k := range keysForLabelValueChunks {
+		mapStringForLabelValueChunks += fmt.Sprintf("%v: %v,", k, this.LabelValueChunks[k])
+	}
+	mapStringForLabelValueChunks += "}"
+	keysForLabelValueRatios := make([]string, 0, len(this.LabelValueRatios))
+	for k, _ := range this.LabelValueRatios {
+		keysForLabelValueRatios = append(keysForLabelValueRatios, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueRatios)
+	mapStringForLabelValueRatios := "map[string]float64{"
+	for _, k := range keysForLabelValueRatios {
+		mapStringForLabelValueRatios += fmt.Sprintf("%v: %v,", k, this.LabelValueRatios[k])
+	}
+	mapStringForLabelValueRatios += "}"
+	keysForLabelValueCoOccurrences := make([]string, 0, len(this.LabelValueCoOccurrences))
+	for k, _ := range this.LabelValueCoOccurrences {
+		keysForLabelValueCoOccurrences = append(keysForLabelValueCoOccurrences, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueCoOccurrences)
+	mapStringForLabelValueCoOccurrences := "map[string]*LabelValueCoOccurrences{"
+	for _, k := range keysForLabelValueCoOccurrences {
+		mapStringForLabelValueCoOccurrences += fmt.Sprintf("%v: %v,", k, this.LabelValueCoOccurrences[k])
+	}
+	mapStringForLabelValueCoOccurrences += "}"
+	keysForLabelValueSeriesGrowthRate := make([]string, 0, len(this.LabelValueSeriesGrowthRate))
+	for k, _ := range this.LabelValueSeriesGrowthRate {
+		keysForLabelValueSeriesGrowthRate = append(keysForLabelValueSeriesGrowthRate, k)
+	}
+	github_com_gogo_protobuf_sortkeys.Strings(keysForLabelValueSeriesGrowthRate)
+	mapStringForLabelValueSeriesGrowthRate := "map[string]float64{"
+	for _, k := range keysForLabelValueSeriesGrowthRate {
+		mapStringForLabelValueSeriesGrowthRate += fmt.Sprintf("%v: %v,", k, this.LabelValueSeriesGrowthRate[k])
+	}
+	mapStringForLabelValueSeriesGrowthRate += "}"
+	s := strings.Join([]string{`&LabelValueSeriesCount{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`LabelValueSeries:` + mapStringForLabelValueSeries + `,`,
+		`LabelValueMetricNamesSeries:` + mapStringForLabelValueMetricNamesSeries + `,`,
+		`LabelValueSeriesDelta:` + mapStringForLabelValueSeriesDelta + `,`,
+		`LabelSeriesEstimate:` + fmt.Sprintf("%v", this.LabelSeriesEstimate) + `,`,
+		`LabelSeriesEstimateRelativeError:` + fmt.Sprintf("%v", this.LabelSeriesEstimateRelativeError) + `,`,
+		`LabelValueChunks:` + mapStringForLabelValueChunks + `,`,
+		`LabelValueRatios:` + mapStringForLabelValueRatios + `,`,
+		`LabelValueCoOccurrences:` + mapStringForLabelValueCoOccurrences + `,`,
+		`LabelValueSeriesGrowthRate:` + mapStringForLabelValueSeriesGrowthRate + `,`,
+		`SeriesCountPercentiles:` + repeatedStringForSeriesCountPercentiles + `,`,
+		`SeriesCountMagnitude:` + fmt.Sprintf("%v", this.SeriesCountMagnitude) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *SeriesCountPercentile) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&SeriesCountPercentile{`,
+		`Percentile:` + fmt.Sprintf("%v", this.Percentile) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValueCoOccurrences) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*LabelValueCoOccurrence{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "LabelValueCoOccurrence", "LabelValueCoOccurrence", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&LabelValueCoOccurrences{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValueCoOccurrence) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValueCoOccurrence{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`LabelValue:` + fmt.Sprintf("%v", this.LabelValue) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricNamesSeriesCount) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForItems := "[]*MetricNameSeriesCount{"
+	for _, f := range this.Items {
+		repeatedStringForItems += strings.Replace(f.String(), "MetricNameSeriesCount", "MetricNameSeriesCount", 1) + ","
+	}
+	repeatedStringForItems += "}"
+	s := strings.Join([]string{`&MetricNamesSeriesCount{`,
+		`Items:` + repeatedStringForItems + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricNameSeriesCount) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MetricNameSeriesCount{`,
+		`MetricName:` + fmt.Sprintf("%v", this.MetricName) + `,`,
+		`SeriesCount:` + fmt.Sprintf("%v", this.SeriesCount) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForQueries := "[]*QueryRequest{"
+	for _, f := range this.Queries {
+		repeatedStringForQueries += strings.Replace(f.String(), "QueryRequest", "QueryRequest", 1) + ","
+	}
+	repeatedStringForQueries += "}"
+	s := strings.Join([]string{`&ReadRequest{`,
+		`Queries:` + repeatedStringForQueries + `,`,
+		`AcceptedResponseTypes:` + fmt.Sprintf("%v", this.AcceptedResponseTypes) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ReadResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForResults := "[]*QueryResponse{"
+	for _, f := range this.Results {
+		repeatedStringForResults += strings.Replace(f.String(), "QueryResponse", "QueryResponse", 1) + ","
+	}
+	repeatedStringForResults += "}"
+	s := strings.Join([]string{`&ReadResponse{`,
+		`Results:` + repeatedStringForResults + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StreamReadResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForChunkedSeries := "[]*StreamChunkedSeries{"
+	for _, f := range this.ChunkedSeries {
+		repeatedStringForChunkedSeries += strings.Replace(f.String(), "StreamChunkedSeries", "StreamChunkedSeries", 1) + ","
+	}
+	repeatedStringForChunkedSeries += "}"
+	s := strings.Join([]string{`&StreamReadResponse{`,
+		`ChunkedSeries:` + repeatedStringForChunkedSeries + `,`,
+		`QueryIndex:` + fmt.Sprintf("%v", this.QueryIndex) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StreamChunkedSeries) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForChunks := "[]StreamChunk{"
+	for _, f := range this.Chunks {
+		repeatedStringForChunks += strings.Replace(strings.Replace(f.String(), "StreamChunk", "StreamChunk", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForChunks += "}"
+	s := strings.Join([]string{`&StreamChunkedSeries{`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Chunks:` + repeatedStringForChunks + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *StreamChunk) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&StreamChunk{`,
+		`MinTimeMs:` + fmt.Sprintf("%v", this.MinTimeMs) + `,`,
+		`MaxTimeMs:` + fmt.Sprintf("%v", this.MaxTimeMs) + `,`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&QueryRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExemplarQueryRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatchers{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&ExemplarQueryRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&QueryResponse{`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *QueryStreamResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForChunkseries := "[]TimeSeriesChunk{"
+	for _, f := range this.Chunkseries {
+		repeatedStringForChunkseries += strings.Replace(strings.Replace(f.String(), "TimeSeriesChunk", "TimeSeriesChunk", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForChunkseries += "}"
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&QueryStreamResponse{`,
+		`Chunkseries:` + repeatedStringForChunkseries + `,`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *ExemplarQueryResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForTimeseries := "[]TimeSeries{"
+	for _, f := range this.Timeseries {
+		repeatedStringForTimeseries += fmt.Sprintf("%v", f) + ","
+	}
+	repeatedStringForTimeseries += "}"
+	s := strings.Join([]string{`&ExemplarQueryResponse{`,
+		`Timeseries:` + repeatedStringForTimeseries + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesRequest{`,
+		`LabelName:` + fmt.Sprintf("%v", this.LabelName) + `,`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelValuesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelValuesResponse{`,
+		`LabelValues:` + fmt.Sprintf("%v", this.LabelValues) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelNamesRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelNamesRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Matchers:` + strings.Replace(this.Matchers.String(), "LabelMatchers", "LabelMatchers", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelNamesResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelNamesResponse{`,
+		`LabelNames:` + fmt.Sprintf("%v", this.LabelNames) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UserStatsRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UserStatsRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UserStatsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UserStatsResponse{`,
+		`IngestionRate:` + fmt.Sprintf("%v", this.IngestionRate) + `,`,
+		`NumSeries:` + fmt.Sprintf("%v", this.NumSeries) + `,`,
+		`ApiIngestionRate:` + fmt.Sprintf("%v", this.ApiIngestionRate) + `,`,
+		`RuleIngestionRate:` + fmt.Sprintf("%v", this.RuleIngestionRate) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UserIDStatsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&UserIDStatsResponse{`,
+		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
+		`Data:` + strings.Replace(this.Data.String(), "UserStatsResponse", "UserStatsResponse", 1) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *UsersStatsResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForStats := "[]*UserIDStatsResponse{"
+	for _, f := range this.Stats {
+		repeatedStringForStats += strings.Replace(f.String(), "UserIDStatsResponse", "UserIDStatsResponse", 1) + ","
+	}
+	repeatedStringForStats += "}"
+	s := strings.Join([]string{`&UsersStatsResponse{`,
+		`Stats:` + repeatedStringForStats + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsForLabelMatchersRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchersSet := "[]*LabelMatchers{"
+	for _, f := range this.MatchersSet {
+		repeatedStringForMatchersSet += strings.Replace(f.String(), "LabelMatchers", "LabelMatchers", 1) + ","
+	}
+	repeatedStringForMatchersSet += "}"
+	s := strings.Join([]string{`&MetricsForLabelMatchersRequest{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`MatchersSet:` + repeatedStringForMatchersSet + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsForLabelMatchersResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetric := "[]*Metric{"
+	for _, f := range this.Metric {
+		repeatedStringForMetric += strings.Replace(fmt.Sprintf("%v", f), "Metric", "mimirpb.Metric", 1) + ","
+	}
+	repeatedStringForMetric += "}"
+	s := strings.Join([]string{`&MetricsForLabelMatchersResponse{`,
+		`Metric:` + repeatedStringForMetric + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsMetadataRequest) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&MetricsMetadataRequest{`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *MetricsMetadataResponse) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMetadata := "[]*MetricMetadata{"
+	for _, f := range this.Metadata {
+		repeatedStringForMetadata += strings.Replace(fmt.Sprintf("%v", f), "MetricMetadata", "mimirpb.MetricMetadata", 1) + ","
+	}
+	repeatedStringForMetadata += "}"
+	s := strings.Join([]string{`&MetricsMetadataResponse{`,
+		`Metadata:` + repeatedStringForMetadata + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TimeSeriesChunk) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForChunks := "[]Chunk{"
+	for _, f := range this.Chunks {
+		repeatedStringForChunks += strings.Replace(strings.Replace(f.String(), "Chunk", "Chunk", 1), `&`, ``, 1) + ","
+	}
+	repeatedStringForChunks += "}"
+	s := strings.Join([]string{`&TimeSeriesChunk{`,
+		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
+		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
+		`Labels:` + fmt.Sprintf("%v", this.Labels) + `,`,
+		`Chunks:` + repeatedStringForChunks + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *Chunk) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&Chunk{`,
+		`StartTimestampMs:` + fmt.Sprintf("%v", this.StartTimestampMs) + `,`,
+		`EndTimestampMs:` + fmt.Sprintf("%v", this.EndTimestampMs) + `,`,
+		`Encoding:` + fmt.Sprintf("%v", this.Encoding) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelMatchers) String() string {
+	if this == nil {
+		return "nil"
+	}
+	repeatedStringForMatchers := "[]*LabelMatcher{"
+	for _, f := range this.Matchers {
+		repeatedStringForMatchers += strings.Replace(f.String(), "LabelMatcher", "LabelMatcher", 1) + ","
+	}
+	repeatedStringForMatchers += "}"
+	s := strings.Join([]string{`&LabelMatchers{`,
+		`Matchers:` + repeatedStringForMatchers + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *LabelMatcher) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&LabelMatcher{`,
+		`Type:` + fmt.Sprintf("%v", this.Type) + `,`,
+		`Name:` + fmt.Sprintf("%v", this.Name) + `,`,
+		`Value:` + fmt.Sprintf("%v", this.Value) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func (this *TimeSeriesFile) String() string {
+	if this == nil {
+		return "nil"
+	}
+	s := strings.Join([]string{`&TimeSeriesFile{`,
+		`FromIngesterId:` + fmt.Sprintf("%v", this.FromIngesterId) + `,`,
+		`UserId:` + fmt.Sprintf("%v", this.UserId) + `,`,
+		`Filename:` + fmt.Sprintf("%v", this.Filename) + `,`,
+		`Data:` + fmt.Sprintf("%v", this.Data) + `,`,
+		`}`,
+	}, "")
+	return s
+}
+func valueToStringIngester(v interface{}) string {
+	rv := reflect.ValueOf(v)
+	if rv.IsNil() {
+		return "nil"
+	}
+	pv := reflect.Indirect(rv).Interface()
+	return fmt.Sprintf("*%v", pv)
+}
+func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelNamesAndValuesRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelNamesAndValuesRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = append(m.Matchers, &LabelMatcher{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeSeriesCount", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeSeriesCount = bool(v != 0)
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fields", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Fields = append(m.Fields, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LongValueLengthThreshold", wireType)
+			}
+			m.LongValueLengthThreshold = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LongValueLengthThreshold |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionByFirstCharacter", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.PartitionByFirstCharacter = bool(v != 0)
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludePresence", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludePresence = bool(v != 0)
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuesBloomFilter", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.ValuesBloomFilter == nil {
+				m.ValuesBloomFilter = &LabelValuesBloomFilter{}
+			}
+			if err := m.ValuesBloomFilter.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxDistinctValues", wireType)
+			}
+			m.MaxDistinctValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxDistinctValues |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field UseValueIds", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.UseValueIds = bool(v != 0)
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CheckpointToken", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CheckpointToken = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuesPreviewSize", wireType)
+			}
+			m.ValuesPreviewSize = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValuesPreviewSize |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuesCompressionDictionary", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValuesCompressionDictionary = append(m.ValuesCompressionDictionary[:0], dAtA[iNdEx:postIndex]...)
+			if m.ValuesCompressionDictionary == nil {
+				m.ValuesCompressionDictionary = []byte{}
+			}
+			iNdEx = postIndex
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeValueCount", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeValueCount = bool(v != 0)
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MaxValues", wireType)
+			}
+			m.MaxValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MaxValues |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeBlocks", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeBlocks = bool(v != 0)
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DedupValues", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.DedupValues = bool(v != 0)
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeRelabelOutcomes", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeRelabelOutcomes = bool(v != 0)
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardIndex", wireType)
+			}
+			m.ShardIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardCount", wireType)
+			}
+			m.ShardCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuesCollation", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValuesCollation = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 21:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Export", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Export = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesBloomFilter) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesBloomFilter: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesBloomFilter: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Bits", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Bits = append(m.Bits[:0], dAtA[iNdEx:postIndex]...)
+			if m.Bits == nil {
+				m.Bits = []byte{}
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field HashCount", wireType)
+			}
+			m.HashCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.HashCount |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelNamesAndValuesResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelNamesAndValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &LabelValues{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
+			}
+			m.SeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LongValues", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LongValues = append(m.LongValues, &LongLabelValues{})
+			if err := m.LongValues[len(m.LongValues)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field PartitionKey", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.PartitionKey = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Dictionary", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Dictionary = append(m.Dictionary, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValuesCompressionDictionaryId", wireType)
+			}
+			m.ValuesCompressionDictionaryId = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValuesCompressionDictionaryId |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Truncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Truncated = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNamesTruncated", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.LabelNamesTruncated = bool(v != 0)
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExportObjectName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ExportObjectName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValues) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValues: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValues: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 3:
+			if wireType == 0 {
+				var v LabelValuePresence
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= LabelValuePresence(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.Presence = append(m.Presence, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIngester
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIngester
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.Presence) == 0 {
+					m.Presence = make([]LabelValuePresence, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v LabelValuePresence
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= LabelValuePresence(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.Presence = append(m.Presence, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field Presence", wireType)
+			}
+		case 4:
+			if wireType == 0 {
+				var v uint32
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= uint32(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.ValueIds = append(m.ValueIds, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIngester
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIngester
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				var count int
+				for _, integer := range dAtA[iNdEx:postIndex] {
+					if integer < 128 {
+						count++
+					}
+				}
+				elementCount = count
+				if elementCount != 0 && len(m.ValueIds) == 0 {
+					m.ValueIds = make([]uint32, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint32
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= uint32(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.ValueIds = append(m.ValueIds, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueIds", wireType)
+			}
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompressedValues", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.CompressedValues = append(m.CompressedValues[:0], dAtA[iNdEx:postIndex]...)
+			if m.CompressedValues == nil {
+				m.CompressedValues = []byte{}
+			}
+			iNdEx = postIndex
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueCount", wireType)
+			}
+			m.ValueCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ValueCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType == 0 {
+				var v LabelValueRelabelOutcome
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					v |= LabelValueRelabelOutcome(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				m.RelabelOutcomes = append(m.RelabelOutcomes, v)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIngester
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIngester
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				if elementCount != 0 && len(m.RelabelOutcomes) == 0 {
+					m.RelabelOutcomes = make([]LabelValueRelabelOutcome, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v LabelValueRelabelOutcome
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						v |= LabelValueRelabelOutcome(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					m.RelabelOutcomes = append(m.RelabelOutcomes, v)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field RelabelOutcomes", wireType)
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LongLabelValues) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LongLabelValues: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LongLabelValues: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Count", wireType)
+			}
+			m.Count = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Count |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Exemplars", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Exemplars = append(m.Exemplars, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalityRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Matchers = append(m.Matchers, &LabelMatcher{})
+			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupByMetricName", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.GroupByMetricName = bool(v != 0)
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeChecksums", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeChecksums = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardIndex", wireType)
+			}
+			m.ShardIndex = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardIndex |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ShardCount", wireType)
+			}
+			m.ShardCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ShardCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 7:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EstimateLabelSeries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.EstimateLabelSeries = bool(v != 0)
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeChunkCount", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeChunkCount = bool(v != 0)
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Explain", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Explain = bool(v != 0)
+		case 10:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProgressIntervalMs", wireType)
+			}
+			m.ProgressIntervalMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ProgressIntervalMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 11:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MinSeriesCount", wireType)
+			}
+			m.MinSeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MinSeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AllLabels", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.AllLabels = bool(v != 0)
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueHashSalt", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueHashSalt = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 14:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeRatios", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeRatios = bool(v != 0)
+		case 15:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleValues", wireType)
+			}
+			m.SampleValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleValues |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 16:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleSeed", wireType)
+			}
+			m.SampleSeed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleSeed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 17:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ValueGroupRegex", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ValueGroupRegex = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CoOccurrenceTopK", wireType)
+			}
+			m.CoOccurrenceTopK = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CoOccurrenceTopK |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricNamesTopK", wireType)
+			}
+			m.MetricNamesTopK = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.MetricNamesTopK |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 20:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field OrderByLabelSeries", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.OrderByLabelSeries = bool(v != 0)
+		case 21:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.SeriesCountPercentiles = append(m.SeriesCountPercentiles, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthIngester
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthIngester
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.SeriesCountPercentiles) == 0 {
+					m.SeriesCountPercentiles = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.SeriesCountPercentiles = append(m.SeriesCountPercentiles, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCountPercentiles", wireType)
+			}
+		case 22:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ContextCheckIntervalSeries", wireType)
+			}
+			m.ContextCheckIntervalSeries = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ContextCheckIntervalSeries |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 23:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SortLabelValues", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.SortLabelValues = bool(v != 0)
+		case 24:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field StartTimestampMs", wireType)
+			}
+			m.StartTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.StartTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 25:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field EndTimestampMs", wireType)
+			}
+			m.EndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.EndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 26:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BestEffort", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BestEffort = bool(v != 0)
+		case 27:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field GroupByMagnitude", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.GroupByMagnitude = bool(v != 0)
+		case 28:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeSummary", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeSummary = bool(v != 0)
+		case 29:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeOffset", wireType)
+			}
+			m.ResumeOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResumeOffset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 30:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ResumeChecksum", wireType)
+			}
+			m.ResumeChecksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ResumeChecksum |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 32:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompareStartTimestampMs", wireType)
+			}
+			m.CompareStartTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompareStartTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 33:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompareEndTimestampMs", wireType)
+			}
+			m.CompareEndTimestampMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CompareEndTimestampMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 34:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CompareGrowthRate", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.CompareGrowthRate = bool(v != 0)
+		case 35:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangedLabelsOnly", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.ChangedLabelsOnly = bool(v != 0)
+		case 36:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangedLabelsState", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChangedLabelsState = append(m.ChangedLabelsState[:0], dAtA[iNdEx:postIndex]...)
+			if m.ChangedLabelsState == nil {
+				m.ChangedLabelsState = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalityStreamRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalityStreamRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalityStreamRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Request", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Request == nil {
+				m.Request = &LabelValuesCardinalityRequest{}
+			}
+			if err := m.Request.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stop", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stop = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field WatchIntervalMs", wireType)
+			}
+			m.WatchIntervalMs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.WatchIntervalMs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Pause", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Pause = bool(v != 0)
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Resume", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Resume = bool(v != 0)
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalityResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Items = append(m.Items, &LabelValueSeriesCount{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field BudgetWarning", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.BudgetWarning = bool(v != 0)
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SequenceNumber", wireType)
+			}
+			m.SequenceNumber = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SequenceNumber |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ItemsChecksum", wireType)
+			}
+			m.ItemsChecksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ItemsChecksum |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Stopped", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.Stopped = bool(v != 0)
+		case 6:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Explain", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Explain == nil {
+				m.Explain = &LabelValuesCardinalityExplain{}
+			}
+			if err := m.Explain.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Progress", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Progress == nil {
+				m.Progress = &LabelValuesCardinalityProgress{}
+			}
+			if err := m.Progress.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 8:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SampleSeed", wireType)
+			}
+			m.SampleSeed = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SampleSeed |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 9:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field FailedLabelValues", wireType)
+			}
+			m.FailedLabelValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.FailedLabelValues |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Errors", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Errors = append(m.Errors, string(dAtA[iNdEx:postIndex]))
+			iNdEx = postIndex
+		case 11:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Summaries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Summaries = append(m.Summaries, &LabelValuesCardinalitySummary{})
+			if err := m.Summaries[len(m.Summaries)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ByteOffset", wireType)
+			}
+			m.ByteOffset = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ByteOffset |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field RunningChecksum", wireType)
+			}
+			m.RunningChecksum = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.RunningChecksum |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 14:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field InstanceId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.InstanceId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 15:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChangedLabelsState", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChangedLabelsState = append(m.ChangedLabelsState[:0], dAtA[iNdEx:postIndex]...)
+			if m.ChangedLabelsState == nil {
+				m.ChangedLabelsState = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalitySummary) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalitySummary: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalitySummary: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValuesCount", wireType)
+			}
+			m.LabelValuesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LabelValuesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
+			}
+			m.SeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalityProgress) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalityProgress: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalityProgress: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValues", wireType)
+			}
+			m.LabelValues = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LabelValues |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Series", wireType)
+			}
+			m.Series = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Series |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValuesCardinalityExplain) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValuesCardinalityExplain: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValuesCardinalityExplain: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValuesDurationNs", wireType)
+			}
+			m.LabelValuesDurationNs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LabelValuesDurationNs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CountingDurationNs", wireType)
+			}
+			m.CountingDurationNs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CountingDurationNs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SendingDurationNs", wireType)
+			}
+			m.SendingDurationNs = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SendingDurationNs |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field CountingGoroutines", wireType)
+			}
+			m.CountingGoroutines = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.CountingGoroutines |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Goroutines", wireType)
+			}
+			m.Goroutines = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Goroutines |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipIngester(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if skippy < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowIngester
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: LabelValueSeriesCount: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: LabelValueSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.LabelName = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueSeries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueSeries == nil {
+				m.LabelValueSeries = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueSeries[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueMetricNamesSeries", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueMetricNamesSeries == nil {
+				m.LabelValueMetricNamesSeries = make(map[string]*MetricNamesSeriesCount)
+			}
+			var mapkey string
+			var mapvalue *MetricNamesSeriesCount
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &MetricNamesSeriesCount{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueMetricNamesSeries[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueSeriesDelta", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueSeriesDelta == nil {
+				m.LabelValueSeriesDelta = make(map[string]int64)
+			}
+			var mapkey string
+			var mapvalue int64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= int64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueSeriesDelta[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 5:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelSeriesEstimate", wireType)
+			}
+			m.LabelSeriesEstimate = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.LabelSeriesEstimate |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 6:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelSeriesEstimateRelativeError", wireType)
+			}
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
+			}
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.LabelSeriesEstimateRelativeError = float64(math.Float64frombits(v))
+		case 7:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueChunks", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueChunks == nil {
+				m.LabelValueChunks = make(map[string]uint64)
+			}
+			var mapkey string
+			var mapvalue uint64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapvalue |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueChunks[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 8:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueRatios", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueRatios == nil {
+				m.LabelValueRatios = make(map[string]float64)
+			}
+			var mapkey string
+			var mapvalue float64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapvaluetemp uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvaluetemp = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					mapvalue = math.Float64frombits(mapvaluetemp)
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueRatios[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 9:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueCoOccurrences", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueCoOccurrences == nil {
+				m.LabelValueCoOccurrences = make(map[string]*LabelValueCoOccurrences)
+			}
+			var mapkey string
+			var mapvalue *LabelValueCoOccurrences
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapmsglen int
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						mapmsglen |= int(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					if mapmsglen < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postmsgIndex := iNdEx + mapmsglen
+					if postmsgIndex < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postmsgIndex > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvalue = &LabelValueCoOccurrences{}
+					if err := mapvalue.Unmarshal(dAtA[iNdEx:postmsgIndex]); err != nil {
+						return err
+					}
+					iNdEx = postmsgIndex
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueCoOccurrences[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 10:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueSeriesGrowthRate", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthIngester
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthIngester
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.LabelValueSeriesGrowthRate == nil {
+				m.LabelValueSeriesGrowthRate = make(map[string]float64)
+			}
+			var mapkey string
+			var mapvalue float64
+			for iNdEx < postIndex {
+				entryPreIndex := iNdEx
+				var wire uint64
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowIngester
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					wire |= uint64(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				fieldNum := int32(wire >> 3)
+				if fieldNum == 1 {
+					var stringLenmapkey uint64
+					for shift := uint(0); ; shift += 7 {
+						if shift >= 64 {
+							return ErrIntOverflowIngester
+						}
+						if iNdEx >= l {
+							return io.ErrUnexpectedEOF
+						}
+						b := dAtA[iNdEx]
+						iNdEx++
+						stringLenmapkey |= uint64(b&0x7F) << shift
+						if b < 0x80 {
+							break
+						}
+					}
+					intStringLenmapkey := int(stringLenmapkey)
+					if intStringLenmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					postStringIndexmapkey := iNdEx + intStringLenmapkey
+					if postStringIndexmapkey < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if postStringIndexmapkey > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
+					iNdEx = postStringIndexmapkey
+				} else if fieldNum == 2 {
+					var mapvaluetemp uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					mapvaluetemp = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					mapvalue = math.Float64frombits(mapvaluetemp)
+				} else {
+					iNdEx = entryPreIndex
+					skippy, err := skipIngester(dAtA[iNdEx:])
+					if err != nil {
+						return err
+					}
+					if skippy < 0 {
+						return ErrInvalidLengthIngester
+					}
+					if (iNdEx + skippy) > postIndex {
+						return io.ErrUnexpectedEOF
+					}
+					iNdEx += skippy
+				}
+			}
+			m.LabelValueSeriesGrowthRate[mapkey] = mapvalue
+			iNdEx = postIndex
+		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCountPercentiles", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -6519,11 +15683,30 @@ func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Matchers = append(m.Matchers, &LabelMatcher{})
-			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.SeriesCountPercentiles = append(m.SeriesCountPercentiles, &SeriesCountPercentile{})
+			if err := m.SeriesCountPercentiles[len(m.SeriesCountPercentiles)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCountMagnitude", wireType)
+			}
+			m.SeriesCountMagnitude = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCountMagnitude |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -6548,7 +15731,7 @@ func (m *LabelNamesAndValuesRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
+func (m *SeriesCountPercentile) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6571,46 +15754,34 @@ func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelNamesAndValuesResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SeriesCountPercentile: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelNamesAndValuesResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SeriesCountPercentile: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIngester
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Percentile", wireType)
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthIngester
+			var v uint64
+			if (iNdEx + 8) > l {
+				return io.ErrUnexpectedEOF
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngester
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.Percentile = float64(math.Float64frombits(v))
+		case 2:
+			if wireType != 1 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
 			}
-			if postIndex > l {
+			var v uint64
+			if (iNdEx + 8) > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, &LabelValues{})
-			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
+			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+			iNdEx += 8
+			m.SeriesCount = float64(math.Float64frombits(v))
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -6635,7 +15806,7 @@ func (m *LabelNamesAndValuesResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValues) Unmarshal(dAtA []byte) error {
+func (m *LabelValueCoOccurrences) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6658,17 +15829,17 @@ func (m *LabelValues) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValues: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValueCoOccurrences: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValues: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValueCoOccurrences: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Items", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -6678,55 +15849,25 @@ func (m *LabelValues) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthIngester
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngester
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelName = string(dAtA[iNdEx:postIndex])
-			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Values", wireType)
-			}
-			var stringLen uint64
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowIngester
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
-				return ErrInvalidLengthIngester
-			}
-			postIndex := iNdEx + intStringLen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
+			m.Items = append(m.Items, &LabelValueCoOccurrence{})
+			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Values = append(m.Values, string(dAtA[iNdEx:postIndex]))
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -6752,7 +15893,7 @@ func (m *LabelValues) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
+func (m *LabelValueCoOccurrence) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6775,15 +15916,15 @@ func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesCardinalityRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: LabelValueCoOccurrence: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesCardinalityRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: LabelValueCoOccurrence: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelNames", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -6811,13 +15952,13 @@ func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelNames = append(m.LabelNames, string(dAtA[iNdEx:postIndex]))
+			m.LabelName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Matchers", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field LabelValue", wireType)
 			}
-			var msglen int
+			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -6827,26 +15968,43 @@ func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				stringLen |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
 				return ErrInvalidLengthIngester
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + intStringLen
 			if postIndex < 0 {
 				return ErrInvalidLengthIngester
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Matchers = append(m.Matchers, &LabelMatcher{})
-			if err := m.Matchers[len(m.Matchers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.LabelValue = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
+			}
+			m.SeriesCount = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowIngester
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.SeriesCount |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -6871,7 +16029,7 @@ func (m *LabelValuesCardinalityRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
+func (m *MetricNamesSeriesCount) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6894,10 +16052,10 @@ func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValuesCardinalityResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: MetricNamesSeriesCount: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValuesCardinalityResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MetricNamesSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -6929,7 +16087,7 @@ func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Items = append(m.Items, &LabelValueSeriesCount{})
+			m.Items = append(m.Items, &MetricNameSeriesCount{})
 			if err := m.Items[len(m.Items)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
@@ -6958,7 +16116,7 @@ func (m *LabelValuesCardinalityResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
+func (m *MetricNameSeriesCount) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -6981,15 +16139,15 @@ func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: LabelValueSeriesCount: wiretype end group for non-group")
+			return fmt.Errorf("proto: MetricNameSeriesCount: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: LabelValueSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: MetricNameSeriesCount: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelName", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field MetricName", wireType)
 			}
 			var stringLen uint64
 			for shift := uint(0); ; shift += 7 {
@@ -7017,13 +16175,13 @@ func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.LabelName = string(dAtA[iNdEx:postIndex])
+			m.MetricName = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field LabelValueSeries", wireType)
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SeriesCount", wireType)
 			}
-			var msglen int
+			m.SeriesCount = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowIngester
@@ -7033,105 +16191,11 @@ func (m *LabelValueSeriesCount) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				m.SeriesCount |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
-				return ErrInvalidLengthIngester
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthIngester
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.LabelValueSeries == nil {
-				m.LabelValueSeries = make(map[string]uint64)
-			}
-			var mapkey string
-			var mapvalue uint64
-			for iNdEx < postIndex {
-				entryPreIndex := iNdEx
-				var wire uint64
-				for shift := uint(0); ; shift += 7 {
-					if shift >= 64 {
-						return ErrIntOverflowIngester
-					}
-					if iNdEx >= l {
-						return io.ErrUnexpectedEOF
-					}
-					b := dAtA[iNdEx]
-					iNdEx++
-					wire |= uint64(b&0x7F) << shift
-					if b < 0x80 {
-						break
-					}
-				}
-				fieldNum := int32(wire >> 3)
-				if fieldNum == 1 {
-					var stringLenmapkey uint64
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowIngester
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						stringLenmapkey |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-					intStringLenmapkey := int(stringLenmapkey)
-					if intStringLenmapkey < 0 {
-						return ErrInvalidLengthIngester
-					}
-					postStringIndexmapkey := iNdEx + intStringLenmapkey
-					if postStringIndexmapkey < 0 {
-						return ErrInvalidLengthIngester
-					}
-					if postStringIndexmapkey > l {
-						return io.ErrUnexpectedEOF
-					}
-					mapkey = string(dAtA[iNdEx:postStringIndexmapkey])
-					iNdEx = postStringIndexmapkey
-				} else if fieldNum == 2 {
-					for shift := uint(0); ; shift += 7 {
-						if shift >= 64 {
-							return ErrIntOverflowIngester
-						}
-						if iNdEx >= l {
-							return io.ErrUnexpectedEOF
-						}
-						b := dAtA[iNdEx]
-						iNdEx++
-						mapvalue |= uint64(b&0x7F) << shift
-						if b < 0x80 {
-							break
-						}
-					}
-				} else {
-					iNdEx = entryPreIndex
-					skippy, err := skipIngester(dAtA[iNdEx:])
-					if err != nil {
-						return err
-					}
-					if skippy < 0 {
-						return ErrInvalidLengthIngester
-					}
-					if (iNdEx + skippy) > postIndex {
-						return io.ErrUnexpectedEOF
-					}
-					iNdEx += skippy
-				}
-			}
-			m.LabelValueSeries[mapkey] = mapvalue
-			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipIngester(dAtA[iNdEx:])
@@ -76,3 +76,8 @@ func (m *IngesterServerMock) LabelValuesCardinality(req *LabelValuesCardinalityR
 	args := m.Called(req, srv)
 	return args.Error(0)
 }
+
+func (m *IngesterServerMock) LabelValuesCardinalityStream(srv Ingester_LabelValuesCardinalityStreamServer) error {
+	args := m.Called(srv)
+	return args.Error(0)
+}
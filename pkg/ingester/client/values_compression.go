@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package client
+
+import (
+	"bufio"
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// MaxLabelValuesCompressionDictionaryBytes is the maximum size of a label values compression dictionary.
+// DEFLATE can't refer to data further back than its 32KiB window, so the rest of a bigger dictionary would be unused.
+const MaxLabelValuesCompressionDictionaryBytes = 32 * 1024
+
+// NewLabelValuesCompressionDictionary returns a dictionary trained on the sample label values, to compress
+// the label values with CompressLabelValues. The most frequent samples are placed at the end of the dictionary,
+// where they're the cheapest to refer to, and the least frequent ones are dropped if the dictionary is too big.
+func NewLabelValuesCompressionDictionary(samples []string) []byte {
+	counts := map[string]int{}
+	for _, sample := range samples {
+		counts[sample]++
+	}
+	values := make([]string, 0, len(counts))
+	for value := range counts {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		if counts[values[i]] != counts[values[j]] {
+			return counts[values[i]] < counts[values[j]]
+		}
+		return values[i] < values[j]
+	})
+
+	// The values are encoded like in the compressed data, so that the dictionary also matches their lengths.
+	var dict []byte
+	for _, value := range values {
+		dict = appendLabelValue(dict, value)
+	}
+	if len(dict) > MaxLabelValuesCompressionDictionaryBytes {
+		dict = dict[len(dict)-MaxLabelValuesCompressionDictionaryBytes:]
+	}
+	return dict
+}
+
+// ValidateLabelValuesCompressionDictionary returns an error if the dictionary can't be used, for example because
+// it has been received from a client.
+func ValidateLabelValuesCompressionDictionary(dict []byte) error {
+	if len(dict) > MaxLabelValuesCompressionDictionaryBytes {
+		return fmt.Errorf("the label values compression dictionary must be at most %d bytes, got %d", MaxLabelValuesCompressionDictionaryBytes, len(dict))
+	}
+	return nil
+}
+
+// LabelValuesCompressionDictionaryID returns the ID of the dictionary, which is its CRC32 (IEEE).
+func LabelValuesCompressionDictionaryID(dict []byte) uint32 {
+	return crc32.ChecksumIEEE(dict)
+}
+
+// CompressLabelValues compresses the label values with DEFLATE, using the dictionary as preset dictionary.
+// The dictionary can be empty. Each value is prefixed by its length, as an unsigned varint.
+func CompressLabelValues(dict []byte, values []string) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriterDict(&buf, flate.BestCompression, dict)
+	if err != nil {
+		return nil, err
+	}
+	var encoded []byte
+	for _, value := range values {
+		encoded = appendLabelValue(encoded[:0], value)
+		if _, err := w.Write(encoded); err != nil {
+			return nil, err
+		}
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// DecompressLabelValues decompresses the label values compressed by CompressLabelValues with the same dictionary.
+func DecompressLabelValues(dict []byte, data []byte) ([]string, error) {
+	r := bufio.NewReader(flate.NewReaderDict(bytes.NewReader(data), dict))
+	var values []string
+	for {
+		length, err := binary.ReadUvarint(r)
+		if err == io.EOF {
+			return values, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress the label values: %w", err)
+		}
+		value := make([]byte, length)
+		if _, err := io.ReadFull(r, value); err != nil {
+			return nil, fmt.Errorf("failed to decompress the label values: %w", err)
+		}
+		values = append(values, string(value))
+	}
+}
+
+// appendLabelValue appends the value prefixed by its length to the buffer.
+func appendLabelValue(buf []byte, value string) []byte {
+	var length [binary.MaxVarintLen64]byte
+	buf = append(buf, length[:binary.PutUvarint(length[:], uint64(len(value)))]...)
+	return append(buf, value...)
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// emptyResultCacheMaxEntries is the maximum number of requests tracked by an emptyResultCache.
+const emptyResultCacheMaxEntries = 10000
+
+// emptyResultCache remembers for a short time the requests which returned an empty result, so that the clients
+// retrying them, for example because of a typo in a matcher, don't recompute them over and over.
+// A nil emptyResultCache never contains any request.
+type emptyResultCache struct {
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mtx sync.Mutex
+	// expiries holds the time at which each cached request expires.
+	expiries map[string]time.Time
+}
+
+func newEmptyResultCache(ttl time.Duration) *emptyResultCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &emptyResultCache{
+		ttl:        ttl,
+		maxEntries: emptyResultCacheMaxEntries,
+		now:        time.Now,
+		expiries:   map[string]time.Time{},
+	}
+}
+
+// contains returns whether the request has returned an empty result less than the TTL ago.
+func (c *emptyResultCache) contains(key string) bool {
+	if c == nil {
+		return false
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	expiry, ok := c.expiries[key]
+	if !ok {
+		return false
+	}
+	if !c.now().Before(expiry) {
+		delete(c.expiries, key)
+		return false
+	}
+	return true
+}
+
+// add records that the request has returned an empty result. If the cache is full, the expired requests are
+// evicted first, and the request isn't cached if there's still no room for it.
+func (c *emptyResultCache) add(key string) {
+	if c == nil {
+		return
+	}
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	now := c.now()
+	if _, ok := c.expiries[key]; !ok && len(c.expiries) >= c.maxEntries {
+		for k, expiry := range c.expiries {
+			if !now.Before(expiry) {
+				delete(c.expiries, k)
+			}
+		}
+		if len(c.expiries) >= c.maxEntries {
+			return
+		}
+	}
+	c.expiries[key] = now.Add(c.ttl)
+}
+
+// emptyResultLabelValuesCardinalityServer is a client.Ingester_LabelValuesCardinalityServer which tracks whether
+// the result is empty: no items have been sent, and the request hasn't been stopped before completing.
+type emptyResultLabelValuesCardinalityServer struct {
+	client.Ingester_LabelValuesCardinalityServer
+	notEmpty atomic.Bool
+}
+
+func (s *emptyResultLabelValuesCardinalityServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	if len(resp.Items) > 0 || resp.Stopped {
+		s.notEmpty.Store(true)
+	}
+	return s.Ingester_LabelValuesCardinalityServer.Send(resp)
+}
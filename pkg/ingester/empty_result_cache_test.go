@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmptyResultCache(t *testing.T) {
+	now := time.Now()
+	c := newEmptyResultCache(time.Minute)
+	c.now = func() time.Time { return now }
+
+	require.False(t, c.contains("a"))
+	c.add("a")
+	require.True(t, c.contains("a"))
+	require.False(t, c.contains("b"))
+
+	now = now.Add(59 * time.Second)
+	require.True(t, c.contains("a"))
+
+	now = now.Add(time.Second)
+	require.False(t, c.contains("a"))
+	require.Empty(t, c.expiries)
+}
+
+func TestEmptyResultCache_MaxEntries(t *testing.T) {
+	now := time.Now()
+	c := newEmptyResultCache(time.Minute)
+	c.now = func() time.Time { return now }
+	c.maxEntries = 2
+
+	c.add("a")
+	now = now.Add(30 * time.Second)
+	c.add("b")
+
+	// The cache is full, so the request isn't cached.
+	c.add("c")
+	require.False(t, c.contains("c"))
+
+	// Once a request has expired, there's room for a new one.
+	now = now.Add(30 * time.Second)
+	c.add("c")
+	require.True(t, c.contains("b"))
+	require.True(t, c.contains("c"))
+	require.False(t, c.contains("a"))
+}
+
+func TestEmptyResultCache_Disabled(t *testing.T) {
+	c := newEmptyResultCache(0)
+	require.Nil(t, c)
+
+	c.add("a")
+	require.False(t, c.contains("a"))
+}
@@ -14,6 +14,7 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -149,6 +150,15 @@ type Config struct {
 
 	IgnoreSeriesLimitForMetricNames string `yaml:"ignore_series_limit_for_metric_names" category:"advanced"`
 
+	LabelNamesAndValuesMessageSizeBytes    int `yaml:"label_names_and_values_message_size_bytes" category:"advanced"`
+	LabelValuesCardinalityMessageSizeBytes int `yaml:"label_values_cardinality_message_size_bytes" category:"advanced"`
+
+	LabelNamesAndValues    LabelNamesAndValuesConfig    `yaml:",inline"`
+	LabelValuesCardinality LabelValuesCardinalityConfig `yaml:",inline"`
+
+	LabelIndexReadMaxConcurrency            int  `yaml:"label_index_read_max_concurrency" category:"experimental"`
+	LabelRequestsRejectComplexRegexMatchers bool `yaml:"label_requests_reject_complex_regex_matchers" category:"experimental"`
+
 	// For testing, you can override the address and ID of this ingester.
 	ingesterClientFactory func(addr string, cfg client.Config) (client.HealthAndIngesterClient, error)
 }
@@ -170,6 +180,20 @@ func (cfg *Config) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	cfg.DefaultLimits.RegisterFlags(f)
 
 	f.StringVar(&cfg.IgnoreSeriesLimitForMetricNames, "ingester.ignore-series-limit-for-metric-names", "", "Comma-separated list of metric names, for which the -ingester.max-global-series-per-metric limit will be ignored. Does not affect the -ingester.max-global-series-per-user limit.")
+
+	// We default to 1 MB because the default limit for a gRPC message is 4 MB.
+	// So, 1 MB limit will prevent reaching the limit and won't affect performance significantly.
+	f.IntVar(&cfg.LabelNamesAndValuesMessageSizeBytes, "ingester.label-names-and-values-message-size-bytes", 1*1024*1024, "Size in bytes at which a message of the streamed label names and values response is sent to the querier. It should be kept below the gRPC max message size.")
+	f.IntVar(&cfg.LabelValuesCardinalityMessageSizeBytes, "ingester.label-values-cardinality-message-size-bytes", 1*1024*1024, "Size in bytes at which a message of the streamed label values cardinality response is sent to the querier. It should be kept below the gRPC max message size.")
+	cfg.LabelNamesAndValues.RegisterFlags(f)
+	cfg.LabelValuesCardinality.RegisterFlags(f)
+	f.IntVar(&cfg.LabelIndexReadMaxConcurrency, "ingester.label-index-read-max-concurrency", 0, "Maximum number of workers reading the index concurrently across all the label names and values requests and label values cardinality requests, including the workers prefetching the label values and counting their series, so that the requests running at the same time don't oversubscribe the CPU. 0 = unlimited.")
+	f.BoolVar(&cfg.LabelRequestsRejectComplexRegexMatchers, "ingester.label-requests-reject-complex-regex-matchers", false, fmt.Sprintf("Reject the label names and values requests and the label values cardinality requests with a regex matcher nesting unbounded quantifiers, such as (a+)*, or compiling to more than %d instructions.", maxRegexMatcherInstructions))
+}
+
+// Validate the config.
+func (cfg *Config) Validate() error {
+	return cfg.LabelValuesCardinality.Validate()
 }
 
 func (cfg *Config) getIgnoreSeriesLimitForMetricNamesMap() map[string]struct{} {
@@ -227,6 +251,21 @@ type Ingester struct {
 	// Maps the per-block series ID with its labels hash.
 	seriesHashCache *hashcache.SeriesHashCache
 
+	// Caches the label values cardinality requests which returned an empty result.
+	labelValuesCardinalityEmptyResults *emptyResultCache
+	labelValuesCardinalityProfiles     *labelValuesCardinalityProfiles
+
+	// Reports whether the ingester is under high load, in which case the label values cardinality requests
+	// count the series serially. Nil if disabled.
+	labelValuesCardinalityHighLoad func() bool
+
+	// Tracks the in-flight streaming label requests of each tenant, so that they can be cancelled at once.
+	labelStreams *labelStreamRegistry
+
+	// Bounds the workers reading the index for the label names and values and the label values cardinality
+	// requests. Nil if unbounded.
+	labelIndexReadPool *labelIndexReadPool
+
 	// Timeout chosen for idle compactions.
 	compactionIdleTimeout time.Duration
 
@@ -278,6 +317,12 @@ func newIngester(cfg Config, limits *validation.Overrides, registerer prometheus
 		shipTrigger:         make(chan requestWithUsersAndCallback),
 		seriesHashCache:     hashcache.NewSeriesHashCache(cfg.BlocksStorageConfig.TSDB.SeriesHashCacheMaxBytes),
 
+		labelValuesCardinalityEmptyResults: newEmptyResultCache(cfg.LabelValuesCardinality.EmptyResultCacheTTL),
+		labelValuesCardinalityProfiles:     newLabelValuesCardinalityProfiles(cfg.LabelValuesCardinality.Profile.Dir, cfg.LabelValuesCardinality.Profile.MaxFiles, logger),
+		labelValuesCardinalityHighLoad:     heapObjectsAbove(cfg.LabelValuesCardinality.Concurrency.SerialCountingHeapBytes),
+		labelStreams:                       newLabelStreamRegistry(),
+		labelIndexReadPool:                 newLabelIndexReadPool(cfg.LabelIndexReadMaxConcurrency),
+
 		memorySeriesStats:                  usagestats.GetAndResetInt(memorySeriesStatsName),
 		memoryTenantsStats:                 usagestats.GetAndResetInt(memoryTenantsStatsName),
 		appendedSamplesStats:               usagestats.GetAndResetCounter(appendedSamplesStatsName),
@@ -419,6 +464,7 @@ func (i *Ingester) stoppingForFlusher(_ error) error {
 	if !i.cfg.BlocksStorageConfig.TSDB.KeepUserTSDBOpenOnShutdown {
 		i.closeAllTSDB()
 	}
+	i.labelValuesCardinalityProfiles.removeAll()
 	return nil
 }
 
@@ -439,6 +485,7 @@ func (i *Ingester) stopping(_ error) error {
 	if !i.cfg.BlocksStorageConfig.TSDB.KeepUserTSDBOpenOnShutdown {
 		i.closeAllTSDB()
 	}
+	i.labelValuesCardinalityProfiles.removeAll()
 	return nil
 }
 
@@ -1164,10 +1211,6 @@ func (i *Ingester) AllUserStats(ctx context.Context, req *client.UserStatsReques
 	return response, nil
 }
 
-// we defined to use the limit of 1 MB because we have default limit for the GRPC message that is 4 MB.
-// So, 1 MB limit will prevent reaching the limit and won't affect performance significantly.
-const labelNamesAndValuesTargetSizeBytes = 1 * 1024 * 1024
-
 func (i *Ingester) LabelNamesAndValues(request *client.LabelNamesAndValuesRequest, server client.Ingester_LabelNamesAndValuesServer) error {
 	if err := i.checkRunning(); err != nil {
 		return err
@@ -1176,6 +1219,10 @@ func (i *Ingester) LabelNamesAndValues(request *client.LabelNamesAndValuesReques
 	if err != nil {
 		return err
 	}
+	reg := i.labelStreams.register(server.Context(), userID)
+	defer i.labelStreams.unregister(userID, reg)
+	server = &labelNamesAndValuesServerWithContext{Ingester_LabelNamesAndValuesServer: server, ctx: reg.ctx}
+
 	db := i.getTSDB(userID)
 	if db == nil {
 		return nil
@@ -1184,19 +1231,271 @@ func (i *Ingester) LabelNamesAndValues(request *client.LabelNamesAndValuesReques
 	if err != nil {
 		return err
 	}
-	defer index.Close()
+	// The label names lookup may keep reading the indexes after the request returned because it was canceled.
+	lookups := &backgroundLookups{}
+	defer lookups.closeWhenDone(func() { _ = index.Close() })
 	matchers, err := client.FromLabelMatchers(request.GetMatchers())
 	if err != nil {
 		return err
 	}
-	return labelNamesAndValues(index, matchers, labelNamesAndValuesTargetSizeBytes, server)
+	omitValues, err := parseLabelNamesAndValuesFields(request.GetFields())
+	if err != nil {
+		return err
+	}
+	opts := labelNamesAndValuesOptions{
+		includeSeriesCount:       request.GetIncludeSeriesCount(),
+		postingsForMatchersFn:    tsdb.PostingsForMatchers,
+		omitValues:               omitValues,
+		includeValueCount:        request.GetIncludeValueCount(),
+		longValueLengthThreshold: int(request.GetLongValueLengthThreshold()),
+		dedupValues:              request.GetDedupValues(),
+		includeRelabelOutcomes:   request.GetIncludeRelabelOutcomes(),
+		shardIndex:               request.GetShardIndex(),
+		shardCount:               request.GetShardCount(),
+		limits: labelNamesAndValuesLimits{
+			maxTotalBytes:        i.cfg.LabelNamesAndValues.MaxTotalBytes,
+			maxDistinctValues:    int(request.GetMaxDistinctValues()),
+			maxValues:            int(request.GetMaxValues()),
+			maxLabelNames:        i.cfg.LabelNamesAndValues.MaxLabelNames,
+			rejectComplexRegexes: i.cfg.LabelRequestsRejectComplexRegexMatchers,
+		},
+		lookup: labelNamesAndValuesLookupOptions{
+			labelValuesPrefetchDepth: i.cfg.LabelNamesAndValues.PrefetchDepth,
+			indexReadPool:            i.labelIndexReadPool,
+			backgroundLookups:        lookups,
+		},
+		encoding: labelNamesAndValuesEncodingOptions{
+			useValueIDs:               request.GetUseValueIds(),
+			compressionDictionary:     request.GetValuesCompressionDictionary(),
+			valuesPreviewSize:         int(request.GetValuesPreviewSize()),
+			partitionByFirstCharacter: request.GetPartitionByFirstCharacter(),
+		},
+	}
+	if opts.includeRelabelOutcomes {
+		opts.relabelConfigs = i.limits.MetricRelabelConfigs(userID)
+	}
+	if collation := request.GetValuesCollation(); collation != "" {
+		if opts.valuesLess, err = collatedValuesLess(collation); err != nil {
+			return err
+		}
+	}
+	if limit := i.cfg.LabelNamesAndValues.MaxResultSize; limit > 0 && (opts.limits.maxValues <= 0 || opts.limits.maxValues > limit) {
+		opts.limits.maxValues = limit
+	}
+	if filter := request.GetValuesBloomFilter(); filter != nil {
+		if err := filter.Validate(); err != nil {
+			return err
+		}
+		opts.valuesBloomFilter = filter
+	}
+	if request.GetIncludePresence() || request.GetIncludeBlocks() {
+		blocksIndex, closeBlocksIndex, err := blocksLabelsReader(db.Blocks())
+		if err != nil {
+			return err
+		}
+		defer lookups.closeWhenDone(closeBlocksIndex)
+		opts.blocksIndex = blocksIndex
+		opts.includePresence = request.GetIncludePresence()
+	}
+	if token := request.GetCheckpointToken(); token != "" {
+		if opts.checkpointer, err = newLabelNamesAndValuesCheckpointer(bucket.NewUserBucketClient(userID, i.bucket, i.limits), token); err != nil {
+			return err
+		}
+	}
+	if request.GetExport() {
+		var name string
+		name, err = exportLabelNamesAndValues(server.Context(), bucket.NewUserBucketClient(userID, i.bucket, i.limits), i.cfg.IngesterRing.InstanceID, time.Now(), index, matchers, i.cfg.LabelNamesAndValuesMessageSizeBytes, opts)
+		if err == nil {
+			err = client.SendLabelNamesAndValuesResponse(server, &client.LabelNamesAndValuesResponse{ExportObjectName: name})
+		}
+	} else {
+		err = labelNamesAndValues(index, matchers, i.cfg.LabelNamesAndValuesMessageSizeBytes, opts, server)
+	}
+	err = reg.wrapErr(err)
+	i.metrics.observeLabelStreamTermination(labelStreamEndpointLabelNamesAndValues, err)
+	return err
 }
 
-// labelValuesCardinalityTargetSizeBytes is the maximum allowed size in bytes for label cardinality response.
-// We arbitrarily set it to 1mb to avoid reaching the actual gRPC default limit (4mb).
-const labelValuesCardinalityTargetSizeBytes = 1 * 1024 * 1024
+// blocksLabelsReader returns a reader of the labels of the blocks, and the function to close it.
+func blocksLabelsReader(blocks []*tsdb.Block) (labelsReader, func(), error) {
+	readers := make(multiLabelsReader, 0, len(blocks))
+	var closers []io.Closer
+	closeAll := func() {
+		for _, c := range closers {
+			_ = c.Close()
+		}
+	}
+	for _, b := range blocks {
+		idx, err := b.Index()
+		if err != nil {
+			closeAll()
+			return nil, nil, errors.Wrapf(err, "open index of block %s", b.Meta().ULID)
+		}
+		readers = append(readers, idx)
+		closers = append(closers, idx)
+	}
+	return readers, closeAll, nil
+}
+
+func (i *Ingester) LabelValuesCardinality(req *client.LabelValuesCardinalityRequest, srv client.Ingester_LabelValuesCardinalityServer) (err error) {
+	userID, err := tenant.TenantID(srv.Context())
+	if err != nil {
+		return err
+	}
+	reg := i.labelStreams.register(srv.Context(), userID)
+	defer i.labelStreams.unregister(userID, reg)
+	defer func() { err = reg.wrapErr(err) }()
+	srv = &labelValuesCardinalityServerWithContext{Ingester_LabelValuesCardinalityServer: srv, ctx: reg.ctx}
+
+	defer i.labelValuesCardinalityProfiles.start(srv.Context())()
 
-func (i *Ingester) LabelValuesCardinality(req *client.LabelValuesCardinalityRequest, srv client.Ingester_LabelValuesCardinalityServer) error {
+	// The ingester must be running to serve the request, even from the cache.
+	if err := i.checkRunning(); err != nil {
+		return err
+	}
+	var head *tsdb.Head
+	if db := i.getTSDB(userID); db != nil {
+		head = db.Head()
+	}
+	cacheKey, cacheable := labelValuesCardinalityEmptyResultCacheKey(srv.Context(), req, head)
+	if cacheable && i.labelValuesCardinalityEmptyResults.contains(cacheKey) {
+		i.metrics.labelValuesCardinalityEmptyResultCacheHits.Inc()
+		return nil
+	}
+	emptyResultSrv := &emptyResultLabelValuesCardinalityServer{Ingester_LabelValuesCardinalityServer: srv}
+	if err := i.streamLabelValuesCardinality(req, emptyResultSrv, nil, nil); err != nil {
+		return err
+	}
+	if cacheable && !emptyResultSrv.notEmpty.Load() {
+		i.labelValuesCardinalityEmptyResults.add(cacheKey)
+	}
+	return nil
+}
+
+// labelValuesCardinalityEmptyResultCacheKey returns the key of the request in the empty results cache, and whether
+// the request can be cached. The requests whose response carries more than the items, like the timing breakdown or
+// the progress messages, are not cached. The key includes the number of series and the time range of the head, so
+// that the cached requests are computed again once series or samples have been appended to it.
+func labelValuesCardinalityEmptyResultCacheKey(ctx context.Context, req *client.LabelValuesCardinalityRequest, head *tsdb.Head) (string, bool) {
+	if req.GetExplain() || req.GetProgressIntervalMs() > 0 {
+		return "", false
+	}
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return "", false
+	}
+	data, err := req.Marshal()
+	if err != nil {
+		return "", false
+	}
+	key := userID + "\x00" + string(data)
+	if head != nil {
+		key += fmt.Sprintf("\x00%d\x00%d\x00%d", head.NumSeries(), head.MinTime(), head.MaxTime())
+	}
+	return key, true
+}
+
+// LabelValuesCardinalityStream works like LabelValuesCardinality, but the client can stop the request
+// by sending a stop message. The pending items are then sent in a last message flagged as stopped.
+// In watch mode, the changes of the series counts keep being sent until the client stops the request.
+func (i *Ingester) LabelValuesCardinalityStream(stream client.Ingester_LabelValuesCardinalityStreamServer) (err error) {
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	if first.GetRequest() == nil {
+		if userID, err := tenant.TenantID(stream.Context()); err == nil {
+			i.metrics.observeLabelCardinalityRejection(userID, labelCardinalityRejectedInvalidRequest)
+		}
+		return status.Error(codes.InvalidArgument, "the first message of the label values cardinality stream must contain the request")
+	}
+	userID, err := tenant.TenantID(stream.Context())
+	if err != nil {
+		return err
+	}
+	reg := i.labelStreams.register(stream.Context(), userID)
+	defer i.labelStreams.unregister(userID, reg)
+	defer func() { err = reg.wrapErr(err) }()
+	stream = &labelValuesCardinalityStreamServerWithContext{Ingester_LabelValuesCardinalityStreamServer: stream, ctx: reg.ctx}
+
+	// Watch the following messages for the stop, pause and resume signals, until the stream is done.
+	stop := make(chan struct{})
+	pause := &labelValuesCardinalityPause{}
+	go func() {
+		stopped := false
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				return
+			}
+			if msg.GetPause() {
+				pause.pause()
+			}
+			if msg.GetResume() {
+				pause.resume()
+			}
+			if msg.GetStop() && !stopped {
+				stopped = true
+				close(stop)
+			}
+		}
+	}()
+
+	if watchInterval := time.Duration(first.GetWatchIntervalMs()) * time.Millisecond; watchInterval > 0 {
+		return i.watchLabelValuesCardinality(first.GetRequest(), stream, stop, pause, watchInterval)
+	}
+	defer i.labelValuesCardinalityProfiles.start(stream.Context())()
+	return i.streamLabelValuesCardinality(first.GetRequest(), stream, stop, pause)
+}
+
+// watchLabelValuesCardinality sends the label values cardinality, and then the changes of the series counts
+// as deltas at every interval, until the client stops the request or the stream is done.
+func (i *Ingester) watchLabelValuesCardinality(req *client.LabelValuesCardinalityRequest, stream client.Ingester_LabelValuesCardinalityStreamServer, stop <-chan struct{}, pause *labelValuesCardinalityPause, interval time.Duration) error {
+	ctx := stream.Context()
+
+	// The initial counts are sent as absolute values, and recorded to compute the following deltas.
+	initial := &labelValuesCardinalityRecorder{Ingester_LabelValuesCardinalityServer: stream, ctx: ctx}
+	if err := i.streamLabelValuesCardinality(req, initial, stop, pause); err != nil {
+		return err
+	}
+	if initial.stopped {
+		return nil
+	}
+	deltas := newLabelValuesCardinalityDeltas(initial.items)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-stop:
+			return client.SendLabelValuesCardinalityResponse(stream, &client.LabelValuesCardinalityResponse{Stopped: true})
+		case <-ticker.C:
+		}
+
+		// While the request is paused, the changes are accumulated in the next update.
+		if err := pause.wait(ctx, stop); err != nil {
+			return err
+		}
+		select {
+		case <-stop:
+			return client.SendLabelValuesCardinalityResponse(stream, &client.LabelValuesCardinalityResponse{Stopped: true})
+		default:
+		}
+
+		current := &labelValuesCardinalityRecorder{ctx: ctx}
+		if err := i.streamLabelValuesCardinality(req, current, nil, nil); err != nil {
+			return err
+		}
+		if err := sendLabelValuesCardinalityDeltas(stream, deltas.update(current.items), i.cfg.LabelValuesCardinalityMessageSizeBytes); err != nil {
+			return err
+		}
+	}
+}
+
+func (i *Ingester) streamLabelValuesCardinality(req *client.LabelValuesCardinalityRequest, srv client.Ingester_LabelValuesCardinalityServer, stop <-chan struct{}, pause *labelValuesCardinalityPause) error {
 	if err := i.checkRunning(); err != nil {
 		return err
 	}
@@ -1213,20 +1512,104 @@ func (i *Ingester) LabelValuesCardinality(req *client.LabelValuesCardinalityRequ
 	if err != nil {
 		return err
 	}
-	defer idx.Close()
+	// The label names lookup may keep reading the index after the request returned because it was canceled.
+	lookups := &backgroundLookups{}
+	defer lookups.closeWhenDone(func() { _ = idx.Close() })
 
 	matchers, err := client.FromLabelMatchers(req.GetMatchers())
 	if err != nil {
 		return err
 	}
-	return labelValuesCardinality(
-		req.GetLabelNames(),
-		matchers,
-		idx,
-		tsdb.PostingsForMatchers,
-		labelValuesCardinalityTargetSizeBytes,
-		srv,
-	)
+	// The seed is echoed in the response, so that a sample selected with a random seed can be reproduced.
+	sampleSeed := req.GetSampleSeed()
+	if req.GetSampleValues() > 0 && sampleSeed == 0 {
+		sampleSeed = rand.Int63()
+	}
+	contextCheckInterval := int(req.GetContextCheckIntervalSeries())
+	if contextCheckInterval == 0 {
+		contextCheckInterval = i.cfg.LabelValuesCardinality.ContextCheckInterval
+	}
+	opts := labelValuesCardinalityOptions{
+		groupByMetricName: req.GetGroupByMetricName() || req.GetMetricNamesTopK() > 0,
+		metricNamesTopK:   int(req.GetMetricNamesTopK()),
+		shardIndex:        req.GetShardIndex(),
+		shardCount:        req.GetShardCount(),
+		instanceID:        i.cfg.IngesterRing.InstanceID,
+		allLabels:         req.GetAllLabels(),
+		sortValues:        req.GetSortLabelValues(),
+		groupByMagnitude:  req.GetGroupByMagnitude(),
+		includeSummary:    req.GetIncludeSummary(),
+		minSeriesCount:    req.GetMinSeriesCount(),
+		includeChunkCount: req.GetIncludeChunkCount(),
+		includeRatios:     req.GetIncludeRatios(),
+		explain:           req.GetExplain(),
+		startMs:           req.GetStartTimestampMs(),
+		endMs:             req.GetEndTimestampMs(),
+		bestEffort:        req.GetBestEffort(),
+		valueGroupRegex:   req.GetValueGroupRegex(),
+		coOccurrenceTopK:  int(req.GetCoOccurrenceTopK()),
+		valueHashSalt:     req.GetValueHashSalt(),
+		logger:            log.With(i.logger, "user", userID),
+		limits: labelValuesCardinalityLimits{
+			maxSeries:                uint64(i.cfg.LabelValuesCardinality.Limits.MaxSeries),
+			seriesBudgetWarningRatio: i.cfg.LabelValuesCardinality.Limits.SeriesBudgetWarningRatio,
+			maxSelectedSeriesRatio:   i.cfg.LabelValuesCardinality.Limits.MaxSelectedSeriesRatio,
+			totalSeries:              db.Head().NumSeries(),
+			maxRegexCandidateValues:  i.cfg.LabelValuesCardinality.Limits.MaxRegexCandidateValues,
+			rejectContradictions:     i.cfg.LabelValuesCardinality.Limits.RejectContradictions,
+			rejectAllMatching:        i.cfg.LabelValuesCardinality.Limits.RejectAllMatching,
+			rejectComplexRegexes:     i.cfg.LabelRequestsRejectComplexRegexMatchers,
+		},
+		concurrency: labelValuesCardinalityConcurrencyOptions{
+			perLabelConcurrency:   i.cfg.LabelValuesCardinality.Concurrency.PerLabel,
+			highLoad:              i.labelValuesCardinalityHighLoad,
+			countingMemoryBudget:  i.cfg.LabelValuesCardinality.Concurrency.CountingMemoryBudget,
+			inflightLabelValues:   i.metrics.labelValuesCardinalityInflightLabelValues,
+			indexReadPool:         i.labelIndexReadPool,
+			allLabelsConcurrency:  i.cfg.LabelValuesCardinality.Concurrency.AllLabels,
+			labelNamesConcurrency: i.cfg.LabelValuesCardinality.Concurrency.LabelNames,
+			inflightLabels:        i.metrics.labelValuesCardinalityInflightLabels,
+			contextCheckInterval:  contextCheckInterval,
+			backgroundLookups:     lookups,
+		},
+		stream: labelValuesCardinalityStreamOptions{
+			includeChecksums: req.GetIncludeChecksums(),
+			resumeOffset:     req.GetResumeOffset(),
+			resumeChecksum:   req.GetResumeChecksum(),
+			progressInterval: time.Duration(req.GetProgressIntervalMs()) * time.Millisecond,
+			sendStallTimeout: i.cfg.LabelValuesCardinality.SendStallTimeout,
+			stop:             stop,
+			pause:            pause,
+		},
+		estimation: labelValuesCardinalityEstimationOptions{
+			estimateLabelSeries:    req.GetEstimateLabelSeries(),
+			orderByLabelSeries:     req.GetOrderByLabelSeries(),
+			seriesCountPercentiles: req.GetSeriesCountPercentiles(),
+			sampleValues:           int(req.GetSampleValues()),
+			sampleSeed:             sampleSeed,
+		},
+	}
+	if req.GetChangedLabelsOnly() {
+		if opts.changedLabelsOnly, err = decodeLabelCardinalityFingerprints(req.GetChangedLabelsState()); err != nil {
+			return err
+		}
+	}
+	if req.GetCompareEndTimestampMs() != 0 {
+		a := labelValuesCardinalityWindow{startMs: req.GetCompareStartTimestampMs(), endMs: req.GetCompareEndTimestampMs()}
+		b := labelValuesCardinalityWindow{startMs: req.GetStartTimestampMs(), endMs: req.GetEndTimestampMs()}
+		if req.GetCompareGrowthRate() {
+			err = labelValuesCardinalityGrowthRate(req.GetLabelNames(), matchers, idx, tsdb.PostingsForMatchers, a, b, i.cfg.LabelValuesCardinalityMessageSizeBytes, opts, srv)
+		} else {
+			err = labelValuesCardinalityDiff(req.GetLabelNames(), matchers, idx, tsdb.PostingsForMatchers, a, b, i.cfg.LabelValuesCardinalityMessageSizeBytes, opts, srv)
+		}
+	} else {
+		err = labelValuesCardinality(req.GetLabelNames(), matchers, idx, tsdb.PostingsForMatchers, i.cfg.LabelValuesCardinalityMessageSizeBytes, opts, srv)
+	}
+	i.metrics.observeLabelStreamTermination(labelStreamEndpointLabelValuesCardinality, err)
+	if reason, rejected := labelCardinalityRejectionReason(err); rejected {
+		i.metrics.observeLabelCardinalityRejection(userID, reason)
+	}
+	return err
 }
 
 func createUserStats(db *userTSDB) *client.UserStatsResponse {
@@ -2258,6 +2641,32 @@ func (i *Ingester) getInstanceLimits() *InstanceLimits {
 	return l
 }
 
+// CancelTenantRequests cancels all the in-flight streaming label requests of the tenant: the label names and values,
+// and the label values cardinality requests. It returns the number of cancelled requests.
+func (i *Ingester) CancelTenantRequests(userID string) int {
+	n := i.labelStreams.cancelTenant(userID)
+	level.Info(i.logger).Log("msg", "cancelled the in-flight streaming label requests of the tenant", "user", userID, "requests", n)
+	return n
+}
+
+// CancelTenantRequestsHandler cancels all the in-flight streaming label requests of the tenant set in the tenant parameter.
+func (i *Ingester) CancelTenantRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	userID := r.Form.Get(tenantParam)
+	if userID == "" {
+		http.Error(w, "the tenant parameter is required", http.StatusBadRequest)
+		return
+	}
+	util.WriteJSONResponse(w, cancelTenantRequestsResponse{Cancelled: i.CancelTenantRequests(userID)})
+}
+
+type cancelTenantRequestsResponse struct {
+	Cancelled int `json:"cancelled"`
+}
+
 // ShutdownHandler triggers the following set of operations in order:
 //   - Change the state of ring to stop accepting writes.
 //   - Flush all the chunks.
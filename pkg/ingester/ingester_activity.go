@@ -129,6 +129,15 @@ func (i *ActivityTrackerWrapper) LabelValuesCardinality(request *client.LabelVal
 	return i.ing.LabelValuesCardinality(request, server)
 }
 
+func (i *ActivityTrackerWrapper) LabelValuesCardinalityStream(stream client.Ingester_LabelValuesCardinalityStreamServer) error {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(stream.Context(), "Ingester/LabelValuesCardinalityStream", nil)
+	})
+	defer i.tracker.Delete(ix)
+
+	return i.ing.LabelValuesCardinalityStream(stream)
+}
+
 func (i *ActivityTrackerWrapper) FlushHandler(w http.ResponseWriter, r *http.Request) {
 	ix := i.tracker.Insert(func() string {
 		return requestActivity(r.Context(), "Ingester/FlushHandler", nil)
@@ -138,6 +147,24 @@ func (i *ActivityTrackerWrapper) FlushHandler(w http.ResponseWriter, r *http.Req
 	i.ing.FlushHandler(w, r)
 }
 
+func (i *ActivityTrackerWrapper) CancelTenantRequestsHandler(w http.ResponseWriter, r *http.Request) {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(r.Context(), "Ingester/CancelTenantRequestsHandler", nil)
+	})
+	defer i.tracker.Delete(ix)
+
+	i.ing.CancelTenantRequestsHandler(w, r)
+}
+
+func (i *ActivityTrackerWrapper) LabelValuesCardinalityHandler(w http.ResponseWriter, r *http.Request) {
+	ix := i.tracker.Insert(func() string {
+		return requestActivity(r.Context(), "Ingester/LabelValuesCardinalityHandler", nil)
+	})
+	defer i.tracker.Delete(ix)
+
+	i.ing.LabelValuesCardinalityHandler(w, r)
+}
+
 func (i *ActivityTrackerWrapper) ShutdownHandler(w http.ResponseWriter, r *http.Request) {
 	ix := i.tracker.Insert(func() string {
 		return requestActivity(r.Context(), "Ingester/ShutdownHandler", nil)
@@ -26,7 +26,7 @@ func TestRequestActivity(t *testing.T) {
 		},
 		{
 			request:  &client.LabelValuesCardinalityRequest{LabelNames: []string{"hello", "world"}, Matchers: []*client.LabelMatcher{{Type: client.EQUAL, Name: "test", Value: "value"}}},
-			expected: "test: user=\"\" trace=\"\" request=&LabelValuesCardinalityRequest{LabelNames:[hello world],Matchers:[]*LabelMatcher{&LabelMatcher{Type:EQUAL,Name:test,Value:value,},},}",
+			expected: "test: user=\"\" trace=\"\" request=&LabelValuesCardinalityRequest{LabelNames:[hello world],Matchers:[]*LabelMatcher{&LabelMatcher{Type:EQUAL,Name:test,Value:value,},},GroupByMetricName:false,IncludeChecksums:false,ShardIndex:0,ShardCount:0,EstimateLabelSeries:false,IncludeChunkCount:false,Explain:false,ProgressIntervalMs:0,MinSeriesCount:0,AllLabels:false,ValueHashSalt:,IncludeRatios:false,SampleValues:0,SampleSeed:0,ValueGroupRegex:,CoOccurrenceTopK:0,MetricNamesTopK:0,OrderByLabelSeries:false,SeriesCountPercentiles:[],ContextCheckIntervalSeries:0,SortLabelValues:false,StartTimestampMs:0,EndTimestampMs:0,BestEffort:false,GroupByMagnitude:false,IncludeSummary:false,ResumeOffset:0,ResumeChecksum:0,CompareStartTimestampMs:0,CompareEndTimestampMs:0,CompareGrowthRate:false,ChangedLabelsOnly:false,ChangedLabelsState:[],}",
 		},
 	} {
 		assert.Equal(t, tc.expected, requestActivity(context.Background(), "test", tc.request))
@@ -49,6 +49,9 @@ type RingConfig struct {
 	FinalSleep               time.Duration `yaml:"final_sleep" category:"advanced"`
 	ReadinessCheckRingHealth bool          `yaml:"readiness_check_ring_health" category:"advanced"`
 
+	// Config validation
+	StrictReplicationFactorValidation bool `yaml:"strict_replication_factor_validation" category:"experimental"`
+
 	// Injected internally
 	ListenPort int `yaml:"-"`
 
@@ -99,6 +102,8 @@ func (cfg *RingConfig) RegisterFlags(f *flag.FlagSet, logger log.Logger) {
 	// multiple ingesters in multi-zone deployments. It's also safe to disable it when deploying in a single zone,
 	// given we expect ingesters to be deployed using StatefulSets.
 	f.BoolVar(&cfg.ReadinessCheckRingHealth, prefix+"readiness-check-ring-health", false, "When enabled the readiness probe succeeds only after all instances are ACTIVE and healthy in the ring, otherwise only the instance itself is checked. This option should be disabled if in your cluster multiple instances can be rolled out simultaneously, otherwise rolling updates may be slowed down.")
+
+	f.BoolVar(&cfg.StrictReplicationFactorValidation, prefix+"strict-replication-factor-validation", false, "When enabled, Mimir fails to start if the replication factor can't be satisfied by the instances the ring can have, for example with a replication factor greater than 1 and the inmemory KV store, which isn't shared between processes. Otherwise, a warning is logged.")
 }
 
 // ToRingConfig returns a ring.Config based on the ingester
@@ -11,6 +11,7 @@ package ingester
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math"
@@ -28,6 +29,7 @@ import (
 	"time"
 
 	"github.com/go-kit/log"
+	"github.com/gogo/status"
 	"github.com/grafana/dskit/ring"
 	"github.com/grafana/dskit/services"
 	"github.com/grafana/dskit/test"
@@ -48,12 +50,16 @@ import (
 	"github.com/weaveworks/common/httpgrpc"
 	"github.com/weaveworks/common/middleware"
 	"github.com/weaveworks/common/user"
+	"go.uber.org/atomic"
 	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpc_metadata "google.golang.org/grpc/metadata"
 
 	"github.com/grafana/mimir/pkg/ingester/activeseries"
 	"github.com/grafana/mimir/pkg/ingester/client"
 	"github.com/grafana/mimir/pkg/mimirpb"
+	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storage/chunk"
 	"github.com/grafana/mimir/pkg/storage/sharding"
 	mimir_tsdb "github.com/grafana/mimir/pkg/storage/tsdb"
@@ -1639,6 +1645,38 @@ func TestIngester_LabelNamesCardinality(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		setup       func(cfg *Config)
+		expectedErr error
+	}{
+		"default config": {
+			setup: func(*Config) {},
+		},
+		"small label values cardinality context check interval": {
+			setup: func(cfg *Config) { cfg.LabelValuesCardinality.ContextCheckInterval = 1 },
+		},
+		"zero label values cardinality context check interval": {
+			setup:       func(cfg *Config) { cfg.LabelValuesCardinality.ContextCheckInterval = 0 },
+			expectedErr: errInvalidLabelValuesCardinalityContextCheckInterval,
+		},
+		"negative label values cardinality context check interval": {
+			setup:       func(cfg *Config) { cfg.LabelValuesCardinality.ContextCheckInterval = -1 },
+			expectedErr: errInvalidLabelValuesCardinalityContextCheckInterval,
+		},
+		"zero label values cardinality profile max files": {
+			setup:       func(cfg *Config) { cfg.LabelValuesCardinality.Profile.MaxFiles = 0 },
+			expectedErr: errInvalidLabelValuesCardinalityProfileMaxFiles,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := defaultIngesterTestConfig(t)
+			tc.setup(&cfg)
+			require.ErrorIs(t, cfg.Validate(), tc.expectedErr)
+		})
+	}
+}
+
 func TestIngester_LabelValuesCardinality(t *testing.T) {
 	series := []series{
 		{
@@ -1753,6 +1791,835 @@ func TestIngester_LabelValuesCardinality(t *testing.T) {
 	}
 }
 
+func TestIngester_LabelValuesCardinality_InstanceID(t *testing.T) {
+	cfg := defaultIngesterTestConfig(t)
+	cfg.IngesterRing.InstanceID = "ingester-zone-a-1"
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+
+	ctx := pushSeriesToIngester(t, []series{
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}}, value: 1.5, timestamp: 100000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_1"}, {Name: "status", Value: "200"}}, value: 1.5, timestamp: 100000},
+	}, i)
+
+	req := &client.LabelValuesCardinalityRequest{LabelNames: []string{labels.MetricName, "status"}}
+	s := &mockLabelValuesCardinalityServer{context: ctx}
+	require.NoError(t, i.LabelValuesCardinality(req, s))
+
+	require.NotEmpty(t, s.SentResponses)
+	for _, resp := range s.SentResponses {
+		require.Equal(t, "ingester-zone-a-1", resp.InstanceId)
+	}
+}
+
+func TestIngester_LabelValuesCardinality_CompareTimeWindows(t *testing.T) {
+	i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), nil)
+
+	ctx := pushSeriesToIngester(t, []series{
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "job", Value: "api"}, {Name: "pod", Value: "1"}}, value: 1, timestamp: 1000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "job", Value: "db"}, {Name: "pod", Value: "2"}}, value: 1, timestamp: 1000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "job", Value: "api"}, {Name: "pod", Value: "3"}}, value: 1, timestamp: 5000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "job", Value: "api"}, {Name: "pod", Value: "4"}}, value: 1, timestamp: 5000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "job", Value: "web"}, {Name: "pod", Value: "5"}}, value: 1, timestamp: 5000},
+	}, i)
+
+	req := &client.LabelValuesCardinalityRequest{
+		LabelNames:              []string{"job"},
+		CompareStartTimestampMs: 0,
+		CompareEndTimestampMs:   2000,
+		StartTimestampMs:        4000,
+		EndTimestampMs:          6000,
+	}
+
+	t.Run("the changes of the series counts between the windows are returned", func(t *testing.T) {
+		s := &mockLabelValuesCardinalityServer{context: ctx}
+		require.NoError(t, i.LabelValuesCardinality(req, s))
+
+		// The head chunks being appended to are open ended, so the series of the first window are also in the second one.
+		require.Len(t, s.SentResponses, 1)
+		require.Equal(t, []*client.LabelValueSeriesCount{
+			{LabelName: "job", LabelValueSeriesDelta: map[string]int64{"api": 2, "web": 1}},
+		}, s.SentResponses[0].Items)
+	})
+
+	t.Run("the growth rates between the windows are returned", func(t *testing.T) {
+		reqWithGrowthRate := *req
+		reqWithGrowthRate.CompareGrowthRate = true
+		s := &mockLabelValuesCardinalityServer{context: ctx}
+		require.NoError(t, i.LabelValuesCardinality(&reqWithGrowthRate, s))
+
+		// The windows end 4s apart.
+		require.Len(t, s.SentResponses, 1)
+		require.Equal(t, []*client.LabelValueSeriesCount{
+			{LabelName: "job", LabelValueSeriesGrowthRate: map[string]float64{"api": 0.5, "web": 0.25}},
+		}, s.SentResponses[0].Items)
+	})
+
+	t.Run("the windows can't be compared with the checksums", func(t *testing.T) {
+		reqWithChecksums := *req
+		reqWithChecksums.IncludeChecksums = true
+		s := &mockLabelValuesCardinalityServer{context: ctx}
+		err := i.LabelValuesCardinality(&reqWithChecksums, s)
+		var invalidErr invalidLabelValuesCardinalityRequestError
+		require.ErrorAs(t, err, &invalidErr)
+		require.Empty(t, s.SentResponses)
+	})
+}
+
+func TestIngester_LabelValuesCardinality_ChangedLabelsOnly(t *testing.T) {
+	i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), nil)
+	ctx := pushSeriesToIngester(t, []series{
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "pod", Value: "pod-0"}, {Name: "zone", Value: "zone-a"}}, value: 1, timestamp: 1000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "pod", Value: "pod-1"}, {Name: "zone", Value: "zone-b"}}, value: 1, timestamp: 1000},
+	}, i)
+
+	// run sends the request with the state of the previous run, and returns the series counts and the new state.
+	run := func(t *testing.T, state []byte) (map[string]map[string]uint64, []byte) {
+		req := &client.LabelValuesCardinalityRequest{
+			LabelNames:         []string{"pod", "zone"},
+			Matchers:           []*client.LabelMatcher{{Type: client.EQUAL, Name: labels.MetricName, Value: "up"}},
+			ChangedLabelsOnly:  true,
+			ChangedLabelsState: state,
+		}
+		s := &mockLabelValuesCardinalityServer{context: ctx}
+		require.NoError(t, i.LabelValuesCardinality(req, s))
+		require.NotEmpty(t, s.SentResponses)
+
+		var items []*client.LabelValueSeriesCount
+		for _, resp := range s.SentResponses {
+			items = append(items, resp.Items...)
+		}
+		last := s.SentResponses[len(s.SentResponses)-1]
+		require.NotEmpty(t, last.ChangedLabelsState)
+		return mergeLabelValueSeriesCounts(items), last.ChangedLabelsState
+	}
+
+	counts, state := run(t, nil)
+	require.Equal(t, map[string]map[string]uint64{
+		"pod":  {"pod-0": 1, "pod-1": 1},
+		"zone": {"zone-a": 1, "zone-b": 1},
+	}, counts)
+
+	// The counts of the labels whose series haven't changed are taken from the state: altering them in the state
+	// shows that they haven't been counted again.
+	var fingerprints labelCardinalityFingerprints
+	require.NoError(t, json.Unmarshal(state, &fingerprints))
+	fingerprints.Labels["zone"].SeriesCounts = []uint64{10, 20}
+	alteredState, err := json.Marshal(&fingerprints)
+	require.NoError(t, err)
+
+	counts, _ = run(t, alteredState)
+	require.Equal(t, map[string]map[string]uint64{
+		"pod":  {"pod-0": 1, "pod-1": 1},
+		"zone": {"zone-a": 10, "zone-b": 20},
+	}, counts)
+
+	// Once a series is added to a zone, its series are counted again.
+	pushSeriesToIngester(t, []series{
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "up"}, {Name: "pod", Value: "pod-2"}, {Name: "zone", Value: "zone-a"}}, value: 1, timestamp: 1000},
+	}, i)
+	counts, _ = run(t, alteredState)
+	require.Equal(t, map[string]map[string]uint64{
+		"pod":  {"pod-0": 1, "pod-1": 1, "pod-2": 1},
+		"zone": {"zone-a": 2, "zone-b": 1},
+	}, counts)
+
+	t.Run("an invalid state is rejected", func(t *testing.T) {
+		req := &client.LabelValuesCardinalityRequest{LabelNames: []string{"pod"}, ChangedLabelsOnly: true, ChangedLabelsState: []byte("{")}
+		s := &mockLabelValuesCardinalityServer{context: ctx}
+		err := i.LabelValuesCardinality(req, s)
+		var invalidErr invalidLabelValuesCardinalityRequestError
+		require.ErrorAs(t, err, &invalidErr)
+		require.Empty(t, s.SentResponses)
+	})
+}
+
+func TestIngester_LabelValuesCardinalityStream(t *testing.T) {
+	var inputSeries []series
+	for v := 0; v < 10; v++ {
+		inputSeries = append(inputSeries, series{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "lbl", Value: fmt.Sprintf("v-%d", v)}}, value: 1, timestamp: 100000})
+	}
+
+	cfg := defaultIngesterTestConfig(t)
+	cfg.LabelValuesCardinalityMessageSizeBytes = 6 // Each message holds 2 values.
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+	ctx := pushSeriesToIngester(t, inputSeries, i)
+
+	t.Run("the request completes if it's not stopped", func(t *testing.T) {
+		stream := newMockLabelValuesCardinalityStreamServer(ctx)
+		defer stream.close()
+		stream.requests <- &client.LabelValuesCardinalityStreamRequest{Request: &client.LabelValuesCardinalityRequest{LabelNames: []string{"lbl"}}}
+
+		require.NoError(t, i.LabelValuesCardinalityStream(stream))
+
+		require.Len(t, stream.SentResponses, 5)
+		for _, resp := range stream.SentResponses {
+			require.False(t, resp.Stopped)
+		}
+	})
+
+	t.Run("the request is stopped by the client", func(t *testing.T) {
+		stream := newMockLabelValuesCardinalityStreamServer(ctx)
+		defer stream.close()
+		stream.requests <- &client.LabelValuesCardinalityStreamRequest{Request: &client.LabelValuesCardinalityRequest{LabelNames: []string{"lbl"}}}
+		stream.onSend = func(sent int) {
+			if sent != 1 {
+				return
+			}
+			// Send the stop message after the first message, and wait until the ingester has received it
+			// and is waiting for the next message.
+			stream.requests <- &client.LabelValuesCardinalityStreamRequest{Stop: true}
+			test.Poll(t, time.Second, true, func() interface{} {
+				return stream.recvCalls.Load() >= 3
+			})
+		}
+
+		require.NoError(t, i.LabelValuesCardinalityStream(stream))
+
+		// The first message is followed by the last one, flagged as stopped.
+		require.Len(t, stream.SentResponses, 2)
+		require.False(t, stream.SentResponses[0].Stopped)
+		require.Len(t, stream.SentResponses[0].Items[0].LabelValueSeries, 2)
+		require.True(t, stream.SentResponses[1].Stopped)
+	})
+
+	t.Run("the first message must contain the request", func(t *testing.T) {
+		stream := newMockLabelValuesCardinalityStreamServer(ctx)
+		defer stream.close()
+		stream.requests <- &client.LabelValuesCardinalityStreamRequest{Stop: true}
+
+		err := i.LabelValuesCardinalityStream(stream)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Empty(t, stream.SentResponses)
+	})
+}
+
+func TestIngester_LabelValuesCardinalityStream_Watch(t *testing.T) {
+	i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), nil)
+	ctx := pushSeriesToIngester(t, []series{
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "lbl", Value: "v-0"}}, value: 1, timestamp: 100000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "lbl", Value: "v-1"}}, value: 1, timestamp: 100000},
+	}, i)
+
+	stream := newMockLabelValuesCardinalityStreamServer(ctx)
+	defer stream.close()
+	sent := make(chan *client.LabelValuesCardinalityResponse, 100)
+	stream.onSend = func(numSent int) {
+		sent <- stream.SentResponses[numSent-1]
+	}
+	stream.requests <- &client.LabelValuesCardinalityStreamRequest{
+		Request:         &client.LabelValuesCardinalityRequest{LabelNames: []string{"lbl"}},
+		WatchIntervalMs: 10,
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- i.LabelValuesCardinalityStream(stream)
+	}()
+
+	receive := func() *client.LabelValuesCardinalityResponse {
+		select {
+		case resp := <-sent:
+			return resp
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for a label values cardinality message")
+			return nil
+		}
+	}
+
+	// The initial counts are sent as absolute values.
+	initial := receive()
+	require.Len(t, initial.Items, 1)
+	require.Equal(t, map[string]uint64{"v-0": 1, "v-1": 1}, initial.Items[0].LabelValueSeries)
+	require.Empty(t, initial.Items[0].LabelValueSeriesDelta)
+
+	// The following changes are sent as deltas.
+	pushSeriesToIngester(t, []series{
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_1"}, {Name: "lbl", Value: "v-0"}}, value: 1, timestamp: 100000},
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "lbl", Value: "v-2"}}, value: 1, timestamp: 100000},
+	}, i)
+
+	// The series may be counted across multiple updates.
+	receivedDeltas := map[string]int64{}
+	for len(receivedDeltas) < 2 || receivedDeltas["v-0"] != 1 || receivedDeltas["v-2"] != 1 {
+		update := receive()
+		require.False(t, update.Stopped)
+		for _, item := range update.Items {
+			require.Equal(t, "lbl", item.LabelName)
+			require.Empty(t, item.LabelValueSeries)
+			for lbValue, delta := range item.LabelValueSeriesDelta {
+				receivedDeltas[lbValue] += delta
+			}
+		}
+	}
+	require.Equal(t, map[string]int64{"v-0": 1, "v-2": 1}, receivedDeltas)
+
+	// The watch ends when the client stops it.
+	stream.requests <- &client.LabelValuesCardinalityStreamRequest{Stop: true}
+	require.True(t, receive().Stopped)
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the label values cardinality watch was not stopped")
+	}
+}
+
+func TestIngester_CancelTenantRequests(t *testing.T) {
+	i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), nil)
+	series := []series{
+		{lbls: labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "lbl", Value: "v-0"}}, value: 1, timestamp: 100000},
+	}
+	ctx := pushSeriesToIngester(t, series, i)
+	otherCtx := user.InjectOrgID(context.Background(), "other")
+	for _, s := range series {
+		req, _, _, _ := mockWriteRequest(t, s.lbls, s.value, s.timestamp)
+		_, err := i.Push(otherCtx, req)
+		require.NoError(t, err)
+	}
+
+	// startWatch starts a request which runs until it's stopped, and returns once its first message has been sent.
+	startWatch := func(ctx context.Context) (*mockLabelValuesCardinalityStreamServer, chan error) {
+		stream := newMockLabelValuesCardinalityStreamServer(ctx)
+		t.Cleanup(stream.close)
+		started := make(chan struct{})
+		stream.onSend = func(numSent int) {
+			if numSent == 1 {
+				close(started)
+			}
+		}
+		stream.requests <- &client.LabelValuesCardinalityStreamRequest{
+			Request:         &client.LabelValuesCardinalityRequest{LabelNames: []string{"lbl"}},
+			WatchIntervalMs: 10,
+		}
+		done := make(chan error, 1)
+		go func() {
+			done <- i.LabelValuesCardinalityStream(stream)
+		}()
+		select {
+		case <-started:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for the request to start")
+		}
+		return stream, done
+	}
+
+	var tenantRequests []chan error
+	for n := 0; n < 3; n++ {
+		_, done := startWatch(ctx)
+		tenantRequests = append(tenantRequests, done)
+	}
+	otherStream, otherRequest := startWatch(otherCtx)
+
+	resp := httptest.NewRecorder()
+	i.CancelTenantRequestsHandler(resp, httptest.NewRequest(http.MethodPost, "/ingester/cancel-tenant-requests?tenant=test", nil))
+	require.Equal(t, http.StatusOK, resp.Code)
+	require.JSONEq(t, `{"cancelled": 3}`, resp.Body.String())
+
+	for _, done := range tenantRequests {
+		select {
+		case err := <-done:
+			require.Equal(t, codes.Canceled, status.Code(err))
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "the request of the tenant was not cancelled")
+		}
+	}
+
+	// The requests of the other tenants keep running.
+	select {
+	case err := <-otherRequest:
+		require.Fail(t, "the request of the other tenant has been cancelled", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	otherStream.requests <- &client.LabelValuesCardinalityStreamRequest{Stop: true}
+	select {
+	case err := <-otherRequest:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the request of the other tenant was not stopped")
+	}
+
+	// The completed requests are not tracked anymore.
+	require.Equal(t, 0, i.CancelTenantRequests("test"))
+
+	t.Run("the tenant parameter is required", func(t *testing.T) {
+		resp := httptest.NewRecorder()
+		i.CancelTenantRequestsHandler(resp, httptest.NewRequest(http.MethodPost, "/ingester/cancel-tenant-requests", nil))
+		require.Equal(t, http.StatusBadRequest, resp.Code)
+	})
+}
+
+type mockLabelValuesCardinalityStreamServer struct {
+	client.Ingester_LabelValuesCardinalityStreamServer
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	requests  chan *client.LabelValuesCardinalityStreamRequest
+	recvCalls atomic.Int64
+
+	// onSend, if set, is called after each sent message with the number of messages sent so far.
+	onSend        func(sent int)
+	SentResponses []*client.LabelValuesCardinalityResponse
+}
+
+func newMockLabelValuesCardinalityStreamServer(ctx context.Context) *mockLabelValuesCardinalityStreamServer {
+	ctx, cancel := context.WithCancel(ctx)
+	return &mockLabelValuesCardinalityStreamServer{
+		ctx:      ctx,
+		cancel:   cancel,
+		requests: make(chan *client.LabelValuesCardinalityStreamRequest, 1),
+	}
+}
+
+func (m *mockLabelValuesCardinalityStreamServer) Recv() (*client.LabelValuesCardinalityStreamRequest, error) {
+	m.recvCalls.Inc()
+	select {
+	case req := <-m.requests:
+		return req, nil
+	case <-m.ctx.Done():
+		return nil, io.EOF
+	}
+}
+
+func (m *mockLabelValuesCardinalityStreamServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	b, err := resp.Marshal()
+	if err != nil {
+		return err
+	}
+	sent := &client.LabelValuesCardinalityResponse{}
+	if err := sent.Unmarshal(b); err != nil {
+		return err
+	}
+	m.SentResponses = append(m.SentResponses, sent)
+	if m.onSend != nil {
+		m.onSend(len(m.SentResponses))
+	}
+	return nil
+}
+
+func (m *mockLabelValuesCardinalityStreamServer) Context() context.Context {
+	return m.ctx
+}
+
+// close ends the stream, like gRPC does once the server handler returns.
+func (m *mockLabelValuesCardinalityStreamServer) close() {
+	m.cancel()
+}
+
+func TestIngester_LabelStreamTerminations(t *testing.T) {
+	series := []series{
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}}, 1, 100000},
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}}, 1, 110000},
+	}
+
+	callLabelNamesAndValues := func(i *Ingester, ctx context.Context) error {
+		return i.LabelNamesAndValues(&client.LabelNamesAndValuesRequest{}, &mockLabelNamesAndValuesServer{context: ctx})
+	}
+	callLabelValuesCardinality := func(i *Ingester, ctx context.Context) error {
+		req := &client.LabelValuesCardinalityRequest{LabelNames: []string{labels.MetricName, "status"}}
+		return i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx})
+	}
+
+	tests := map[string]struct {
+		call            func(*Ingester, context.Context) error
+		ctx             func(context.Context) (context.Context, context.CancelFunc)
+		expectedErr     error
+		expectedMetrics string
+	}{
+		"label names and values request cancelled": {
+			call: callLabelNamesAndValues,
+			ctx: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(ctx)
+				cancel()
+				return ctx, cancel
+			},
+			expectedErr: context.Canceled,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_stream_terminations_total The total number of label names and values streaming requests terminated because their context was cancelled or its deadline exceeded.
+				# TYPE cortex_ingester_label_stream_terminations_total counter
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="cancelled"} 1
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="deadline_exceeded"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="deadline_exceeded"} 0
+			`,
+		},
+		"label names and values request deadline exceeded": {
+			call: callLabelNamesAndValues,
+			ctx: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithDeadline(ctx, time.Now().Add(-time.Second))
+			},
+			expectedErr: context.DeadlineExceeded,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_stream_terminations_total The total number of label names and values streaming requests terminated because their context was cancelled or its deadline exceeded.
+				# TYPE cortex_ingester_label_stream_terminations_total counter
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="deadline_exceeded"} 1
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="deadline_exceeded"} 0
+			`,
+		},
+		"label values cardinality request cancelled": {
+			call: callLabelValuesCardinality,
+			ctx: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(ctx)
+				cancel()
+				return ctx, cancel
+			},
+			expectedErr: context.Canceled,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_stream_terminations_total The total number of label names and values streaming requests terminated because their context was cancelled or its deadline exceeded.
+				# TYPE cortex_ingester_label_stream_terminations_total counter
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="deadline_exceeded"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="cancelled"} 1
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="deadline_exceeded"} 0
+			`,
+		},
+		"label values cardinality request deadline exceeded": {
+			call: callLabelValuesCardinality,
+			ctx: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithDeadline(ctx, time.Now().Add(-time.Second))
+			},
+			expectedErr: context.DeadlineExceeded,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_stream_terminations_total The total number of label names and values streaming requests terminated because their context was cancelled or its deadline exceeded.
+				# TYPE cortex_ingester_label_stream_terminations_total counter
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="deadline_exceeded"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="deadline_exceeded"} 1
+			`,
+		},
+		"successful requests are not tracked": {
+			call: func(i *Ingester, ctx context.Context) error {
+				if err := callLabelNamesAndValues(i, ctx); err != nil {
+					return err
+				}
+				return callLabelValuesCardinality(i, ctx)
+			},
+			ctx: func(ctx context.Context) (context.Context, context.CancelFunc) {
+				return context.WithCancel(ctx)
+			},
+			expectedMetrics: `
+				# HELP cortex_ingester_label_stream_terminations_total The total number of label names and values streaming requests terminated because their context was cancelled or its deadline exceeded.
+				# TYPE cortex_ingester_label_stream_terminations_total counter
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_names_and_values",reason="deadline_exceeded"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="cancelled"} 0
+				cortex_ingester_label_stream_terminations_total{endpoint="label_values_cardinality",reason="deadline_exceeded"} 0
+			`,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			registry := prometheus.NewRegistry()
+			i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), registry)
+			ctx := pushSeriesToIngester(t, series, i)
+
+			ctx, cancel := testData.ctx(ctx)
+			defer cancel()
+
+			err := testData.call(i, ctx)
+			if testData.expectedErr != nil {
+				require.ErrorIs(t, err, testData.expectedErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(testData.expectedMetrics), "cortex_ingester_label_stream_terminations_total"))
+		})
+	}
+}
+
+func TestIngester_LabelNamesAndValues_Presence(t *testing.T) {
+	inputSeries := []series{
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}}, 1, 100000},
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}}, 1, 110000},
+	}
+	i := requireActiveIngesterWithBlocksStorage(t, defaultIngesterTestConfig(t), nil)
+	ctx := pushSeriesToIngester(t, inputSeries, i)
+
+	server := &mockLabelNamesAndValuesServer{context: ctx}
+	require.NoError(t, i.LabelNamesAndValues(&client.LabelNamesAndValuesRequest{IncludePresence: true}, server))
+
+	// There are no persisted blocks yet, so all the values are only in the head.
+	items := extractItemsWithSortedValues(server.SentResponses)
+	require.Len(t, items, 2)
+	for _, item := range items {
+		require.Len(t, item.Presence, len(item.Values))
+		for _, presence := range item.Presence {
+			require.Equal(t, client.PRESENT_IN_HEAD_ONLY, presence)
+		}
+	}
+}
+
+func TestIngester_LabelNamesAndValues_Export(t *testing.T) {
+	inputSeries := []series{
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}}, 1, 100000},
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}}, 1, 110000},
+	}
+	cfg := defaultIngesterTestConfig(t)
+	cfg.IngesterRing.InstanceID = "ingester-zone-a-1"
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+	ctx := pushSeriesToIngester(t, inputSeries, i)
+
+	server := &mockLabelNamesAndValuesServer{context: ctx}
+	require.NoError(t, i.LabelNamesAndValues(&client.LabelNamesAndValuesRequest{Export: true}, server))
+
+	// The response only carries the name of the export, in the bucket of the tenant.
+	require.Len(t, server.SentResponses, 1)
+	name := server.SentResponses[0].ExportObjectName
+	require.True(t, strings.HasPrefix(name, "label-names-and-values-exports/ingester-zone-a-1/"), name)
+	require.Empty(t, server.SentResponses[0].Items)
+
+	export := readLabelNamesAndValuesExport(t, bucket.NewUserBucketClient("test", i.bucket, i.limits), name)
+	for _, item := range export.Items {
+		sort.Strings(item.Values)
+	}
+	require.Equal(t, []labelNamesAndValuesExportItem{
+		{LabelName: labels.MetricName, Values: []string{"metric_0"}},
+		{LabelName: "status", Values: []string{"200", "500"}},
+	}, export.Items)
+
+	t.Run("the export is rejected with the options changing the values", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: ctx}
+		require.Error(t, i.LabelNamesAndValues(&client.LabelNamesAndValuesRequest{Export: true, UseValueIds: true}, server))
+		require.Empty(t, server.SentResponses)
+	})
+}
+
+func TestIngester_LabelValuesCardinalityProfile(t *testing.T) {
+	inputSeries := []series{
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}}, 1, 100000},
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}}, 1, 110000},
+	}
+	req := &client.LabelValuesCardinalityRequest{LabelNames: []string{labels.MetricName, "status"}}
+
+	profileFiles := func(t *testing.T, dir string) []os.DirEntry {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		return entries
+	}
+
+	t.Run("the request is profiled when sent with the profile header", func(t *testing.T) {
+		cfg := defaultIngesterTestConfig(t)
+		cfg.LabelValuesCardinality.Profile.Dir = t.TempDir()
+		i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+		ctx := pushSeriesToIngester(t, inputSeries, i)
+		ctx = grpc_metadata.NewIncomingContext(ctx, grpc_metadata.Pairs(labelValuesCardinalityProfileHeader, "true"))
+
+		require.NoError(t, i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx}))
+
+		files := profileFiles(t, cfg.LabelValuesCardinality.Profile.Dir)
+		require.Len(t, files, 1)
+		require.True(t, strings.HasSuffix(files[0].Name(), ".pprof"))
+		info, err := files[0].Info()
+		require.NoError(t, err)
+		require.Greater(t, info.Size(), int64(0))
+	})
+
+	t.Run("the request isn't profiled when sent without the profile header", func(t *testing.T) {
+		cfg := defaultIngesterTestConfig(t)
+		cfg.LabelValuesCardinality.Profile.Dir = t.TempDir()
+		i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+		ctx := pushSeriesToIngester(t, inputSeries, i)
+
+		require.NoError(t, i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx}))
+		require.Empty(t, profileFiles(t, cfg.LabelValuesCardinality.Profile.Dir))
+	})
+
+	t.Run("the oldest profiles are deleted, and the others are deleted when the ingester stops", func(t *testing.T) {
+		cfg := defaultIngesterTestConfig(t)
+		cfg.LabelValuesCardinality.Profile.Dir = t.TempDir()
+		cfg.LabelValuesCardinality.Profile.MaxFiles = 2
+		i := requireActiveIngesterWithBlocksStorage(t, cfg, nil)
+		ctx := pushSeriesToIngester(t, inputSeries, i)
+		ctx = grpc_metadata.NewIncomingContext(ctx, grpc_metadata.Pairs(labelValuesCardinalityProfileHeader, "true"))
+
+		for n := 0; n < 3; n++ {
+			require.NoError(t, i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx}))
+		}
+		require.Len(t, profileFiles(t, cfg.LabelValuesCardinality.Profile.Dir), 2)
+
+		require.NoError(t, services.StopAndAwaitTerminated(context.Background(), i))
+		require.Empty(t, profileFiles(t, cfg.LabelValuesCardinality.Profile.Dir))
+	})
+}
+
+func TestIngester_LabelValuesCardinalityEmptyResultCache(t *testing.T) {
+	inputSeries := []series{
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}}, 1, 100000},
+	}
+	// The matcher has a typo, so the request returns an empty result.
+	req := &client.LabelValuesCardinalityRequest{
+		LabelNames: []string{"status"},
+		Matchers:   []*client.LabelMatcher{{Type: client.EQUAL, Name: labels.MetricName, Value: "metric_O"}},
+	}
+
+	cfg := defaultIngesterTestConfig(t)
+	cfg.LabelValuesCardinality.EmptyResultCacheTTL = time.Hour
+	registry := prometheus.NewRegistry()
+	i := requireActiveIngesterWithBlocksStorage(t, cfg, registry)
+	ctx := pushSeriesToIngester(t, inputSeries, i)
+
+	cacheHits := func(expected int) {
+		t.Helper()
+		require.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(fmt.Sprintf(`
+			# HELP cortex_ingester_label_values_cardinality_empty_result_cache_hits_total The total number of label values cardinality requests served from the cache of the requests which returned an empty result.
+			# TYPE cortex_ingester_label_values_cardinality_empty_result_cache_hits_total counter
+			cortex_ingester_label_values_cardinality_empty_result_cache_hits_total %d
+		`, expected)), "cortex_ingester_label_values_cardinality_empty_result_cache_hits_total"))
+	}
+
+	server := &mockLabelValuesCardinalityServer{context: ctx}
+	require.NoError(t, i.LabelValuesCardinality(req, server))
+	require.Empty(t, server.SentResponses)
+
+	// The same request is served from the cache without querying the index.
+	server = &mockLabelValuesCardinalityServer{context: ctx}
+	require.NoError(t, i.LabelValuesCardinality(req, server))
+	require.Empty(t, server.SentResponses)
+	cacheHits(1)
+
+	// A different request isn't served from the cache.
+	otherReq := &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}, Matchers: req.Matchers, MinSeriesCount: 1}
+	server = &mockLabelValuesCardinalityServer{context: ctx}
+	require.NoError(t, i.LabelValuesCardinality(otherReq, server))
+	require.Empty(t, server.SentResponses)
+	cacheHits(1)
+
+	// Once a matching series has been appended, the request is computed again.
+	pushSeriesToIngester(t, []series{
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_O"}, {Name: "status", Value: "200"}}, 1, 100000},
+	}, i)
+	server = &mockLabelValuesCardinalityServer{context: ctx}
+	require.NoError(t, i.LabelValuesCardinality(req, server))
+	require.Len(t, server.SentResponses, 1)
+	require.Equal(t, map[string]uint64{"200": 1}, server.SentResponses[0].Items[0].LabelValueSeries)
+	cacheHits(1)
+
+	// Once the TTL has expired, the request is computed again.
+	emptyReq := &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}, Matchers: []*client.LabelMatcher{{Type: client.EQUAL, Name: labels.MetricName, Value: "metric_1"}}}
+	require.NoError(t, i.LabelValuesCardinality(emptyReq, &mockLabelValuesCardinalityServer{context: ctx}))
+	require.NoError(t, i.LabelValuesCardinality(emptyReq, &mockLabelValuesCardinalityServer{context: ctx}))
+	cacheHits(2)
+	i.labelValuesCardinalityEmptyResults.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	require.NoError(t, i.LabelValuesCardinality(emptyReq, &mockLabelValuesCardinalityServer{context: ctx}))
+	cacheHits(2)
+
+	// A stopped ingester doesn't serve the requests from the cache.
+	i.labelValuesCardinalityEmptyResults.now = time.Now
+	require.NoError(t, i.LabelValuesCardinality(emptyReq, &mockLabelValuesCardinalityServer{context: ctx}))
+	require.NoError(t, services.StopAndAwaitTerminated(context.Background(), i))
+	err := i.LabelValuesCardinality(emptyReq, &mockLabelValuesCardinalityServer{context: ctx})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+}
+
+func TestIngester_LabelCardinalityRejections(t *testing.T) {
+	inputSeries := []series{
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "500"}}, 1, 100000},
+		{labels.Labels{{Name: labels.MetricName, Value: "metric_0"}, {Name: "status", Value: "200"}}, 1, 110000},
+	}
+
+	tests := map[string]struct {
+		maxSeries              int
+		maxSelectedSeriesRatio float64
+		call                   func(*Ingester, context.Context) error
+		expectedErr            bool
+		expectedMetrics        string
+	}{
+		"too many selected series": {
+			// The matchers select 1 of the 2 series of the head.
+			maxSelectedSeriesRatio: 0.4,
+			call: func(i *Ingester, ctx context.Context) error {
+				req := &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}, Matchers: []*client.LabelMatcher{{Type: client.EQUAL, Name: "status", Value: "500"}}}
+				return i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx})
+			},
+			expectedErr: true,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_cardinality_rejected_total The total number of label values cardinality requests rejected by the ingester, by tenant bucket and reason. Only a limited number of tenants get their own bucket, the other ones are tracked in the "other" bucket.
+				# TYPE cortex_ingester_label_cardinality_rejected_total counter
+				cortex_ingester_label_cardinality_rejected_total{reason="too_many_selected_series",tenant_bucket="test"} 1
+			`,
+		},
+		"selected series below the max ratio": {
+			maxSelectedSeriesRatio: 0.5,
+			call: func(i *Ingester, ctx context.Context) error {
+				req := &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}, Matchers: []*client.LabelMatcher{{Type: client.EQUAL, Name: "status", Value: "500"}}}
+				return i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx})
+			},
+			expectedMetrics: ``,
+		},
+		"max series exceeded": {
+			maxSeries: 1,
+			call: func(i *Ingester, ctx context.Context) error {
+				req := &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}}
+				return i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx})
+			},
+			expectedErr: true,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_cardinality_rejected_total The total number of label values cardinality requests rejected by the ingester, by tenant bucket and reason. Only a limited number of tenants get their own bucket, the other ones are tracked in the "other" bucket.
+				# TYPE cortex_ingester_label_cardinality_rejected_total counter
+				cortex_ingester_label_cardinality_rejected_total{reason="max_series_exceeded",tenant_bucket="test"} 1
+			`,
+		},
+		"invalid shard": {
+			call: func(i *Ingester, ctx context.Context) error {
+				req := &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}, ShardIndex: 2, ShardCount: 2}
+				return i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx})
+			},
+			expectedErr: true,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_cardinality_rejected_total The total number of label values cardinality requests rejected by the ingester, by tenant bucket and reason. Only a limited number of tenants get their own bucket, the other ones are tracked in the "other" bucket.
+				# TYPE cortex_ingester_label_cardinality_rejected_total counter
+				cortex_ingester_label_cardinality_rejected_total{reason="invalid_request",tenant_bucket="test"} 1
+			`,
+		},
+		"stream without request": {
+			call: func(i *Ingester, ctx context.Context) error {
+				stream := newMockLabelValuesCardinalityStreamServer(ctx)
+				defer stream.close()
+				stream.requests <- &client.LabelValuesCardinalityStreamRequest{}
+				return i.LabelValuesCardinalityStream(stream)
+			},
+			expectedErr: true,
+			expectedMetrics: `
+				# HELP cortex_ingester_label_cardinality_rejected_total The total number of label values cardinality requests rejected by the ingester, by tenant bucket and reason. Only a limited number of tenants get their own bucket, the other ones are tracked in the "other" bucket.
+				# TYPE cortex_ingester_label_cardinality_rejected_total counter
+				cortex_ingester_label_cardinality_rejected_total{reason="invalid_request",tenant_bucket="test"} 1
+			`,
+		},
+		"successful requests are not tracked": {
+			call: func(i *Ingester, ctx context.Context) error {
+				req := &client.LabelValuesCardinalityRequest{LabelNames: []string{"status"}}
+				return i.LabelValuesCardinality(req, &mockLabelValuesCardinalityServer{context: ctx})
+			},
+			expectedMetrics: ``,
+		},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			cfg := defaultIngesterTestConfig(t)
+			cfg.LabelValuesCardinality.Limits.MaxSeries = testData.maxSeries
+			cfg.LabelValuesCardinality.Limits.MaxSelectedSeriesRatio = testData.maxSelectedSeriesRatio
+			registry := prometheus.NewRegistry()
+			i := requireActiveIngesterWithBlocksStorage(t, cfg, registry)
+			ctx := pushSeriesToIngester(t, inputSeries, i)
+
+			err := testData.call(i, ctx)
+			if testData.expectedErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(testData.expectedMetrics), "cortex_ingester_label_cardinality_rejected_total"))
+		})
+	}
+}
+
 func BenchmarkIngester_LabelValuesCardinality(b *testing.B) {
 	var (
 		userID              = "test"
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// labelIndexReadPool bounds the number of workers reading the index concurrently on behalf of the label names and
+// values requests and the label values cardinality requests, so that the requests running at the same time don't
+// oversubscribe the CPU. It's shared by all the requests of the ingester. A nil pool doesn't bound the workers.
+//
+// The workers must only hold a slot while reading the index, and never wait for a slot while holding one, otherwise
+// the workers could wait for each other.
+type labelIndexReadPool struct {
+	sem *semaphore.Weighted
+}
+
+// newLabelIndexReadPool returns a pool of size workers, or nil if size is not greater than 0.
+func newLabelIndexReadPool(size int) *labelIndexReadPool {
+	if size <= 0 {
+		return nil
+	}
+	return &labelIndexReadPool{sem: semaphore.NewWeighted(int64(size))}
+}
+
+// acquire waits until a worker slot is available, or the context is done. The returned function releases the slot.
+func (p *labelIndexReadPool) acquire(ctx context.Context) (release func(), _ error) {
+	if p == nil {
+		return func() {}, nil
+	}
+	if err := p.sem.Acquire(ctx, 1); err != nil {
+		return nil, err
+	}
+	return func() { p.sem.Release(1) }, nil
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/index"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelIndexReadPool_SharedBetweenLabelNamesAndValuesAndCardinality(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for l := 0; l < 8; l++ {
+		for v := 0; v < 8; v++ {
+			name := fmt.Sprintf("lbl-%d", l)
+			existingLabels[name] = append(existingLabels[name], fmt.Sprintf("val-%d", v))
+		}
+	}
+
+	// run runs a label names and values request and a label values cardinality request concurrently, and returns
+	// the maximum number of index reads they ran concurrently.
+	run := func(pool *labelIndexReadPool) int64 {
+		active := &maxTrackingGauge{}
+		idx := &activeReadsIndex{mockIndex: mockIndex{existingLabels: existingLabels}, active: active}
+		postingsForMatchersFn := func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+			active.Inc()
+			defer active.Dec()
+			time.Sleep(time.Millisecond)
+			return &mockPostings{n: 10}, nil
+		}
+
+		wg := sync.WaitGroup{}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{lookup: labelNamesAndValuesLookupOptions{labelValuesPrefetchDepth: 4, indexReadPool: pool}}
+			require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, 1024, opts, server))
+			require.NotEmpty(t, server.SentResponses)
+		}()
+		go func() {
+			defer wg.Done()
+			server := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{allLabels: true, concurrency: labelValuesCardinalityConcurrencyOptions{allLabelsConcurrency: 4, perLabelConcurrency: 8, indexReadPool: pool}}
+			require.NoError(t, labelValuesCardinality(nil, []*labels.Matcher{}, idx, postingsForMatchersFn, 1024, opts, server))
+			require.NotEmpty(t, server.SentResponses)
+		}()
+		wg.Wait()
+		return active.max.Load()
+	}
+
+	const poolSize = 2
+	require.LessOrEqual(t, run(newLabelIndexReadPool(poolSize)), int64(poolSize))
+	// Without the pool, the requests read the index with more workers.
+	require.Greater(t, run(nil), int64(poolSize))
+}
+
+func TestLabelIndexReadPool_Acquire(t *testing.T) {
+	t.Run("nil pool is unbounded", func(t *testing.T) {
+		var pool *labelIndexReadPool
+		require.Nil(t, newLabelIndexReadPool(0))
+		for i := 0; i < 10; i++ {
+			_, err := pool.acquire(context.Background())
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("acquire waits for a released slot", func(t *testing.T) {
+		pool := newLabelIndexReadPool(1)
+		release, err := pool.acquire(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = pool.acquire(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		release()
+		release, err = pool.acquire(context.Background())
+		require.NoError(t, err)
+		release()
+	})
+}
+
+// activeReadsIndex is a mockIndex tracking the label values lookups running concurrently.
+type activeReadsIndex struct {
+	mockIndex
+	active *maxTrackingGauge
+}
+
+func (i *activeReadsIndex) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	i.active.Inc()
+	defer i.active.Dec()
+	time.Sleep(time.Millisecond)
+	return i.mockIndex.LabelValues(name, matchers...)
+}
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"fmt"
+	"regexp/syntax"
+
+	"github.com/gogo/status"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/index"
+	"google.golang.org/grpc/codes"
+)
+
+// maxRegexMatcherInstructions is the maximum number of instructions of the compiled program of a regex matcher.
+// It's high enough for the alternations of a few hundred values built by the dashboards variables.
+const maxRegexMatcherInstructions = 20000
+
+// checkRegexMatchers returns an InvalidArgument error if a regex matcher is expensive to match against the label
+// values: either it nests unbounded quantifiers, like (a+)*, or its compiled program is too big. The regexes are
+// matched in linear time, so they can't backtrack catastrophically, but the cost per byte grows with the program.
+func checkRegexMatchers(matchers []*labels.Matcher) error {
+	for _, m := range matchers {
+		if m.Type != labels.MatchRegexp && m.Type != labels.MatchNotRegexp {
+			continue
+		}
+		re, err := syntax.Parse(m.Value, syntax.Perl)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid regex matcher %s: %v", m, err)
+		}
+		if hasNestedUnboundedQuantifiers(re, false) {
+			return status.Errorf(codes.InvalidArgument, "the regex matcher %s is rejected because it nests unbounded quantifiers", m)
+		}
+		prog, err := syntax.Compile(re.Simplify())
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid regex matcher %s: %v", m, err)
+		}
+		if len(prog.Inst) > maxRegexMatcherInstructions {
+			return status.Errorf(codes.InvalidArgument, "the regex matcher %s is rejected because it's too complex: it compiles to %d instructions, more than the maximum of %d", m, len(prog.Inst), maxRegexMatcherInstructions)
+		}
+	}
+	return nil
+}
+
+// hasNestedUnboundedQuantifiers returns whether an unbounded quantifier is nested in another one in the regex.
+// inQuantifier is whether the regex is itself in an unbounded quantifier.
+func hasNestedUnboundedQuantifiers(re *syntax.Regexp, inQuantifier bool) bool {
+	unbounded := re.Op == syntax.OpStar || re.Op == syntax.OpPlus || (re.Op == syntax.OpRepeat && re.Max == -1)
+	if unbounded && inQuantifier {
+		return true
+	}
+	for _, sub := range re.Sub {
+		if hasNestedUnboundedQuantifiers(sub, inQuantifier || unbounded) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkNonEmptyMatcher returns an InvalidArgument error if all the matchers match the empty string, like foo=~".*",
+// or if there are no matchers, in which case they select all the series and counting them iterates the whole index.
+func checkNonEmptyMatcher(matchers []*labels.Matcher) error {
+	for _, m := range matchers {
+		if !m.Matches("") {
+			return nil
+		}
+	}
+	return status.Error(codes.InvalidArgument, "the label values cardinality request is rejected because its matchers select all the series: at least one matcher must not match the empty string")
+}
+
+// checkContradictoryMatchers returns an error if several matchers on the same label name can't all match.
+// Only the contradictions with an equality matcher are detected: the value of the equality matcher must be
+// matched by all the other matchers on the same label name.
+func checkContradictoryMatchers(matchers []*labels.Matcher) error {
+	for _, eq := range matchers {
+		if eq.Type != labels.MatchEqual {
+			continue
+		}
+		for _, m := range matchers {
+			if m != eq && m.Name == eq.Name && !m.Matches(eq.Value) {
+				return invalidLabelValuesCardinalityRequestError(fmt.Sprintf("the matchers %s and %s on the same label name can't both match", eq, m))
+			}
+		}
+	}
+	return nil
+}
+
+// checkSelectedSeriesRatio returns a FailedPrecondition error if the matchers are empty, or if they select more than
+// maxRatio of the totalSeries of the tenant. The selected series are counted from the postings, without reading the
+// series, and only until the ratio is exceeded.
+func checkSelectedSeriesRatio(
+	ctx context.Context,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	matchers []*labels.Matcher,
+	maxRatio float64,
+	totalSeries uint64,
+) error {
+	if len(matchers) == 0 {
+		return status.Error(codes.FailedPrecondition, "the label values cardinality request selects all the series of the tenant: narrow it down with matchers")
+	}
+	if totalSeries == 0 {
+		return nil
+	}
+	maxSelected := uint64(maxRatio * float64(totalSeries))
+
+	p, err := postingsForMatchersFn(idxReader, matchers...)
+	if err != nil {
+		return err
+	}
+	var selected uint64
+	for p.Next() {
+		selected++
+		if selected > maxSelected {
+			return status.Errorf(codes.FailedPrecondition, "the matchers of the label values cardinality request select more than the allowed %.1f%% of the series of the tenant: narrow it down with more selective matchers", maxRatio*100)
+		}
+		if selected%checkContextErrorSeriesCount == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+	}
+	return p.Err()
+}
+
+// checkRegexMatchersCandidateValues returns a ResourceExhausted error if a regex matcher would be evaluated against
+// more than maxCandidates values of its label name. The regex matchers which are an alternation of literal values,
+// like foo=~"a|b|c", are not checked, because they're never evaluated against the label values: their series are
+// looked up directly from the postings of the literal values, like a set matcher.
+func checkRegexMatchersCandidateValues(idxReader tsdb.IndexReader, matchers []*labels.Matcher, maxCandidates int) error {
+	for _, m := range matchers {
+		if m.Type != labels.MatchRegexp && m.Type != labels.MatchNotRegexp {
+			continue
+		}
+		if _, ok := regexMatcherSetMatches(m); ok {
+			continue
+		}
+		values, err := idxReader.LabelValues(m.Name)
+		if err != nil {
+			return err
+		}
+		if len(values) > maxCandidates {
+			return status.Errorf(codes.ResourceExhausted, "the regex matcher %s is rejected because it would be evaluated against %d values of the label %s, more than the maximum of %d: use an alternation of literal values, or more selective matchers", m, len(values), m.Name, maxCandidates)
+		}
+	}
+	return nil
+}
+
+// regexMatcherSetMatches returns the literal values matched by the regex matcher, and whether its series are looked
+// up directly from the postings of these values, instead of evaluating the regex against all the label values.
+// That's the case of the regex matchers which are an alternation of literal values and don't match the empty string.
+func regexMatcherSetMatches(m *labels.Matcher) ([]string, bool) {
+	if m.Type != labels.MatchRegexp || m.Matches("") {
+		return nil, false
+	}
+	setMatches := m.SetMatches()
+	return setMatches, len(setMatches) > 0
+}
+
+// normalizeMatchers returns an empty slice for nil matchers, so that nil and empty matchers are handled the same way.
+func normalizeMatchers(matchers []*labels.Matcher) []*labels.Matcher {
+	if matchers == nil {
+		return []*labels.Matcher{}
+	}
+	return matchers
+}
+
+// countMatchingSeries returns the number of series matching the matchers,
+// or the number of all series in the index if there are no matchers.
+func countMatchingSeries(
+	ctx context.Context,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	matchers []*labels.Matcher,
+) (uint64, error) {
+	if len(matchers) == 0 {
+		postingsForMatchersFn = func(r tsdb.IndexPostingsReader, _ ...*labels.Matcher) (index.Postings, error) {
+			return r.Postings(index.AllPostingsKey())
+		}
+	}
+	return countLabelValueSeries(ctx, idxReader, postingsForMatchersFn, matchers, 0, 0, checkContextErrorSeriesCount)
+}
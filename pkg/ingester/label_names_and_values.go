@@ -4,15 +4,431 @@ package ingester
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"math/bits"
+	"sort"
+	"sync"
+	"unicode/utf8"
 
+	"github.com/gogo/status"
+	"github.com/pkg/errors"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/tsdb"
 	"github.com/prometheus/prometheus/tsdb/index"
+	"golang.org/x/text/collate"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc/codes"
 
 	"github.com/grafana/mimir/pkg/ingester/client"
 )
 
-const checkContextErrorSeriesCount = 1000 // series count interval in which context cancellation must be checked.
+const labelNamesAndValuesMaxTotalBytesFlag = "ingester.label-names-and-values-max-total-bytes"
+
+var errResponseTooLarge = errors.New("the label names and values request has been aborted because its response exceeded the maximum size, configured with -" + labelNamesAndValuesMaxTotalBytesFlag)
+
+// LabelNamesAndValuesConfig configures the label names and values requests served by the ingester.
+type LabelNamesAndValuesConfig struct {
+	MaxTotalBytes int `yaml:"label_names_and_values_max_total_bytes" category:"experimental"`
+	PrefetchDepth int `yaml:"label_names_and_values_prefetch_depth" category:"experimental"`
+	MaxResultSize int `yaml:"label_names_and_values_max_result_size" category:"experimental"`
+	MaxLabelNames int `yaml:"label_names_and_values_max_label_names" category:"experimental"`
+}
+
+func (cfg *LabelNamesAndValuesConfig) RegisterFlags(f *flag.FlagSet) {
+	f.IntVar(&cfg.MaxTotalBytes, labelNamesAndValuesMaxTotalBytesFlag, 0, "Maximum size in bytes of all the messages of the streamed label names and values response. Requests exceeding the limit are aborted. 0 = unlimited.")
+	f.IntVar(&cfg.PrefetchDepth, "ingester.label-names-and-values-prefetch-depth", 0, "Number of label names whose values are looked up ahead of the label being sent by the label names and values requests, overlapping the index lookups with sending the response. 0 to disable.")
+	f.IntVar(&cfg.MaxResultSize, "ingester.label-names-and-values-max-result-size", 0, "Maximum number of label values returned by a label names and values request. Once it's reached, the response is flagged as truncated. Requests can ask for a lower maximum. 0 = unlimited.")
+	f.IntVar(&cfg.MaxLabelNames, "ingester.label-names-and-values-max-label-names", 0, "Maximum number of label names whose values are looked up by a label names and values request. Beyond it, only the first label names in lexicographic order are returned, and the response is flagged as having truncated label names. 0 = unlimited.")
+}
+
+// labelNamesAndValuesOptions holds the optional behaviours of labelNamesAndValues.
+type labelNamesAndValuesOptions struct {
+	// includeSeriesCount enables counting the series matching the matchers. The count is sent in the last message.
+	includeSeriesCount bool
+	// postingsForMatchersFn is used to count the series matching the matchers, when includeSeriesCount is set.
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error)
+	// omitValues enables returning only the label names, without their values.
+	omitValues bool
+	// includeValueCount enables returning the number of distinct values of each label, even if omitValues is set.
+	includeValueCount bool
+	// longValueLengthThreshold enables reporting the label values longer than this number of bytes, if greater than 0.
+	// Long values are not reported when omitValues is set.
+	longValueLengthThreshold int
+	// valuesLess, if set, is used to sort the values of each label before they're split across messages.
+	valuesLess func(a, b string) bool
+	// ensureSortedValues enables sorting the values returned by the index readers which are not sorted, because the
+	// merge of the head and blocks values, and the clients resuming a request from the last value received, rely on it.
+	ensureSortedValues bool
+	// dedupValues enables dropping the duplicate values of each label returned by the index reader, keeping the
+	// first occurrence of each value. The values are deduplicated before their size is accounted.
+	dedupValues bool
+	// blocksIndex, if set, is used to look up the labels and values of the persisted blocks. The labels and values
+	// of both the head and the blocks are then merged and deduplicated.
+	blocksIndex labelsReader
+	// includePresence enables flagging each value with where it's present. It requires blocksIndex.
+	includePresence bool
+	// valuesBloomFilter, if set, filters out the label values which are not in the bloom filter.
+	valuesBloomFilter *client.LabelValuesBloomFilter
+	// checkpointer, if set, persists a checkpoint after each message sent, and resumes the request from the last
+	// persisted checkpoint.
+	checkpointer *labelNamesAndValuesCheckpointer
+	// includeRelabelOutcomes enables returning each value with the outcome of relabelConfigs, applied to a series
+	// made of the label alone.
+	includeRelabelOutcomes bool
+	relabelConfigs         []*relabel.Config
+	// shardIndex and shardCount restrict the label names and values to the ones of the series whose hash falls in
+	// the shard. Sharding is disabled if shardCount is 0.
+	shardIndex uint64
+	shardCount uint64
+
+	limits   labelNamesAndValuesLimits
+	lookup   labelNamesAndValuesLookupOptions
+	encoding labelNamesAndValuesEncodingOptions
+}
+
+// labelNamesAndValuesLimits holds the limits on the labels and values returned by labelNamesAndValues.
+type labelNamesAndValuesLimits struct {
+	// maxTotalBytes, if greater than 0, is the maximum number of bytes of all the messages of the response.
+	// The request is aborted with errResponseTooLarge when the limit is exceeded.
+	maxTotalBytes int
+	// maxDistinctValues, if greater than 0, filters out the labels with more distinct values. The values are counted
+	// before they're filtered by the bloom filter.
+	maxDistinctValues int
+	// maxValues, if greater than 0, is the maximum number of label values returned across the whole response.
+	// Once it's reached, the remaining labels and values are not returned and the last message is flagged as truncated.
+	maxValues int
+	// maxLabelNames, if greater than 0, is the maximum number of label names returned. Beyond it, only the first
+	// maxLabelNames label names are returned, without looking up the values of the other ones, and the last message
+	// is flagged as having truncated label names.
+	maxLabelNames int
+	// rejectComplexRegexes enables rejecting the regex matchers which nest unbounded quantifiers or compile
+	// to too many instructions.
+	rejectComplexRegexes bool
+}
+
+// labelNamesAndValuesLookupOptions holds how labelNamesAndValues looks up the label names and values in the index.
+type labelNamesAndValuesLookupOptions struct {
+	// labelValuesPrefetchDepth is the number of label names whose values are looked up ahead of the label being sent.
+	// Values lower than 1 disable prefetching.
+	labelValuesPrefetchDepth int
+	// indexReadPool, if set, bounds the number of workers prefetching the label values, together with the workers
+	// of the other requests sharing the pool.
+	indexReadPool *labelIndexReadPool
+	// backgroundLookups, if set, tracks the label names lookup left running in the background when the context is
+	// done, so that the request can return right away. The index readers must then be closed through it.
+	backgroundLookups *backgroundLookups
+}
+
+// labelNamesAndValuesEncodingOptions holds how labelNamesAndValues encodes the labels and values in the messages.
+type labelNamesAndValuesEncodingOptions struct {
+	// useValueIDs enables sending the symbols of the index as a dictionary in the first messages, and the label
+	// values as IDs in the dictionary. It can't be used with blocksIndex, whose values may not be in the symbols.
+	useValueIDs bool
+	// compressionDictionary, if not empty, enables compressing the values of each label with DEFLATE,
+	// using it as preset dictionary.
+	compressionDictionary []byte
+	// valuesPreviewSize, if greater than 0, enables sending each label name with a preview of up to valuesPreviewSize
+	// values, and the remaining values of all the labels after the previews. It can't be used with
+	// partitionByFirstCharacter or checkpointer, which rely on the labels being sent in order.
+	valuesPreviewSize int
+	// partitionByFirstCharacter enables partitioning the labels by the first character of their name.
+	// Each message only carries labels of a single partition, tagged with the partition key.
+	partitionByFirstCharacter bool
+}
+
+// labelsReader is the subset of tsdb.IndexReader used to look up the label names and values.
+type labelsReader interface {
+	LabelNames(matchers ...*labels.Matcher) ([]string, error)
+	LabelValues(name string, matchers ...*labels.Matcher) ([]string, error)
+}
+
+// multiLabelsReader looks up the label names and values of multiple readers, merging their results.
+// The returned names and values are sorted.
+type multiLabelsReader []labelsReader
+
+func (r multiLabelsReader) LabelNames(matchers ...*labels.Matcher) ([]string, error) {
+	var names [][]string
+	for _, reader := range r {
+		n, err := reader.LabelNames(matchers...)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, n)
+	}
+	return mergeStrings(names...), nil
+}
+
+func (r multiLabelsReader) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	var values [][]string
+	for _, reader := range r {
+		v, err := reader.LabelValues(name, matchers...)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return mergeStrings(values...), nil
+}
+
+// mergeStrings returns the sorted union of the input strings.
+func mergeStrings(inputs ...[]string) []string {
+	set := map[string]struct{}{}
+	for _, input := range inputs {
+		for _, s := range input {
+			set[s] = struct{}{}
+		}
+	}
+	merged := make([]string, 0, len(set))
+	for s := range set {
+		merged = append(merged, s)
+	}
+	sort.Strings(merged)
+	return merged
+}
+
+// labelValuesPresence returns where each of the values is present, given the values of the head and of the blocks.
+func labelValuesPresence(values, headValues, blocksValues []string) []client.LabelValuePresence {
+	inHead := make(map[string]struct{}, len(headValues))
+	for _, v := range headValues {
+		inHead[v] = struct{}{}
+	}
+	inBlocks := make(map[string]struct{}, len(blocksValues))
+	for _, v := range blocksValues {
+		inBlocks[v] = struct{}{}
+	}
+
+	presence := make([]client.LabelValuePresence, len(values))
+	for i, v := range values {
+		_, head := inHead[v]
+		_, blocks := inBlocks[v]
+		switch {
+		case head && blocks:
+			presence[i] = client.PRESENT_IN_HEAD_AND_BLOCKS
+		case head:
+			presence[i] = client.PRESENT_IN_HEAD_ONLY
+		default:
+			presence[i] = client.PRESENT_IN_BLOCKS_ONLY
+		}
+	}
+	return presence
+}
+
+// labelNamePartitionKey returns the key of the partition of the label name, which is its first character.
+func labelNamePartitionKey(labelName string) string {
+	if labelName == "" {
+		return ""
+	}
+	_, size := utf8.DecodeRuneInString(labelName)
+	return labelName[:size]
+}
+
+// longLabelValuesMaxExemplars is the maximum number of long values reported as exemplars for each label.
+const longLabelValuesMaxExemplars = 3
+
+// findLongLabelValues returns the report of the values longer than lengthThreshold, or nil if there are none.
+func findLongLabelValues(labelName string, values []string, lengthThreshold int) *client.LongLabelValues {
+	var report *client.LongLabelValues
+	for _, val := range values {
+		if len(val) <= lengthThreshold {
+			continue
+		}
+		if report == nil {
+			report = &client.LongLabelValues{LabelName: labelName}
+		}
+		report.Count++
+		if len(report.Exemplars) < longLabelValuesMaxExemplars {
+			report.Exemplars = append(report.Exemplars, val)
+		}
+	}
+	return report
+}
+
+const (
+	labelNamesAndValuesFieldNames  = "names"
+	labelNamesAndValuesFieldValues = "values"
+)
+
+// parseLabelNamesAndValuesFields parses the fields requested to labelNamesAndValues,
+// returning whether the label values must be omitted. No fields means all fields.
+func parseLabelNamesAndValuesFields(fields []string) (omitValues bool, err error) {
+	if len(fields) == 0 {
+		return false, nil
+	}
+	names, values := false, false
+	for _, field := range fields {
+		switch field {
+		case labelNamesAndValuesFieldNames:
+			names = true
+		case labelNamesAndValuesFieldValues:
+			values = true
+		default:
+			return false, fmt.Errorf("unknown field %q, supported fields are %q and %q", field, labelNamesAndValuesFieldNames, labelNamesAndValuesFieldValues)
+		}
+	}
+	if !names {
+		return false, fmt.Errorf("the %q field can't be omitted", labelNamesAndValuesFieldNames)
+	}
+	return !values, nil
+}
+
+// shardLabelsIndex is an index reader whose label names and values are the ones of the series of a shard matching
+// the matchers it has been built with, regardless of the matchers passed to LabelNames and LabelValues.
+type shardLabelsIndex struct {
+	tsdb.IndexReader
+	names       []string
+	values      map[string][]string
+	seriesCount uint64
+}
+
+// newShardLabelsIndex reads the labels of the series of the shard matching the matchers, selected with the sharded
+// postings of the index.
+func newShardLabelsIndex(
+	ctx context.Context,
+	idx tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	matchers []*labels.Matcher,
+	shardIndex, shardCount uint64,
+) (*shardLabelsIndex, error) {
+	var (
+		postings index.Postings
+		err      error
+	)
+	if len(matchers) == 0 {
+		postings, err = idx.Postings(index.AllPostingsKey())
+	} else {
+		if postingsForMatchersFn == nil {
+			postingsForMatchersFn = tsdb.PostingsForMatchers
+		}
+		postings, err = postingsForMatchersFn(idx, matchers...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	postings = idx.ShardedPostings(postings, shardIndex, shardCount)
+
+	valueSets := map[string]map[string]struct{}{}
+	shardIdx := &shardLabelsIndex{IndexReader: idx}
+	var lset labels.Labels
+	for postings.Next() {
+		if shardIdx.seriesCount%checkContextErrorSeriesCount == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if err := idx.Series(postings.At(), &lset, nil); err != nil {
+			// The series may have been garbage collected since the postings have been read.
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		shardIdx.seriesCount++
+		for _, l := range lset {
+			if valueSets[l.Name] == nil {
+				valueSets[l.Name] = map[string]struct{}{}
+			}
+			valueSets[l.Name][l.Value] = struct{}{}
+		}
+	}
+	if err := postings.Err(); err != nil {
+		return nil, err
+	}
+
+	shardIdx.values = make(map[string][]string, len(valueSets))
+	for name, set := range valueSets {
+		shardIdx.names = append(shardIdx.names, name)
+		values := make([]string, 0, len(set))
+		for val := range set {
+			values = append(values, val)
+		}
+		sort.Strings(values)
+		shardIdx.values[name] = values
+	}
+	sort.Strings(shardIdx.names)
+	return shardIdx, nil
+}
+
+func (i *shardLabelsIndex) LabelNames(...*labels.Matcher) ([]string, error) {
+	return i.names, nil
+}
+
+func (i *shardLabelsIndex) LabelValues(name string, _ ...*labels.Matcher) ([]string, error) {
+	return i.values[name], nil
+}
+
+// labelValuesLookup looks up the values of the label names.
+type labelValuesLookup struct {
+	index      tsdb.IndexReader
+	labelNames []string
+	matchers   []*labels.Matcher
+
+	// prefetched receives the values of the label names, in order, when prefetching.
+	prefetched     chan labelValuesResult
+	nextPrefetched int
+}
+
+type labelValuesResult struct {
+	values []string
+	err    error
+}
+
+func newLabelValuesLookup(index tsdb.IndexReader, labelNames []string, matchers []*labels.Matcher) *labelValuesLookup {
+	return &labelValuesLookup{index: index, labelNames: labelNames, matchers: matchers}
+}
+
+// prefetch starts looking up the values of the label names in the background, up to depth label names ahead of
+// the values returned by valuesAt, so that the index latency overlaps with sending the messages. The values are
+// only prefetched if depth is greater than 0. valuesAt must then be called with increasing indexes. Each lookup holds a worker slot of the pool. The returned function stops prefetching,
+// and must be called before closing the index.
+func (l *labelValuesLookup) prefetch(ctx context.Context, depth int, pool *labelIndexReadPool) (stop func()) {
+	if depth <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	l.prefetched = make(chan labelValuesResult, depth)
+	go func() {
+		defer close(done)
+		defer close(l.prefetched)
+		for _, name := range l.labelNames {
+			values, err := pooledLabelValues(ctx, l.index, name, l.matchers, pool)
+			select {
+			case l.prefetched <- labelValuesResult{values: values, err: err}:
+			case <-ctx.Done():
+				return
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// valuesAt returns the values of the i-th label name.
+func (l *labelValuesLookup) valuesAt(i int) ([]string, error) {
+	if l.prefetched != nil {
+		for ; l.nextPrefetched <= i; l.nextPrefetched++ {
+			res, ok := <-l.prefetched
+			if !ok {
+				return nil, fmt.Errorf("the values of label name %d have not been prefetched", i)
+			}
+			if res.err != nil || l.nextPrefetched == i {
+				l.nextPrefetched++
+				return res.values, res.err
+			}
+		}
+		return nil, fmt.Errorf("the values of label name %d have already been returned", i)
+	}
+	return l.index.LabelValues(l.labelNames[i], l.matchers...)
+}
 
 // labelNamesAndValues streams the messages with the labels and values of the labels matching the `matchers` param.
 // Messages are immediately sent as soon they reach message size threshold defined in `messageSizeThreshold` param.
@@ -20,169 +436,497 @@ func labelNamesAndValues(
 	index tsdb.IndexReader,
 	matchers []*labels.Matcher,
 	messageSizeThreshold int,
+	opts labelNamesAndValuesOptions,
 	server client.Ingester_LabelNamesAndValuesServer,
 ) error {
 	ctx := server.Context()
+	// The index isn't read for the requests whose client has already given up.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	matchers = normalizeMatchers(matchers)
+	if opts.limits.rejectComplexRegexes {
+		if err := checkRegexMatchers(matchers); err != nil {
+			return err
+		}
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
 
-	labelNames, err := index.LabelNames(matchers...)
+	var seriesCount *uint64
+	if opts.shardCount > 0 {
+		shardIndex, err := newShardLabelsIndex(ctx, index, opts.postingsForMatchersFn, matchers, opts.shardIndex, opts.shardCount)
+		if err != nil {
+			return err
+		}
+		index, seriesCount = shardIndex, &shardIndex.seriesCount
+	}
+
+	var namesReader labelsReader = index
+	if opts.blocksIndex != nil {
+		namesReader = multiLabelsReader{index, opts.blocksIndex}
+	}
+	labelNames, err := labelNamesWithContext(ctx, namesReader, matchers, opts.lookup.backgroundLookups)
 	if err != nil {
 		return err
 	}
+	// The label names are sorted, so the same ones are kept by the requests resumed from a checkpoint.
+	labelNamesTruncated := opts.limits.maxLabelNames > 0 && len(labelNames) > opts.limits.maxLabelNames
+	if labelNamesTruncated {
+		labelNames = labelNames[:opts.limits.maxLabelNames]
+	}
+
+	s := newLabelNamesAndValuesSender(ctx, server, messageSizeThreshold, opts)
+	if opts.checkpointer != nil {
+		resumeFrom, err := opts.checkpointer.load(ctx)
+		if err != nil {
+			return err
+		}
+		if resumeFrom != nil && resumeFrom.Completed {
+			return nil
+		}
+		labelNames = labelNamesFromCheckpoint(labelNames, resumeFrom)
+		s.checkpoint, s.resumeFrom = resumeFrom, resumeFrom
+	}
+	valuesLess := opts.valuesLess
+	if valuesLess == nil {
+		valuesLess = func(a, b string) bool { return a < b }
+	}
+
+	lookup := newLabelValuesLookup(index, labelNames, matchers)
+	if !opts.omitValues || opts.limits.maxDistinctValues > 0 || opts.includeValueCount {
+		defer lookup.prefetch(ctx, opts.lookup.labelValuesPrefetchDepth, opts.lookup.indexReadPool)()
+	}
+
+	// The dictionary of the label values is sent before any item.
+	if opts.encoding.useValueIDs {
+		if err := s.sendDictionary(index.Symbols()); err != nil {
+			return err
+		}
+	}
 
-	response := client.LabelNamesAndValuesResponse{}
-	responseSizeBytes := 0
-	for _, labelName := range labelNames {
+	// remainders are the values of the labels which haven't been sent with their preview.
+	var remainders []labelValuesRemainder
+	for labelIdx, labelName := range labelNames {
 		if err := ctx.Err(); err != nil {
 			return err
 		}
-		labelItem := &client.LabelValues{LabelName: labelName}
-		responseSizeBytes += len(labelName)
-		// send message if (response size + size of label name of current label) is greater or equals to threshold
-		if responseSizeBytes >= messageSizeThreshold {
-			err = client.SendLabelNamesAndValuesResponse(server, &response)
-			if err != nil {
+		if s.valuesCapReached() {
+			break
+		}
+		// The resumed label has already been sent if its values are not returned.
+		resumedLabel := s.resumeFrom != nil && labelName == s.resumeFrom.LabelName
+		if resumedLabel && opts.omitValues {
+			continue
+		}
+		if opts.encoding.partitionByFirstCharacter {
+			if err := s.setPartitionKey(labelNamePartitionKey(labelName)); err != nil {
 				return err
 			}
-			response.Items = response.Items[:0]
-			responseSizeBytes = len(labelName)
 		}
-		values, err := index.LabelValues(labelName, matchers...)
+		labelItem := &client.LabelValues{LabelName: labelName}
+		if err := s.addLabelName(labelName); err != nil {
+			return err
+		}
+		// The omitted values are only looked up if they're needed to count them.
+		if opts.omitValues && opts.limits.maxDistinctValues <= 0 && !opts.includeValueCount {
+			s.addLabelItem(labelItem)
+			continue
+		}
+		values, presence, err := lookupLabelValues(lookup, labelIdx, matchers, opts)
+		if err != nil {
+			return err
+		}
+		if opts.limits.maxDistinctValues > 0 && len(values) > opts.limits.maxDistinctValues {
+			s.removeLabelName(labelName)
+			continue
+		}
+		if opts.includeValueCount {
+			labelItem.ValueCount = uint64(len(values))
+		}
+		if opts.omitValues {
+			s.addLabelItem(labelItem)
+			continue
+		}
+		if opts.valuesBloomFilter != nil {
+			values, presence = filterLabelValues(values, presence, opts.valuesBloomFilter.MayContain)
+		}
+		if resumedLabel {
+			values, presence = filterLabelValues(values, presence, func(val string) bool {
+				return valuesLess(s.resumeFrom.Value, val)
+			})
+			if len(values) == 0 {
+				s.removeLabelName(labelName)
+				continue
+			}
+		}
+		ids, err := s.labelValueIDs(labelName, values)
 		if err != nil {
 			return err
 		}
 
-		lastAddedValueIndex := -1
-		for i, val := range values {
-			// sum up label values length until response size reached the threshold and after that add all values to the response
-			// starting from last sent value or from the first element and up to the current element (including).
-			responseSizeBytes += len(val)
-			if responseSizeBytes >= messageSizeThreshold {
-				labelItem.Values = values[lastAddedValueIndex+1 : i+1]
-				lastAddedValueIndex = i
-				response.Items = append(response.Items, labelItem)
-				err = client.SendLabelNamesAndValuesResponse(server, &response)
-				if err != nil {
+		allValues := values
+		// Only a preview of the values is sent with the label name, and the remaining values are sent after the previews
+		// of all the labels.
+		if opts.encoding.valuesPreviewSize > 0 && len(values) > opts.encoding.valuesPreviewSize {
+			remaining := labelValuesRemainder{labelName: labelName}
+			remaining.values, remaining.presence, remaining.ids = splitLabelValuesRemainder(values, presence, ids, opts.encoding.valuesPreviewSize)
+			remainders = append(remainders, remaining)
+			values, presence, ids = splitLabelValuesPreview(values, presence, ids, opts.encoding.valuesPreviewSize)
+		}
+		if err := s.emitLabelValues(labelItem, values, presence, ids); err != nil {
+			return err
+		}
+		if opts.longValueLengthThreshold > 0 {
+			if report := findLongLabelValues(labelName, allValues, opts.longValueLengthThreshold); report != nil {
+				if err := s.addLongValues(report); err != nil {
 					return err
 				}
-				// reset label values to reuse labelItem for the next values of current label.
-				labelItem.Values = labelItem.Values[:0]
-				response.Items = response.Items[:0]
-				if i+1 == len(values) {
-					// if it's the last value for this label then response size must be set to `0`
-					responseSizeBytes = 0
-				} else {
-					// if it is not the last value for this label then response size must be set to length of current label name.
-					responseSizeBytes = len(labelName)
-				}
-			} else if i+1 == len(values) {
-				// if response size does not reach the threshold, but it's the last label value then it must be added to labelItem
-				// and label item must be added to response.
-				labelItem.Values = values[lastAddedValueIndex+1 : i+1]
-				response.Items = append(response.Items, labelItem)
 			}
 		}
 	}
-	// send the last message if there is some data that was not sent.
-	if response.Size() > 0 {
-		return client.SendLabelNamesAndValuesResponse(server, &response)
+	for _, remaining := range remainders {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if s.valuesCapReached() {
+			break
+		}
+		if err := s.addLabelName(remaining.labelName); err != nil {
+			return err
+		}
+		if err := s.emitLabelValues(&client.LabelValues{LabelName: remaining.labelName}, remaining.values, remaining.presence, remaining.ids); err != nil {
+			return err
+		}
+	}
+	if opts.includeSeriesCount && seriesCount != nil {
+		s.response.SeriesCount = *seriesCount
+	} else if opts.includeSeriesCount {
+		seriesCount, err := countMatchingSeries(ctx, index, opts.postingsForMatchersFn, matchers)
+		if err != nil {
+			return err
+		}
+		s.response.SeriesCount = seriesCount
+	}
+	return s.sendLast(labelNamesTruncated)
+}
+
+// validate returns an error if the options can't be used together.
+func (o labelNamesAndValuesOptions) validate() error {
+	if o.encoding.valuesPreviewSize > 0 && (o.encoding.partitionByFirstCharacter || o.checkpointer != nil) {
+		return errors.New("the values preview can't be used with the partitioning by first character or the checkpoints")
+	}
+	if o.includePresence && o.blocksIndex == nil {
+		return errors.New("the presence of the label values can't be returned without the blocks index")
+	}
+	if dict := o.encoding.compressionDictionary; len(dict) > 0 {
+		if o.encoding.useValueIDs {
+			return errors.New("the label values can't be compressed when they're returned as IDs")
+		}
+		if err := client.ValidateLabelValuesCompressionDictionary(dict); err != nil {
+			return err
+		}
+	}
+	if o.shardCount > 0 {
+		if o.blocksIndex != nil {
+			return errors.New("the label names and values can't be sharded when the values of the blocks are included")
+		}
+		if o.shardIndex >= o.shardCount {
+			return status.Errorf(codes.InvalidArgument, "invalid shard index %d: it must be lower than the shard count %d", o.shardIndex, o.shardCount)
+		}
+	}
+	if o.encoding.useValueIDs && o.blocksIndex != nil {
+		return errors.New("the label values can't be returned as IDs when the values of the blocks are included")
 	}
 	return nil
 }
 
-// labelValuesCardinality returns all values and series total count for label_names labels that match the matchers.
-// Messages are immediately sent as soon they reach message size threshold.
-func labelValuesCardinality(
-	lbNames []string,
-	matchers []*labels.Matcher,
-	idxReader tsdb.IndexReader,
-	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
-	msgSizeThreshold int,
-	srv client.Ingester_LabelValuesCardinalityServer,
-) error {
-	ctx := srv.Context()
+// lookupLabelValues returns the values of the i-th label of the lookup, merged with the values of the blocks if the
+// blocks index is set, with where each value is present if it's requested.
+func lookupLabelValues(lookup *labelValuesLookup, i int, matchers []*labels.Matcher, opts labelNamesAndValuesOptions) ([]string, []client.LabelValuePresence, error) {
+	values, err := lookup.valuesAt(i)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.dedupValues {
+		values = dedupLabelValues(values)
+	}
+	if opts.ensureSortedValues {
+		values = ensureSortedLabelValues(values)
+	}
+	if opts.blocksIndex == nil {
+		if opts.valuesLess != nil {
+			values = sortedLabelValues(values, opts.valuesLess)
+		}
+		return values, nil, nil
+	}
+
+	headValues := values
+	blocksValues, err := opts.blocksIndex.LabelValues(lookup.labelNames[i], matchers...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if opts.ensureSortedValues {
+		blocksValues = ensureSortedLabelValues(blocksValues)
+	}
+	values = mergeStrings(headValues, blocksValues)
+	if opts.valuesLess != nil {
+		values = sortedLabelValues(values, opts.valuesLess)
+	}
+	var presence []client.LabelValuePresence
+	if opts.includePresence {
+		presence = labelValuesPresence(values, headValues, blocksValues)
+	}
+	return values, presence, nil
+}
 
-	resp := client.LabelValuesCardinalityResponse{}
-	respSize := 0
+// protoUvarintSize returns the size of the value encoded as a protobuf varint.
+func protoUvarintSize(x uint64) int {
+	return (bits.Len64(x|1) + 6) / 7
+}
 
-	// We will use original matchers + one extra matcher for label value.
-	lblValMatchers := make([]*labels.Matcher, len(matchers)+1)
-	copy(lblValMatchers, matchers)
+// protoBytesFieldSize returns the marshaled size of a length-delimited protobuf field of n bytes, including its tag.
+// The tags of the fields of the label names and values messages fit in 1 byte.
+func protoBytesFieldSize(n int) int {
+	return 1 + protoUvarintSize(uint64(n)) + n
+}
 
-	for _, lbName := range lbNames {
-		if err := ctx.Err(); err != nil {
-			return err
+// labelValuesRemainder holds the values of a label which haven't been sent with its preview.
+type labelValuesRemainder struct {
+	labelName string
+	values    []string
+	presence  []client.LabelValuePresence
+	ids       []uint32
+}
+
+// splitLabelValuesPreview returns the first n values, and their presence and IDs if they're set.
+func splitLabelValuesPreview(values []string, presence []client.LabelValuePresence, ids []uint32, n int) ([]string, []client.LabelValuePresence, []uint32) {
+	if presence != nil {
+		presence = presence[:n]
+	}
+	if ids != nil {
+		ids = ids[:n]
+	}
+	return values[:n], presence, ids
+}
+
+// splitLabelValuesRemainder returns the values after the first n ones, and their presence and IDs if they're set.
+func splitLabelValuesRemainder(values []string, presence []client.LabelValuePresence, ids []uint32, n int) ([]string, []client.LabelValuePresence, []uint32) {
+	if presence != nil {
+		presence = presence[n:]
+	}
+	if ids != nil {
+		ids = ids[n:]
+	}
+	return values[n:], presence, ids
+}
+
+// compressLabelNamesAndValuesResponse returns a copy of the response whose items carry their values compressed
+// with the dictionary. The input response is left untouched, because its items may be reused after being sent.
+func compressLabelNamesAndValuesResponse(resp *client.LabelNamesAndValuesResponse, dict []byte) (*client.LabelNamesAndValuesResponse, error) {
+	compressed := *resp
+	compressed.ValuesCompressionDictionaryId = client.LabelValuesCompressionDictionaryID(dict)
+	compressed.Items = make([]*client.LabelValues, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		compressedItem := *item
+		compressedItem.Values = nil
+		if len(item.Values) > 0 {
+			var err error
+			if compressedItem.CompressedValues, err = client.CompressLabelValues(dict, item.Values); err != nil {
+				return nil, err
+			}
 		}
-		// Obtain all values for current label name.
-		lbValues, err := idxReader.LabelValues(lbName, matchers...)
-		if err != nil {
-			return err
+		compressed.Items = append(compressed.Items, &compressedItem)
+	}
+	return &compressed, nil
+}
+
+// setLabelItemValues sets the values from start to end (excluded) in the item, as IDs if they're set,
+// and with their presence if it's set.
+func setLabelItemValues(item *client.LabelValues, values []string, presence []client.LabelValuePresence, ids []uint32, start, end int) {
+	if ids != nil {
+		item.ValueIds = ids[start:end]
+	} else {
+		item.Values = values[start:end]
+	}
+	if presence != nil {
+		item.Presence = presence[start:end]
+	}
+}
+
+// labelValuesRelabelOutcomes returns the outcome of the relabel configs for each value of the label. The configs
+// are applied to a series made of the label alone.
+func labelValuesRelabelOutcomes(labelName string, values []string, cfgs []*relabel.Config) []client.LabelValueRelabelOutcome {
+	outcomes := make([]client.LabelValueRelabelOutcome, len(values))
+	if len(cfgs) == 0 {
+		return outcomes
+	}
+	for i, val := range values {
+		relabeled := relabel.Process(labels.FromStrings(labelName, val), cfgs...)
+		switch {
+		case relabeled == nil:
+			outcomes[i] = client.RELABEL_DROPPED
+		case relabeled.Get(labelName) != val:
+			outcomes[i] = client.RELABEL_REWRITTEN
 		}
-		// For each value count total number of series storing the result into cardinality response item.
-		var respItem *client.LabelValueSeriesCount
+	}
+	return outcomes
+}
 
-		for _, lbValue := range lbValues {
-			if err := ctx.Err(); err != nil {
-				return err
-			}
-			// Create label name response item entry.
-			if respItem == nil {
-				respItem = &client.LabelValueSeriesCount{
-					LabelName:        lbName,
-					LabelValueSeries: make(map[string]uint64),
-				}
-				resp.Items = append(resp.Items, respItem)
-			}
-			// Get total series count applying label matchers.
-			lblValMatchers[len(lblValMatchers)-1] = labels.MustNewMatcher(labels.MatchEqual, lbName, lbValue)
+// filterLabelValues returns the values for which keep returns true, and their presence if set. The input values are
+// left untouched because they may be shared with the index reader.
+func filterLabelValues(values []string, presence []client.LabelValuePresence, keep func(string) bool) ([]string, []client.LabelValuePresence) {
+	var (
+		filtered         = make([]string, 0, len(values))
+		filteredPresence []client.LabelValuePresence
+	)
+	if presence != nil {
+		filteredPresence = make([]client.LabelValuePresence, 0, len(values))
+	}
+	for i, val := range values {
+		if !keep(val) {
+			continue
+		}
+		filtered = append(filtered, val)
+		if presence != nil {
+			filteredPresence = append(filteredPresence, presence[i])
+		}
+	}
+	return filtered, filteredPresence
+}
 
-			seriesCount, err := countLabelValueSeries(ctx, idxReader, postingsForMatchersFn, lblValMatchers)
-			if err != nil {
-				return err
-			}
-			respItem.LabelValueSeries[lbValue] = seriesCount
+// ensureSortedLabelValues returns the values sorted lexicographically. The values are only copied if they're not sorted.
+func ensureSortedLabelValues(values []string) []string {
+	if sort.StringsAreSorted(values) {
+		return values
+	}
+	return sortedLabelValues(values, func(a, b string) bool { return a < b })
+}
 
-			respSize += len(lbValue)
-			if respSize < msgSizeThreshold {
-				continue
-			}
-			// Flush the response when reached message threshold.
-			if err := client.SendLabelValuesCardinalityResponse(srv, &resp); err != nil {
-				return err
+// dedupLabelValues returns the values without duplicates, keeping the first occurrence of each value.
+// The values are only copied if they have duplicates, leaving the input values untouched because they may be
+// shared with the index reader.
+func dedupLabelValues(values []string) []string {
+	seen := make(map[string]struct{}, len(values))
+	for i, val := range values {
+		if _, ok := seen[val]; !ok {
+			seen[val] = struct{}{}
+			continue
+		}
+		deduped := make([]string, i, len(values)-1)
+		copy(deduped, values[:i])
+		for _, val := range values[i+1:] {
+			if _, ok := seen[val]; !ok {
+				seen[val] = struct{}{}
+				deduped = append(deduped, val)
 			}
-			resp.Items = resp.Items[:0]
-			respSize = 0
-			respItem = nil
 		}
+		return deduped
 	}
-	// Send response in case there are any pending items.
-	if len(resp.Items) > 0 {
-		return client.SendLabelValuesCardinalityResponse(srv, &resp)
-	}
-	return nil
+	return values
 }
 
-func countLabelValueSeries(
-	ctx context.Context,
-	idxReader tsdb.IndexReader,
-	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
-	lblValMatchers []*labels.Matcher,
-) (uint64, error) {
-	var count uint64
+// sortedLabelValues returns a copy of the values sorted with less, leaving the input values untouched
+// because they may be shared with the index reader.
+func sortedLabelValues(values []string, less func(a, b string) bool) []string {
+	sorted := make([]string, len(values))
+	copy(sorted, values)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+	return sorted
+}
 
-	p, err := postingsForMatchersFn(idxReader, lblValMatchers...)
+// collatedValuesLess returns a comparator sorting the values with the collation of the BCP 47 language tag.
+// The values which are equal in the collation are sorted in byte order, so that the order is total.
+func collatedValuesLess(tag string) (func(a, b string) bool, error) {
+	lang, err := language.Parse(tag)
 	if err != nil {
-		return 0, err
+		return nil, status.Errorf(codes.InvalidArgument, "invalid values collation %q: %v", tag, err)
 	}
-	for p.Next() {
-		count++
-		if count%checkContextErrorSeriesCount == 0 {
-			if err := ctx.Err(); err != nil {
-				return 0, err
-			}
+	// The collator isn't safe for concurrent use, but the values of a request are sorted by a single goroutine.
+	collator := collate.New(lang)
+	return func(a, b string) bool {
+		if c := collator.CompareString(a, b); c != 0 {
+			return c < 0
 		}
+		return a < b
+	}, nil
+}
+
+// labelNamesWithContext calls index.LabelNames(), returning early with the context error if the context
+// is done before the label names have been looked up. The lookup can't be interrupted, so it then keeps running
+// in the background until it completes, tracked by lookups so that the index isn't closed while it's still used,
+// and its result is discarded. If lookups is nil, the lookup is waited for before returning the context error.
+func labelNamesWithContext(ctx context.Context, index labelsReader, matchers []*labels.Matcher, lookups *backgroundLookups) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-	if p.Err() != nil {
-		return 0, p.Err()
+
+	type result struct {
+		names []string
+		err   error
+	}
+	resultCh := make(chan result, 1)
+	done := lookups.start()
+	go func() {
+		defer done()
+		names, err := index.LabelNames(matchers...)
+		resultCh <- result{names: names, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		if lookups == nil {
+			<-resultCh
+		}
+		return nil, ctx.Err()
+	case res := <-resultCh:
+		return res.names, res.err
+	}
+}
+
+// backgroundLookups tracks the index lookups left running in the background by the requests which returned early
+// because their context is done, so that the index readers they use are closed once they've completed.
+// The zero value is ready to use.
+type backgroundLookups struct {
+	mtx     sync.Mutex
+	running int
+	closers []func()
+}
+
+// start registers a lookup, and returns the function to call once it's completed.
+func (l *backgroundLookups) start() (done func()) {
+	if l == nil {
+		return func() {}
+	}
+	l.mtx.Lock()
+	l.running++
+	l.mtx.Unlock()
+
+	return func() {
+		l.mtx.Lock()
+		defer l.mtx.Unlock()
+		l.running--
+		if l.running > 0 {
+			return
+		}
+		for _, closeFn := range l.closers {
+			closeFn()
+		}
+		l.closers = nil
+	}
+}
+
+// closeWhenDone calls closeFn right away if no lookup is running, or otherwise once the running lookups have
+// completed, without waiting for them. The delayed calls are made in the order of the closeWhenDone calls.
+func (l *backgroundLookups) closeWhenDone(closeFn func()) {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+	if l.running == 0 {
+		closeFn()
+		return
 	}
-	return count, nil
+	l.closers = append(l.closers, closeFn)
 }
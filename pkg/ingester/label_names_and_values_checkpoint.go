@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+
+	"github.com/pkg/errors"
+	"github.com/thanos-io/thanos/pkg/objstore"
+)
+
+// labelNamesAndValuesCheckpointsPrefix is the prefix of the objects holding the checkpoints in the tenant bucket.
+const labelNamesAndValuesCheckpointsPrefix = "label-names-and-values-checkpoints"
+
+var labelNamesAndValuesCheckpointTokenRegexp = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,128}$`)
+
+// labelNamesAndValuesCheckpoint is the position of a labelNamesAndValues request in its result, after the last
+// message sent to the client.
+type labelNamesAndValuesCheckpoint struct {
+	// LabelName is the name of the last label sent.
+	LabelName string `json:"label_name,omitempty"`
+	// Value is the last value of the label sent. It's empty when the values are not returned.
+	Value string `json:"value,omitempty"`
+	// Completed is set once the whole result has been sent.
+	Completed bool `json:"completed,omitempty"`
+}
+
+// labelNamesAndValuesCheckpointer persists the checkpoints of a labelNamesAndValues request in the object store,
+// so that the request can be resumed with the same token after the ingester restarted.
+type labelNamesAndValuesCheckpointer struct {
+	bkt   objstore.Bucket
+	token string
+}
+
+func newLabelNamesAndValuesCheckpointer(bkt objstore.Bucket, token string) (*labelNamesAndValuesCheckpointer, error) {
+	if !labelNamesAndValuesCheckpointTokenRegexp.MatchString(token) {
+		return nil, fmt.Errorf("invalid checkpoint token %q: it must be 1 to 128 letters, digits, underscores or dashes", token)
+	}
+	return &labelNamesAndValuesCheckpointer{bkt: bkt, token: token}, nil
+}
+
+func (c *labelNamesAndValuesCheckpointer) objectName() string {
+	return path.Join(labelNamesAndValuesCheckpointsPrefix, c.token+".json")
+}
+
+// load returns the last checkpoint persisted for the token, or nil if the request hasn't been started yet.
+func (c *labelNamesAndValuesCheckpointer) load(ctx context.Context) (*labelNamesAndValuesCheckpoint, error) {
+	r, err := c.bkt.Get(ctx, c.objectName())
+	if c.bkt.IsObjNotFoundErr(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the label names and values checkpoint")
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read the label names and values checkpoint")
+	}
+	checkpoint := &labelNamesAndValuesCheckpoint{}
+	if err := json.Unmarshal(data, checkpoint); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the label names and values checkpoint")
+	}
+	return checkpoint, nil
+}
+
+// save persists the checkpoint, replacing the previous one.
+func (c *labelNamesAndValuesCheckpointer) save(ctx context.Context, checkpoint labelNamesAndValuesCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the label names and values checkpoint")
+	}
+	if err := c.bkt.Upload(ctx, c.objectName(), bytes.NewReader(data)); err != nil {
+		return errors.Wrap(err, "failed to write the label names and values checkpoint")
+	}
+	return nil
+}
+
+// labelNamesFromCheckpoint returns the label names which have not been completely sent before the checkpoint.
+// The label of the checkpoint is kept, because only some of its values may have been sent.
+func labelNamesFromCheckpoint(labelNames []string, checkpoint *labelNamesAndValuesCheckpoint) []string {
+	if checkpoint == nil {
+		return labelNames
+	}
+	for i, name := range labelNames {
+		if name >= checkpoint.LabelName {
+			return labelNames[i:]
+		}
+	}
+	return nil
+}
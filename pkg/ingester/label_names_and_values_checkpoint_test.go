@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+func TestLabelNamesAndValues_Checkpoints(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("label-%d", i)
+		existingLabels[name] = []string{name + "-a", name + "-b", name + "-c"}
+	}
+	idxReader := mockIndex{existingLabels: existingLabels}
+
+	for name, omitValues := range map[string]bool{"names and values": false, "names only": true} {
+		t.Run(name, func(t *testing.T) {
+			bkt := objstore.NewInMemBucket()
+			run := func(maxMessages int) (sent []string, _ error) {
+				checkpointer, err := newLabelNamesAndValuesCheckpointer(bkt, "audit-1")
+				require.NoError(t, err)
+				server := &mockLabelNamesAndValuesServer{context: context.Background()}
+				opts := labelNamesAndValuesOptions{omitValues: omitValues, checkpointer: checkpointer}
+				// The small message size threshold splits the values of each label across messages.
+				err = labelNamesAndValues(idxReader, []*labels.Matcher{}, 20, opts, &interruptedLabelNamesAndValuesServer{
+					Ingester_LabelNamesAndValuesServer: server,
+					remaining:                          maxMessages,
+				})
+				for _, resp := range server.SentResponses {
+					for _, item := range resp.Items {
+						if omitValues {
+							sent = append(sent, item.LabelName)
+							continue
+						}
+						for _, value := range item.Values {
+							sent = append(sent, item.LabelName+"="+value)
+						}
+					}
+				}
+				return sent, err
+			}
+
+			expected, err := run(-1)
+			require.NoError(t, err)
+			require.NoError(t, bkt.Delete(context.Background(), "label-names-and-values-checkpoints/audit-1.json"))
+
+			// The request is interrupted, for example because the ingester restarted, and then resumed.
+			firstRun, err := run(3)
+			require.ErrorIs(t, err, errInterrupted)
+			require.NotEmpty(t, firstRun)
+			secondRun, err := run(-1)
+			require.NoError(t, err)
+			require.NotEmpty(t, secondRun)
+
+			// No data is lost or duplicated.
+			require.Equal(t, expected, append(firstRun, secondRun...))
+
+			// The completed request returns nothing.
+			thirdRun, err := run(-1)
+			require.NoError(t, err)
+			require.Empty(t, thirdRun)
+		})
+	}
+
+	t.Run("invalid token", func(t *testing.T) {
+		_, err := newLabelNamesAndValuesCheckpointer(objstore.NewInMemBucket(), "../other-tenant")
+		require.ErrorContains(t, err, "invalid checkpoint token")
+	})
+}
+
+var errInterrupted = errors.New("interrupted")
+
+// interruptedLabelNamesAndValuesServer fails to send the messages after the remaining ones have been sent.
+// A negative remaining number of messages never fails.
+type interruptedLabelNamesAndValuesServer struct {
+	client.Ingester_LabelNamesAndValuesServer
+	remaining int
+}
+
+func (s *interruptedLabelNamesAndValuesServer) Send(resp *client.LabelNamesAndValuesResponse) error {
+	if s.remaining == 0 {
+		return errInterrupted
+	}
+	s.remaining--
+	return s.Ingester_LabelNamesAndValuesServer.Send(resp)
+}
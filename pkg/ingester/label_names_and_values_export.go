@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"io"
+	"path"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/pkg/errors"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// labelNamesAndValuesExportsPrefix is the prefix of the objects holding the exports in the tenant bucket.
+const labelNamesAndValuesExportsPrefix = "label-names-and-values-exports"
+
+var errLabelNamesAndValuesExportUploadStopped = errors.New("the label names and values export upload has stopped")
+
+// labelNamesAndValuesExport is the content of an exported labelNamesAndValues result, once decompressed.
+type labelNamesAndValuesExport struct {
+	Items []labelNamesAndValuesExportItem `json:"items"`
+}
+
+// labelNamesAndValuesExportItem holds all the values of a label. Unlike in the streamed messages, the values
+// of a label are never split across several items.
+type labelNamesAndValuesExportItem struct {
+	LabelName string   `json:"label_name"`
+	Values    []string `json:"values"`
+}
+
+// exportLabelNamesAndValues computes the label names and values like labelNamesAndValues, and uploads the whole
+// result to the bucket as a single gzip-compressed JSON object, decoding to labelNamesAndValuesExport. The result
+// is streamed to the upload while it's computed, so it's never held in memory. It returns the name of the object,
+// which is unique across the ingesters and the exports of an ingester.
+func exportLabelNamesAndValues(
+	ctx context.Context,
+	bkt objstore.Bucket,
+	instanceID string,
+	now time.Time,
+	index tsdb.IndexReader,
+	matchers []*labels.Matcher,
+	messageSizeThreshold int,
+	opts labelNamesAndValuesOptions,
+) (string, error) {
+	// Only the names and values of the labels are exported.
+	if opts.encoding.useValueIDs || len(opts.encoding.compressionDictionary) > 0 || opts.includePresence || opts.includeRelabelOutcomes || opts.encoding.valuesPreviewSize > 0 || opts.checkpointer != nil {
+		return "", errors.New("the label names and values can't be exported with the value IDs, the compression, the presence, the relabel outcomes, the values preview or the checkpoints")
+	}
+	id, err := ulid.New(ulid.Timestamp(now), rand.Reader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate the name of the label names and values export")
+	}
+	name := path.Join(labelNamesAndValuesExportsPrefix, instanceID, id.String()+".json.gz")
+
+	pr, pw := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		w := newLabelNamesAndValuesExportWriter(pw)
+		err := labelNamesAndValues(index, matchers, messageSizeThreshold, opts, &exportLabelNamesAndValuesServer{ctx: ctx, w: w})
+		if err == nil {
+			err = w.Close()
+		}
+		// The upload reads the error of the result once it has read the data written before.
+		_ = pw.CloseWithError(err)
+		done <- err
+	}()
+
+	err = bkt.Upload(ctx, name, pr)
+	// The upload could stop reading before the end of the result, which must not block the result computation.
+	_ = pr.CloseWithError(errLabelNamesAndValuesExportUploadStopped)
+	if resultErr := <-done; resultErr != nil && !errors.Is(resultErr, errLabelNamesAndValuesExportUploadStopped) {
+		err = resultErr
+	}
+	if err != nil {
+		// The object could have been partially written.
+		_ = bkt.Delete(ctx, name)
+		return "", errors.Wrap(err, "failed to export the label names and values")
+	}
+	return name, nil
+}
+
+// exportLabelNamesAndValuesServer is a client.Ingester_LabelNamesAndValuesServer writing the sent messages to an export.
+type exportLabelNamesAndValuesServer struct {
+	client.Ingester_LabelNamesAndValuesServer
+	ctx context.Context
+	w   *labelNamesAndValuesExportWriter
+}
+
+func (s *exportLabelNamesAndValuesServer) Send(resp *client.LabelNamesAndValuesResponse) error {
+	for _, item := range resp.Items {
+		if err := s.w.write(item.LabelName, item.Values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *exportLabelNamesAndValuesServer) Context() context.Context {
+	return s.ctx
+}
+
+// labelNamesAndValuesExportWriter encodes the items of a labelNamesAndValues result as a labelNamesAndValuesExport,
+// compressed with gzip. The items are encoded as they're written, merging the consecutive items of the same label.
+type labelNamesAndValuesExportWriter struct {
+	gz  *gzip.Writer
+	buf *bufio.Writer
+
+	// labelName is the name of the label whose values are being written.
+	labelName string
+	// items is the number of labels written so far, and values the number of values of the current label.
+	items, values int
+}
+
+func newLabelNamesAndValuesExportWriter(w io.Writer) *labelNamesAndValuesExportWriter {
+	gz := gzip.NewWriter(w)
+	return &labelNamesAndValuesExportWriter{gz: gz, buf: bufio.NewWriter(gz)}
+}
+
+func (w *labelNamesAndValuesExportWriter) write(labelName string, values []string) error {
+	if w.items == 0 {
+		if _, err := w.buf.WriteString(`{"items":[`); err != nil {
+			return err
+		}
+	}
+	if w.items == 0 || labelName != w.labelName {
+		if w.items > 0 {
+			if _, err := w.buf.WriteString("]},"); err != nil {
+				return err
+			}
+		}
+		if _, err := w.buf.WriteString(`{"label_name":`); err != nil {
+			return err
+		}
+		if err := w.writeJSON(labelName); err != nil {
+			return err
+		}
+		if _, err := w.buf.WriteString(`,"values":[`); err != nil {
+			return err
+		}
+		w.labelName = labelName
+		w.items++
+		w.values = 0
+	}
+	for _, v := range values {
+		if w.values > 0 {
+			if err := w.buf.WriteByte(','); err != nil {
+				return err
+			}
+		}
+		if err := w.writeJSON(v); err != nil {
+			return err
+		}
+		w.values++
+	}
+	return nil
+}
+
+func (w *labelNamesAndValuesExportWriter) writeJSON(s string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	_, err = w.buf.Write(data)
+	return err
+}
+
+// Close terminates the export, and flushes it to the underlying writer.
+func (w *labelNamesAndValuesExportWriter) Close() error {
+	end := "]}]}"
+	if w.items == 0 {
+		end = `{"items":[]}`
+	}
+	if _, err := w.buf.WriteString(end); err != nil {
+		return err
+	}
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+	return w.gz.Close()
+}
@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"github.com/thanos-io/thanos/pkg/objstore"
+
+	"github.com/grafana/mimir/pkg/storage/bucket/filesystem"
+)
+
+func TestExportLabelNamesAndValues(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("label-%02d", i)
+		for j := 0; j < 10*i+1; j++ {
+			existingLabels[name] = append(existingLabels[name], fmt.Sprintf("value-%d", j))
+		}
+	}
+	existingLabels["label-escaped"] = []string{`"quoted"`, "multi\nline"}
+
+	bkt, err := filesystem.NewBucketClient(filesystem.Config{Directory: t.TempDir()})
+	require.NoError(t, err)
+
+	now := time.UnixMilli(1650000000000)
+	// The small message size threshold splits the values of the labels in several messages.
+	name, err := exportLabelNamesAndValues(context.Background(), bkt, "ingester-1", now, mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, 100, labelNamesAndValuesOptions{})
+	require.NoError(t, err)
+	require.Regexp(t, `^label-names-and-values-exports/ingester-1/[0-9A-Z]{26}\.json\.gz$`, name)
+	id, err := ulid.Parse(strings.TrimSuffix(path.Base(name), ".json.gz"))
+	require.NoError(t, err)
+	require.Equal(t, ulid.Timestamp(now), id.Time())
+
+	export := readLabelNamesAndValuesExport(t, bkt, name)
+
+	var expectedNames []string
+	for lbName := range existingLabels {
+		expectedNames = append(expectedNames, lbName)
+	}
+	sort.Strings(expectedNames)
+	require.Len(t, export.Items, len(expectedNames))
+	for i, item := range export.Items {
+		require.Equal(t, expectedNames[i], item.LabelName)
+		require.ElementsMatch(t, existingLabels[item.LabelName], item.Values, "label %s", item.LabelName)
+	}
+}
+
+func TestExportLabelNamesAndValues_EmptyResult(t *testing.T) {
+	bkt := objstore.NewInMemBucket()
+
+	name, err := exportLabelNamesAndValues(context.Background(), bkt, "ingester-1", time.Now(), mockIndex{}, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{})
+	require.NoError(t, err)
+	require.Empty(t, readLabelNamesAndValuesExport(t, bkt, name).Items)
+}
+
+func TestExportLabelNamesAndValues_UniqueNames(t *testing.T) {
+	bkt := objstore.NewInMemBucket()
+	idx := mockIndex{existingLabels: map[string][]string{"label": {"value"}}}
+
+	// The exports of the same time, from the same or from different ingesters, don't overwrite each other.
+	now := time.Now()
+	names := map[string]struct{}{}
+	for _, instanceID := range []string{"ingester-1", "ingester-1", "ingester-2"} {
+		name, err := exportLabelNamesAndValues(context.Background(), bkt, instanceID, now, idx, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{})
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(name, path.Join(labelNamesAndValuesExportsPrefix, instanceID)+"/"))
+		names[name] = struct{}{}
+	}
+	require.Len(t, names, 3)
+	require.Len(t, bkt.Objects(), 3)
+}
+
+func TestExportLabelNamesAndValues_UnsupportedOptions(t *testing.T) {
+	for name, opts := range map[string]labelNamesAndValuesOptions{
+		"value IDs":       {encoding: labelNamesAndValuesEncodingOptions{useValueIDs: true}},
+		"compression":     {encoding: labelNamesAndValuesEncodingOptions{compressionDictionary: []byte("dictionary")}},
+		"presence":        {includePresence: true},
+		"relabel outcome": {includeRelabelOutcomes: true},
+		"values preview":  {encoding: labelNamesAndValuesEncodingOptions{valuesPreviewSize: 1}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			bkt := objstore.NewInMemBucket()
+			_, err := exportLabelNamesAndValues(context.Background(), bkt, "ingester-1", time.Now(), mockIndex{}, []*labels.Matcher{}, 1024, opts)
+			require.Error(t, err)
+			require.Empty(t, bkt.Objects())
+		})
+	}
+}
+
+func TestExportLabelNamesAndValues_Failure(t *testing.T) {
+	bkt, err := filesystem.NewBucketClient(filesystem.Config{Directory: t.TempDir()})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = exportLabelNamesAndValues(ctx, bkt, "ingester-1", time.Now(), mockIndex{existingLabels: map[string][]string{"label": {"value"}}}, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{})
+	require.ErrorIs(t, err, context.Canceled)
+
+	// The partially written object is removed.
+	var objects []string
+	require.NoError(t, bkt.Iter(context.Background(), path.Join(labelNamesAndValuesExportsPrefix, "ingester-1"), func(name string) error {
+		objects = append(objects, name)
+		return nil
+	}))
+	require.Empty(t, objects)
+}
+
+func readLabelNamesAndValuesExport(t *testing.T, bkt objstore.Bucket, name string) labelNamesAndValuesExport {
+	t.Helper()
+
+	r, err := bkt.Get(context.Background(), name)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Close() })
+
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+
+	var export labelNamesAndValuesExport
+	dec := json.NewDecoder(gz)
+	require.NoError(t, dec.Decode(&export))
+	require.False(t, dec.More(), "the export must hold a single JSON document")
+	return export
+}
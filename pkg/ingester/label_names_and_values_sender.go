@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/prometheus/prometheus/tsdb/index"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// labelNamesAndValuesSender builds the messages of a label names and values response, and sends each of them as soon
+// as it would exceed the message size threshold.
+type labelNamesAndValuesSender struct {
+	ctx                  context.Context
+	server               client.Ingester_LabelNamesAndValuesServer
+	messageSizeThreshold int
+	opts                 labelNamesAndValuesOptions
+
+	response client.LabelNamesAndValuesResponse
+	// responseSizeBytes is the marshaled size of the response, or a close over-estimate of it, so that the messages
+	// don't exceed the threshold once the protobuf framing of the fields is added to the label names and values.
+	responseSizeBytes int
+	totalBytes        int
+	// itemLengthSize is the size of the length of a label item. The length of an item which doesn't exceed the
+	// threshold fits in as many bytes as the threshold, and a bigger item is sent alone anyway.
+	itemLengthSize int
+
+	// valueIDs maps the label values to their IDs in the dictionary, if the values are returned as IDs.
+	valueIDs map[string]uint32
+
+	// checkpoint is the position after the last item added to the response.
+	checkpoint, resumeFrom *labelNamesAndValuesCheckpoint
+
+	// valuesCount is the number of label values added to the response, to cap it to opts.limits.maxValues.
+	// The response is truncated if some labels or values have been left out because of the cap.
+	valuesCount int
+	truncated   bool
+}
+
+func newLabelNamesAndValuesSender(ctx context.Context, server client.Ingester_LabelNamesAndValuesServer, messageSizeThreshold int, opts labelNamesAndValuesOptions) *labelNamesAndValuesSender {
+	return &labelNamesAndValuesSender{
+		ctx:                  ctx,
+		server:               server,
+		messageSizeThreshold: messageSizeThreshold,
+		opts:                 opts,
+		itemLengthSize:       protoUvarintSize(uint64(messageSizeThreshold)),
+	}
+}
+
+// send sends the response, compressed if a compression dictionary is set, and persists the checkpoint once it has
+// been sent, so that a resumed request doesn't send it again.
+func (s *labelNamesAndValuesSender) send() error {
+	toSend := &s.response
+	if dict := s.opts.encoding.compressionDictionary; len(dict) > 0 {
+		var err error
+		if toSend, err = compressLabelNamesAndValuesResponse(&s.response, dict); err != nil {
+			return err
+		}
+	}
+	s.totalBytes += toSend.Size()
+	if s.opts.limits.maxTotalBytes > 0 && s.totalBytes > s.opts.limits.maxTotalBytes {
+		return errResponseTooLarge
+	}
+	if err := client.SendLabelNamesAndValuesResponse(s.server, toSend); err != nil {
+		return err
+	}
+	if s.opts.checkpointer != nil && s.checkpoint != nil && s.checkpoint != s.resumeFrom {
+		return s.opts.checkpointer.save(s.ctx, *s.checkpoint)
+	}
+	return nil
+}
+
+// flush sends the items of the response, and resets it for the next items.
+func (s *labelNamesAndValuesSender) flush() error {
+	if err := s.send(); err != nil {
+		return err
+	}
+	s.response.Items = s.response.Items[:0]
+	s.response.LongValues = s.response.LongValues[:0]
+	// The partition key is kept for the next items.
+	s.responseSizeBytes = 0
+	if s.response.PartitionKey != "" {
+		s.responseSizeBytes = protoBytesFieldSize(len(s.response.PartitionKey))
+	}
+	return nil
+}
+
+// sendLast flags the truncation of the response, which is only done once no more data is sent, sends the last
+// message if there is some data that was not sent, and marks the checkpoint as completed.
+func (s *labelNamesAndValuesSender) sendLast(labelNamesTruncated bool) error {
+	s.response.Truncated = s.truncated
+	s.response.LabelNamesTruncated = labelNamesTruncated
+	if s.response.Size() > 0 {
+		if err := s.send(); err != nil {
+			return err
+		}
+	}
+	if s.opts.checkpointer != nil {
+		return s.opts.checkpointer.save(s.ctx, labelNamesAndValuesCheckpoint{Completed: true})
+	}
+	return nil
+}
+
+// hasData returns true if the response holds some data which can be sent.
+func (s *labelNamesAndValuesSender) hasData() bool {
+	return len(s.response.Items) > 0 || len(s.response.LongValues) > 0
+}
+
+// wouldExceedThreshold returns true if adding the bytes to the response would make it exceed the threshold,
+// and the response already holds some data which can be sent first.
+func (s *labelNamesAndValuesSender) wouldExceedThreshold(size int) bool {
+	return s.responseSizeBytes+size > s.messageSizeThreshold && s.hasData()
+}
+
+// sendDictionary sends the symbols of the index as the dictionary of the label values, and maps each of them to its
+// ID for the label items sent afterwards.
+func (s *labelNamesAndValuesSender) sendDictionary(symbols index.StringIter) error {
+	s.valueIDs = map[string]uint32{}
+	sendDictionary := func() error {
+		if err := s.send(); err != nil {
+			return err
+		}
+		s.response.Dictionary = s.response.Dictionary[:0]
+		s.responseSizeBytes = 0
+		return nil
+	}
+	for symbols.Next() {
+		symbol := symbols.At()
+		size := protoBytesFieldSize(len(symbol))
+		if len(s.response.Dictionary) > 0 && s.responseSizeBytes+size > s.messageSizeThreshold {
+			if err := s.ctx.Err(); err != nil {
+				return err
+			}
+			if err := sendDictionary(); err != nil {
+				return err
+			}
+		}
+		s.valueIDs[symbol] = uint32(len(s.valueIDs))
+		s.response.Dictionary = append(s.response.Dictionary, symbol)
+		s.responseSizeBytes += size
+	}
+	if err := symbols.Err(); err != nil {
+		return err
+	}
+	if len(s.response.Dictionary) > 0 {
+		return sendDictionary()
+	}
+	return nil
+}
+
+// labelValueIDs returns the IDs of the label values in the dictionary, or nil if the values aren't returned as IDs.
+func (s *labelNamesAndValuesSender) labelValueIDs(labelName string, values []string) ([]uint32, error) {
+	if s.valueIDs == nil {
+		return nil, nil
+	}
+	ids := make([]uint32, len(values))
+	for i, val := range values {
+		id, ok := s.valueIDs[val]
+		if !ok {
+			return nil, fmt.Errorf("the value %q of the label %q is not in the symbols of the index", val, labelName)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// setPartitionKey sets the partition key of the next items. Labels of different partitions are never sent in the
+// same message.
+func (s *labelNamesAndValuesSender) setPartitionKey(key string) error {
+	if key == s.response.PartitionKey {
+		return nil
+	}
+	if s.hasData() {
+		if err := s.flush(); err != nil {
+			return err
+		}
+	}
+	s.response.PartitionKey = key
+	s.responseSizeBytes = protoBytesFieldSize(len(key))
+	return nil
+}
+
+// labelItemSize returns the size of the label item without its values.
+func (s *labelNamesAndValuesSender) labelItemSize(labelName string) int {
+	size := 1 + s.itemLengthSize + protoBytesFieldSize(len(labelName))
+	if s.opts.includeValueCount {
+		size += 1 + binary.MaxVarintLen64
+	}
+	// The presence and the IDs of the values are packed fields.
+	if s.opts.includePresence {
+		size += 1 + s.itemLengthSize
+	}
+	if s.valueIDs != nil {
+		size += 1 + s.itemLengthSize
+	}
+	if s.opts.includeRelabelOutcomes {
+		size += 1 + s.itemLengthSize
+	}
+	return size
+}
+
+// labelValueSize returns the size of the i-th value in the label item.
+func (s *labelNamesAndValuesSender) labelValueSize(values []string, presence []client.LabelValuePresence, ids []uint32, i int) int {
+	size := 0
+	if ids != nil {
+		size += protoUvarintSize(uint64(ids[i]))
+	} else {
+		size += protoBytesFieldSize(len(values[i]))
+	}
+	if presence != nil {
+		size += protoUvarintSize(uint64(presence[i]))
+	}
+	// The relabel outcomes fit in a single byte.
+	if s.opts.includeRelabelOutcomes {
+		size++
+	}
+	return size
+}
+
+// valuesCapReached returns true if no more label values can be added to the response, flagging it as truncated.
+func (s *labelNamesAndValuesSender) valuesCapReached() bool {
+	if s.opts.omitValues || s.opts.limits.maxValues <= 0 || s.valuesCount < s.opts.limits.maxValues {
+		return false
+	}
+	s.truncated = true
+	return true
+}
+
+// addLabelName accounts the label item in the size of the response, sending the response first if the label item
+// would make it exceed the threshold.
+func (s *labelNamesAndValuesSender) addLabelName(labelName string) error {
+	size := s.labelItemSize(labelName)
+	if s.wouldExceedThreshold(size) {
+		if err := s.flush(); err != nil {
+			return err
+		}
+	}
+	s.responseSizeBytes += size
+	return nil
+}
+
+// removeLabelName removes the label item accounted with addLabelName from the size of the response, when the label
+// is left out of it.
+func (s *labelNamesAndValuesSender) removeLabelName(labelName string) {
+	s.responseSizeBytes -= s.labelItemSize(labelName)
+}
+
+// addLabelItem adds the label item without values, accounted with addLabelName, to the response.
+func (s *labelNamesAndValuesSender) addLabelItem(labelItem *client.LabelValues) {
+	s.response.Items = append(s.response.Items, labelItem)
+	s.checkpoint = &labelNamesAndValuesCheckpoint{LabelName: labelItem.LabelName}
+}
+
+// addLongValues adds the report of the long values of a label to the response, sending the response first if the
+// report would make it exceed the threshold.
+func (s *labelNamesAndValuesSender) addLongValues(report *client.LongLabelValues) error {
+	size := protoBytesFieldSize(report.Size())
+	if s.wouldExceedThreshold(size) {
+		if err := s.flush(); err != nil {
+			return err
+		}
+	}
+	s.response.LongValues = append(s.response.LongValues, report)
+	s.responseSizeBytes += size
+	return nil
+}
+
+// emitLabelValues adds the values to the response in the label item, splitting them across messages when the next
+// value would make the response exceed the threshold. The label item must have been accounted with addLabelName.
+func (s *labelNamesAndValuesSender) emitLabelValues(labelItem *client.LabelValues, values []string, presence []client.LabelValuePresence, ids []uint32) error {
+	labelName := labelItem.LabelName
+	if maxValues := s.opts.limits.maxValues; maxValues > 0 {
+		if remaining := maxValues - s.valuesCount; len(values) > remaining {
+			values, presence, ids = splitLabelValuesPreview(values, presence, ids, remaining)
+			s.truncated = true
+		}
+		s.valuesCount += len(values)
+	}
+	var outcomes []client.LabelValueRelabelOutcome
+	if s.opts.includeRelabelOutcomes {
+		outcomes = labelValuesRelabelOutcomes(labelName, values, s.opts.relabelConfigs)
+	}
+	// start is the index of the first value which hasn't been added to the response yet.
+	start := 0
+	for i := range values {
+		size := s.labelValueSize(values, presence, ids, i)
+		if s.responseSizeBytes+size > s.messageSizeThreshold && (i > start || s.hasData()) {
+			labelItemSent := i > start
+			if labelItemSent {
+				setLabelItemValues(labelItem, values, presence, ids, start, i)
+				if outcomes != nil {
+					labelItem.RelabelOutcomes = outcomes[start:i]
+				}
+				s.response.Items = append(s.response.Items, labelItem)
+				s.checkpoint = &labelNamesAndValuesCheckpoint{LabelName: labelName, Value: values[i-1]}
+			}
+			if err := s.flush(); err != nil {
+				return err
+			}
+			if labelItemSent {
+				// reset label values to reuse labelItem for the next values of current label.
+				labelItem.Values = labelItem.Values[:0]
+				labelItem.Presence = labelItem.Presence[:0]
+				labelItem.ValueIds = labelItem.ValueIds[:0]
+				labelItem.RelabelOutcomes = labelItem.RelabelOutcomes[:0]
+				// The value count is only set in the first item of the label.
+				labelItem.ValueCount = 0
+			}
+			start = i
+			s.responseSizeBytes += s.labelItemSize(labelName)
+		}
+		s.responseSizeBytes += size
+	}
+	if start < len(values) {
+		setLabelItemValues(labelItem, values, presence, ids, start, len(values))
+		if outcomes != nil {
+			labelItem.RelabelOutcomes = outcomes[start:]
+		}
+		s.response.Items = append(s.response.Items, labelItem)
+		s.checkpoint = &labelNamesAndValuesCheckpoint{LabelName: labelName, Value: values[len(values)-1]}
+	}
+	return nil
+}
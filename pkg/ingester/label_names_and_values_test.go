@@ -5,38 +5,56 @@ package ingester
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"hash/crc32"
+	"math"
+	"math/rand"
 	"sort"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/gogo/status"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/prometheus/prometheus/storage"
 	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/chunks"
 	"github.com/prometheus/prometheus/tsdb/index"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
 
 	"github.com/grafana/mimir/pkg/ingester/client"
 )
 
 // Scenario: each label name or label value is 8 bytes value. Except `label-c` label, its label name is 7 bytes in length.
+// Once marshaled, each label value takes 10 bytes and each label item takes 12 bytes without its values.
 //
-// expected 7 messages:
-// 0. {Items:[&LabelValues{LabelName:label-aa,Values:[a0000000 a1111111 a2222222],}]}
-// This message size is 32 bytes. it must be sent because its size reached the threshold of 32 bytes.
-// 1. {Items:[&LabelValues{LabelName:label-bb,Values:[b0000000 b1111111 b2222222],}]}
-// This message size is 32 bytes. it must be sent because its size reached the threshold of 32 bytes.
-// 2. {Items:[&LabelValues{LabelName:label-bb,Values:[b3333333],} &LabelValues{LabelName:label-c,Values:[c0000000],}]}
-// This message size is 32 bytes. it must be sent because its size reached the threshold of 32 bytes.
-// 3. {Items:[&LabelValues{LabelName:label-dd,Values:[d0000000],}]}
-// This message size is 16 bytes. it must be sent even if it's not reached the threshold of 32 bytes, but adding the next label-name leads to passing threshold.
-// 4. {Items:[&LabelValues{LabelName:strings.Repeat("label-ee", 10),Values:[e0000000],}]}
-// This message size is 88 bytes, but anyway it must be sent.
-// 5. {Items:[&LabelValues{LabelName:"label-ff",Values:[f0000000 f1111111 f2222222],}]}
-// This message size is 32 bytes. it must be sent because its size reached the threshold of 32 bytes.
-// 6. {Items:[&LabelValues{LabelName:"label-gg",Values:[g0000000],}]}
-// This message size is 16 bytes. it must be sent even if it's not reached the threshold of 32 bytes, but it's the last message.
+// expected 10 messages:
+// 0. {Items:[&LabelValues{LabelName:label-aa,Values:[a0000000 a1111111],}]}
+// This message size is 32 bytes. it must be sent because the next value would exceed the threshold of 32 bytes.
+// 1. {Items:[&LabelValues{LabelName:label-aa,Values:[a2222222],}]}
+// This message size is 22 bytes. it must be sent because the next label item would exceed the threshold of 32 bytes.
+// 2. {Items:[&LabelValues{LabelName:label-bb,Values:[b0000000 b1111111],}]}
+// 3. {Items:[&LabelValues{LabelName:label-bb,Values:[b2222222 b3333333],}]}
+// These messages size is 32 bytes.
+// 4. {Items:[&LabelValues{LabelName:label-c,Values:[c0000000],}]}
+// 5. {Items:[&LabelValues{LabelName:label-dd,Values:[d0000000],}]}
+// These messages size is 21 and 22 bytes. they must be sent because the next label item would exceed the threshold of 32 bytes.
+// 6. {Items:[&LabelValues{LabelName:strings.Repeat("label-ee", 10),Values:[e0000000],}]}
+// This message size is 94 bytes, but anyway it must be sent.
+// 7. {Items:[&LabelValues{LabelName:"label-ff",Values:[f0000000 f1111111],}]}
+// 8. {Items:[&LabelValues{LabelName:"label-ff",Values:[f2222222],}]}
+// These messages size is 32 and 22 bytes, like the messages of label-aa.
+// 9. {Items:[&LabelValues{LabelName:"label-gg",Values:[g0000000],}]}
+// This message size is 22 bytes. it must be sent even if it's not reached the threshold of 32 bytes, but it's the last message.
 func TestLabelNamesAndValuesAreSentInBatches(t *testing.T) {
 
 	existingLabels := map[string][]string{
@@ -50,31 +68,59 @@ func TestLabelNamesAndValuesAreSentInBatches(t *testing.T) {
 	}
 	mockServer := mockLabelNamesAndValuesServer{context: context.Background()}
 	var server client.Ingester_LabelNamesAndValuesServer = &mockServer
-	require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, 32, server))
-
-	require.Len(t, mockServer.SentResponses, 7)
-
-	require.Equal(t, []*client.LabelValues{
-		{LabelName: "label-aa", Values: []string{"a0000000", "a1111111", "a2222222"}}},
-		mockServer.SentResponses[0].Items)
-	require.Equal(t, []*client.LabelValues{
-		{LabelName: "label-bb", Values: []string{"b0000000", "b1111111", "b2222222"}}},
-		mockServer.SentResponses[1].Items)
-	require.Equal(t, []*client.LabelValues{
-		{LabelName: "label-bb", Values: []string{"b3333333"}}, {LabelName: "label-c", Values: []string{"c0000000"}}},
-		mockServer.SentResponses[2].Items)
-	require.Equal(t, []*client.LabelValues{
-		{LabelName: "label-dd", Values: []string{"d0000000"}}},
-		mockServer.SentResponses[3].Items)
-	require.Equal(t, []*client.LabelValues{
-		{LabelName: strings.Repeat("label-ee", 10), Values: []string{"e0000000"}}},
-		mockServer.SentResponses[4].Items)
-	require.Equal(t, []*client.LabelValues{
-		{LabelName: "label-ff", Values: []string{"f0000000", "f1111111", "f2222222"}}},
-		mockServer.SentResponses[5].Items)
-	require.Equal(t, []*client.LabelValues{
-		{LabelName: "label-gg", Values: []string{"g0000000"}}},
-		mockServer.SentResponses[6].Items)
+	require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, 32, labelNamesAndValuesOptions{}, server))
+
+	expected := [][]*client.LabelValues{
+		{{LabelName: "label-aa", Values: []string{"a0000000", "a1111111"}}},
+		{{LabelName: "label-aa", Values: []string{"a2222222"}}},
+		{{LabelName: "label-bb", Values: []string{"b0000000", "b1111111"}}},
+		{{LabelName: "label-bb", Values: []string{"b2222222", "b3333333"}}},
+		{{LabelName: "label-c", Values: []string{"c0000000"}}},
+		{{LabelName: "label-dd", Values: []string{"d0000000"}}},
+		{{LabelName: strings.Repeat("label-ee", 10), Values: []string{"e0000000"}}},
+		{{LabelName: "label-ff", Values: []string{"f0000000", "f1111111"}}},
+		{{LabelName: "label-ff", Values: []string{"f2222222"}}},
+		{{LabelName: "label-gg", Values: []string{"g0000000"}}},
+	}
+	expectedSizes := []int{32, 22, 32, 32, 21, 22, 94, 32, 22, 22}
+	require.Len(t, mockServer.SentResponses, len(expected))
+	for i, resp := range mockServer.SentResponses {
+		require.Equal(t, expected[i], resp.Items, "message %d", i)
+		require.Equal(t, expectedSizes[i], resp.Size(), "message %d", i)
+	}
+}
+
+func TestLabelNamesAndValues_MessagesDontExceedThreshold(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for i := 0; i < 20; i++ {
+		name := fmt.Sprintf("label-%02d", i)
+		for j := 0; j < 10*i; j++ {
+			existingLabels[name] = append(existingLabels[name], fmt.Sprintf("value-%d", j))
+		}
+	}
+	idx := mockIndex{existingLabels: existingLabels}
+
+	for name, opts := range map[string]labelNamesAndValuesOptions{
+		"names and values":    {},
+		"names only":          {omitValues: true},
+		"value counts":        {includeValueCount: true},
+		"value IDs":           {encoding: labelNamesAndValuesEncodingOptions{useValueIDs: true}},
+		"values presence":     {blocksIndex: idx, includePresence: true},
+		"partitions":          {encoding: labelNamesAndValuesEncodingOptions{partitionByFirstCharacter: true}},
+		"long values reports": {longValueLengthThreshold: 7},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, threshold := range []int{64, 100, 128, 1000} {
+				server := &mockLabelNamesAndValuesServer{context: context.Background()}
+				require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, threshold, opts, server))
+
+				require.NotEmpty(t, server.SentResponses)
+				for i, resp := range server.SentResponses {
+					require.LessOrEqualf(t, resp.Size(), threshold, "message %d with threshold %d", i, threshold)
+				}
+			}
+		})
+	}
 }
 
 func TestExpectedAllLabelNamesAndValuesToBeReturnedInSingleMessage(t *testing.T) {
@@ -106,7 +152,7 @@ func TestExpectedAllLabelNamesAndValuesToBeReturnedInSingleMessage(t *testing.T)
 			mockServer := mockLabelNamesAndValuesServer{context: context.Background()}
 			var server client.Ingester_LabelNamesAndValuesServer = &mockServer
 
-			require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: tc.existingLabels}, []*labels.Matcher{}, 128, server))
+			require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: tc.existingLabels}, []*labels.Matcher{}, 128, labelNamesAndValuesOptions{}, server))
 
 			require.Len(t, mockServer.SentResponses, 1)
 			require.Equal(t, tc.expectedMessage, mockServer.SentResponses[0].Items)
@@ -114,6 +160,152 @@ func TestExpectedAllLabelNamesAndValuesToBeReturnedInSingleMessage(t *testing.T)
 	}
 }
 
+func TestLabelNamesAndValues_PrefetchedLabelValues(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for i := 0; i < 10; i++ {
+		existingLabels[fmt.Sprintf("label-%d", i)] = []string{fmt.Sprintf("a-%d", i), fmt.Sprintf("b-%d", i)}
+	}
+
+	expectedServer := mockLabelNamesAndValuesServer{context: context.Background()}
+	require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, 40, labelNamesAndValuesOptions{}, &expectedServer))
+
+	for _, depth := range []int{0, 1, 3, 10, 20} {
+		t.Run(fmt.Sprintf("prefetch depth %d", depth), func(t *testing.T) {
+			idxReader := &countingLabelValuesIndex{mockIndex: mockIndex{existingLabels: existingLabels}}
+			mockServer := mockLabelNamesAndValuesServer{context: context.Background()}
+			require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 40, labelNamesAndValuesOptions{lookup: labelNamesAndValuesLookupOptions{labelValuesPrefetchDepth: depth}}, &mockServer))
+
+			// Responses must not depend on whether the values have been prefetched.
+			require.Equal(t, expectedServer.SentResponses, mockServer.SentResponses)
+			require.Equal(t, len(existingLabels), int(idxReader.labelValuesCalls.Load()))
+		})
+	}
+
+	t.Run("the lookup error is returned", func(t *testing.T) {
+		idxReader := failingLabelValuesIndex{mockIndex: mockIndex{existingLabels: existingLabels}, failingLabelName: "label-5"}
+		mockServer := mockLabelNamesAndValuesServer{context: context.Background()}
+		err := labelNamesAndValues(idxReader, []*labels.Matcher{}, 40, labelNamesAndValuesOptions{lookup: labelNamesAndValuesLookupOptions{labelValuesPrefetchDepth: 3}}, &mockServer)
+		require.EqualError(t, err, "failed to look up the values of label-5")
+
+		// Only labels before the failing one have been sent.
+		require.NotEmpty(t, mockServer.SentResponses)
+		for _, resp := range mockServer.SentResponses {
+			for _, item := range resp.Items {
+				require.Less(t, item.LabelName, "label-5")
+			}
+		}
+	})
+
+	t.Run("prefetching stops when the request is aborted", func(t *testing.T) {
+		const depth = 2
+		idxReader := &countingLabelValuesIndex{mockIndex: mockIndex{existingLabels: existingLabels}}
+		mockServer := mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{lookup: labelNamesAndValuesLookupOptions{labelValuesPrefetchDepth: depth}, limits: labelNamesAndValuesLimits{maxTotalBytes: 1}}
+		err := labelNamesAndValues(idxReader, []*labels.Matcher{}, 16, opts, &mockServer)
+		require.ErrorIs(t, err, errResponseTooLarge)
+
+		// The first label has been consumed when aborting: the prefetched values are buffered, and one more lookup
+		// can be in progress.
+		require.LessOrEqual(t, int(idxReader.labelValuesCalls.Load()), 1+depth+1)
+	})
+}
+
+func BenchmarkLabelNamesAndValues_PrefetchedLabelValues(b *testing.B) {
+	existingLabels := map[string][]string{}
+	for i := 0; i < 50; i++ {
+		existingLabels[fmt.Sprintf("label-%d", i)] = []string{"value-0", "value-1", "value-2"}
+	}
+	// The values of each label are looked up from a slow index, and sent in their own message to a slow client.
+	idxReader := &countingLabelValuesIndex{mockIndex: mockIndex{existingLabels: existingLabels}}
+	const delay = 200 * time.Microsecond
+
+	var sequentialDuration time.Duration
+	for _, depth := range []int{0, 4} {
+		b.Run(fmt.Sprintf("prefetch depth %d", depth), func(b *testing.B) {
+			slowIdxReader := slowLabelValuesIndex{countingLabelValuesIndex: idxReader, delay: delay}
+			start := time.Now()
+			for n := 0; n < b.N; n++ {
+				mockServer := mockLabelNamesAndValuesServer{context: context.Background(), sendDelay: delay}
+				err := labelNamesAndValues(slowIdxReader, []*labels.Matcher{}, 16, labelNamesAndValuesOptions{lookup: labelNamesAndValuesLookupOptions{labelValuesPrefetchDepth: depth}}, &mockServer)
+				require.NoError(b, err)
+			}
+			perOp := time.Since(start) / time.Duration(b.N)
+			if depth == 0 {
+				sequentialDuration = perOp
+			} else if sequentialDuration > 0 {
+				// The index lookups overlap with sending, so the wall time is reduced.
+				require.Less(b, perOp, sequentialDuration)
+			}
+		})
+	}
+}
+
+// slowLabelValuesIndex is a countingLabelValuesIndex whose label values lookups are delayed.
+type slowLabelValuesIndex struct {
+	*countingLabelValuesIndex
+	delay time.Duration
+}
+
+func (i slowLabelValuesIndex) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	time.Sleep(i.delay)
+	return i.countingLabelValuesIndex.LabelValues(name, matchers...)
+}
+
+func TestLabelNamesAndValues_IncludeSeriesCount(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-1"),
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-2"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "instance", "i-1", "code", "200"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "instance", "i-1", "code", "500"),
+	}}
+
+	for name, tc := range map[string]struct {
+		matchers            []*labels.Matcher
+		messageSize         int
+		expectedMessages    int
+		expectedSeriesCount uint64
+	}{
+		"no matchers": {
+			matchers:            []*labels.Matcher{},
+			messageSize:         1024,
+			expectedMessages:    1,
+			expectedSeriesCount: 4,
+		},
+		"with matchers": {
+			matchers:            []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "instance", "i-1")},
+			messageSize:         1024,
+			expectedMessages:    1,
+			expectedSeriesCount: 3,
+		},
+		"series count is sent in the last message only": {
+			matchers:            []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")},
+			messageSize:         12,
+			expectedMessages:    3,
+			expectedSeriesCount: 2,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockServer := mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{includeSeriesCount: true, postingsForMatchersFn: idxReader.postingsForMatchers}
+			require.NoError(t, labelNamesAndValues(idxReader, tc.matchers, tc.messageSize, opts, &mockServer))
+
+			require.Len(t, mockServer.SentResponses, tc.expectedMessages)
+			for _, resp := range mockServer.SentResponses[:tc.expectedMessages-1] {
+				require.Zero(t, resp.SeriesCount)
+			}
+			require.Equal(t, tc.expectedSeriesCount, mockServer.SentResponses[tc.expectedMessages-1].SeriesCount)
+		})
+	}
+
+	t.Run("series count is not sent when not requested", func(t *testing.T) {
+		mockServer := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{}, &mockServer))
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Zero(t, mockServer.SentResponses[0].SeriesCount)
+	})
+}
+
 func TestLabelValues_CardinalityReportSentInBatches(t *testing.T) {
 	existingLabels := map[string][]string{
 		"lbl-a": {"a0000000", "a1111111", "a2222222"},
@@ -139,6 +331,7 @@ func TestLabelValues_CardinalityReportSentInBatches(t *testing.T) {
 		idxReader,
 		postingsForMatchersFn,
 		25,
+		labelValuesCardinalityOptions{},
 		server,
 	)
 	require.NoError(t, err)
@@ -217,6 +410,85 @@ func TestLabelValues_CardinalityReportSentInBatches(t *testing.T) {
 	}, mockServer.SentResponses[3].Items)
 }
 
+func TestLabelValuesCardinality_Summary(t *testing.T) {
+	existingLabels := map[string][]string{
+		"lbl-a": {"a0000000", "a1111111", "a2222222"},
+		"lbl-b": {"b0000000", "b1111111", "b2222222", "b3333333"},
+		"lbl-c": {"c0000000"},
+		"lbl-d": {"d0000000"},
+		"lbl-e": {"e0000000"},
+		"lbl-f": {"f0000000", "f1111111", "f2222222"},
+		"lbl-g": {"g0000000"},
+	}
+	lbNames := []string{"lbl-a", "lbl-b", "lbl-c", "lbl-d", "lbl-e", "lbl-f", "lbl-g"}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 100}, nil
+	}
+
+	t.Run("summary is sent in the last message", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(lbNames, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 25, labelValuesCardinalityOptions{includeSummary: true}, mockServer)
+		require.NoError(t, err)
+
+		// The items are batched like without the summary.
+		require.Len(t, mockServer.SentResponses, 4)
+		var items []*client.LabelValueSeriesCount
+		for i, resp := range mockServer.SentResponses {
+			items = append(items, resp.Items...)
+			if i < len(mockServer.SentResponses)-1 {
+				require.Empty(t, resp.Summaries, "message %d", i)
+			}
+		}
+
+		// The summary matches the totals of the merged items, in the order of the labels.
+		merged := mergeLabelValueSeriesCounts(items)
+		var expected []*client.LabelValuesCardinalitySummary
+		for _, lbName := range lbNames {
+			summary := &client.LabelValuesCardinalitySummary{LabelName: lbName, LabelValuesCount: uint64(len(merged[lbName]))}
+			for _, count := range merged[lbName] {
+				summary.SeriesCount += count
+			}
+			expected = append(expected, summary)
+		}
+		require.Equal(t, expected, mockServer.SentResponses[3].Summaries)
+		require.Equal(t, &client.LabelValuesCardinalitySummary{LabelName: "lbl-b", LabelValuesCount: 4, SeriesCount: 400}, mockServer.SentResponses[3].Summaries[1])
+	})
+
+	t.Run("summary is sent in its own message if there are no pending items", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"lbl-a"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1, labelValuesCardinalityOptions{includeSummary: true}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 4)
+		last := mockServer.SentResponses[3]
+		require.Empty(t, last.Items)
+		require.Equal(t, []*client.LabelValuesCardinalitySummary{{LabelName: "lbl-a", LabelValuesCount: 3, SeriesCount: 300}}, last.Summaries)
+	})
+
+	t.Run("summary only includes the returned values", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{includeSummary: true, minSeriesCount: 101}
+		err := labelValuesCardinality([]string{"lbl-a"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 25, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Empty(t, mockServer.SentResponses[0].Items)
+		require.Equal(t, []*client.LabelValuesCardinalitySummary{{LabelName: "lbl-a"}}, mockServer.SentResponses[0].Summaries)
+	})
+
+	t.Run("summary is not sent unless requested", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(lbNames, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 25, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 4)
+		for _, resp := range mockServer.SentResponses {
+			require.Empty(t, resp.Summaries)
+		}
+	})
+}
+
 func TestLabelValues_ExpectedAllValuesToBeReturnedInSingleMessage(t *testing.T) {
 	testCases := map[string]struct {
 		labels         []string
@@ -276,6 +548,7 @@ func TestLabelValues_ExpectedAllValuesToBeReturnedInSingleMessage(t *testing.T)
 				idxReader,
 				postingsForMatchersFn,
 				1000,
+				labelValuesCardinalityOptions{},
 				server,
 			)
 			require.NoError(t, err)
@@ -289,183 +562,4212 @@ func TestLabelValues_ExpectedAllValuesToBeReturnedInSingleMessage(t *testing.T)
 	}
 }
 
-func TestLabelNamesAndValues_ContextCancellation(t *testing.T) {
-	cctx, cancel := context.WithCancel(context.Background())
+func TestLabelValuesCardinality_TrailingFlush(t *testing.T) {
+	idxReader := &mockIndex{existingLabels: map[string][]string{
+		"label-a": {"a-0", "a-1", "a-2"},
+	}}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 50}, nil
+	}
+	expectedItems := []*client.LabelValueSeriesCount{
+		{LabelName: "label-a", LabelValueSeries: map[string]uint64{"a-0": 50, "a-1": 50, "a-2": 50}},
+	}
+	// The values of the label add up to 9 bytes.
+	const labelSize = 9
 
-	// Server mock.
-	mockServer := mockLabelNamesAndValuesServer{context: cctx}
-	var server client.Ingester_LabelNamesAndValuesServer = &mockServer
+	// Only the last message carries the timing breakdown, so it tells apart the items sent by the trailing flush
+	// from the ones sent by an intermediate flush.
+	opts := labelValuesCardinalityOptions{explain: true}
 
-	// Index reader mock.
-	existingLabels := make(map[string][]string)
-	lbValues := make([]string, 0, 100)
-	for j := 0; j < 100; j++ {
-		lbValues = append(lbValues, fmt.Sprintf("val-%d", j))
-	}
-	existingLabels["__name__"] = lbValues
+	t.Run("the items below the message size threshold are sent by the trailing flush", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"label-a"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, labelSize+1, opts, mockServer)
+		require.NoError(t, err)
 
-	idxOpDelay := time.Millisecond * 100
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Equal(t, expectedItems, mockServer.SentResponses[0].Items)
+		require.NotNil(t, mockServer.SentResponses[0].Explain)
+	})
 
-	idxReader := &mockIndex{
-		existingLabels: existingLabels,
-		opDelay:        idxOpDelay,
-	}
+	t.Run("the items reaching the message size threshold are sent by an intermediate flush", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"label-a"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, labelSize, opts, mockServer)
+		require.NoError(t, err)
 
-	doneCh := make(chan error, 1)
-	go func() {
-		err := labelNamesAndValues(
-			idxReader,
-			[]*labels.Matcher{},
-			1*1024*1024, // 1MB
-			server,
-		)
-		doneCh <- err // Signal request completion.
-	}()
+		require.Len(t, mockServer.SentResponses, 2)
+		require.Equal(t, expectedItems, mockServer.SentResponses[0].Items)
+		require.Nil(t, mockServer.SentResponses[0].Explain)
+		// The trailing flush has no pending items left, and only carries the timing breakdown.
+		require.Empty(t, mockServer.SentResponses[1].Items)
+		require.NotNil(t, mockServer.SentResponses[1].Explain)
+	})
+}
 
-	cancel() // Cancel stream context.
+func TestLabelValuesCardinality_OrderByLabelSeries(t *testing.T) {
+	var series []labels.Labels
+	for i := 0; i < 6; i++ {
+		lbls := []string{labels.MetricName, "up", "pod", fmt.Sprintf("pod-%d", i)}
+		if i < 4 {
+			lbls = append(lbls, "job", fmt.Sprintf("job-%d", i%2))
+		}
+		if i < 1 {
+			lbls = append(lbls, "env", "prod")
+		}
+		series = append(series, labels.FromStrings(lbls...))
+	}
+	idxReader := mockSeriesIndex{series: series}
 
-	// Assert labelNamesAndValues completion.
-	select {
-	case err := <-doneCh:
-		require.ErrorIsf(t, err, context.Canceled, "labelNamesAndValues unexpected error: %s", err)
+	// The label names of the items, in the order of their first item.
+	labelNamesOfItems := func(responses []client.LabelValuesCardinalityResponse) []string {
+		var names []string
+		for _, resp := range responses {
+			for _, item := range resp.Items {
+				if len(names) == 0 || names[len(names)-1] != item.LabelName {
+					names = append(names, item.LabelName)
+				}
+			}
+		}
+		return names
+	}
 
-	case <-time.After(time.Second):
-		require.Fail(t, "labelNamesAndValues was not completed after context cancellation")
+	for name, tc := range map[string]struct {
+		matchers      []*labels.Matcher
+		opts          labelValuesCardinalityOptions
+		expectedOrder []string
+	}{
+		"requested order": {
+			expectedOrder: []string{"env", "job", "pod"},
+		},
+		"ordered by label series": {
+			opts:          labelValuesCardinalityOptions{estimation: labelValuesCardinalityEstimationOptions{orderByLabelSeries: true}},
+			expectedOrder: []string{"pod", "job", "env"},
+		},
+		"ordered by label series selected by the matchers": {
+			// The matcher selects the 4 series with the job label, so the job and pod labels have the same number
+			// of series and keep their requested order.
+			matchers:      []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "job", ".+")},
+			opts:          labelValuesCardinalityOptions{estimation: labelValuesCardinalityEstimationOptions{orderByLabelSeries: true}},
+			expectedOrder: []string{"job", "pod", "env"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			// The label without values isn't returned, but it's requested to check it doesn't affect the order.
+			err := labelValuesCardinality([]string{"env", "job", "missing", "pod"}, tc.matchers, idxReader, idxReader.postingsForMatchers, 1, tc.opts, mockServer)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedOrder, labelNamesOfItems(mockServer.SentResponses))
+		})
 	}
 }
 
-func TestLabelValuesCardinality_ContextCancellation(t *testing.T) {
-	cctx, cancel := context.WithCancel(context.Background())
+func TestLabelValuesCardinality_SortValues(t *testing.T) {
+	var series []labels.Labels
+	for i := 0; i < 12; i++ {
+		series = append(series, labels.FromStrings(labels.MetricName, "up", "pod", fmt.Sprintf("pod-%02d", i), "job", fmt.Sprintf("job-%d", i%3)))
+	}
+	idxReader := reversedLabelValuesIndex{mockSeriesIndex{series: series}}
 
-	// Server mock.
-	mockServer := &mockLabelValuesCardinalityServer{context: cctx}
-	var server client.Ingester_LabelValuesCardinalityServer = mockServer
+	// The small message size threshold sends each value in its own message, so that the order of the values
+	// across messages can be checked.
+	valuesInOrder := func(opts labelValuesCardinalityOptions) map[string][]string {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"job", "pod"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, opts, mockServer)
+		require.NoError(t, err)
 
-	// Index reader mock.
-	existingLabels := make(map[string][]string)
-	lbValues := make([]string, 0, 100)
-	for j := 0; j < 100; j++ {
-		lbValues = append(lbValues, fmt.Sprintf("val-%d", j))
+		values := map[string][]string{}
+		for _, resp := range mockServer.SentResponses {
+			for _, item := range resp.Items {
+				require.Len(t, item.LabelValueSeries, 1)
+				for value := range item.LabelValueSeries {
+					values[item.LabelName] = append(values[item.LabelName], value)
+				}
+			}
+		}
+		return values
 	}
-	existingLabels["__name__"] = lbValues
 
-	idxOpDelay := time.Millisecond * 100
+	t.Run("values are sent in the order of the index by default", func(t *testing.T) {
+		values := valuesInOrder(labelValuesCardinalityOptions{})
+		require.Equal(t, []string{"job-2", "job-1", "job-0"}, values["job"])
+		require.Len(t, values["pod"], 12)
+		require.False(t, sort.StringsAreSorted(values["pod"]))
+	})
 
-	idxReader := &mockIndex{
-		existingLabels: existingLabels,
-		opDelay:        idxOpDelay,
-	}
+	t.Run("values are sent sorted when requested", func(t *testing.T) {
+		for _, opts := range []labelValuesCardinalityOptions{
+			{sortValues: true},
+			{sortValues: true, concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: 4}},
+			{sortValues: true, concurrency: labelValuesCardinalityConcurrencyOptions{allLabelsConcurrency: 2}},
+		} {
+			values := valuesInOrder(opts)
+			require.Equal(t, []string{"job-0", "job-1", "job-2"}, values["job"])
+			require.Len(t, values["pod"], 12)
+			require.True(t, sort.StringsAreSorted(values["pod"]))
+		}
+	})
+}
 
-	// Posting mock.
-	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
-		return &mockPostings{n: 100}, nil
+func TestLabelValuesCardinality_GroupByMagnitude(t *testing.T) {
+	seriesCounts := map[string]int{"a": 1, "b": 5, "c": 12, "d": 150, "e": 999, "f": 1000, "g": 25000, "h": 0}
+	idxReader := &mockIndex{existingLabels: map[string][]string{"pod": {"a", "b", "c", "d", "e", "f", "g", "h"}}}
+	postingsForMatchersFn := func(_ tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: seriesCounts[matchers[len(matchers)-1].Value]}, nil
 	}
-	doneCh := make(chan error, 1)
-	go func() {
-		err := labelValuesCardinality(
-			[]string{"__name__"},
-			nil,
-			idxReader,
-			postingsForMatchersFn,
-			1*1024*1024, // 1MB
-			server,
-		)
-		doneCh <- err // Signal request completion.
-	}()
 
-	cancel() // Cancel stream context.
+	t.Run("values are partitioned by magnitude, from the highest one", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{groupByMagnitude: true}
+		err := labelValuesCardinality([]string{"pod"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+		require.NoError(t, err)
 
-	// Assert labelValuesCardinality completion.
-	select {
-	case err := <-doneCh:
-		require.ErrorIsf(t, err, context.Canceled, "labelValuesCardinality unexpected error: %s", err)
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Equal(t, []*client.LabelValueSeriesCount{
+			{LabelName: "pod", SeriesCountMagnitude: 1000, LabelValueSeries: map[string]uint64{"f": 1000, "g": 25000}},
+			{LabelName: "pod", SeriesCountMagnitude: 100, LabelValueSeries: map[string]uint64{"d": 150, "e": 999}},
+			{LabelName: "pod", SeriesCountMagnitude: 10, LabelValueSeries: map[string]uint64{"c": 12}},
+			{LabelName: "pod", SeriesCountMagnitude: 1, LabelValueSeries: map[string]uint64{"a": 1, "b": 5}},
+			{LabelName: "pod", SeriesCountMagnitude: 0, LabelValueSeries: map[string]uint64{"h": 0}},
+		}, mockServer.SentResponses[0].Items)
+	})
 
-	case <-time.After(time.Second):
-		require.Fail(t, "labelValuesCardinality was not completed after context cancellation")
-	}
-}
+	t.Run("magnitude is not set when not requested", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"pod"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
 
-type mockPostings struct {
-	index.Postings
-	n int
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Len(t, mockServer.SentResponses[0].Items, 1)
+		require.Zero(t, mockServer.SentResponses[0].Items[0].SeriesCountMagnitude)
+		require.Len(t, mockServer.SentResponses[0].Items[0].LabelValueSeries, len(seriesCounts))
+	})
 }
 
-func (m *mockPostings) Next() bool {
-	if m.n == 0 {
-		return false
+func TestSeriesCountMagnitude(t *testing.T) {
+	for seriesCount, expected := range map[uint64]uint64{
+		0: 0, 1: 1, 9: 1, 10: 10, 99: 10, 100: 100, 999: 100, 1000: 1000, 9999: 1000, 10000: 1000, math.MaxUint64: 1000,
+	} {
+		require.Equal(t, expected, seriesCountMagnitude(seriesCount), "series count %d", seriesCount)
 	}
-	m.n--
-	return true
 }
-func (m *mockPostings) Err() error { return nil }
 
-type mockIndex struct {
-	tsdb.IndexReader
-	existingLabels map[string][]string
-	opDelay        time.Duration
+// reversedLabelValuesIndex returns the label values in reverse order, like an index reader not sorting them.
+type reversedLabelValuesIndex struct {
+	mockSeriesIndex
 }
 
-func (i mockIndex) LabelNames(_ ...*labels.Matcher) ([]string, error) {
-	if i.opDelay > 0 {
-		time.Sleep(i.opDelay)
-	}
-	var l []string
-	for k := range i.existingLabels {
-		l = append(l, k)
+func (i reversedLabelValuesIndex) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	values, err := i.mockSeriesIndex.LabelValues(name, matchers...)
+	for l, r := 0, len(values)-1; l < r; l, r = l+1, r-1 {
+		values[l], values[r] = values[r], values[l]
 	}
-	sort.Strings(l)
-	return l, nil
+	return values, err
 }
 
-func (i mockIndex) LabelValues(name string, _ ...*labels.Matcher) ([]string, error) {
-	if i.opDelay > 0 {
-		time.Sleep(i.opDelay)
+func TestLabelValuesCardinality_SeriesCountPercentiles(t *testing.T) {
+	// The series counts of the values are a permutation of 1 to numValues.
+	const numValues = 10000
+	seriesCounts := map[string]int{}
+	var lbValues []string
+	for i := 0; i < numValues; i++ {
+		lbValue := fmt.Sprintf("v-%d", i)
+		lbValues = append(lbValues, lbValue)
+		seriesCounts[lbValue] = 1 + (i*7919)%numValues
+	}
+	idxReader := &mockIndex{existingLabels: map[string][]string{"job": lbValues}}
+	postingsForMatchersFn := func(_ tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		for _, m := range matchers {
+			if m.Name == "job" && m.Type == labels.MatchEqual {
+				return &mockPostings{n: seriesCounts[m.Value]}, nil
+			}
+		}
+		return &mockPostings{}, nil
 	}
-	return i.existingLabels[name], nil
-}
 
-func (i mockIndex) Close() error { return nil }
+	percentiles := []float64{0.5, 0.9, 0.99, 0.999}
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{estimation: labelValuesCardinalityEstimationOptions{seriesCountPercentiles: percentiles}}
+	err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+	require.NoError(t, err)
 
-type mockLabelNamesAndValuesServer struct {
-	client.Ingester_LabelNamesAndValuesServer
-	SentResponses []client.LabelNamesAndValuesResponse
-	context       context.Context
+	sortedCounts := make([]int, 0, numValues)
+	for _, count := range seriesCounts {
+		sortedCounts = append(sortedCounts, count)
+	}
+	sort.Ints(sortedCounts)
+
+	require.Len(t, mockServer.SentResponses, 1)
+	require.Len(t, mockServer.SentResponses[0].Items, 1)
+	item := mockServer.SentResponses[0].Items[0]
+	require.Len(t, item.SeriesCountPercentiles, len(percentiles))
+	for i, p := range percentiles {
+		exact := float64(sortedCounts[int(math.Ceil(p*numValues))-1])
+		require.Equal(t, p, item.SeriesCountPercentiles[i].Percentile)
+		// The rank error of the sketch is 0.1%, which is 10 values, each 1 series apart.
+		require.InDelta(t, exact, item.SeriesCountPercentiles[i].SeriesCount, 0.001*numValues, "percentile %v", p)
+	}
+
+	t.Run("invalid percentiles are rejected", func(t *testing.T) {
+		for _, percentiles := range [][]float64{{1.5}, {-0.1}, {math.NaN()}, make([]float64, maxSeriesCountPercentiles+1)} {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{estimation: labelValuesCardinalityEstimationOptions{seriesCountPercentiles: percentiles}}
+			err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+			require.ErrorAs(t, err, new(invalidLabelValuesCardinalityRequestError))
+		}
+	})
 }
 
-func (m *mockLabelNamesAndValuesServer) Send(response *client.LabelNamesAndValuesResponse) error {
-	items := make([]*client.LabelValues, len(response.Items))
-	for i, it := range response.Items {
-		values := make([]string, len(it.Values))
-		copy(values, it.Values)
-		items[i] = &client.LabelValues{LabelName: it.LabelName, Values: values}
+func TestLabelValuesCardinality_ContextCheckInterval(t *testing.T) {
+	idxReader := &mockIndex{existingLabels: map[string][]string{"job": {"api"}}}
+
+	for name, tc := range map[string]struct {
+		contextCheckInterval int
+		expectedNextCalls    int
+	}{
+		"default interval": {expectedNextCalls: checkContextErrorSeriesCount},
+		"small interval":   {contextCheckInterval: 10, expectedNextCalls: 10},
+		"single series":    {contextCheckInterval: 1, expectedNextCalls: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			// The request is cancelled as soon as its series start being counted.
+			postings := &cancellingPostings{mockPostings: mockPostings{n: 1000000}, cancel: cancel}
+			postingsForMatchersFn := func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+				return postings, nil
+			}
+
+			mockServer := &mockLabelValuesCardinalityServer{context: ctx}
+			opts := labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{contextCheckInterval: tc.contextCheckInterval}}
+			err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+			require.ErrorIs(t, err, context.Canceled)
+			require.Equal(t, tc.expectedNextCalls, postings.nextCalls)
+		})
 	}
-	m.SentResponses = append(m.SentResponses, client.LabelNamesAndValuesResponse{Items: items})
-	return nil
 }
 
-func (m *mockLabelNamesAndValuesServer) Context() context.Context {
-	return m.context
+// cancellingPostings calls cancel when it's iterated for the first time, and counts the calls to Next.
+type cancellingPostings struct {
+	mockPostings
+	cancel    context.CancelFunc
+	nextCalls int
 }
 
-type mockLabelValuesCardinalityServer struct {
-	client.Ingester_LabelValuesCardinalityServer
-	SentResponses []client.LabelValuesCardinalityResponse
-	context       context.Context
+func (p *cancellingPostings) Next() bool {
+	p.cancel()
+	p.nextCalls++
+	return p.mockPostings.Next()
 }
 
-func (m *mockLabelValuesCardinalityServer) Send(resp *client.LabelValuesCardinalityResponse) error {
-	var sentResp client.LabelValuesCardinalityResponse
-	b, err := json.Marshal(resp)
-	if err != nil {
-		return err
-	}
-	if err := json.NewDecoder(bytes.NewReader(b)).Decode(&sentResp); err != nil {
-		return err
-	}
-	m.SentResponses = append(m.SentResponses, sentResp)
-	return nil
+func TestLabelValuesCardinality_GroupByMetricName(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-1"),
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-2"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "instance", "i-1", "code", "200"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "instance", "i-1", "code", "500"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "instance", "i-2", "code", "200"),
+		labels.FromStrings(labels.MetricName, "cpu_seconds_total", "instance", "i-1"),
+		labels.FromStrings("instance", "i-2"),
+	}}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	err := labelValuesCardinality(
+		[]string{"instance"},
+		[]*labels.Matcher{},
+		idxReader,
+		idxReader.postingsForMatchers,
+		1000,
+		labelValuesCardinalityOptions{groupByMetricName: true},
+		mockServer,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, mockServer.SentResponses, 1)
+	require.Equal(t, []*client.LabelValueSeriesCount{
+		{
+			LabelName:        "instance",
+			LabelValueSeries: map[string]uint64{"i-1": 4, "i-2": 3},
+			LabelValueMetricNamesSeries: map[string]*client.MetricNamesSeriesCount{
+				"i-1": {Items: []*client.MetricNameSeriesCount{
+					{MetricName: "cpu_seconds_total", SeriesCount: 1},
+					{MetricName: "http_requests_total", SeriesCount: 2},
+					{MetricName: "up", SeriesCount: 1},
+				}},
+				"i-2": {Items: []*client.MetricNameSeriesCount{
+					{MetricName: "http_requests_total", SeriesCount: 1},
+					{MetricName: "up", SeriesCount: 1},
+				}},
+			},
+		},
+	}, mockServer.SentResponses[0].Items)
 }
 
-func (m *mockLabelValuesCardinalityServer) Context() context.Context {
+func TestLabelValuesCardinality_SeriesBudget(t *testing.T) {
+	existingLabels := map[string][]string{
+		"lbl-a": {"a-0", "a-1", "a-2", "a-3"},
+		"lbl-b": {"b-0", "b-1", "b-2", "b-3"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 100}, nil
+	}
+
+	t.Run("budget warning is set once the soft threshold is crossed", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(
+			[]string{"lbl-a", "lbl-b"},
+			[]*labels.Matcher{},
+			idxReader,
+			postingsForMatchersFn,
+			12, // Each message holds 4 values.
+			labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{maxSeries: 1000, seriesBudgetWarningRatio: 0.8}},
+			mockServer,
+		)
+		require.NoError(t, err)
+
+		// The first message accounts for 400 series, while the second one brings the total to 800.
+		require.Len(t, mockServer.SentResponses, 2)
+		require.False(t, mockServer.SentResponses[0].BudgetWarning)
+		require.True(t, mockServer.SentResponses[1].BudgetWarning)
+	})
+
+	t.Run("budget warning is not set below the soft threshold", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(
+			[]string{"lbl-a", "lbl-b"},
+			[]*labels.Matcher{},
+			idxReader,
+			postingsForMatchersFn,
+			12,
+			labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{maxSeries: 2000, seriesBudgetWarningRatio: 0.8}},
+			mockServer,
+		)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 2)
+		require.False(t, mockServer.SentResponses[0].BudgetWarning)
+		require.False(t, mockServer.SentResponses[1].BudgetWarning)
+	})
+
+	t.Run("request is aborted once the hard limit is exceeded", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(
+			[]string{"lbl-a", "lbl-b"},
+			[]*labels.Matcher{},
+			idxReader,
+			postingsForMatchersFn,
+			12,
+			labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{maxSeries: 500, seriesBudgetWarningRatio: 0.8}},
+			mockServer,
+		)
+		require.ErrorIs(t, err, errLabelValuesCardinalityMaxSeriesExceeded)
+		require.Len(t, mockServer.SentResponses, 1)
+	})
+}
+
+func TestLabelValuesCardinality_SeriesBudgetEarlyAbort(t *testing.T) {
+	idxReader := &mockIndex{existingLabels: map[string][]string{
+		"lbl-a": {"a-0", "a-1", "a-2", "a-3"},
+		"lbl-b": {"b-0", "b-1"},
+	}}
+
+	var countedLabelB atomic.Int64
+	postingsForMatchersFn := func(_ tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		lblValMatcher := matchers[len(matchers)-1]
+		switch {
+		case lblValMatcher.Name == "lbl-b":
+			countedLabelB.Inc()
+			return &mockPostings{n: 1}, nil
+		case lblValMatcher.Value == "a-0":
+			// The first value alone exceeds the series limit.
+			return &mockPostings{n: 1000}, nil
+		default:
+			// The other values are never done counting, unless they're cancelled.
+			return &mockPostings{n: math.MaxInt}, nil
+		}
+	}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{maxSeries: 500}, concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: 4, contextCheckInterval: 1}}
+	err := labelValuesCardinality([]string{"lbl-a", "lbl-b"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+	require.ErrorIs(t, err, errLabelValuesCardinalityMaxSeriesExceeded)
+	require.Empty(t, mockServer.SentResponses)
+	require.Zero(t, countedLabelB.Load())
+}
+
+func TestLabelValuesCardinality_Checksums(t *testing.T) {
+	existingLabels := map[string][]string{
+		"lbl-a": {"a-0", "a-1", "a-2", "a-3"},
+		"lbl-b": {"b-0", "b-1", "b-2", "b-3"},
+		"lbl-c": {"c-0", "c-1"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 100}, nil
+	}
+
+	t.Run("sequence numbers and checksums are set on each message", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(
+			[]string{"lbl-a", "lbl-b", "lbl-c"},
+			[]*labels.Matcher{},
+			idxReader,
+			postingsForMatchersFn,
+			12, // Each message holds 4 values.
+			labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{includeChecksums: true}},
+			mockServer,
+		)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 3)
+		for i, resp := range mockServer.SentResponses {
+			require.Equal(t, uint64(i), resp.SequenceNumber)
+			require.NotZero(t, resp.ItemsChecksum)
+			require.Equal(t, resp.ComputeItemsChecksum(), resp.ItemsChecksum)
+		}
+
+		// A corrupted message doesn't match its checksum anymore.
+		corrupted := mockServer.SentResponses[1]
+		corrupted.Items[0].LabelValueSeries["b-0"]++
+		require.NotEqual(t, corrupted.ComputeItemsChecksum(), corrupted.ItemsChecksum)
+	})
+
+	t.Run("sequence numbers and checksums are not set by default", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(
+			[]string{"lbl-a", "lbl-b", "lbl-c"},
+			[]*labels.Matcher{},
+			idxReader,
+			postingsForMatchersFn,
+			12,
+			labelValuesCardinalityOptions{},
+			mockServer,
+		)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 3)
+		for _, resp := range mockServer.SentResponses {
+			require.Zero(t, resp.SequenceNumber)
+			require.Zero(t, resp.ItemsChecksum)
+		}
+	})
+}
+
+func TestLabelValuesCardinality_ResumeFromOffset(t *testing.T) {
+	existingLabels := map[string][]string{
+		"lbl-a": {"a-0", "a-1", "a-2", "a-3"},
+		"lbl-b": {"b-0", "b-1", "b-2", "b-3"},
+		"lbl-c": {"c-0", "c-1"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 100}, nil
+	}
+	run := func(opts labelValuesCardinalityOptions) ([]client.LabelValuesCardinalityResponse, error) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts.stream.includeChecksums = true
+		opts.sortValues = true
+		// Each message holds 2 values.
+		err := labelValuesCardinality([]string{"lbl-a", "lbl-b", "lbl-c"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 6, opts, mockServer)
+		return mockServer.SentResponses, err
+	}
+
+	// verifyChain verifies that the messages continue the stream received up to the offset with the running checksum,
+	// and returns the offset and the running checksum after the messages.
+	verifyChain := func(t *testing.T, msgs []client.LabelValuesCardinalityResponse, offset uint64, checksum uint32) (uint64, uint32) {
+		for i, msg := range msgs {
+			require.Equal(t, offset, msg.ByteOffset, "message %d", i)
+			itemsChecksum, runningChecksum, size := msg.ComputeChainedItemsChecksums(checksum)
+			require.Equal(t, itemsChecksum, msg.ItemsChecksum, "message %d", i)
+			require.Equal(t, runningChecksum, msg.RunningChecksum, "message %d", i)
+			offset, checksum = offset+size, runningChecksum
+		}
+		return offset, checksum
+	}
+
+	full, err := run(labelValuesCardinalityOptions{})
+	require.NoError(t, err)
+	require.Len(t, full, 5)
+	endOffset, endChecksum := verifyChain(t, full, 0, 0)
+	// The running checksum covers the items of all the messages.
+	require.Equal(t, crc32.ChecksumIEEE(labelValuesCardinalityItemsEncoding(full)), endChecksum)
+	require.Equal(t, uint64(len(labelValuesCardinalityItemsEncoding(full))), endOffset)
+
+	for received := 1; received < len(full); received++ {
+		t.Run(fmt.Sprintf("resumed after %d messages", received), func(t *testing.T) {
+			// The download has been interrupted after some messages: it's resumed from the offset of the next message.
+			offset, checksum := verifyChain(t, full[:received], 0, 0)
+			resumed, err := run(labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{resumeOffset: offset, resumeChecksum: checksum}})
+			require.NoError(t, err)
+
+			// The remaining messages are sent without duplicating the received ones, and they continue the chain.
+			require.Equal(t, full[received:], resumed)
+			resumedEndOffset, resumedEndChecksum := verifyChain(t, resumed, offset, checksum)
+			require.Equal(t, endOffset, resumedEndOffset)
+			require.Equal(t, endChecksum, resumedEndChecksum)
+		})
+	}
+
+	t.Run("resumed at the end of the stream", func(t *testing.T) {
+		resumed, err := run(labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{resumeOffset: endOffset, resumeChecksum: endChecksum}})
+		require.NoError(t, err)
+		require.Empty(t, resumed)
+	})
+
+	for name, tc := range map[string]struct {
+		offset   uint64
+		checksum uint32
+	}{
+		"checksum not matching the received messages": {offset: full[2].ByteOffset, checksum: full[2].RunningChecksum},
+		"offset not at the start of a message":        {offset: full[2].ByteOffset + 1, checksum: full[1].RunningChecksum},
+		"offset after the end of the stream":          {offset: endOffset + 1, checksum: endChecksum},
+	} {
+		t.Run(name, func(t *testing.T) {
+			resumed, err := run(labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{resumeOffset: tc.offset, resumeChecksum: tc.checksum}})
+			require.ErrorIs(t, err, errLabelValuesCardinalityResumeMismatch)
+			require.Equal(t, codes.Aborted, status.Code(err))
+			require.Empty(t, resumed)
+		})
+	}
+
+	t.Run("requires the checksums", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"lbl-a"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 6, labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{resumeOffset: 1}}, mockServer)
+		require.ErrorAs(t, err, new(invalidLabelValuesCardinalityRequestError))
+	})
+}
+
+// labelValuesCardinalityItemsEncoding returns the concatenated canonical encoding of the items of the messages,
+// as checksummed by the running checksums.
+func labelValuesCardinalityItemsEncoding(msgs []client.LabelValuesCardinalityResponse) []byte {
+	var buf bytes.Buffer
+	varint := make([]byte, binary.MaxVarintLen64)
+	writeUvarint := func(v uint64) {
+		buf.Write(varint[:binary.PutUvarint(varint, v)])
+	}
+	writeString := func(s string) {
+		writeUvarint(uint64(len(s)))
+		buf.WriteString(s)
+	}
+	for _, msg := range msgs {
+		for _, item := range msg.Items {
+			writeString(item.LabelName)
+			writeUvarint(uint64(len(item.LabelValueSeries)))
+			values := make([]string, 0, len(item.LabelValueSeries))
+			for v := range item.LabelValueSeries {
+				values = append(values, v)
+			}
+			sort.Strings(values)
+			for _, v := range values {
+				writeString(v)
+				writeUvarint(item.LabelValueSeries[v])
+				writeUvarint(0)
+			}
+		}
+	}
+	return buf.Bytes()
+}
+
+func TestLabelValuesCardinality_Sharding(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for _, lbName := range []string{"lbl-a", "lbl-b"} {
+		for i := 0; i < 50; i++ {
+			existingLabels[lbName] = append(existingLabels[lbName], fmt.Sprintf("%s-%d", lbName, i))
+		}
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 1}, nil
+	}
+
+	t.Run("the shards cover all the label values with no overlap", func(t *testing.T) {
+		const shardCount = 2
+		countedValues := map[string]map[string]int{}
+
+		for shardIndex := uint64(0); shardIndex < shardCount; shardIndex++ {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			err := labelValuesCardinality(
+				[]string{"lbl-a", "lbl-b"},
+				[]*labels.Matcher{},
+				idxReader,
+				postingsForMatchersFn,
+				1*1024*1024, // 1MB
+				labelValuesCardinalityOptions{shardIndex: shardIndex, shardCount: shardCount},
+				mockServer,
+			)
+			require.NoError(t, err)
+
+			shardValues := 0
+			for _, resp := range mockServer.SentResponses {
+				for _, item := range resp.Items {
+					if countedValues[item.LabelName] == nil {
+						countedValues[item.LabelName] = map[string]int{}
+					}
+					for lbValue := range item.LabelValueSeries {
+						countedValues[item.LabelName][lbValue]++
+						shardValues++
+					}
+				}
+			}
+			// Each shard processes only a part of the label values.
+			require.Greater(t, shardValues, 0)
+			require.Less(t, shardValues, 100)
+		}
+
+		require.Len(t, countedValues, len(existingLabels))
+		for lbName, lbValues := range existingLabels {
+			require.Len(t, countedValues[lbName], len(lbValues))
+			for _, lbValue := range lbValues {
+				require.Equalf(t, 1, countedValues[lbName][lbValue], "label %s value %s", lbName, lbValue)
+			}
+		}
+	})
+
+	t.Run("invalid shard index", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(
+			[]string{"lbl-a", "lbl-b"},
+			[]*labels.Matcher{},
+			idxReader,
+			postingsForMatchersFn,
+			1*1024*1024,
+			labelValuesCardinalityOptions{shardIndex: 2, shardCount: 2},
+			mockServer,
+		)
+		require.EqualError(t, err, "invalid shard index 2: it must be lower than the shard count 2")
+		require.Empty(t, mockServer.SentResponses)
+	})
+}
+
+func TestLabelValuesCardinality_PerLabelConcurrency(t *testing.T) {
+	const (
+		numValues           = 500
+		perLabelConcurrency = 4
+	)
+	existingLabels := map[string][]string{}
+	for i := 0; i < numValues; i++ {
+		existingLabels["lbl-a"] = append(existingLabels["lbl-a"], fmt.Sprintf("a-%d", i))
+	}
+	existingLabels["lbl-b"] = []string{"b-0", "b-1"}
+
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		// Slow down the counting, so that the counts overlap.
+		time.Sleep(time.Millisecond)
+		return &mockPostings{n: 1}, nil
+	}
+
+	inflight := &maxTrackingGauge{}
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	err := labelValuesCardinality(
+		[]string{"lbl-a", "lbl-b"},
+		[]*labels.Matcher{},
+		idxReader,
+		postingsForMatchersFn,
+		1*1024*1024, // 1MB
+		labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: perLabelConcurrency, inflightLabelValues: inflight}},
+		mockServer,
+	)
+	require.NoError(t, err)
+
+	require.Len(t, mockServer.SentResponses, 1)
+	require.Len(t, mockServer.SentResponses[0].Items, 2)
+	require.Len(t, mockServer.SentResponses[0].Items[0].LabelValueSeries, numValues)
+	require.Len(t, mockServer.SentResponses[0].Items[1].LabelValueSeries, 2)
+
+	require.Greater(t, inflight.max.Load(), int64(0))
+	require.LessOrEqual(t, inflight.max.Load(), int64(perLabelConcurrency))
+	require.Zero(t, inflight.current.Load())
+}
+
+func TestLabelValuesCardinality_CountingMemoryBudget(t *testing.T) {
+	const (
+		numValues           = 200
+		perLabelConcurrency = 16
+	)
+	existingLabels := map[string][]string{}
+	for i := 0; i < numValues; i++ {
+		existingLabels["lbl"] = append(existingLabels["lbl"], fmt.Sprintf("value-%d", i))
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		// Slow down the counting, so that the counts overlap.
+		time.Sleep(time.Millisecond)
+		return &mockPostings{n: 1}, nil
+	}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")}
+	footprint := labelValueCountingFootprint("lbl", existingLabels["lbl"], matchers)
+
+	for name, tc := range map[string]struct {
+		budget         int
+		maxConcurrency int64
+	}{
+		"no budget":                     {budget: 0, maxConcurrency: perLabelConcurrency},
+		"budget of 2 goroutines":        {budget: 2*footprint + footprint/2, maxConcurrency: 2},
+		"budget lower than a goroutine": {budget: footprint / 2, maxConcurrency: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			inflight := &maxTrackingGauge{}
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: perLabelConcurrency, countingMemoryBudget: tc.budget, inflightLabelValues: inflight}}
+			require.NoError(t, labelValuesCardinality([]string{"lbl"}, matchers, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer))
+
+			require.Len(t, mockServer.SentResponses, 1)
+			require.Len(t, mockServer.SentResponses[0].Items[0].LabelValueSeries, numValues)
+
+			require.Greater(t, inflight.max.Load(), int64(0))
+			require.LessOrEqual(t, inflight.max.Load(), tc.maxConcurrency)
+			if tc.budget == 0 {
+				// Without budget, the counting isn't throttled.
+				require.Greater(t, inflight.max.Load(), int64(2))
+			}
+		})
+	}
+}
+
+func TestLabelValuesCardinality_HighLoad(t *testing.T) {
+	const (
+		numValues           = 100
+		perLabelConcurrency = 16
+	)
+	existingLabels := map[string][]string{}
+	for i := 0; i < numValues; i++ {
+		existingLabels["lbl"] = append(existingLabels["lbl"], fmt.Sprintf("value-%d", i))
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		// Slow down the counting, so that the counts overlap.
+		time.Sleep(time.Millisecond)
+		return &mockPostings{n: 1}, nil
+	}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")}
+
+	highLoad := atomic.NewBool(false)
+	count := func() int64 {
+		inflight := &maxTrackingGauge{}
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: perLabelConcurrency, inflightLabelValues: inflight, highLoad: highLoad.Load}}
+		require.NoError(t, labelValuesCardinality([]string{"lbl"}, matchers, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer))
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Len(t, mockServer.SentResponses[0].Items[0].LabelValueSeries, numValues)
+		return inflight.max.Load()
+	}
+
+	require.Greater(t, count(), int64(2))
+
+	// Under high load, the series are counted serially.
+	highLoad.Store(true)
+	require.Equal(t, int64(1), count())
+
+	// The concurrency is restored once the load decreases.
+	highLoad.Store(false)
+	require.Greater(t, count(), int64(2))
+}
+
+func TestLabelValuesCardinality_LabelNamesConcurrency(t *testing.T) {
+	fixture := cardinalityFixture{
+		seed:         1,
+		numSeries:    1000,
+		labelValues:  map[string]int{labels.MetricName: 10, "pod": 100, "status": 5, "zone": 3, "cluster": 2},
+		distribution: zipfianDistribution,
+	}
+	idxReader := mockSeriesIndex{series: fixture.build()}
+	lbNames := []string{"zone", "pod", labels.MetricName, "status", "cluster"}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchNotEqual, "status", "status-0")}
+	// Slow down the counting, so that the labels processing overlaps.
+	postingsForMatchersFn := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		time.Sleep(100 * time.Microsecond)
+		return idxReader.postingsForMatchers(r, matchers...)
+	}
+
+	cardinality := func(opts labelValuesCardinalityOptions) []client.LabelValuesCardinalityResponse {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		require.NoError(t, labelValuesCardinality(lbNames, matchers, idxReader, postingsForMatchersFn, 64, opts, mockServer))
+		return mockServer.SentResponses
+	}
+	expected := cardinality(labelValuesCardinalityOptions{})
+
+	for _, labelNamesConcurrency := range []int{2, 4, 16} {
+		t.Run(fmt.Sprintf("concurrency=%d", labelNamesConcurrency), func(t *testing.T) {
+			inflight := &maxTrackingGauge{}
+			actual := cardinality(labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{labelNamesConcurrency: labelNamesConcurrency, inflightLabels: inflight}})
+
+			// The labels are processed concurrently, within the bound.
+			require.Greater(t, inflight.max.Load(), int64(1))
+			require.LessOrEqual(t, inflight.max.Load(), int64(labelNamesConcurrency))
+			require.Zero(t, inflight.current.Load())
+
+			// The counts are the same, and the labels are sent in the same order, batched in the same messages.
+			var expectedItems, actualItems []*client.LabelValueSeriesCount
+			for _, resp := range expected {
+				expectedItems = append(expectedItems, resp.Items...)
+			}
+			for _, resp := range actual {
+				actualItems = append(actualItems, resp.Items...)
+			}
+			require.Equal(t, mergeLabelValueSeriesCounts(expectedItems), mergeLabelValueSeriesCounts(actualItems))
+			require.Equal(t, len(expected), len(actual))
+			for i := range expected {
+				require.Equal(t, len(expected[i].Items), len(actual[i].Items), "message %d", i)
+				for j := range expected[i].Items {
+					require.Equal(t, expected[i].Items[j].LabelName, actual[i].Items[j].LabelName, "message %d", i)
+				}
+			}
+		})
+	}
+
+	t.Run("the first error is returned", func(t *testing.T) {
+		failingPostingsForMatchers := func(r tsdb.IndexPostingsReader, ms ...*labels.Matcher) (index.Postings, error) {
+			if ms[len(ms)-1].Name == labels.MetricName {
+				return nil, fmt.Errorf("failed to read the postings")
+			}
+			return postingsForMatchersFn(r, ms...)
+		}
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{labelNamesConcurrency: 4}}
+		err := labelValuesCardinality(lbNames, matchers, idxReader, failingPostingsForMatchers, 64, opts, mockServer)
+		require.EqualError(t, err, "failed to read the postings")
+	})
+}
+
+func TestHeapObjectsAbove(t *testing.T) {
+	require.Nil(t, heapObjectsAbove(0))
+	require.True(t, heapObjectsAbove(1)())
+	require.False(t, heapObjectsAbove(math.MaxInt)())
+}
+
+func TestLabelValuesCardinality_AllLabelsConcurrency(t *testing.T) {
+	const (
+		numLabels            = 20
+		allLabelsConcurrency = 3
+	)
+	existingLabels := map[string][]string{}
+	for i := 0; i < numLabels; i++ {
+		name := fmt.Sprintf("lbl-%02d", i)
+		existingLabels[name] = []string{name + "-a", name + "-b"}
+	}
+
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		// Slow down the counting, so that the labels processing overlaps.
+		time.Sleep(time.Millisecond)
+		return &mockPostings{n: 1}, nil
+	}
+
+	inflight := &maxTrackingGauge{}
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{
+		allLabels: true,
+		concurrency: labelValuesCardinalityConcurrencyOptions{
+			allLabelsConcurrency: allLabelsConcurrency,
+			perLabelConcurrency:  2,
+			inflightLabels:       inflight,
+		},
+	}
+	err := labelValuesCardinality(nil, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+	require.NoError(t, err)
+
+	// All the labels are returned, in order.
+	require.Len(t, mockServer.SentResponses, 1)
+	items := mockServer.SentResponses[0].Items
+	require.Len(t, items, numLabels)
+	for i, item := range items {
+		require.Equal(t, fmt.Sprintf("lbl-%02d", i), item.LabelName)
+		require.Len(t, item.LabelValueSeries, 2)
+	}
+
+	require.Greater(t, inflight.max.Load(), int64(0))
+	require.LessOrEqual(t, inflight.max.Load(), int64(allLabelsConcurrency))
+	require.Zero(t, inflight.current.Load())
+}
+
+func TestLabelValuesCardinality_AllLabelsWithLabelNames(t *testing.T) {
+	idxReader := &mockIndex{existingLabels: map[string][]string{"lbl": {"a"}}}
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	postingsForMatchersFn := func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 1}, nil
+	}
+	err := labelValuesCardinality([]string{"lbl"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, labelValuesCardinalityOptions{allLabels: true}, mockServer)
+
+	var invalidErr invalidLabelValuesCardinalityRequestError
+	require.ErrorAs(t, err, &invalidErr)
+	require.Empty(t, mockServer.SentResponses)
+}
+
+func TestLabelValuesCardinalityDiff(t *testing.T) {
+	// The series of window A have samples before 100, and the ones of window B after 200.
+	idxReader := mockSeriesIndex{
+		series: []labels.Labels{
+			labels.FromStrings(labels.MetricName, "up", "job", "api", "pod", "1"),
+			labels.FromStrings(labels.MetricName, "up", "job", "api", "pod", "2"),
+			labels.FromStrings(labels.MetricName, "up", "job", "db", "pod", "3"),
+			labels.FromStrings(labels.MetricName, "up", "job", "cache", "pod", "4"),
+			labels.FromStrings(labels.MetricName, "up", "job", "queue", "pod", "5"),
+			labels.FromStrings(labels.MetricName, "up", "job", "api", "pod", "6"),
+			labels.FromStrings(labels.MetricName, "up", "job", "web", "pod", "7"),
+			labels.FromStrings(labels.MetricName, "up", "job", "web", "pod", "8"),
+		},
+		chunks:     []int{1, 1, 1, 1, 1, 1, 1, 1},
+		timeRanges: [][2]int64{{0, 300}, {0, 300}, {0, 50}, {0, 300}, {0, 300}, {250, 300}, {250, 300}, {200, 300}},
+	}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")}
+	a := labelValuesCardinalityWindow{startMs: 0, endMs: 100}
+	b := labelValuesCardinalityWindow{startMs: 200, endMs: 300}
+
+	t.Run("the changes of the series counts are returned", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinalityDiff([]string{"job"}, matchers, idxReader, idxReader.postingsForMatchers, a, b, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Len(t, mockServer.SentResponses[0].Items, 1)
+		item := mockServer.SentResponses[0].Items[0]
+		require.Equal(t, "job", item.LabelName)
+		require.Empty(t, item.LabelValueSeries)
+		require.Equal(t, map[string]int64{
+			"api": 1,  // Grown.
+			"db":  -1, // Only in window A.
+			"web": 2,  // Only in window B.
+		}, item.LabelValueSeriesDelta)
+	})
+
+	t.Run("the windows can't be compared with options adding other fields to the messages", func(t *testing.T) {
+		for name, opts := range map[string]labelValuesCardinalityOptions{
+			"checksums":   {stream: labelValuesCardinalityStreamOptions{includeChecksums: true}},
+			"explain":     {explain: true},
+			"progress":    {stream: labelValuesCardinalityStreamOptions{progressInterval: time.Second}},
+			"summary":     {includeSummary: true},
+			"best effort": {bestEffort: true},
+		} {
+			t.Run(name, func(t *testing.T) {
+				mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+				err := labelValuesCardinalityDiff([]string{"job"}, matchers, idxReader, idxReader.postingsForMatchers, a, b, 1*1024*1024, opts, mockServer)
+				var invalidErr invalidLabelValuesCardinalityRequestError
+				require.ErrorAs(t, err, &invalidErr)
+				require.Empty(t, mockServer.SentResponses)
+			})
+		}
+	})
+
+	t.Run("the stopped message is sent when the request is stopped", func(t *testing.T) {
+		stop := make(chan struct{})
+		close(stop)
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinalityDiff([]string{"job"}, matchers, idxReader, idxReader.postingsForMatchers, a, b, 1*1024*1024, labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{stop: stop}}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.True(t, mockServer.SentResponses[0].Stopped)
+		require.Empty(t, mockServer.SentResponses[0].Items)
+	})
+}
+
+func TestLabelValuesCardinalityGrowthRate(t *testing.T) {
+	// Window A ends at 1s, and window B ends an hour later.
+	a := labelValuesCardinalityWindow{startMs: 0, endMs: 1000}
+	b := labelValuesCardinalityWindow{startMs: 2000, endMs: 3601000}
+
+	var idxReader mockSeriesIndex
+	addSeries := func(timeRange [2]int64, lbls ...string) {
+		idxReader.series = append(idxReader.series, labels.FromStrings(lbls...))
+		idxReader.chunks = append(idxReader.chunks, 1)
+		idxReader.timeRanges = append(idxReader.timeRanges, timeRange)
+	}
+	addSeries([2]int64{0, 1000}, labels.MetricName, "up", "job", "api", "pod", "1")
+	addSeries([2]int64{0, 1000}, labels.MetricName, "up", "job", "db", "pod", "2")
+	addSeries([2]int64{0, 3601000}, labels.MetricName, "up", "job", "cache", "pod", "3")
+	for i := 0; i < 361; i++ {
+		addSeries([2]int64{2000, 3601000}, labels.MetricName, "up", "job", "api", "pod", fmt.Sprintf("api-%d", i))
+	}
+	for i := 0; i < 36; i++ {
+		addSeries([2]int64{2000, 3601000}, labels.MetricName, "up", "job", "web", "pod", fmt.Sprintf("web-%d", i))
+	}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")}
+
+	t.Run("the deltas are normalized per second", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinalityGrowthRate([]string{"job"}, matchers, idxReader, idxReader.postingsForMatchers, a, b, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Len(t, mockServer.SentResponses[0].Items, 1)
+		item := mockServer.SentResponses[0].Items[0]
+		require.Equal(t, "job", item.LabelName)
+		require.Empty(t, item.LabelValueSeries)
+		require.Empty(t, item.LabelValueSeriesDelta)
+		require.Equal(t, map[string]float64{
+			"api": 0.1,         // 360 more series over an hour.
+			"db":  -1.0 / 3600, // Only in window A.
+			"web": 0.01,        // Only in window B.
+		}, item.LabelValueSeriesGrowthRate)
+	})
+
+	t.Run("the second window must end after the first one", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinalityGrowthRate([]string{"job"}, matchers, idxReader, idxReader.postingsForMatchers, b, a, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		var invalidErr invalidLabelValuesCardinalityRequestError
+		require.ErrorAs(t, err, &invalidErr)
+		require.Empty(t, mockServer.SentResponses)
+	})
+}
+
+func TestLabelValuesCardinality_SendStallTimeout(t *testing.T) {
+	var inputSeries []labels.Labels
+	for v := 0; v < 20; v++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "job", fmt.Sprintf("job-%d", v)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+	slowPostingsForMatchers := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		p, err := idxReader.postingsForMatchers(r, matchers...)
+		if err != nil {
+			return nil, err
+		}
+		return &slowPostings{Postings: p, delay: 20 * time.Millisecond}, nil
+	}
+
+	t.Run("the request is aborted when sending a message stalls", func(t *testing.T) {
+		unblock := make(chan struct{})
+		mockServer := &blockingLabelValuesCardinalityServer{context: context.Background(), unblock: unblock}
+
+		opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{sendStallTimeout: 50 * time.Millisecond}}
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, opts, mockServer)
+		}()
+
+		// The stalled send isn't abandoned: the request returns once the stream stops blocking it,
+		// like when the client cancels the stream.
+		select {
+		case err := <-errCh:
+			require.Fail(t, "the request returned while sending a message", "err: %v", err)
+		case <-time.After(200 * time.Millisecond):
+		}
+		close(unblock)
+
+		err := <-errCh
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		require.Equal(t, int64(1), mockServer.sendCalls.Load())
+		require.Equal(t, int64(0), mockServer.inflightSends.Load())
+	})
+
+	t.Run("the counting is cancelled when sending a progress message stalls", func(t *testing.T) {
+		unblock := make(chan struct{})
+		mockServer := &blockingLabelValuesCardinalityServer{context: context.Background(), unblock: unblock}
+
+		var countedValues atomic.Int64
+		countingPostingsForMatchers := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+			countedValues.Inc()
+			return slowPostingsForMatchers(r, matchers...)
+		}
+
+		// Counting all the values takes about 800ms, which is much longer than the stall timeout.
+		opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{sendStallTimeout: 50 * time.Millisecond, progressInterval: 5 * time.Millisecond}}
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, countingPostingsForMatchers, 1*1024*1024, opts, mockServer)
+		}()
+
+		// The counting stops while the progress message is still being sent.
+		time.Sleep(200 * time.Millisecond)
+		counted := countedValues.Load()
+		time.Sleep(200 * time.Millisecond)
+		require.Equal(t, counted, countedValues.Load())
+		require.Less(t, counted, int64(len(inputSeries)))
+
+		close(unblock)
+		err := <-errCh
+		require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		require.Equal(t, int64(0), mockServer.inflightSends.Load())
+	})
+
+	t.Run("the request completes if sending doesn't stall", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{sendStallTimeout: time.Second}}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.NoError(t, err)
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Len(t, mockServer.SentResponses[0].Items[0].LabelValueSeries, len(inputSeries))
+	})
+}
+
+// blockingLabelValuesCardinalityServer is a server whose Send blocks until unblock is closed, like when
+// the client stops reading the stream.
+type blockingLabelValuesCardinalityServer struct {
+	client.Ingester_LabelValuesCardinalityServer
+	context       context.Context
+	unblock       chan struct{}
+	sendCalls     atomic.Int64
+	inflightSends atomic.Int64
+}
+
+func (m *blockingLabelValuesCardinalityServer) Send(*client.LabelValuesCardinalityResponse) error {
+	m.sendCalls.Inc()
+	m.inflightSends.Inc()
+	defer m.inflightSends.Dec()
+	<-m.unblock
+	return nil
+}
+
+func (m *blockingLabelValuesCardinalityServer) Context() context.Context {
+	return m.context
+}
+
+func TestLabelValuesCardinality_PauseAndResume(t *testing.T) {
+	var inputSeries []labels.Labels
+	for v := 0; v < 20; v++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "job", fmt.Sprintf("job-%d", v)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	expectedServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	require.NoError(t, labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, labelValuesCardinalityOptions{}, expectedServer))
+	require.Len(t, expectedServer.SentResponses, 20)
+
+	t.Run("the messages are held while the request is paused", func(t *testing.T) {
+		pause := &labelValuesCardinalityPause{}
+		// The client pauses the request once it receives the first message.
+		mockServer := &pausingLabelValuesCardinalityServer{
+			mockLabelValuesCardinalityServer: &mockLabelValuesCardinalityServer{context: context.Background()},
+			onFirstSend:                      pause.pause,
+		}
+
+		done := make(chan error, 1)
+		go func() {
+			opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{pause: pause}}
+			done <- labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, opts, mockServer)
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		require.Equal(t, int64(1), mockServer.sendCalls.Load())
+		require.Empty(t, done)
+
+		pause.resume()
+		require.NoError(t, <-done)
+		require.Equal(t, expectedServer.SentResponses, mockServer.SentResponses)
+	})
+
+	t.Run("the series are not counted while the request is paused", func(t *testing.T) {
+		pause := &labelValuesCardinalityPause{}
+		pause.pause()
+		var postingsCalls atomic.Int64
+		countingPostingsForMatchers := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+			postingsCalls.Inc()
+			return idxReader.postingsForMatchers(r, matchers...)
+		}
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+
+		done := make(chan error, 1)
+		go func() {
+			opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{pause: pause}}
+			done <- labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, countingPostingsForMatchers, 1, opts, mockServer)
+		}()
+
+		time.Sleep(100 * time.Millisecond)
+		require.Zero(t, postingsCalls.Load())
+
+		pause.resume()
+		require.NoError(t, <-done)
+		require.Equal(t, int64(20), postingsCalls.Load())
+		require.Equal(t, expectedServer.SentResponses, mockServer.SentResponses)
+	})
+
+	t.Run("the pending items are sent when a paused request is stopped", func(t *testing.T) {
+		pause := &labelValuesCardinalityPause{}
+		stop := make(chan struct{})
+		mockServer := &pausingLabelValuesCardinalityServer{
+			mockLabelValuesCardinalityServer: &mockLabelValuesCardinalityServer{context: context.Background()},
+			onFirstSend: func() {
+				pause.pause()
+				close(stop)
+			},
+		}
+
+		opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{pause: pause, stop: stop}}
+		require.NoError(t, labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, opts, mockServer))
+		require.True(t, mockServer.SentResponses[len(mockServer.SentResponses)-1].Stopped)
+	})
+
+	t.Run("a paused request is aborted when the context is canceled", func(t *testing.T) {
+		pause := &labelValuesCardinalityPause{}
+		pause.pause()
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		mockServer := &mockLabelValuesCardinalityServer{context: ctx}
+
+		opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{pause: pause}}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, opts, mockServer)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Empty(t, mockServer.SentResponses)
+	})
+}
+
+// pausingLabelValuesCardinalityServer is a mockLabelValuesCardinalityServer calling onFirstSend when
+// the first message is sent.
+type pausingLabelValuesCardinalityServer struct {
+	*mockLabelValuesCardinalityServer
+	onFirstSend func()
+	sendCalls   atomic.Int64
+}
+
+func (m *pausingLabelValuesCardinalityServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	if m.sendCalls.Inc() == 1 {
+		m.onFirstSend()
+	}
+	return m.mockLabelValuesCardinalityServer.Send(resp)
+}
+
+func TestLabelNamesAndValues_MaxTotalBytes(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for i := 0; i < 10; i++ {
+		name := fmt.Sprintf("label-%d", i)
+		existingLabels[name] = []string{name + "-a", name + "-b"}
+	}
+
+	t.Run("the request is aborted after partial streaming when the response exceeds the limit", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		// The small message size threshold makes each message hold a single label value.
+		opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxTotalBytes: 100}}
+		err := labelNamesAndValues(mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, 16, opts, server)
+		require.ErrorIs(t, err, errResponseTooLarge)
+
+		require.NotEmpty(t, server.SentResponses)
+		require.Less(t, len(server.SentResponses), 2*len(existingLabels))
+		totalBytes := 0
+		for _, resp := range server.SentResponses {
+			totalBytes += resp.Size()
+		}
+		require.LessOrEqual(t, totalBytes, opts.limits.maxTotalBytes)
+	})
+
+	t.Run("the request completes when the response doesn't exceed the limit", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxTotalBytes: 10 * 1024}}
+		require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, 16, opts, server))
+		require.Len(t, server.SentResponses, 2*len(existingLabels))
+	})
+}
+
+func TestLabelNamesAndValues_CompressionDictionary(t *testing.T) {
+	existingLabels := map[string][]string{}
+	var samples []string
+	for _, name := range []string{"pod", "instance"} {
+		for i := 0; i < 20; i++ {
+			value := fmt.Sprintf("ingester-zone-a-%d.ingester.cortex-prod-01.svc.cluster.local:9095", i)
+			existingLabels[name] = append(existingLabels[name], value)
+			// The dictionary is trained on values similar to the returned ones.
+			samples = append(samples, fmt.Sprintf("ingester-zone-b-%d.ingester.cortex-prod-01.svc.cluster.local:9095", i))
+		}
+	}
+	idxReader := mockIndex{existingLabels: existingLabels}
+	dict := client.NewLabelValuesCompressionDictionary(samples)
+
+	plainServer := &mockLabelNamesAndValuesServer{context: context.Background()}
+	require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{}, plainServer))
+	compressedServer := &mockLabelNamesAndValuesServer{context: context.Background()}
+	require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{compressionDictionary: dict}}, compressedServer))
+
+	var decompressed []*client.LabelValues
+	dictSize, noDictSize := 0, 0
+	for _, resp := range compressedServer.SentResponses {
+		require.Equal(t, client.LabelValuesCompressionDictionaryID(dict), resp.ValuesCompressionDictionaryId)
+		for _, item := range resp.Items {
+			require.Empty(t, item.Values)
+			values, err := client.DecompressLabelValues(dict, item.CompressedValues)
+			require.NoError(t, err)
+			decompressed = append(decompressed, &client.LabelValues{LabelName: item.LabelName, Values: values})
+
+			noDict, err := client.CompressLabelValues(nil, values)
+			require.NoError(t, err)
+			dictSize += len(item.CompressedValues)
+			noDictSize += len(noDict)
+		}
+	}
+	require.Equal(t, extractItemsWithSortedValues(plainServer.SentResponses), extractItemsWithSortedValues([]client.LabelNamesAndValuesResponse{{Items: decompressed}}))
+	require.Less(t, dictSize, noDictSize)
+
+	t.Run("can't be used with the value IDs", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{compressionDictionary: dict, useValueIDs: true}}
+		require.Error(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server))
+	})
+
+	t.Run("the dictionary can't be bigger than 32KiB", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{compressionDictionary: make([]byte, client.MaxLabelValuesCompressionDictionaryBytes+1)}}
+		require.ErrorContains(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server), "compression dictionary")
+	})
+}
+
+func TestLabelNamesAndValues_ValueCount(t *testing.T) {
+	existingLabels := map[string][]string{
+		"label-a": {"a-0", "a-1", "a-2", "a-3", "a-4"},
+		"label-b": {"b-0"},
+		"label-c": {"c-0", "c-1", "c-2"},
+	}
+	idxReader := mockIndex{existingLabels: existingLabels}
+
+	t.Run("the names are returned with their value count, without the values", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{omitValues: true, includeValueCount: true}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server))
+
+		valueCounts := map[string]uint64{}
+		for _, resp := range server.SentResponses {
+			for _, item := range resp.Items {
+				require.Empty(t, item.Values)
+				valueCounts[item.LabelName] = item.ValueCount
+			}
+		}
+		require.Equal(t, map[string]uint64{
+			"label-a": uint64(len(existingLabels["label-a"])),
+			"label-b": uint64(len(existingLabels["label-b"])),
+			"label-c": uint64(len(existingLabels["label-c"])),
+		}, valueCounts)
+	})
+
+	t.Run("the value count is set in the first item of the label when the values are returned", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{includeValueCount: true}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 10, opts, server))
+
+		require.Greater(t, len(server.SentResponses), 1)
+		values := map[string][]string{}
+		for _, resp := range server.SentResponses {
+			for _, item := range resp.Items {
+				if _, ok := values[item.LabelName]; ok {
+					require.Zero(t, item.ValueCount)
+				} else {
+					require.Equal(t, uint64(len(existingLabels[item.LabelName])), item.ValueCount)
+				}
+				values[item.LabelName] = append(values[item.LabelName], item.Values...)
+			}
+		}
+		require.Equal(t, existingLabels, values)
+	})
+}
+
+func TestLabelNamesAndValues_MaxValues(t *testing.T) {
+	existingLabels := map[string][]string{
+		"label-a": {"a-0", "a-1", "a-2"},
+		"label-b": {"b-0", "b-1", "b-2", "b-3"},
+		"label-c": {"c-0"},
+	}
+	idxReader := mockIndex{existingLabels: existingLabels}
+
+	for _, tc := range []struct {
+		name              string
+		maxValues         int
+		threshold         int
+		expectedValues    map[string][]string
+		expectedTruncated bool
+	}{
+		{
+			name:              "truncated in the middle of a label",
+			maxValues:         5,
+			threshold:         1024,
+			expectedValues:    map[string][]string{"label-a": {"a-0", "a-1", "a-2"}, "label-b": {"b-0", "b-1"}},
+			expectedTruncated: true,
+		},
+		{
+			name:              "truncated in the middle of a label split across messages",
+			maxValues:         5,
+			threshold:         20,
+			expectedValues:    map[string][]string{"label-a": {"a-0", "a-1", "a-2"}, "label-b": {"b-0", "b-1"}},
+			expectedTruncated: true,
+		},
+		{
+			name:              "truncated at the boundary of a label",
+			maxValues:         3,
+			threshold:         1024,
+			expectedValues:    map[string][]string{"label-a": {"a-0", "a-1", "a-2"}},
+			expectedTruncated: true,
+		},
+		{
+			name:           "not truncated when the cap is exactly the number of values",
+			maxValues:      8,
+			threshold:      1024,
+			expectedValues: existingLabels,
+		},
+		{
+			name:           "not truncated without a cap",
+			threshold:      1024,
+			expectedValues: existingLabels,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxValues: tc.maxValues}}
+			require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, tc.threshold, opts, server))
+
+			require.NotEmpty(t, server.SentResponses)
+			values := map[string][]string{}
+			for i, resp := range server.SentResponses {
+				// Only the last message is flagged as truncated.
+				require.Equal(t, tc.expectedTruncated && i == len(server.SentResponses)-1, resp.Truncated, "message %d", i)
+				for _, item := range resp.Items {
+					values[item.LabelName] = append(values[item.LabelName], item.Values...)
+				}
+			}
+			require.Equal(t, tc.expectedValues, values)
+		})
+	}
+
+	t.Run("the cap applies to the previews and then to the remaining values", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxValues: 5}, encoding: labelNamesAndValuesEncodingOptions{valuesPreviewSize: 2}}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.True(t, server.SentResponses[0].Truncated)
+		require.Equal(t, []*client.LabelValues{
+			{LabelName: "label-a", Values: []string{"a-0", "a-1"}},
+			{LabelName: "label-b", Values: []string{"b-0", "b-1"}},
+			{LabelName: "label-c", Values: []string{"c-0"}},
+		}, server.SentResponses[0].Items)
+	})
+
+	t.Run("the label names are not capped when the values are omitted", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxValues: 1}, omitValues: true}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.False(t, server.SentResponses[0].Truncated)
+		require.Equal(t, []string{"label-a", "label-b", "label-c"}, labelNamesWithoutValues(t, server.SentResponses[0]))
+	})
+}
+
+func TestLabelNamesAndValues_MaxLabelNames(t *testing.T) {
+	existingLabels := map[string][]string{}
+	for i := 0; i < 10000; i++ {
+		name := fmt.Sprintf("label-%05d", i)
+		existingLabels[name] = []string{name + "-a", name + "-b"}
+	}
+	idxReader := &countingLabelValuesIndex{mockIndex: mockIndex{existingLabels: existingLabels}}
+
+	for _, tc := range []struct {
+		name                string
+		maxLabelNames       int
+		expectedLabelNames  int
+		expectedTruncated   bool
+		expectedLookupCalls int
+	}{
+		{
+			name:                "oversized label names set is truncated",
+			maxLabelNames:       100,
+			expectedLabelNames:  100,
+			expectedTruncated:   true,
+			expectedLookupCalls: 100,
+		},
+		{
+			name:                "label names set within the limit is not truncated",
+			maxLabelNames:       10000,
+			expectedLabelNames:  10000,
+			expectedLookupCalls: 10000,
+		},
+		{
+			name:                "label names set is not truncated without a limit",
+			expectedLabelNames:  10000,
+			expectedLookupCalls: 10000,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			idxReader.labelValuesCalls.Store(0)
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxLabelNames: tc.maxLabelNames}}
+			require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server))
+
+			require.NotEmpty(t, server.SentResponses)
+			var labelNames []string
+			for i, resp := range server.SentResponses {
+				// Only the last message is flagged as having truncated label names.
+				require.Equal(t, tc.expectedTruncated && i == len(server.SentResponses)-1, resp.LabelNamesTruncated, "message %d", i)
+				require.False(t, resp.Truncated)
+				for _, item := range resp.Items {
+					if len(labelNames) == 0 || labelNames[len(labelNames)-1] != item.LabelName {
+						labelNames = append(labelNames, item.LabelName)
+					}
+				}
+			}
+			require.Len(t, labelNames, tc.expectedLabelNames)
+			// The first label names in lexicographic order are returned.
+			require.Equal(t, "label-00000", labelNames[0])
+			require.Equal(t, fmt.Sprintf("label-%05d", tc.expectedLabelNames-1), labelNames[len(labelNames)-1])
+			// The values of the label names beyond the limit are not looked up.
+			require.Equal(t, int64(tc.expectedLookupCalls), idxReader.labelValuesCalls.Load())
+		})
+	}
+}
+
+// countingLabelValuesIndex counts the label values lookups.
+type countingLabelValuesIndex struct {
+	mockIndex
+	labelValuesCalls atomic.Int64
+}
+
+func (i *countingLabelValuesIndex) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	i.labelValuesCalls.Inc()
+	return i.mockIndex.LabelValues(name, matchers...)
+}
+
+func TestLabelNamesAndValues_ValuesPreview(t *testing.T) {
+	existingLabels := map[string][]string{
+		"label-a": {"a-0", "a-1", "a-2", "a-3", "a-4"},
+		"label-b": {"b-0"},
+		"label-c": {"c-0", "c-1", "c-2"},
+	}
+	idxReader := mockIndex{existingLabels: existingLabels}
+	opts := labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{valuesPreviewSize: 2}}
+
+	t.Run("the previews of all the labels are sent before the remaining values", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server))
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, []*client.LabelValues{
+			{LabelName: "label-a", Values: []string{"a-0", "a-1"}},
+			{LabelName: "label-b", Values: []string{"b-0"}},
+			{LabelName: "label-c", Values: []string{"c-0", "c-1"}},
+			{LabelName: "label-a", Values: []string{"a-2", "a-3", "a-4"}},
+			{LabelName: "label-c", Values: []string{"c-2"}},
+		}, server.SentResponses[0].Items)
+	})
+
+	t.Run("the order is kept when the values are split across messages", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 10, opts, server))
+		require.Greater(t, len(server.SentResponses), 1)
+		var sent []string
+		for _, resp := range server.SentResponses {
+			for _, item := range resp.Items {
+				for _, value := range item.Values {
+					sent = append(sent, item.LabelName+"="+value)
+				}
+			}
+		}
+		require.Equal(t, []string{
+			"label-a=a-0", "label-a=a-1", "label-b=b-0", "label-c=c-0", "label-c=c-1",
+			"label-a=a-2", "label-a=a-3", "label-a=a-4", "label-c=c-2",
+		}, sent)
+	})
+
+	t.Run("can't be used with the partitioning by first character", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{valuesPreviewSize: 2, partitionByFirstCharacter: true}}
+		require.Error(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1024, opts, server))
+	})
+}
+
+func TestLabelNamesAndValues_Fields(t *testing.T) {
+	existingLabels := map[string][]string{
+		"label-a": {"a-0", "a-1", "a-2"},
+		"label-b": {"b-0", "b-1"},
+		"label-c": {"c-0"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+
+	t.Run("fields=names returns only the label names", func(t *testing.T) {
+		omitValues, err := parseLabelNamesAndValuesFields([]string{"names"})
+		require.NoError(t, err)
+
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, labelNamesAndValuesOptions{omitValues: omitValues}, &server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, []string{"label-a", "label-b", "label-c"}, labelNamesWithoutValues(t, server.SentResponses[0]))
+	})
+
+	t.Run("fields=names splits the label names across messages", func(t *testing.T) {
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 24, labelNamesAndValuesOptions{omitValues: true}, &server))
+
+		// Each message holds 2 label names.
+		require.Len(t, server.SentResponses, 2)
+		require.Equal(t, []string{"label-a", "label-b"}, labelNamesWithoutValues(t, server.SentResponses[0]))
+		require.Equal(t, []string{"label-c"}, labelNamesWithoutValues(t, server.SentResponses[1]))
+	})
+
+	t.Run("fields=names,values returns the label names and values", func(t *testing.T) {
+		omitValues, err := parseLabelNamesAndValuesFields([]string{"names", "values"})
+		require.NoError(t, err)
+
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, labelNamesAndValuesOptions{omitValues: omitValues}, &server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, []*client.LabelValues{
+			{LabelName: "label-a", Values: []string{"a-0", "a-1", "a-2"}},
+			{LabelName: "label-b", Values: []string{"b-0", "b-1"}},
+			{LabelName: "label-c", Values: []string{"c-0"}},
+		}, server.SentResponses[0].Items)
+	})
+
+	t.Run("invalid fields", func(t *testing.T) {
+		_, err := parseLabelNamesAndValuesFields([]string{"names", "series"})
+		require.EqualError(t, err, `unknown field "series", supported fields are "names" and "values"`)
+
+		_, err = parseLabelNamesAndValuesFields([]string{"values"})
+		require.EqualError(t, err, `the "names" field can't be omitted`)
+	})
+}
+
+func TestLabelNamesAndValues_LongValues(t *testing.T) {
+	longValue := func(prefix string) string {
+		return prefix + strings.Repeat("x", 40)
+	}
+	existingLabels := map[string][]string{
+		"label-a": {"a-0", longValue("a-1"), "a-2", longValue("a-3")},
+		"label-b": {"b-0", "b-1"},
+		"label-c": {longValue("c-0"), longValue("c-1"), longValue("c-2"), longValue("c-3"), longValue("c-4"), "c-5"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+
+	t.Run("long values are reported per label", func(t *testing.T) {
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{longValueLengthThreshold: 32}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, opts, &server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, []*client.LongLabelValues{
+			{LabelName: "label-a", Count: 2, Exemplars: []string{longValue("a-1"), longValue("a-3")}},
+			{LabelName: "label-c", Count: 5, Exemplars: []string{longValue("c-0"), longValue("c-1"), longValue("c-2")}},
+		}, server.SentResponses[0].LongValues)
+
+		// Long values are still returned as regular values.
+		require.Equal(t, existingLabels["label-c"], server.SentResponses[0].Items[2].Values)
+	})
+
+	t.Run("long values are reported across messages", func(t *testing.T) {
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{longValueLengthThreshold: 32}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 64, opts, &server))
+
+		var reports []*client.LongLabelValues
+		for _, resp := range server.SentResponses {
+			reports = append(reports, resp.LongValues...)
+		}
+		require.Greater(t, len(server.SentResponses), 1)
+		require.Len(t, reports, 2)
+		require.Equal(t, "label-a", reports[0].LabelName)
+		require.Equal(t, uint64(2), reports[0].Count)
+		require.Equal(t, "label-c", reports[1].LabelName)
+		require.Equal(t, uint64(5), reports[1].Count)
+	})
+
+	t.Run("long values are not reported by default", func(t *testing.T) {
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, labelNamesAndValuesOptions{}, &server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.Empty(t, server.SentResponses[0].LongValues)
+	})
+}
+
+func TestLabelNamesAndValues_ValuesComparator(t *testing.T) {
+	existingLabels := map[string][]string{
+		"version": {"v1", "v10", "v2"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+
+	// versionLess compares the numeric part of the "v<number>" values.
+	versionLess := func(a, b string) bool {
+		aNum, aErr := strconv.Atoi(strings.TrimPrefix(a, "v"))
+		bNum, bErr := strconv.Atoi(strings.TrimPrefix(b, "v"))
+		if aErr != nil || bErr != nil {
+			return a < b
+		}
+		return aNum < bNum
+	}
+
+	t.Run("values are sorted with the comparator", func(t *testing.T) {
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, labelNamesAndValuesOptions{valuesLess: versionLess}, &server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, []*client.LabelValues{
+			{LabelName: "version", Values: []string{"v1", "v2", "v10"}},
+		}, server.SentResponses[0].Items)
+
+		// The values returned by the index are left untouched.
+		require.Equal(t, []string{"v1", "v10", "v2"}, existingLabels["version"])
+	})
+
+	t.Run("values are sorted before being split across messages", func(t *testing.T) {
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 20, labelNamesAndValuesOptions{valuesLess: versionLess}, &server))
+
+		require.Len(t, server.SentResponses, 2)
+		require.Equal(t, []*client.LabelValues{{LabelName: "version", Values: []string{"v1", "v2"}}}, server.SentResponses[0].Items)
+		require.Equal(t, []*client.LabelValues{{LabelName: "version", Values: []string{"v10"}}}, server.SentResponses[1].Items)
+	})
+
+	t.Run("values are returned in the index order without a comparator", func(t *testing.T) {
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, labelNamesAndValuesOptions{}, &server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, []string{"v1", "v10", "v2"}, server.SentResponses[0].Items[0].Values)
+	})
+}
+
+func TestLabelNamesAndValues_ValuesCollation(t *testing.T) {
+	existingLabels := map[string][]string{
+		"city": {"Zürich", "Århus", "Ängelholm", "Aachen", "Öland", "Zagreb"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+	collatedValues := func(t *testing.T, tag string) []string {
+		valuesLess, err := collatedValuesLess(tag)
+		require.NoError(t, err)
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, labelNamesAndValuesOptions{valuesLess: valuesLess}, &server))
+		require.Len(t, server.SentResponses, 1)
+		return server.SentResponses[0].Items[0].Values
+	}
+
+	byteOrder := []string{"Aachen", "Zagreb", "Zürich", "Ängelholm", "Århus", "Öland"}
+	require.Equal(t, byteOrder, ensureSortedLabelValues(existingLabels["city"]))
+
+	// The accented letters are sorted with their base letter by the root collation, and after Z in Swedish.
+	require.Equal(t, []string{"Aachen", "Ängelholm", "Århus", "Öland", "Zagreb", "Zürich"}, collatedValues(t, "und"))
+	require.Equal(t, []string{"Aachen", "Zagreb", "Zürich", "Århus", "Ängelholm", "Öland"}, collatedValues(t, "sv"))
+
+	t.Run("values are collated before being split across messages", func(t *testing.T) {
+		valuesLess, err := collatedValuesLess("sv")
+		require.NoError(t, err)
+		server := mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 30, labelNamesAndValuesOptions{valuesLess: valuesLess}, &server))
+		require.Greater(t, len(server.SentResponses), 1)
+		var values []string
+		for _, resp := range server.SentResponses {
+			values = append(values, resp.Items[0].Values...)
+		}
+		require.Equal(t, []string{"Aachen", "Zagreb", "Zürich", "Århus", "Ängelholm", "Öland"}, values)
+	})
+
+	t.Run("invalid language tag", func(t *testing.T) {
+		_, err := collatedValuesLess("not a tag")
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+// labelNamesWithoutValues returns the label names in the response, asserting they have no values.
+func labelNamesWithoutValues(t *testing.T, resp client.LabelNamesAndValuesResponse) []string {
+	names := make([]string, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		require.Emptyf(t, item.Values, "label %s", item.LabelName)
+		names = append(names, item.LabelName)
+	}
+	return names
+}
+
+func TestLabelValuesCardinality_Stop(t *testing.T) {
+	existingLabels := map[string][]string{
+		"lbl-a": {"a-0", "a-1", "a-2", "a-3"},
+		"lbl-b": {"b-0", "b-1", "b-2", "b-3"},
+		"lbl-c": {"c-0", "c-1", "c-2", "c-3"},
+	}
+	idxReader := &mockIndex{existingLabels: existingLabels}
+
+	stop := make(chan struct{})
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		// The client asks to stop while the values of lbl-b are being counted.
+		if matchers[len(matchers)-1].Value == "b-0" {
+			close(stop)
+		}
+		return &mockPostings{n: 1}, nil
+	}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	err := labelValuesCardinality(
+		[]string{"lbl-a", "lbl-b", "lbl-c"},
+		[]*labels.Matcher{},
+		idxReader,
+		postingsForMatchersFn,
+		1*1024*1024, // 1MB
+		labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{stop: stop}},
+		mockServer,
+	)
+	require.NoError(t, err)
+
+	// The pending items are flushed in a last message flagged as stopped.
+	require.Len(t, mockServer.SentResponses, 1)
+	require.True(t, mockServer.SentResponses[0].Stopped)
+	require.Len(t, mockServer.SentResponses[0].Items, 1)
+	require.Equal(t, "lbl-a", mockServer.SentResponses[0].Items[0].LabelName)
+	require.Len(t, mockServer.SentResponses[0].Items[0].LabelValueSeries, 4)
+}
+
+func TestLabelValuesCardinalityDeltas(t *testing.T) {
+	snapshot := func(items ...*client.LabelValueSeriesCount) []*client.LabelValueSeriesCount {
+		return items
+	}
+	counts := func(lbName string, lbValueSeries map[string]uint64) *client.LabelValueSeriesCount {
+		return &client.LabelValueSeriesCount{LabelName: lbName, LabelValueSeries: lbValueSeries}
+	}
+	deltas := func(lbName string, lbValueDeltas map[string]int64) *client.LabelValueSeriesCount {
+		return &client.LabelValueSeriesCount{LabelName: lbName, LabelValueSeriesDelta: lbValueDeltas}
+	}
+
+	d := newLabelValuesCardinalityDeltas(snapshot(
+		counts("job", map[string]uint64{"api": 3, "db": 2}),
+		// The values of the same label can be split across multiple items.
+		counts("job", map[string]uint64{"web": 1}),
+		counts("zone", map[string]uint64{"eu": 6}),
+	))
+
+	// No changes.
+	require.Empty(t, d.update(snapshot(
+		counts("job", map[string]uint64{"api": 3, "db": 2, "web": 1}),
+		counts("zone", map[string]uint64{"eu": 6}),
+	)))
+
+	// Series are added to and removed from existing values, a value disappears and a new one appears.
+	require.Equal(t, []*client.LabelValueSeriesCount{
+		deltas("job", map[string]int64{"api": 2, "db": -1, "web": -1, "cache": 4}),
+	}, d.update(snapshot(
+		counts("job", map[string]uint64{"api": 5, "db": 1, "cache": 4}),
+		counts("zone", map[string]uint64{"eu": 6}),
+	)))
+
+	// A label disappears and a new one appears.
+	require.Equal(t, []*client.LabelValueSeriesCount{
+		deltas("region", map[string]int64{"eu-west": 6}),
+		deltas("zone", map[string]int64{"eu": -6}),
+	}, d.update(snapshot(
+		counts("job", map[string]uint64{"api": 5, "db": 1, "cache": 4}),
+		counts("region", map[string]uint64{"eu-west": 6}),
+	)))
+
+	// All series are removed.
+	require.Equal(t, []*client.LabelValueSeriesCount{
+		deltas("job", map[string]int64{"api": -5, "db": -1, "cache": -4}),
+		deltas("region", map[string]int64{"eu-west": -6}),
+	}, d.update(nil))
+}
+
+func TestSendLabelValuesCardinalityDeltas(t *testing.T) {
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	err := sendLabelValuesCardinalityDeltas(mockServer, []*client.LabelValueSeriesCount{
+		{LabelName: "lbl-a", LabelValueSeriesDelta: map[string]int64{"a-0": 1, "a-1": -2, "a-2": 3}},
+		{LabelName: "lbl-b", LabelValueSeriesDelta: map[string]int64{"b-0": -4}},
+	}, 6) // Each message holds 2 values.
+	require.NoError(t, err)
+
+	require.Len(t, mockServer.SentResponses, 2)
+	require.Equal(t, []*client.LabelValueSeriesCount{
+		{LabelName: "lbl-a", LabelValueSeriesDelta: map[string]int64{"a-0": 1, "a-1": -2}},
+	}, mockServer.SentResponses[0].Items)
+	require.Equal(t, []*client.LabelValueSeriesCount{
+		{LabelName: "lbl-a", LabelValueSeriesDelta: map[string]int64{"a-2": 3}},
+		{LabelName: "lbl-b", LabelValueSeriesDelta: map[string]int64{"b-0": -4}},
+	}, mockServer.SentResponses[1].Items)
+}
+
+func TestLabelValuesCardinality_EstimateLabelSeries(t *testing.T) {
+	const numSeries = 20000
+
+	var series []labels.Labels
+	for i := 0; i < numSeries; i++ {
+		lbls := []string{labels.MetricName, "metric", "series_id", fmt.Sprintf("%d", i), "lbl", fmt.Sprintf("v-%d", i%50)}
+		if i%2 == 0 {
+			lbls = append(lbls, "even", fmt.Sprintf("v-%d", i%10))
+		}
+		series = append(series, labels.FromStrings(lbls...))
+	}
+	idxReader := mockSeriesIndex{series: series}
+
+	for name, opts := range map[string]labelValuesCardinalityOptions{
+		"sequential": {estimation: labelValuesCardinalityEstimationOptions{estimateLabelSeries: true}},
+		"concurrent": {estimation: labelValuesCardinalityEstimationOptions{estimateLabelSeries: true}, concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: 4}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			err := labelValuesCardinality(
+				[]string{"lbl", "even"},
+				[]*labels.Matcher{},
+				idxReader,
+				idxReader.postingsForMatchers,
+				1*1024*1024, // 1MB
+				opts,
+				mockServer,
+			)
+			require.NoError(t, err)
+
+			require.Len(t, mockServer.SentResponses, 1)
+			items := mockServer.SentResponses[0].Items
+			require.Len(t, items, 2)
+
+			for _, tc := range []struct {
+				item     *client.LabelValueSeriesCount
+				expected float64
+			}{
+				{item: items[0], expected: numSeries},
+				{item: items[1], expected: numSeries / 2},
+			} {
+				require.InDelta(t, 0.008125, tc.item.LabelSeriesEstimateRelativeError, 0.000001)
+				// The estimate is expected to be within 3 standard errors.
+				require.InDelta(t, tc.expected, float64(tc.item.LabelSeriesEstimate), 3*tc.item.LabelSeriesEstimateRelativeError*tc.expected, tc.item.LabelName)
+			}
+		})
+	}
+
+	t.Run("estimate is not set by default", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"lbl"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Zero(t, mockServer.SentResponses[0].Items[0].LabelSeriesEstimate)
+		require.Zero(t, mockServer.SentResponses[0].Items[0].LabelSeriesEstimateRelativeError)
+	})
+}
+
+func TestLabelValuesCardinality_ChunkCount(t *testing.T) {
+	idxReader := mockSeriesIndex{
+		series: []labels.Labels{
+			labels.FromStrings(labels.MetricName, "up", "job", "a"),
+			labels.FromStrings(labels.MetricName, "up", "job", "b"),
+			labels.FromStrings(labels.MetricName, "down", "job", "a"),
+		},
+		chunks: []int{3, 1, 2},
+	}
+
+	t.Run("chunk count is returned when requested", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{includeChunkCount: true}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Len(t, mockServer.SentResponses[0].Items, 1)
+		item := mockServer.SentResponses[0].Items[0]
+		require.Equal(t, map[string]uint64{"a": 2, "b": 1}, item.LabelValueSeries)
+		require.Equal(t, map[string]uint64{"a": 5, "b": 1}, item.LabelValueChunks)
+	})
+
+	t.Run("chunk count is returned together with the series count by metric name", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{includeChunkCount: true, groupByMetricName: true}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		item := mockServer.SentResponses[0].Items[0]
+		require.Equal(t, map[string]uint64{"a": 5, "b": 1}, item.LabelValueChunks)
+		require.NotEmpty(t, item.LabelValueMetricNamesSeries)
+	})
+
+	t.Run("chunk count is not returned when not requested", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		item := mockServer.SentResponses[0].Items[0]
+		require.Equal(t, map[string]uint64{"a": 2, "b": 1}, item.LabelValueSeries)
+		require.Nil(t, item.LabelValueChunks)
+	})
+}
+
+func TestLabelValuesCardinality_TimeRange(t *testing.T) {
+	idxReader := mockSeriesIndex{
+		series: []labels.Labels{
+			labels.FromStrings(labels.MetricName, "up", "job", "a"),
+			labels.FromStrings(labels.MetricName, "up", "job", "b"),
+			labels.FromStrings(labels.MetricName, "down", "job", "a"),
+			labels.FromStrings(labels.MetricName, "down", "job", "b"),
+		},
+		chunks:     []int{2, 1, 1, 3},
+		timeRanges: [][2]int64{{0, 100}, {100, 200}, {150, 300}, {300, 400}},
+	}
+
+	for name, tc := range map[string]struct {
+		startMs, endMs         int64
+		expectedSeries         map[string]uint64
+		expectedChunks         map[string]uint64
+		expectedMetricNameJobA []*client.MetricNameSeriesCount
+	}{
+		"zero range counts all the series": {
+			expectedSeries: map[string]uint64{"a": 2, "b": 2},
+			expectedChunks: map[string]uint64{"a": 3, "b": 4},
+			expectedMetricNameJobA: []*client.MetricNameSeriesCount{
+				{MetricName: "down", SeriesCount: 1},
+				{MetricName: "up", SeriesCount: 1},
+			},
+		},
+		"range counts only the series having samples in it": {
+			startMs:        120,
+			endMs:          160,
+			expectedSeries: map[string]uint64{"a": 1, "b": 1},
+			expectedChunks: map[string]uint64{"a": 1, "b": 1},
+			expectedMetricNameJobA: []*client.MetricNameSeriesCount{
+				{MetricName: "down", SeriesCount: 1},
+			},
+		},
+		"range boundaries are inclusive": {
+			startMs:        100,
+			endMs:          150,
+			expectedSeries: map[string]uint64{"a": 2, "b": 1},
+			expectedChunks: map[string]uint64{"a": 3, "b": 1},
+			expectedMetricNameJobA: []*client.MetricNameSeriesCount{
+				{MetricName: "down", SeriesCount: 1},
+				{MetricName: "up", SeriesCount: 1},
+			},
+		},
+		"zero end leaves the range open": {
+			startMs:        250,
+			expectedSeries: map[string]uint64{"a": 1, "b": 1},
+			expectedChunks: map[string]uint64{"a": 1, "b": 3},
+			expectedMetricNameJobA: []*client.MetricNameSeriesCount{
+				{MetricName: "down", SeriesCount: 1},
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{startMs: tc.startMs, endMs: tc.endMs, includeChunkCount: true}
+			err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+			require.NoError(t, err)
+
+			require.Len(t, mockServer.SentResponses, 1)
+			item := mockServer.SentResponses[0].Items[0]
+			require.Equal(t, tc.expectedSeries, item.LabelValueSeries)
+			require.Equal(t, tc.expectedChunks, item.LabelValueChunks)
+
+			mockServer = &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts.groupByMetricName = true
+			err = labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+			require.NoError(t, err)
+
+			require.Len(t, mockServer.SentResponses, 1)
+			item = mockServer.SentResponses[0].Items[0]
+			require.Equal(t, tc.expectedSeries, item.LabelValueSeries)
+			require.Equal(t, tc.expectedMetricNameJobA, item.LabelValueMetricNamesSeries["a"].Items)
+		})
+	}
+
+	t.Run("start after end is rejected", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{startMs: 200, endMs: 100}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.Error(t, err)
+		require.Empty(t, mockServer.SentResponses)
+	})
+}
+
+func TestLabelValuesCardinality_BestEffort(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "job", "a"),
+		labels.FromStrings(labels.MetricName, "up", "job", "b"),
+		labels.FromStrings(labels.MetricName, "down", "job", "b"),
+		labels.FromStrings(labels.MetricName, "up", "job", "c"),
+	}}
+	// The series of job="b" can't be counted.
+	failingPostingsForMatchers := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		for _, m := range matchers {
+			if m.Name == "job" && m.Type == labels.MatchEqual && m.Value == "b" {
+				return nil, errors.New("index corrupted")
+			}
+		}
+		return idxReader.postingsForMatchers(r, matchers...)
+	}
+
+	t.Run("strict mode aborts the request", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, failingPostingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		require.ErrorContains(t, err, "index corrupted")
+		require.Empty(t, mockServer.SentResponses)
+	})
+
+	t.Run("best-effort mode returns the other values", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{bestEffort: true, groupByMetricName: true}
+		err := labelValuesCardinality([]string{"job", "missing"}, []*labels.Matcher{}, idxReader, failingPostingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		resp := mockServer.SentResponses[0]
+		require.Len(t, resp.Items, 1)
+		require.Equal(t, map[string]uint64{"a": 1, "c": 1}, resp.Items[0].LabelValueSeries)
+		require.Equal(t, uint64(1), resp.FailedLabelValues)
+		require.Equal(t, []string{`failed to count the series of job="b": index corrupted`}, resp.Errors)
+	})
+
+	t.Run("best-effort mode reports the failed values in the last message", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{bestEffort: true}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, failingPostingsForMatchers, 1, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 3)
+		for _, resp := range mockServer.SentResponses[:2] {
+			require.Zero(t, resp.FailedLabelValues)
+			require.Empty(t, resp.Errors)
+		}
+		last := mockServer.SentResponses[2]
+		require.Empty(t, last.Items)
+		require.Equal(t, uint64(1), last.FailedLabelValues)
+		require.Len(t, last.Errors, 1)
+	})
+
+	t.Run("best-effort mode reports at most the errors of the first failed values", func(t *testing.T) {
+		var series []labels.Labels
+		for i := 0; i < 2*maxReportedLabelValuesErrors; i++ {
+			series = append(series, labels.FromStrings("job", fmt.Sprintf("job-%02d", i)))
+		}
+		idxReader := mockSeriesIndex{series: series}
+		alwaysFailing := func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+			return nil, errors.New("index corrupted")
+		}
+
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{bestEffort: true}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, alwaysFailing, 1*1024*1024, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Empty(t, mockServer.SentResponses[0].Items)
+		require.Equal(t, uint64(2*maxReportedLabelValuesErrors), mockServer.SentResponses[0].FailedLabelValues)
+		require.Len(t, mockServer.SentResponses[0].Errors, maxReportedLabelValuesErrors)
+	})
+
+	t.Run("best-effort mode is aborted by the cancellation of the request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		mockServer := &mockLabelValuesCardinalityServer{context: ctx}
+		opts := labelValuesCardinalityOptions{bestEffort: true}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, failingPostingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestLabelValuesCardinality_Explain(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "job", "a"),
+		labels.FromStrings(labels.MetricName, "up", "job", "b"),
+		labels.FromStrings(labels.MetricName, "down", "job", "a"),
+	}}
+	lbNames := []string{labels.MetricName, "job"}
+
+	t.Run("timing breakdown is returned in the last message when requested", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{explain: true, concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: 2}}
+		// A low message size threshold makes the response split across multiple messages.
+		err := labelValuesCardinality(lbNames, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Greater(t, len(mockServer.SentResponses), 1)
+		for _, resp := range mockServer.SentResponses[:len(mockServer.SentResponses)-1] {
+			require.Nil(t, resp.Explain)
+		}
+		explain := mockServer.SentResponses[len(mockServer.SentResponses)-1].Explain
+		require.NotNil(t, explain)
+		require.Greater(t, explain.LabelValuesDurationNs, int64(0))
+		require.Greater(t, explain.CountingDurationNs, int64(0))
+		require.Greater(t, explain.SendingDurationNs, int64(0))
+		require.Equal(t, uint32(2), explain.CountingGoroutines)
+		require.Greater(t, explain.Goroutines, uint32(0))
+	})
+
+	t.Run("timing breakdown is not returned when not requested", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality(lbNames, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
+
+		require.NotEmpty(t, mockServer.SentResponses)
+		for _, resp := range mockServer.SentResponses {
+			require.Nil(t, resp.Explain)
+		}
+	})
+}
+
+func TestLabelValuesCardinality_NilPostings(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "job", "a"),
+		labels.FromStrings(labels.MetricName, "up", "job", "b"),
+	}}
+	nilPostingsForMatchers := func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+		return nil, nil
+	}
+
+	for _, groupByMetricName := range []bool{false, true} {
+		t.Run(fmt.Sprintf("groupByMetricName=%t", groupByMetricName), func(t *testing.T) {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{groupByMetricName: groupByMetricName, includeChunkCount: true, estimation: labelValuesCardinalityEstimationOptions{estimateLabelSeries: true}}
+			err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, nilPostingsForMatchers, 1*1024*1024, opts, mockServer)
+			require.NoError(t, err)
+
+			require.Len(t, mockServer.SentResponses, 1)
+			item := mockServer.SentResponses[0].Items[0]
+			require.Equal(t, map[string]uint64{"a": 0, "b": 0}, item.LabelValueSeries)
+			require.Equal(t, map[string]uint64{"a": 0, "b": 0}, item.LabelValueChunks)
+			require.Zero(t, item.LabelSeriesEstimate)
+		})
+	}
+}
+
+func TestLabelNamesAndValues_PartitionByFirstCharacter(t *testing.T) {
+	existingLabels := map[string][]string{
+		"alpha":    {"a1", "a2", "a3"},
+		"apple":    {"b1"},
+		"beta":     {"c1", "c2"},
+		"cluster":  {"d1", "d2", "d3", "d4"},
+		"cpu":      {"e1"},
+		"customer": {"f1", "f2"},
+		"zone":     {"g1"},
+		"__name__": {"up"},
+	}
+
+	for _, omitValues := range []bool{false, true} {
+		for _, threshold := range []int{1, 10, 1024} {
+			t.Run(fmt.Sprintf("omitValues=%t threshold=%d", omitValues, threshold), func(t *testing.T) {
+				server := &mockLabelNamesAndValuesServer{context: context.Background()}
+				opts := labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{partitionByFirstCharacter: true}, omitValues: omitValues}
+				require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, threshold, opts, server))
+
+				partitionByName := map[string]string{}
+				valuesByName := map[string][]string{}
+				for _, resp := range server.SentResponses {
+					require.NotEmpty(t, resp.PartitionKey)
+					for _, item := range resp.Items {
+						require.Equal(t, item.LabelName[:1], resp.PartitionKey, "label %q sent in partition %q", item.LabelName, resp.PartitionKey)
+						if partition, ok := partitionByName[item.LabelName]; ok {
+							require.Equal(t, partition, resp.PartitionKey, "label %q sent in multiple partitions", item.LabelName)
+						}
+						partitionByName[item.LabelName] = resp.PartitionKey
+						valuesByName[item.LabelName] = append(valuesByName[item.LabelName], item.Values...)
+					}
+				}
+
+				require.Len(t, partitionByName, len(existingLabels))
+				for name, values := range existingLabels {
+					require.Contains(t, partitionByName, name)
+					if omitValues {
+						require.Empty(t, valuesByName[name])
+					} else {
+						require.Equal(t, values, valuesByName[name])
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestLabelNamesAndValues_WithoutPartitioning(t *testing.T) {
+	existingLabels := map[string][]string{"alpha": {"a1"}, "beta": {"b1"}}
+	server := &mockLabelNamesAndValuesServer{context: context.Background()}
+	require.NoError(t, labelNamesAndValues(mockIndex{existingLabels: existingLabels}, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{}, server))
+
+	require.Len(t, server.SentResponses, 1)
+	require.Empty(t, server.SentResponses[0].PartitionKey)
+	require.Len(t, server.SentResponses[0].Items, 2)
+}
+
+func TestLabelValuesCardinality_Progress(t *testing.T) {
+	var inputSeries []labels.Labels
+	for v := 0; v < 5; v++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "job", fmt.Sprintf("job-%d", v)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+	slowPostingsForMatchers := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		p, err := idxReader.postingsForMatchers(r, matchers...)
+		if err != nil {
+			return nil, err
+		}
+		return &slowPostings{Postings: p, delay: 20 * time.Millisecond}, nil
+	}
+
+	t.Run("progress messages are sent while counting series", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{progressInterval: 5 * time.Millisecond}}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, slowPostingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.NoError(t, err)
+
+		require.Greater(t, len(mockServer.SentResponses), 1)
+		progressResponses := mockServer.SentResponses[:len(mockServer.SentResponses)-1]
+		var lastProgress client.LabelValuesCardinalityProgress
+		for _, resp := range progressResponses {
+			require.NotNil(t, resp.Progress)
+			require.Empty(t, resp.Items)
+			require.GreaterOrEqual(t, resp.Progress.LabelValues, lastProgress.LabelValues)
+			require.GreaterOrEqual(t, resp.Progress.Series, lastProgress.Series)
+			require.LessOrEqual(t, resp.Progress.LabelValues, uint64(len(inputSeries)))
+			lastProgress = *resp.Progress
+		}
+
+		data := mockServer.SentResponses[len(mockServer.SentResponses)-1]
+		require.Nil(t, data.Progress)
+		require.Len(t, data.Items, 1)
+		require.Len(t, data.Items[0].LabelValueSeries, len(inputSeries))
+	})
+
+	t.Run("progress messages are not part of the checksum chain", func(t *testing.T) {
+		// Each value is sent in its own message.
+		run := func(progressInterval time.Duration) []client.LabelValuesCardinalityResponse {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{stream: labelValuesCardinalityStreamOptions{includeChecksums: true, progressInterval: progressInterval}}
+			err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, slowPostingsForMatchers, 1, opts, mockServer)
+			require.NoError(t, err)
+			return mockServer.SentResponses
+		}
+
+		var progressResponses, dataResponses []client.LabelValuesCardinalityResponse
+		for _, resp := range run(5 * time.Millisecond) {
+			if resp.Progress != nil {
+				progressResponses = append(progressResponses, resp)
+			} else {
+				dataResponses = append(dataResponses, resp)
+			}
+		}
+		require.NotEmpty(t, progressResponses)
+		for _, resp := range progressResponses {
+			require.Zero(t, resp.SequenceNumber)
+			require.Zero(t, resp.ByteOffset)
+			require.Zero(t, resp.ItemsChecksum)
+			require.Zero(t, resp.RunningChecksum)
+		}
+
+		// The data messages are chained as if the progress messages hadn't been sent.
+		expected := run(0)
+		require.Len(t, dataResponses, len(expected))
+		for i, resp := range dataResponses {
+			require.Equal(t, expected[i].SequenceNumber, resp.SequenceNumber)
+			require.Equal(t, expected[i].ByteOffset, resp.ByteOffset)
+			require.Equal(t, expected[i].ItemsChecksum, resp.ItemsChecksum)
+			require.Equal(t, expected[i].RunningChecksum, resp.RunningChecksum)
+		}
+	})
+
+	t.Run("progress messages are not sent when not requested", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		err := labelValuesCardinality([]string{"job"}, []*labels.Matcher{}, idxReader, slowPostingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+		require.NoError(t, err)
+
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Nil(t, mockServer.SentResponses[0].Progress)
+	})
+}
+
+// slowPostings is a postings iterator which waits for the delay before moving to the next posting.
+type slowPostings struct {
+	index.Postings
+	delay time.Duration
+}
+
+func (p *slowPostings) Next() bool {
+	time.Sleep(p.delay)
+	return p.Postings.Next()
+}
+
+func TestLabelValuesCardinality_MinSeriesCount(t *testing.T) {
+	var inputSeries []labels.Labels
+	for value, count := range map[string]int{"small": 1, "medium": 50, "large": 100} {
+		for i := 0; i < count; i++ {
+			inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "size", value, "id", strconv.Itoa(i)))
+		}
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{minSeriesCount: 50}
+	err := labelValuesCardinality([]string{"size"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+	require.NoError(t, err)
+
+	require.Len(t, mockServer.SentResponses, 1)
+	require.Len(t, mockServer.SentResponses[0].Items, 1)
+	require.Equal(t, map[string]uint64{"medium": 50, "large": 100}, mockServer.SentResponses[0].Items[0].LabelValueSeries)
+}
+
+func TestLabelValuesCardinality_DuplicateLabelNames(t *testing.T) {
+	var inputSeries []labels.Labels
+	for i := 0; i < 10; i++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "zone", fmt.Sprintf("zone-%d", i%2), "pod", fmt.Sprintf("pod-%d", i)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	err := labelValuesCardinality([]string{"zone", "pod", "zone"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+	require.NoError(t, err)
+
+	require.Len(t, mockServer.SentResponses, 1)
+	items := mockServer.SentResponses[0].Items
+	require.Len(t, items, 2)
+	require.Equal(t, "zone", items[0].LabelName)
+	require.Equal(t, map[string]uint64{"zone-0": 5, "zone-1": 5}, items[0].LabelValueSeries)
+	require.Equal(t, "pod", items[1].LabelName)
+	require.Len(t, items[1].LabelValueSeries, 10)
+}
+
+func TestLabelNamesAndValues_ValuesBloomFilter(t *testing.T) {
+	const numValues = 10000
+	values := make([]string, 0, numValues)
+	for i := 0; i < numValues; i++ {
+		values = append(values, fmt.Sprintf("pod-%05d", i))
+	}
+	idx := mockIndex{existingLabels: map[string][]string{"pod": values}}
+
+	// The client looks up a sparse subset of the values.
+	wanted := map[string]bool{}
+	filter := client.NewLabelValuesBloomFilter(100, 0.01)
+	for i := 0; i < numValues; i += numValues / 100 {
+		wanted[values[i]] = true
+		filter.Add(values[i])
+	}
+
+	// The filter is sent to the ingester serialized in the request.
+	req := &client.LabelNamesAndValuesRequest{ValuesBloomFilter: filter}
+	data, err := req.Marshal()
+	require.NoError(t, err)
+	received := &client.LabelNamesAndValuesRequest{}
+	require.NoError(t, received.Unmarshal(data))
+	require.NoError(t, received.ValuesBloomFilter.Validate())
+
+	server := &mockLabelNamesAndValuesServer{context: context.Background()}
+	opts := labelNamesAndValuesOptions{valuesBloomFilter: received.ValuesBloomFilter}
+	require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, 256, opts, server))
+
+	streamed := map[string]bool{}
+	for _, item := range extractItemsWithSortedValues(server.SentResponses) {
+		require.Equal(t, "pod", item.LabelName)
+		for _, value := range item.Values {
+			streamed[value] = true
+		}
+	}
+	for value := range wanted {
+		require.True(t, streamed[value], "value %s is missing", value)
+	}
+	falsePositives := len(streamed) - len(wanted)
+	require.Less(t, float64(falsePositives)/float64(numValues-len(wanted)), 0.03)
+
+	t.Run("invalid filter", func(t *testing.T) {
+		require.Error(t, (&client.LabelValuesBloomFilter{HashCount: 1}).Validate())
+		require.Error(t, (&client.LabelValuesBloomFilter{Bits: []byte{0xff}}).Validate())
+	})
+}
+
+func TestLabelNamesAndValues_EnsureSortedValues(t *testing.T) {
+	unsortedValues := []string{"pod-3", "pod-1", "pod-4", "pod-0", "pod-2"}
+	idx := mockIndex{existingLabels: map[string][]string{"pod": unsortedValues}}
+
+	for _, threshold := range []int{1, 12, 1024} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{ensureSortedValues: true}
+			require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, threshold, opts, server))
+
+			var emitted []string
+			for _, resp := range server.SentResponses {
+				for _, item := range resp.Items {
+					emitted = append(emitted, item.Values...)
+				}
+			}
+			require.Equal(t, []string{"pod-0", "pod-1", "pod-2", "pod-3", "pod-4"}, emitted)
+		})
+	}
+
+	// The values of the index reader are left untouched.
+	require.Equal(t, []string{"pod-3", "pod-1", "pod-4", "pod-0", "pod-2"}, unsortedValues)
+}
+
+func TestLabelNamesAndValues_DedupValues(t *testing.T) {
+	valuesWithRepeats := []string{"pod-3", "pod-1", "pod-3", "pod-0", "pod-1", "pod-2", "pod-3"}
+	idx := mockIndex{existingLabels: map[string][]string{"job": {"api", "api"}, "pod": valuesWithRepeats}}
+	// The same values without the repeats, in first-seen order.
+	dedupedIdx := mockIndex{existingLabels: map[string][]string{"job": {"api"}, "pod": {"pod-3", "pod-1", "pod-0", "pod-2"}}}
+
+	for _, threshold := range []int{1, 12, 1024} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{dedupValues: true}
+			require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, threshold, opts, server))
+
+			emitted := map[string][]string{}
+			for _, resp := range server.SentResponses {
+				for _, item := range resp.Items {
+					emitted[item.LabelName] = append(emitted[item.LabelName], item.Values...)
+				}
+			}
+			require.Equal(t, map[string][]string{"job": {"api"}, "pod": {"pod-3", "pod-1", "pod-0", "pod-2"}}, emitted)
+
+			// The messages are batched as if the index had no repeats.
+			expected := &mockLabelNamesAndValuesServer{context: context.Background()}
+			require.NoError(t, labelNamesAndValues(dedupedIdx, []*labels.Matcher{}, threshold, labelNamesAndValuesOptions{}, expected))
+			require.Equal(t, expected.SentResponses, server.SentResponses)
+		})
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{}, server))
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, valuesWithRepeats, server.SentResponses[0].Items[1].Values)
+	})
+
+	// The values of the index reader are left untouched.
+	require.Equal(t, []string{"pod-3", "pod-1", "pod-3", "pod-0", "pod-1", "pod-2", "pod-3"}, valuesWithRepeats)
+}
+
+func TestLabelNamesAndValues_RelabelOutcomes(t *testing.T) {
+	idx := mockIndex{existingLabels: map[string][]string{
+		"env": {"dev", "prod"},
+		"pod": {"api-0", "canary-0", "api-1", "canary-1"},
+		"tmp": {"x"},
+	}}
+	relabelConfigs := []*relabel.Config{
+		{
+			SourceLabels: []model.LabelName{"pod"},
+			Action:       relabel.Drop,
+			Regex:        relabel.MustNewRegexp("canary-.*"),
+		},
+		{
+			SourceLabels: []model.LabelName{"env"},
+			Action:       relabel.Replace,
+			Regex:        relabel.MustNewRegexp("dev"),
+			TargetLabel:  "env",
+			Replacement:  "development",
+		},
+		{
+			Action: relabel.LabelDrop,
+			Regex:  relabel.MustNewRegexp("tmp"),
+		},
+	}
+
+	for _, threshold := range []int{1, 16, 1024} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{includeRelabelOutcomes: true, relabelConfigs: relabelConfigs}
+			require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, threshold, opts, server))
+
+			outcomes := map[string]client.LabelValueRelabelOutcome{}
+			for _, resp := range server.SentResponses {
+				for _, item := range resp.Items {
+					require.Len(t, item.RelabelOutcomes, len(item.Values))
+					for i, val := range item.Values {
+						outcomes[item.LabelName+"="+val] = item.RelabelOutcomes[i]
+					}
+				}
+			}
+			require.Equal(t, map[string]client.LabelValueRelabelOutcome{
+				"env=dev":      client.RELABEL_REWRITTEN,
+				"env=prod":     client.RELABEL_KEPT,
+				"pod=api-0":    client.RELABEL_KEPT,
+				"pod=canary-0": client.RELABEL_DROPPED,
+				"pod=api-1":    client.RELABEL_KEPT,
+				"pod=canary-1": client.RELABEL_DROPPED,
+				"tmp=x":        client.RELABEL_REWRITTEN,
+			}, outcomes)
+		})
+	}
+
+	t.Run("no relabel configs", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{includeRelabelOutcomes: true}
+		require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, 1024, opts, server))
+		require.Len(t, server.SentResponses, 1)
+		for _, item := range server.SentResponses[0].Items {
+			require.Equal(t, make([]client.LabelValueRelabelOutcome, len(item.Values)), item.RelabelOutcomes)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{relabelConfigs: relabelConfigs}
+		require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, 1024, opts, server))
+		require.Len(t, server.SentResponses, 1)
+		for _, item := range server.SentResponses[0].Items {
+			require.Empty(t, item.RelabelOutcomes)
+		}
+	})
+}
+
+func TestLabelNamesAndValues_Shard(t *testing.T) {
+	var series []labels.Labels
+	for i := 0; i < 50; i++ {
+		series = append(series, labels.FromStrings(labels.MetricName, fmt.Sprintf("metric_%d", i%3), "pod", fmt.Sprintf("pod-%d", i), "zone", fmt.Sprintf("zone-%d", i%5)))
+	}
+	idx := mockSeriesIndex{series: series}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, "metric_[01]")}
+
+	// labelValuesOf returns the emitted values of each label, and the series count of the response.
+	labelValuesOf := func(opts labelNamesAndValuesOptions) (map[string][]string, uint64) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts.postingsForMatchersFn = idx.postingsForMatchers
+		opts.includeSeriesCount = true
+		require.NoError(t, labelNamesAndValues(idx, matchers, 64, opts, server))
+		emitted := map[string][]string{}
+		var seriesCount uint64
+		for _, resp := range server.SentResponses {
+			for _, item := range resp.Items {
+				emitted[item.LabelName] = append(emitted[item.LabelName], item.Values...)
+			}
+			seriesCount += resp.SeriesCount
+		}
+		return emitted, seriesCount
+	}
+
+	all, allSeriesCount := labelValuesOf(labelNamesAndValuesOptions{})
+	require.Equal(t, uint64(34), allSeriesCount)
+
+	const shardCount = 2
+	union := map[string][]string{}
+	var unionSeriesCount uint64
+	for shardIndex := uint64(0); shardIndex < shardCount; shardIndex++ {
+		shard, seriesCount := labelValuesOf(labelNamesAndValuesOptions{shardIndex: shardIndex, shardCount: shardCount})
+		require.NotEmpty(t, shard["pod"], "shard %d", shardIndex)
+		for name, values := range shard {
+			require.Subset(t, all[name], values, "shard %d", shardIndex)
+			require.True(t, sort.StringsAreSorted(values), "shard %d", shardIndex)
+			union[name] = append(union[name], values...)
+		}
+		unionSeriesCount += seriesCount
+	}
+	// Each series belongs to a single shard, so the values identifying the series aren't returned by both shards.
+	require.ElementsMatch(t, all["pod"], union["pod"])
+	require.Equal(t, allSeriesCount, unionSeriesCount)
+	for name, values := range all {
+		require.Subset(t, union[name], values, name)
+	}
+
+	t.Run("invalid shard index", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		err := labelNamesAndValues(idx, matchers, 64, labelNamesAndValuesOptions{shardIndex: 2, shardCount: 2}, server)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("not supported with the blocks", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		err := labelNamesAndValues(idx, matchers, 64, labelNamesAndValuesOptions{shardCount: 2, blocksIndex: idx}, server)
+		require.Error(t, err)
+	})
+}
+
+func TestLabelNamesAndValues_MaxDistinctValues(t *testing.T) {
+	podValues := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		podValues = append(podValues, fmt.Sprintf("pod-%d", i))
+	}
+	idx := mockIndex{existingLabels: map[string][]string{
+		"cluster": {"prod"},
+		"job":     {"api"},
+		"pod":     podValues,
+		"zone":    {"eu", "us"},
+	}}
+
+	t.Run("values", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxDistinctValues: 2}}
+		require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, 16, opts, server))
+		require.Equal(t, []*client.LabelValues{
+			{LabelName: "cluster", Values: []string{"prod"}},
+			{LabelName: "job", Values: []string{"api"}},
+			{LabelName: "zone", Values: []string{"eu", "us"}},
+		}, extractItemsWithSortedValues(server.SentResponses))
+	})
+
+	t.Run("names only", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{maxDistinctValues: 1}, omitValues: true}
+		require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, 1024, opts, server))
+		require.Len(t, server.SentResponses, 1)
+		require.Equal(t, []string{"cluster", "job"}, labelNamesWithoutValues(t, server.SentResponses[0]))
+	})
+}
+
+func TestLabelValuesCardinality_IncludeRatios(t *testing.T) {
+	counts := map[string]int{"eu": 6, "us": 3, "ap": 1}
+	var inputSeries []labels.Labels
+	for value, count := range counts {
+		for i := 0; i < count; i++ {
+			inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "region", value, "id", strconv.Itoa(i)))
+		}
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	// The small message size threshold splits the values of the label across messages.
+	for _, threshold := range []int{1, 1024} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{includeRatios: true}
+			require.NoError(t, labelValuesCardinality([]string{"region"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, threshold, opts, mockServer))
+
+			ratios := map[string]float64{}
+			for _, resp := range mockServer.SentResponses {
+				for _, item := range resp.Items {
+					require.Len(t, item.LabelValueRatios, len(item.LabelValueSeries))
+					for value, ratio := range item.LabelValueRatios {
+						ratios[value] = ratio
+					}
+				}
+			}
+
+			sum := 0.0
+			for value, count := range counts {
+				require.InDelta(t, float64(count)/10, ratios[value], 1e-9)
+				sum += ratios[value]
+			}
+			require.InDelta(t, 1.0, sum, 1e-9)
+		})
+	}
+
+	t.Run("the ratios are not returned by default", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		require.NoError(t, labelValuesCardinality([]string{"region"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1024, labelValuesCardinalityOptions{}, mockServer))
+		require.Nil(t, mockServer.SentResponses[0].Items[0].LabelValueRatios)
+	})
+}
+
+func TestLabelValuesCardinality_SampleSeed(t *testing.T) {
+	var inputSeries []labels.Labels
+	for i := 0; i < 100; i++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "pod", fmt.Sprintf("pod-%d", i)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	sample := func(seed int64) map[string]uint64 {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{estimation: labelValuesCardinalityEstimationOptions{sampleValues: 10, sampleSeed: seed}}
+		require.NoError(t, labelValuesCardinality([]string{"pod"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer))
+		require.Len(t, mockServer.SentResponses, 1)
+		// The seed is echoed in the response.
+		require.Equal(t, seed, mockServer.SentResponses[0].SampleSeed)
+		require.Len(t, mockServer.SentResponses[0].Items, 1)
+		return mockServer.SentResponses[0].Items[0].LabelValueSeries
+	}
+
+	sampled := sample(42)
+	require.Len(t, sampled, 10)
+	for value, seriesCount := range sampled {
+		require.Contains(t, value, "pod-")
+		require.Equal(t, uint64(1), seriesCount)
+	}
+
+	// The same seed selects the same values, and another seed selects different values.
+	require.Equal(t, sampled, sample(42))
+	require.NotEqual(t, sampled, sample(43))
+
+	// The values are all counted when there are fewer of them than the sample size.
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{estimation: labelValuesCardinalityEstimationOptions{sampleValues: 1000, sampleSeed: 42}}
+	require.NoError(t, labelValuesCardinality([]string{"pod"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer))
+	require.Len(t, mockServer.SentResponses[0].Items[0].LabelValueSeries, 100)
+}
+
+func TestLabelValuesCardinality_ValueGroupRegex(t *testing.T) {
+	var inputSeries []labels.Labels
+	for value, count := range map[string]int{"app-1234": 3, "app-5678": 2, "db-1": 1, "standalone": 4} {
+		for i := 0; i < count; i++ {
+			metricName := "up"
+			if i%2 == 1 {
+				metricName = "requests_total"
+			}
+			inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, metricName, "pod", value, "id", strconv.Itoa(i)))
+		}
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{valueGroupRegex: `(.+)-\d+`, groupByMetricName: true, includeRatios: true}
+	require.NoError(t, labelValuesCardinality([]string{"pod"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer))
+	require.Len(t, mockServer.SentResponses, 1)
+	require.Len(t, mockServer.SentResponses[0].Items, 1)
+	item := mockServer.SentResponses[0].Items[0]
+
+	require.Equal(t, map[string]uint64{"app": 5, "db": 1, unmatchedLabelValuesGroup: 4}, item.LabelValueSeries)
+	require.Equal(t, map[string]float64{"app": 0.5, "db": 0.1, unmatchedLabelValuesGroup: 0.4}, item.LabelValueRatios)
+	require.Equal(t, []*client.MetricNameSeriesCount{
+		{MetricName: "requests_total", SeriesCount: 2},
+		{MetricName: "up", SeriesCount: 3},
+	}, item.LabelValueMetricNamesSeries["app"].Items)
+
+	t.Run("regex without capture group", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{valueGroupRegex: `app-\d+`}
+		err := labelValuesCardinality([]string{"pod"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.ErrorContains(t, err, "it must have a capture group")
+		_, rejected := labelCardinalityRejectionReason(err)
+		require.True(t, rejected)
+	})
+}
+
+func TestLabelValuesCardinality_CoOccurrences(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "requests_total", "pod", "pod-1", "zone", "a", "status", "200"),
+		labels.FromStrings(labels.MetricName, "requests_total", "pod", "pod-1", "zone", "a", "status", "500"),
+		labels.FromStrings(labels.MetricName, "requests_total", "pod", "pod-2", "zone", "a", "status", "200"),
+		labels.FromStrings(labels.MetricName, "errors_total", "pod", "pod-1", "zone", "a"),
+		labels.FromStrings(labels.MetricName, "requests_total", "pod", "pod-3", "zone", "b", "status", "200"),
+		labels.FromStrings(labels.MetricName, "requests_total", "pod", "pod-4", "zone", "b"),
+		labels.FromStrings(labels.MetricName, "up", "zone", "c"),
+	}}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{coOccurrenceTopK: 2}
+	require.NoError(t, labelValuesCardinality([]string{"zone"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer))
+	require.Len(t, mockServer.SentResponses, 1)
+	require.Len(t, mockServer.SentResponses[0].Items, 1)
+	item := mockServer.SentResponses[0].Items[0]
+
+	require.Equal(t, map[string]uint64{"a": 4, "b": 2, "c": 1}, item.LabelValueSeries)
+	// Only the 2 values with the most series report their 2 most frequent co-occurring label values.
+	require.Equal(t, map[string]*client.LabelValueCoOccurrences{
+		"a": {Items: []*client.LabelValueCoOccurrence{
+			{LabelName: labels.MetricName, LabelValue: "requests_total", SeriesCount: 3},
+			{LabelName: "pod", LabelValue: "pod-1", SeriesCount: 3},
+		}},
+		"b": {Items: []*client.LabelValueCoOccurrence{
+			{LabelName: labels.MetricName, LabelValue: "requests_total", SeriesCount: 2},
+			{LabelName: "pod", LabelValue: "pod-3", SeriesCount: 1},
+		}},
+	}, item.LabelValueCoOccurrences)
+
+	t.Run("can't be used with the value group regex", func(t *testing.T) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{coOccurrenceTopK: 2, valueGroupRegex: "(.*)"}
+		err := labelValuesCardinality([]string{"zone"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+		require.ErrorContains(t, err, "the label values co-occurrences can't be returned when the label values are grouped")
+	})
+}
+
+func TestLabelValuesCardinality_RegexMatchers(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "job", "api-1"),
+		labels.FromStrings(labels.MetricName, "up", "job", "db-1"),
+	}}
+
+	for name, tc := range map[string]struct {
+		regex    string
+		rejected bool
+	}{
+		"prefix":                              {regex: "api-.*"},
+		"alternation":                         {regex: "api-1|api-2|db-1"},
+		"optional group":                      {regex: "(api-.*)?"},
+		"bounded quantifier in unbounded one": {regex: "(a{1,3}-)+.*"},
+		"nested plus":                         {regex: "(a+)+b", rejected: true},
+		"nested star":                         {regex: "(.*)*", rejected: true},
+		"nested quantifier in alternation":    {regex: "(x|y+)*z", rejected: true},
+		"nested unbounded repeat":             {regex: "(a{2,})+", rejected: true},
+		"too many instructions":               {regex: strings.Repeat("[a-z]{1000}", 21), rejected: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "job", tc.regex)}
+
+			// The complex regexes are accepted unless they're explicitly rejected.
+			require.NoError(t, labelValuesCardinality([]string{"job"}, matchers, idxReader, idxReader.postingsForMatchers, 1024, labelValuesCardinalityOptions{}, &mockLabelValuesCardinalityServer{context: context.Background()}))
+			require.NoError(t, labelNamesAndValues(idxReader, matchers, 1024, labelNamesAndValuesOptions{}, &mockLabelNamesAndValuesServer{context: context.Background()}))
+
+			cardinalityServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			cardinalityErr := labelValuesCardinality([]string{"job"}, matchers, idxReader, idxReader.postingsForMatchers, 1024, labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{rejectComplexRegexes: true}}, cardinalityServer)
+			namesAndValuesServer := &mockLabelNamesAndValuesServer{context: context.Background()}
+			namesAndValuesErr := labelNamesAndValues(idxReader, matchers, 1024, labelNamesAndValuesOptions{limits: labelNamesAndValuesLimits{rejectComplexRegexes: true}}, namesAndValuesServer)
+
+			if !tc.rejected {
+				require.NoError(t, cardinalityErr)
+				require.NoError(t, namesAndValuesErr)
+				return
+			}
+			require.Equal(t, codes.InvalidArgument, status.Code(cardinalityErr))
+			require.Empty(t, cardinalityServer.SentResponses)
+			require.Equal(t, codes.InvalidArgument, status.Code(namesAndValuesErr))
+			require.Empty(t, namesAndValuesServer.SentResponses)
+
+			reason, ok := labelCardinalityRejectionReason(cardinalityErr)
+			require.True(t, ok)
+			require.Equal(t, labelCardinalityRejectedInvalidRequest, reason)
+		})
+	}
+}
+
+func TestLabelValuesCardinality_MatchersOnTheSameLabelName(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "foo", "a", "zone", "z1"),
+		labels.FromStrings(labels.MetricName, "up", "foo", "ab", "zone", "z1"),
+		labels.FromStrings(labels.MetricName, "up", "foo", "ab", "zone", "z2"),
+		labels.FromStrings(labels.MetricName, "up", "foo", "b", "zone", "z2"),
+	}}
+
+	cardinality := func(opts labelValuesCardinalityOptions, matchers ...*labels.Matcher) (map[string]uint64, error) {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		if err := labelValuesCardinality([]string{"zone"}, matchers, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer); err != nil {
+			return nil, err
+		}
+		result := map[string]uint64{}
+		for _, resp := range mockServer.SentResponses {
+			for _, item := range resp.Items {
+				for value, count := range item.LabelValueSeries {
+					result[value] += count
+				}
+			}
+		}
+		return result, nil
+	}
+
+	t.Run("the matchers on the same label name are ANDed", func(t *testing.T) {
+		result, err := cardinality(labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{rejectContradictions: true}},
+			labels.MustNewMatcher(labels.MatchRegexp, "foo", "a.*"),
+			labels.MustNewMatcher(labels.MatchNotEqual, "foo", "a"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string]uint64{"z1": 1, "z2": 1}, result)
+
+		result, err = cardinality(labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{rejectContradictions: true}},
+			labels.MustNewMatcher(labels.MatchEqual, "foo", "ab"),
+			labels.MustNewMatcher(labels.MatchRegexp, "foo", "a.*"),
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[string]uint64{"z1": 1, "z2": 1}, result)
+	})
+
+	contradictoryMatchers := []*labels.Matcher{
+		labels.MustNewMatcher(labels.MatchEqual, "foo", "a"),
+		labels.MustNewMatcher(labels.MatchRegexp, "foo", "b.*"),
+	}
+
+	t.Run("contradictory matchers match no series", func(t *testing.T) {
+		result, err := cardinality(labelValuesCardinalityOptions{}, contradictoryMatchers...)
+		require.NoError(t, err)
+		require.Empty(t, result)
+	})
+
+	t.Run("contradictory matchers are rejected", func(t *testing.T) {
+		_, err := cardinality(labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{rejectContradictions: true}}, contradictoryMatchers...)
+		require.EqualError(t, err, `the matchers foo="a" and foo=~"b.*" on the same label name can't both match`)
+		reason, rejected := labelCardinalityRejectionReason(err)
+		require.True(t, rejected)
+		require.Equal(t, labelCardinalityRejectedInvalidRequest, reason)
+	})
+}
+
+func TestLabelValuesCardinality_RejectAllMatching(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "job", "api", "zone", "z1"),
+		labels.FromStrings(labels.MetricName, "up", "job", "db", "zone", "z2"),
+		labels.FromStrings(labels.MetricName, "go_goroutines", "job", "api", "zone", "z2"),
+	}}
+
+	for name, tc := range map[string]struct {
+		matchers []*labels.Matcher
+		rejected bool
+	}{
+		"no matchers": {
+			matchers: []*labels.Matcher{},
+			rejected: true,
+		},
+		"single match-all regex matcher": {
+			matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "job", ".*")},
+			rejected: true,
+		},
+		"only matchers matching the empty string": {
+			matchers: []*labels.Matcher{
+				labels.MustNewMatcher(labels.MatchRegexp, "job", "api|"),
+				labels.MustNewMatcher(labels.MatchNotEqual, "zone", "z1"),
+				labels.MustNewMatcher(labels.MatchEqual, "pod", ""),
+			},
+			rejected: true,
+		},
+		"equal matcher": {
+			matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")},
+		},
+		"regex matcher not matching the empty string": {
+			matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "job", ".+")},
+		},
+		"match-all matcher with a non-empty matcher": {
+			matchers: []*labels.Matcher{
+				labels.MustNewMatcher(labels.MatchRegexp, "zone", ".*"),
+				labels.MustNewMatcher(labels.MatchNotRegexp, "job", ""),
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			err := labelValuesCardinality([]string{"job"}, tc.matchers, idxReader, idxReader.postingsForMatchers, 1024, labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{rejectAllMatching: true}}, mockServer)
+			if !tc.rejected {
+				require.NoError(t, err)
+				require.NotEmpty(t, mockServer.SentResponses)
+				return
+			}
+			require.Equal(t, codes.InvalidArgument, status.Code(err))
+			require.Empty(t, mockServer.SentResponses)
+			reason, rejected := labelCardinalityRejectionReason(err)
+			require.True(t, rejected)
+			require.Equal(t, labelCardinalityRejectedInvalidRequest, reason)
+
+			// The requests are allowed when the policy is disabled.
+			mockServer = &mockLabelValuesCardinalityServer{context: context.Background()}
+			require.NoError(t, labelValuesCardinality([]string{"job"}, tc.matchers, idxReader, idxReader.postingsForMatchers, 1024, labelValuesCardinalityOptions{}, mockServer))
+		})
+	}
+}
+
+func TestLabelValuesCardinality_MaxSelectedSeriesRatio(t *testing.T) {
+	var inputSeries []labels.Labels
+	for i := 0; i < 1000; i++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "pod", fmt.Sprintf("pod-%d", i), "zone", fmt.Sprintf("zone-%d", i%2)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	for name, tc := range map[string]struct {
+		matchers      []*labels.Matcher
+		maxRatio      float64
+		expectedError string
+	}{
+		"empty matchers are rejected when the guard is enabled": {
+			matchers:      []*labels.Matcher{},
+			maxRatio:      0.1,
+			expectedError: "the label values cardinality request selects all the series of the tenant: narrow it down with matchers",
+		},
+		"trivially true matchers are rejected when the guard is enabled": {
+			matchers:      []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, ".+")},
+			maxRatio:      0.1,
+			expectedError: "the matchers of the label values cardinality request select more than the allowed 10.0% of the series of the tenant: narrow it down with more selective matchers",
+		},
+		"matchers selecting too many series are rejected": {
+			matchers:      []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, "zone", "zone-0")},
+			maxRatio:      0.1,
+			expectedError: "the matchers of the label values cardinality request select more than the allowed 10.0% of the series of the tenant: narrow it down with more selective matchers",
+		},
+		"selective matchers are accepted": {
+			matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "pod", "pod-1.")},
+			maxRatio: 0.1,
+		},
+		"empty matchers are accepted when the guard is disabled": {
+			matchers: []*labels.Matcher{},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{maxSelectedSeriesRatio: tc.maxRatio, totalSeries: uint64(len(inputSeries))}}
+			err := labelValuesCardinality([]string{"zone"}, tc.matchers, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				require.NotEmpty(t, mockServer.SentResponses)
+				return
+			}
+			require.Equal(t, codes.FailedPrecondition, status.Code(err))
+			require.Equal(t, tc.expectedError, status.Convert(err).Message())
+			require.Empty(t, mockServer.SentResponses)
+			reason, rejected := labelCardinalityRejectionReason(err)
+			require.True(t, rejected)
+			require.Equal(t, labelCardinalityRejectedTooManySelectedSeries, reason)
+		})
+	}
+}
+
+func TestCheckSelectedSeriesRatio_CountsTheSelectedSeriesUntilTheRatioIsExceeded(t *testing.T) {
+	var inputSeries []labels.Labels
+	for i := 0; i < 1000; i++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "pod", fmt.Sprintf("pod-%d", i)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	var matchersCalled [][]*labels.Matcher
+	var iterated int
+	postingsForMatchers := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		matchersCalled = append(matchersCalled, matchers)
+		p, err := idxReader.postingsForMatchers(r, matchers...)
+		return &iteratedPostings{Postings: p, iterated: &iterated}, err
+	}
+
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")}
+	err := checkSelectedSeriesRatio(context.Background(), idxReader, postingsForMatchers, matchers, 0.1, uint64(len(inputSeries)))
+	require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	// The series of the tenant aren't iterated, and the selected ones only until the ratio is exceeded.
+	require.Equal(t, [][]*labels.Matcher{matchers}, matchersCalled)
+	require.Equal(t, 101, iterated)
+}
+
+// iteratedPostings counts the postings iterated by Next.
+type iteratedPostings struct {
+	index.Postings
+	iterated *int
+}
+
+func (p *iteratedPostings) Next() bool {
+	if !p.Postings.Next() {
+		return false
+	}
+	*p.iterated++
+	return true
+}
+
+func TestLabelValuesCardinality_MaxRegexCandidateValues(t *testing.T) {
+	var inputSeries []labels.Labels
+	for i := 0; i < 1000; i++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "pod", fmt.Sprintf("pod-%d", i), "zone", fmt.Sprintf("zone-%d", i%2)))
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	for name, tc := range map[string]struct {
+		matchers           []*labels.Matcher
+		expectedSetMatches []string
+		expectedError      string
+	}{
+		"literal alternation is looked up as a set matcher": {
+			matchers:           []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "pod", "pod-1|pod-2|pod-3")},
+			expectedSetMatches: []string{"pod-1", "pod-2", "pod-3"},
+		},
+		"broad regex on a label with too many values is rejected": {
+			matchers:      []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "pod", "pod-1.*")},
+			expectedError: `the regex matcher pod=~"pod-1.*" is rejected because it would be evaluated against 1000 values of the label pod, more than the maximum of 100: use an alternation of literal values, or more selective matchers`,
+		},
+		"negative literal alternation is evaluated against all the values": {
+			matchers:      []*labels.Matcher{labels.MustNewMatcher(labels.MatchNotRegexp, "pod", "pod-1|pod-2")},
+			expectedError: `the regex matcher pod!~"pod-1|pod-2" is rejected because it would be evaluated against 1000 values of the label pod, more than the maximum of 100: use an alternation of literal values, or more selective matchers`,
+		},
+		"literal alternation matching the empty string is evaluated against all the values": {
+			matchers:      []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "pod", "pod-1|")},
+			expectedError: `the regex matcher pod=~"pod-1|" is rejected because it would be evaluated against 1000 values of the label pod, more than the maximum of 100: use an alternation of literal values, or more selective matchers`,
+		},
+		"broad regex on a label with few values is accepted": {
+			matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "zone", "zone-.*")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, m := range tc.matchers {
+				setMatches, ok := regexMatcherSetMatches(m)
+				require.Equal(t, tc.expectedSetMatches != nil, ok)
+				require.ElementsMatch(t, tc.expectedSetMatches, setMatches)
+			}
+
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			opts := labelValuesCardinalityOptions{limits: labelValuesCardinalityLimits{maxRegexCandidateValues: 100}}
+			err := labelValuesCardinality([]string{"zone"}, tc.matchers, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				require.NotEmpty(t, mockServer.SentResponses)
+				return
+			}
+			require.Equal(t, codes.ResourceExhausted, status.Code(err))
+			require.Equal(t, tc.expectedError, status.Convert(err).Message())
+			require.Empty(t, mockServer.SentResponses)
+			reason, rejected := labelCardinalityRejectionReason(err)
+			require.True(t, rejected)
+			require.Equal(t, labelCardinalityRejectedTooManyRegexCandidates, reason)
+
+			// The requests are allowed when the guard is disabled.
+			mockServer = &mockLabelValuesCardinalityServer{context: context.Background()}
+			require.NoError(t, labelValuesCardinality([]string{"zone"}, tc.matchers, idxReader, idxReader.postingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer))
+		})
+	}
+}
+
+func TestLabelValuesCardinality_MetricNamesTopK(t *testing.T) {
+	var inputSeries []labels.Labels
+	for instance, metrics := range map[string]map[string]int{
+		"instance-1": {"up": 1, "requests_total": 3, "errors_total": 2, "latency_seconds": 2},
+		"instance-2": {"up": 1, "requests_total": 1},
+	} {
+		for metricName, count := range metrics {
+			for i := 0; i < count; i++ {
+				inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, metricName, "instance", instance, "id", strconv.Itoa(i)))
+			}
+		}
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	opts := labelValuesCardinalityOptions{groupByMetricName: true, metricNamesTopK: 2}
+	require.NoError(t, labelValuesCardinality([]string{"instance"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer))
+	require.Len(t, mockServer.SentResponses, 1)
+	require.Len(t, mockServer.SentResponses[0].Items, 1)
+	item := mockServer.SentResponses[0].Items[0]
+
+	require.Equal(t, map[string]uint64{"instance-1": 8, "instance-2": 2}, item.LabelValueSeries)
+	require.Equal(t, map[string]*client.MetricNamesSeriesCount{
+		// The metric names with the same number of series are picked by name.
+		"instance-1": {Items: []*client.MetricNameSeriesCount{
+			{MetricName: "errors_total", SeriesCount: 2},
+			{MetricName: "requests_total", SeriesCount: 3},
+		}},
+		"instance-2": {Items: []*client.MetricNameSeriesCount{
+			{MetricName: "requests_total", SeriesCount: 1},
+			{MetricName: "up", SeriesCount: 1},
+		}},
+	}, item.LabelValueMetricNamesSeries)
+}
+
+func TestLabelValuesCardinality_ValueHashSalt(t *testing.T) {
+	var inputSeries []labels.Labels
+	for value, count := range map[string]int{"alice": 3, "bob": 2, "carol": 1} {
+		for i := 0; i < count; i++ {
+			inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "user", value, "id", strconv.Itoa(i)))
+		}
+	}
+	idxReader := mockSeriesIndex{series: inputSeries}
+
+	cardinality := func(salt string) map[string]uint64 {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{valueHashSalt: salt, includeChunkCount: true}
+		require.NoError(t, labelValuesCardinality([]string{"user"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, mockServer))
+		require.Len(t, mockServer.SentResponses, 1)
+		require.Len(t, mockServer.SentResponses[0].Items, 1)
+		item := mockServer.SentResponses[0].Items[0]
+		require.Equal(t, "user", item.LabelName)
+		// All the maps keyed by label value use the hashed value.
+		require.Len(t, item.LabelValueChunks, len(item.LabelValueSeries))
+		for key := range item.LabelValueChunks {
+			require.Contains(t, item.LabelValueSeries, key)
+		}
+		return item.LabelValueSeries
+	}
+
+	hashed := cardinality("salt-1")
+	require.Equal(t, map[string]uint64{
+		hashLabelValue("salt-1", "alice"): 3,
+		hashLabelValue("salt-1", "bob"):   2,
+		hashLabelValue("salt-1", "carol"): 1,
+	}, hashed)
+	for _, value := range []string{"alice", "bob", "carol"} {
+		require.NotContains(t, hashed, value)
+	}
+
+	// The values are consistently hashed with the same salt, and differently with another salt.
+	require.Equal(t, hashed, cardinality("salt-1"))
+	otherSalt := cardinality("salt-2")
+	require.Len(t, otherSalt, 3)
+	for key := range otherSalt {
+		require.NotContains(t, hashed, key)
+	}
+
+	// Without salt, the raw values are returned.
+	require.Equal(t, map[string]uint64{"alice": 3, "bob": 2, "carol": 1}, cardinality(""))
+}
+
+func TestLabelNamesAndValues_UseValueIDs(t *testing.T) {
+	existingLabels := map[string][]string{
+		"cached":   {"false", "true"},
+		"enabled":  {"false", "true"},
+		"instance": {"instance-0", "instance-1", "instance-2"},
+		"zone":     {"eu", "us"},
+	}
+	idx := mockIndex{existingLabels: existingLabels}
+
+	for _, threshold := range []int{1, 16, 1024} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			expectedServer := &mockLabelNamesAndValuesServer{context: context.Background()}
+			require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, threshold, labelNamesAndValuesOptions{}, expectedServer))
+
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			require.NoError(t, labelNamesAndValues(idx, []*labels.Matcher{}, threshold, labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{useValueIDs: true}}, server))
+
+			// The client reconstructs the values from the dictionary received in the first messages.
+			var dictionary []string
+			actual := map[string][]string{}
+			itemsReceived := false
+			for _, resp := range server.SentResponses {
+				data, err := resp.Marshal()
+				require.NoError(t, err)
+				var received client.LabelNamesAndValuesResponse
+				require.NoError(t, received.Unmarshal(data))
+
+				if len(received.Dictionary) > 0 {
+					require.False(t, itemsReceived, "the dictionary must be sent before any item")
+					dictionary = append(dictionary, received.Dictionary...)
+				}
+				for _, item := range received.Items {
+					itemsReceived = true
+					require.Empty(t, item.Values)
+					for _, id := range item.ValueIds {
+						require.Less(t, int(id), len(dictionary))
+						actual[item.LabelName] = append(actual[item.LabelName], dictionary[id])
+					}
+				}
+			}
+			require.Equal(t, existingLabels, actual)
+
+			expected := map[string][]string{}
+			for _, item := range extractItemsWithSortedValues(expectedServer.SentResponses) {
+				expected[item.LabelName] = append(expected[item.LabelName], item.Values...)
+			}
+			require.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("value IDs can't be used with presence", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{encoding: labelNamesAndValuesEncodingOptions{useValueIDs: true}, blocksIndex: idx}
+		require.Error(t, labelNamesAndValues(idx, []*labels.Matcher{}, 1024, opts, server))
+		require.Empty(t, server.SentResponses)
+	})
+}
+
+func TestLabelNamesAndValues_Presence(t *testing.T) {
+	headIndex := mockIndex{existingLabels: map[string][]string{
+		"job":  {"api", "db"},
+		"pod":  {"pod-1", "pod-2"},
+		"zone": {"eu"},
+	}}
+	blocksIndex := mockIndex{existingLabels: map[string][]string{
+		"job":     {"db", "cache"},
+		"pod":     {"pod-0"},
+		"cluster": {"prod"},
+	}}
+	expected := map[string]map[string]client.LabelValuePresence{
+		"cluster": {"prod": client.PRESENT_IN_BLOCKS_ONLY},
+		"job":     {"api": client.PRESENT_IN_HEAD_ONLY, "cache": client.PRESENT_IN_BLOCKS_ONLY, "db": client.PRESENT_IN_HEAD_AND_BLOCKS},
+		"pod":     {"pod-0": client.PRESENT_IN_BLOCKS_ONLY, "pod-1": client.PRESENT_IN_HEAD_ONLY, "pod-2": client.PRESENT_IN_HEAD_ONLY},
+		"zone":    {"eu": client.PRESENT_IN_HEAD_ONLY},
+	}
+
+	for _, threshold := range []int{1, 10, 1024} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{blocksIndex: blocksIndex, includePresence: true}
+			require.NoError(t, labelNamesAndValues(headIndex, []*labels.Matcher{}, threshold, opts, server))
+
+			actual := map[string]map[string]client.LabelValuePresence{}
+			for _, resp := range server.SentResponses {
+				for _, item := range resp.Items {
+					require.Len(t, item.Presence, len(item.Values))
+					if actual[item.LabelName] == nil {
+						actual[item.LabelName] = map[string]client.LabelValuePresence{}
+					}
+					for i, value := range item.Values {
+						actual[item.LabelName][value] = item.Presence[i]
+					}
+				}
+			}
+			require.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("presence is not returned without the blocks index", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		require.NoError(t, labelNamesAndValues(headIndex, []*labels.Matcher{}, 1024, labelNamesAndValuesOptions{}, server))
+
+		require.Len(t, server.SentResponses, 1)
+		require.Len(t, server.SentResponses[0].Items, 3)
+		for _, item := range server.SentResponses[0].Items {
+			require.Empty(t, item.Presence)
+		}
+	})
+}
+
+func TestLabelNamesAndValues_IncludeBlocks(t *testing.T) {
+	headIndex := mockIndex{existingLabels: map[string][]string{
+		"job": {"api", "db"},
+		"pod": {"pod-1", "pod-2"},
+	}}
+	// The blocks overlap with each other and with the head.
+	blocksIndex := multiLabelsReader{
+		mockIndex{existingLabels: map[string][]string{
+			"job":     {"db", "cache"},
+			"cluster": {"prod"},
+		}},
+		mockIndex{existingLabels: map[string][]string{
+			"job":     {"api", "cache"},
+			"pod":     {"pod-0", "pod-2"},
+			"cluster": {"prod", "dev"},
+		}},
+	}
+	expected := map[string][]string{
+		"cluster": {"dev", "prod"},
+		"job":     {"api", "cache", "db"},
+		"pod":     {"pod-0", "pod-1", "pod-2"},
+	}
+
+	for _, threshold := range []int{1, 20, 1024} {
+		t.Run(fmt.Sprintf("threshold=%d", threshold), func(t *testing.T) {
+			server := &mockLabelNamesAndValuesServer{context: context.Background()}
+			opts := labelNamesAndValuesOptions{blocksIndex: blocksIndex}
+			require.NoError(t, labelNamesAndValues(headIndex, []*labels.Matcher{}, threshold, opts, server))
+
+			var names []string
+			actual := map[string][]string{}
+			for _, resp := range server.SentResponses {
+				for _, item := range resp.Items {
+					require.Empty(t, item.Presence)
+					if _, ok := actual[item.LabelName]; !ok {
+						names = append(names, item.LabelName)
+					}
+					actual[item.LabelName] = append(actual[item.LabelName], item.Values...)
+				}
+			}
+			// The merged result is streamed in order, without duplicates.
+			require.Equal(t, []string{"cluster", "job", "pod"}, names)
+			require.Equal(t, expected, actual)
+		})
+	}
+
+	t.Run("presence requires the blocks index", func(t *testing.T) {
+		server := &mockLabelNamesAndValuesServer{context: context.Background()}
+		opts := labelNamesAndValuesOptions{includePresence: true}
+		require.EqualError(t, labelNamesAndValues(headIndex, []*labels.Matcher{}, 1024, opts, server), "the presence of the label values can't be returned without the blocks index")
+	})
+}
+
+func TestLabelNamesAndValues_NilMatchers(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-1"),
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-2"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "instance", "i-1", "code", "200"),
+	}}
+	opts := labelNamesAndValuesOptions{includeSeriesCount: true, postingsForMatchersFn: idxReader.postingsForMatchers}
+
+	emptyServer := mockLabelNamesAndValuesServer{context: context.Background()}
+	require.NoError(t, labelNamesAndValues(idxReader, []*labels.Matcher{}, 16, opts, &emptyServer))
+
+	nilServer := mockLabelNamesAndValuesServer{context: context.Background()}
+	require.NoError(t, labelNamesAndValues(idxReader, nil, 16, opts, &nilServer))
+
+	require.NotEmpty(t, emptyServer.SentResponses)
+	require.Equal(t, emptyServer.SentResponses, nilServer.SentResponses)
+}
+
+func TestLabelValuesCardinality_NilMatchers(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-1"),
+		labels.FromStrings(labels.MetricName, "up", "instance", "i-2"),
+		labels.FromStrings(labels.MetricName, "http_requests_total", "instance", "i-1", "code", "200"),
+	}}
+	lbNames := []string{labels.MetricName, "instance", "code"}
+	opts := labelValuesCardinalityOptions{groupByMetricName: true}
+
+	emptyServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	require.NoError(t, labelValuesCardinality(lbNames, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 16, opts, emptyServer))
+
+	nilServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+	require.NoError(t, labelValuesCardinality(lbNames, nil, idxReader, idxReader.postingsForMatchers, 16, opts, nilServer))
+
+	require.NotEmpty(t, emptyServer.SentResponses)
+	require.Equal(t, emptyServer.SentResponses, nilServer.SentResponses)
+}
+
+func TestLabelNamesAndValues_ContextCancellation(t *testing.T) {
+	cctx, cancel := context.WithCancel(context.Background())
+
+	// Server mock.
+	mockServer := mockLabelNamesAndValuesServer{context: cctx}
+	var server client.Ingester_LabelNamesAndValuesServer = &mockServer
+
+	// Index reader mock.
+	existingLabels := make(map[string][]string)
+	lbValues := make([]string, 0, 100)
+	for j := 0; j < 100; j++ {
+		lbValues = append(lbValues, fmt.Sprintf("val-%d", j))
+	}
+	existingLabels["__name__"] = lbValues
+
+	idxOpDelay := time.Millisecond * 100
+
+	idxReader := &mockIndex{
+		existingLabels: existingLabels,
+		opDelay:        idxOpDelay,
+	}
+
+	doneCh := make(chan error, 1)
+	go func() {
+		err := labelNamesAndValues(
+			idxReader,
+			[]*labels.Matcher{},
+			1*1024*1024, // 1MB
+			labelNamesAndValuesOptions{},
+			server,
+		)
+		doneCh <- err // Signal request completion.
+	}()
+
+	cancel() // Cancel stream context.
+
+	// Assert labelNamesAndValues completion.
+	select {
+	case err := <-doneCh:
+		require.ErrorIsf(t, err, context.Canceled, "labelNamesAndValues unexpected error: %s", err)
+
+	case <-time.After(time.Second):
+		require.Fail(t, "labelNamesAndValues was not completed after context cancellation")
+	}
+}
+
+func TestLabelNamesAndValues_ContextCancellationDuringLabelNames(t *testing.T) {
+	cctx, cancel := context.WithCancel(context.Background())
+	server := &mockLabelNamesAndValuesServer{context: cctx}
+
+	// The label names lookup blocks until the test releases it, after the request returned.
+	idxReader := blockingLabelNamesIndex{
+		mockIndex: mockIndex{existingLabels: map[string][]string{"__name__": {"val-0"}}},
+		release:   make(chan struct{}),
+	}
+	lookups := &backgroundLookups{}
+
+	doneCh := make(chan error, 1)
+	go func() {
+		doneCh <- labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, labelNamesAndValuesOptions{lookup: labelNamesAndValuesLookupOptions{backgroundLookups: lookups}}, server)
+	}()
+
+	// Give labelNamesAndValues the time to call LabelNames() before cancelling the context.
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-doneCh:
+		require.ErrorIs(t, err, context.Canceled)
+		require.Empty(t, server.SentResponses)
+	case <-time.After(time.Second):
+		require.Fail(t, "labelNamesAndValues was not completed after context cancellation while looking up label names")
+	}
+
+	// The index is only closed once the lookup left running in the background has completed.
+	closed := atomic.NewBool(false)
+	lookups.closeWhenDone(func() { closed.Store(true) })
+	require.False(t, closed.Load())
+
+	close(idxReader.release)
+	require.Eventually(t, closed.Load, time.Second, 10*time.Millisecond)
+}
+
+func TestLabelNamesWithContext_WaitsForTheLookupWithoutBackgroundLookups(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	idxReader := blockingLabelNamesIndex{
+		mockIndex: mockIndex{existingLabels: map[string][]string{"__name__": {"val-0"}}},
+		release:   make(chan struct{}),
+	}
+
+	doneCh := make(chan error, 1)
+	go func() {
+		_, err := labelNamesWithContext(ctx, idxReader, nil, nil)
+		doneCh <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	// The index may be closed as soon as labelNamesWithContext returns, so it must wait for the lookup.
+	select {
+	case <-doneCh:
+		require.Fail(t, "labelNamesWithContext returned before the label names lookup completed")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(idxReader.release)
+	select {
+	case err := <-doneCh:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		require.Fail(t, "labelNamesWithContext was not completed after the label names lookup completed")
+	}
+}
+
+func TestBackgroundLookups_CloseWhenDone(t *testing.T) {
+	lookups := &backgroundLookups{}
+	var closed []string
+
+	// Without running lookups, the closers are called right away.
+	lookups.closeWhenDone(func() { closed = append(closed, "head") })
+	require.Equal(t, []string{"head"}, closed)
+
+	done1, done2 := lookups.start(), lookups.start()
+	lookups.closeWhenDone(func() { closed = append(closed, "blocks") })
+	lookups.closeWhenDone(func() { closed = append(closed, "head") })
+	done1()
+	require.Equal(t, []string{"head"}, closed)
+	done2()
+	require.Equal(t, []string{"head", "blocks", "head"}, closed)
+}
+
+func TestLabelValuesCardinality_ContextCancellation(t *testing.T) {
+	cctx, cancel := context.WithCancel(context.Background())
+
+	// Server mock.
+	mockServer := &mockLabelValuesCardinalityServer{context: cctx}
+	var server client.Ingester_LabelValuesCardinalityServer = mockServer
+
+	// Index reader mock.
+	existingLabels := make(map[string][]string)
+	lbValues := make([]string, 0, 100)
+	for j := 0; j < 100; j++ {
+		lbValues = append(lbValues, fmt.Sprintf("val-%d", j))
+	}
+	existingLabels["__name__"] = lbValues
+
+	idxOpDelay := time.Millisecond * 100
+
+	idxReader := &mockIndex{
+		existingLabels: existingLabels,
+		opDelay:        idxOpDelay,
+	}
+
+	// Posting mock.
+	postingsForMatchersFn := func(reader tsdb.IndexPostingsReader, matcher ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 100}, nil
+	}
+	doneCh := make(chan error, 1)
+	go func() {
+		err := labelValuesCardinality(
+			[]string{"__name__"},
+			nil,
+			idxReader,
+			postingsForMatchersFn,
+			1*1024*1024, // 1MB
+			labelValuesCardinalityOptions{},
+			server,
+		)
+		doneCh <- err // Signal request completion.
+	}()
+
+	cancel() // Cancel stream context.
+
+	// Assert labelValuesCardinality completion.
+	select {
+	case err := <-doneCh:
+		require.ErrorIsf(t, err, context.Canceled, "labelValuesCardinality unexpected error: %s", err)
+
+	case <-time.After(time.Second):
+		require.Fail(t, "labelValuesCardinality was not completed after context cancellation")
+	}
+}
+
+func TestLabelNamesAndValuesAndCardinality_PreCancelledContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	// The client has given up before the request is processed.
+	cancel()
+
+	newIndex := func() *callsTrackingIndex {
+		return &callsTrackingIndex{mockIndex: mockIndex{existingLabels: map[string][]string{"__name__": {"val-0", "val-1"}, "pod": {"pod-0"}}}}
+	}
+
+	t.Run("label names and values", func(t *testing.T) {
+		for name, opts := range map[string]labelNamesAndValuesOptions{
+			"prefetched values": {lookup: labelNamesAndValuesLookupOptions{labelValuesPrefetchDepth: 2}},
+			"series count":      {includeSeriesCount: true},
+			"sharded":           {shardIndex: 1, shardCount: 2},
+		} {
+			t.Run(name, func(t *testing.T) {
+				idxReader := newIndex()
+				server := &mockLabelNamesAndValuesServer{context: ctx}
+				opts.postingsForMatchersFn = idxReader.postingsForMatchers
+				err := labelNamesAndValues(idxReader, []*labels.Matcher{}, 1*1024*1024, opts, server)
+				require.ErrorIs(t, err, context.Canceled)
+				require.Empty(t, server.SentResponses)
+				require.Zero(t, idxReader.calls.Load())
+			})
+		}
+	})
+
+	t.Run("label values cardinality", func(t *testing.T) {
+		for name, opts := range map[string]labelValuesCardinalityOptions{
+			"requested labels": {},
+			"all labels":       {allLabels: true},
+			"ordered labels":   {estimation: labelValuesCardinalityEstimationOptions{orderByLabelSeries: true}},
+			"send timeout":     {stream: labelValuesCardinalityStreamOptions{sendStallTimeout: time.Second}},
+		} {
+			t.Run(name, func(t *testing.T) {
+				var lbNames []string
+				if !opts.allLabels {
+					lbNames = []string{"__name__", "pod"}
+				}
+				idxReader := newIndex()
+				server := &mockLabelValuesCardinalityServer{context: ctx}
+				err := labelValuesCardinality(lbNames, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, opts, server)
+				require.ErrorIs(t, err, context.Canceled)
+				require.Empty(t, server.SentResponses)
+				require.Zero(t, idxReader.calls.Load())
+			})
+		}
+	})
+}
+
+// callsTrackingIndex is a mockIndex counting the calls reading the index.
+type callsTrackingIndex struct {
+	mockIndex
+	calls atomic.Int64
+}
+
+func (i *callsTrackingIndex) LabelNames(matchers ...*labels.Matcher) ([]string, error) {
+	i.calls.Inc()
+	return i.mockIndex.LabelNames(matchers...)
+}
+
+func (i *callsTrackingIndex) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	i.calls.Inc()
+	return i.mockIndex.LabelValues(name, matchers...)
+}
+
+func (i *callsTrackingIndex) Postings(string, ...string) (index.Postings, error) {
+	i.calls.Inc()
+	return &mockPostings{n: 1}, nil
+}
+
+func (i *callsTrackingIndex) postingsForMatchers(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+	i.calls.Inc()
+	return &mockPostings{n: 1}, nil
+}
+
+type mockPostings struct {
+	index.Postings
+	n int
+}
+
+func (m *mockPostings) Next() bool {
+	if m.n == 0 {
+		return false
+	}
+	m.n--
+	return true
+}
+func (m *mockPostings) Err() error { return nil }
+
+// labelValueDistribution is the distribution of the label values across the series of a cardinality fixture.
+type labelValueDistribution int
+
+const (
+	// uniformDistribution assigns each label value to about the same number of series.
+	uniformDistribution labelValueDistribution = iota
+	// zipfianDistribution assigns the label values to series with a Zipf's law, so that a few values have
+	// most of the series, as it usually happens in the real world.
+	zipfianDistribution
+)
+
+func (d labelValueDistribution) String() string {
+	switch d {
+	case uniformDistribution:
+		return "uniform"
+	case zipfianDistribution:
+		return "zipfian"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(d))
+	}
+}
+
+// cardinalityFixture builds a reproducible set of series, to make the cardinality benchmarks comparable across runs.
+type cardinalityFixture struct {
+	// seed of the random generator. The same seed always builds the same series.
+	seed int64
+	// numSeries is the number of series to build. Each series has a unique "series_id" label.
+	numSeries int
+	// labelValues is the number of distinct values of each label.
+	labelValues map[string]int
+	// distribution of the label values across the series.
+	distribution labelValueDistribution
+}
+
+func (f cardinalityFixture) build() []labels.Labels {
+	rnd := rand.New(rand.NewSource(f.seed))
+
+	// Iterate the label names in a stable order, so that the random values don't depend on the map ordering.
+	names := make([]string, 0, len(f.labelValues))
+	for name := range f.labelValues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pickers := make([]func() int, len(names))
+	for i, name := range names {
+		numValues := f.labelValues[name]
+		switch f.distribution {
+		case zipfianDistribution:
+			zipf := rand.NewZipf(rnd, 1.1, 1, uint64(numValues-1))
+			pickers[i] = func() int { return int(zipf.Uint64()) }
+		default:
+			pickers[i] = func() int { return rnd.Intn(numValues) }
+		}
+	}
+
+	series := make([]labels.Labels, 0, f.numSeries)
+	for s := 0; s < f.numSeries; s++ {
+		builder := labels.NewBuilder(labels.FromStrings("series_id", strconv.Itoa(s)))
+		for i, name := range names {
+			builder.Set(name, fmt.Sprintf("%s-%d", name, pickers[i]()))
+		}
+		series = append(series, builder.Labels())
+	}
+	return series
+}
+
+func TestCardinalityFixture(t *testing.T) {
+	fixture := cardinalityFixture{
+		seed:         1,
+		numSeries:    1000,
+		labelValues:  map[string]int{labels.MetricName: 10, "pod": 100},
+		distribution: zipfianDistribution,
+	}
+
+	series := fixture.build()
+	require.Len(t, series, fixture.numSeries)
+	require.Equal(t, series, fixture.build(), "the same seed must build the same series")
+
+	fixture.seed = 2
+	require.NotEqual(t, series, fixture.build(), "a different seed must build different series")
+
+	// With a zipfian distribution, the first value is the most frequent one.
+	podSeries := map[string]int{}
+	for _, s := range series {
+		podSeries[s.Get("pod")]++
+	}
+	require.LessOrEqual(t, len(podSeries), 100)
+	for value, count := range podSeries {
+		require.LessOrEqual(t, count, podSeries["pod-0"], value)
+	}
+}
+
+func BenchmarkLabelValuesCardinality_Distributions(b *testing.B) {
+	for _, distribution := range []labelValueDistribution{uniformDistribution, zipfianDistribution} {
+		fixture := cardinalityFixture{
+			seed:         1,
+			numSeries:    10000,
+			labelValues:  map[string]int{labels.MetricName: 50, "pod": 500, "status": 5},
+			distribution: distribution,
+		}
+		idxReader := mockSeriesIndex{series: fixture.build()}
+
+		b.Run(distribution.String(), func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+				err := labelValuesCardinality([]string{labels.MetricName, "status"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer)
+				require.NoError(b, err)
+			}
+		})
+	}
+}
+
+// BenchmarkLabelValuesCardinality_LabelNamesConcurrency shows the speedup of processing the requested labels
+// concurrently, when counting their series is slow.
+func BenchmarkLabelValuesCardinality_LabelNamesConcurrency(b *testing.B) {
+	fixture := cardinalityFixture{
+		seed:         1,
+		numSeries:    10000,
+		labelValues:  map[string]int{labels.MetricName: 50, "pod": 500, "status": 5, "zone": 3, "cluster": 2},
+		distribution: uniformDistribution,
+	}
+	idxReader := mockSeriesIndex{series: fixture.build()}
+	lbNames := []string{labels.MetricName, "status", "zone", "cluster"}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, "pod", "pod-1.*")}
+	postingsForMatchersFn := func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		time.Sleep(50 * time.Microsecond)
+		return idxReader.postingsForMatchers(r, matchers...)
+	}
+
+	var expected map[string]map[string]uint64
+	for _, labelNamesConcurrency := range []int{1, 2, 4} {
+		b.Run(fmt.Sprintf("%d labels, concurrency=%d", len(lbNames), labelNamesConcurrency), func(b *testing.B) {
+			opts := labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{labelNamesConcurrency: labelNamesConcurrency}}
+			var items []*client.LabelValueSeriesCount
+			for n := 0; n < b.N; n++ {
+				mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+				err := labelValuesCardinality(lbNames, matchers, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+				require.NoError(b, err)
+				items = items[:0]
+				for _, resp := range mockServer.SentResponses {
+					items = append(items, resp.Items...)
+				}
+			}
+
+			// The output is the same regardless of the concurrency.
+			merged := mergeLabelValueSeriesCounts(items)
+			if expected == nil {
+				expected = merged
+			}
+			require.Equal(b, expected, merged)
+		})
+	}
+}
+
+// BenchmarkLabelValuesCardinality_PerLabelConcurrency shows that the goroutines counting the series of the values
+// of a label are bounded by the per-label concurrency, regardless of the number of values of the label.
+func BenchmarkLabelValuesCardinality_PerLabelConcurrency(b *testing.B) {
+	const numValues = 10000
+	lbValues := make([]string, 0, numValues)
+	for v := 0; v < numValues; v++ {
+		lbValues = append(lbValues, fmt.Sprintf("user-%d", v))
+	}
+	idxReader := &mockIndex{existingLabels: map[string][]string{"user_id": lbValues}}
+	postingsForMatchersFn := func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+		return &mockPostings{n: 10}, nil
+	}
+
+	for _, perLabelConcurrency := range []int{1, 8, 64, numValues} {
+		b.Run(fmt.Sprintf("1 label with 10k values, concurrency=%d", perLabelConcurrency), func(b *testing.B) {
+			inflight := &maxTrackingGauge{}
+			opts := labelValuesCardinalityOptions{concurrency: labelValuesCardinalityConcurrencyOptions{perLabelConcurrency: perLabelConcurrency, inflightLabelValues: inflight}}
+			b.ReportAllocs()
+			for n := 0; n < b.N; n++ {
+				mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+				err := labelValuesCardinality([]string{"user_id"}, []*labels.Matcher{}, idxReader, postingsForMatchersFn, 1*1024*1024, opts, mockServer)
+				require.NoError(b, err)
+			}
+			b.ReportMetric(float64(inflight.max.Load()), "max-inflight-values")
+			require.LessOrEqual(b, inflight.max.Load(), int64(perLabelConcurrency))
+		})
+	}
+}
+
+type mockIndex struct {
+	tsdb.IndexReader
+	existingLabels map[string][]string
+	opDelay        time.Duration
+}
+
+func (i mockIndex) LabelNames(_ ...*labels.Matcher) ([]string, error) {
+	if i.opDelay > 0 {
+		time.Sleep(i.opDelay)
+	}
+	var l []string
+	for k := range i.existingLabels {
+		l = append(l, k)
+	}
+	sort.Strings(l)
+	return l, nil
+}
+
+func (i mockIndex) LabelValues(name string, _ ...*labels.Matcher) ([]string, error) {
+	if i.opDelay > 0 {
+		time.Sleep(i.opDelay)
+	}
+	return i.existingLabels[name], nil
+}
+
+func (i mockIndex) Symbols() index.StringIter {
+	symbols := map[string]struct{}{}
+	for name, values := range i.existingLabels {
+		symbols[name] = struct{}{}
+		for _, value := range values {
+			symbols[value] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(symbols))
+	for symbol := range symbols {
+		sorted = append(sorted, symbol)
+	}
+	sort.Strings(sorted)
+	return index.NewStringListIter(sorted)
+}
+
+func (i mockIndex) Close() error { return nil }
+
+// blockingLabelNamesIndex is a mockIndex whose LabelNames blocks until release is closed.
+type blockingLabelNamesIndex struct {
+	mockIndex
+	release chan struct{}
+}
+
+func (i blockingLabelNamesIndex) LabelNames(matchers ...*labels.Matcher) ([]string, error) {
+	<-i.release
+	return i.mockIndex.LabelNames(matchers...)
+}
+
+// failingLabelValuesIndex is a mockIndex whose label values lookup fails for a label name.
+type failingLabelValuesIndex struct {
+	mockIndex
+	failingLabelName string
+}
+
+func (i failingLabelValuesIndex) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	if name == i.failingLabelName {
+		return nil, fmt.Errorf("failed to look up the values of %s", name)
+	}
+	return i.mockIndex.LabelValues(name, matchers...)
+}
+
+// mockSeriesIndex is an index reader backed by a list of series, where the reference
+// of each series is its position in the list.
+type mockSeriesIndex struct {
+	tsdb.IndexReader
+	series []labels.Labels
+	// chunks is the number of chunks of each series, by series ref.
+	chunks []int
+	// timeRanges is the min and max time of the samples of each series, by series ref. Each series having a time
+	// range has at least one chunk, and all its chunks span the time range.
+	timeRanges [][2]int64
+}
+
+func (i mockSeriesIndex) postingsForMatchers(_ tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+	var refs []storage.SeriesRef
+	for ref, s := range i.series {
+		if seriesMatches(s, matchers) {
+			refs = append(refs, storage.SeriesRef(ref))
+		}
+	}
+	return index.NewListPostings(refs), nil
+}
+
+func (i mockSeriesIndex) Postings(name string, values ...string) (index.Postings, error) {
+	if allName, allValue := index.AllPostingsKey(); name == allName && len(values) == 1 && values[0] == allValue {
+		return i.postingsForMatchers(i)
+	}
+	return i.postingsForMatchers(i, labels.MustNewMatcher(labels.MatchRegexp, name, strings.Join(values, "|")))
+}
+
+func (i mockSeriesIndex) LabelNames(matchers ...*labels.Matcher) ([]string, error) {
+	names := map[string]struct{}{}
+	for _, s := range i.series {
+		if !seriesMatches(s, matchers) {
+			continue
+		}
+		for _, l := range s {
+			names[l.Name] = struct{}{}
+		}
+	}
+	return sortedKeys(names), nil
+}
+
+func (i mockSeriesIndex) LabelValues(name string, matchers ...*labels.Matcher) ([]string, error) {
+	values := map[string]struct{}{}
+	for _, s := range i.series {
+		if v := s.Get(name); v != "" && seriesMatches(s, matchers) {
+			values[v] = struct{}{}
+		}
+	}
+	return sortedKeys(values), nil
+}
+
+func (i mockSeriesIndex) LabelValueFor(ref storage.SeriesRef, name string) (string, error) {
+	if int(ref) >= len(i.series) {
+		return "", storage.ErrNotFound
+	}
+	if v := i.series[ref].Get(name); v != "" {
+		return v, nil
+	}
+	return "", storage.ErrNotFound
+}
+
+func (i mockSeriesIndex) Series(ref storage.SeriesRef, lset *labels.Labels, chks *[]chunks.Meta) error {
+	if int(ref) >= len(i.series) {
+		return storage.ErrNotFound
+	}
+	*lset = append((*lset)[:0], i.series[ref]...)
+	if chks == nil {
+		return nil
+	}
+	*chks = (*chks)[:0]
+	if int(ref) < len(i.chunks) {
+		for c := 0; c < i.chunks[ref]; c++ {
+			*chks = append(*chks, chunks.Meta{Ref: chunks.ChunkRef(c)})
+		}
+	}
+	if int(ref) < len(i.timeRanges) {
+		if len(*chks) == 0 {
+			*chks = append(*chks, chunks.Meta{})
+		}
+		for c := range *chks {
+			(*chks)[c].MinTime, (*chks)[c].MaxTime = i.timeRanges[ref][0], i.timeRanges[ref][1]
+		}
+	}
+	return nil
+}
+
+func (i mockSeriesIndex) ShardedPostings(p index.Postings, shardIndex, shardCount uint64) index.Postings {
+	var refs []storage.SeriesRef
+	for p.Next() {
+		if ref := p.At(); int(ref) < len(i.series) && i.series[ref].Hash()%shardCount == shardIndex {
+			refs = append(refs, ref)
+		}
+	}
+	return index.NewListPostings(refs)
+}
+
+func (i mockSeriesIndex) Close() error { return nil }
+
+func seriesMatches(s labels.Labels, matchers []*labels.Matcher) bool {
+	for _, m := range matchers {
+		if !m.Matches(s.Get(m.Name)) {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type mockLabelNamesAndValuesServer struct {
+	client.Ingester_LabelNamesAndValuesServer
+	SentResponses []client.LabelNamesAndValuesResponse
+	context       context.Context
+	sendDelay     time.Duration
+}
+
+func (m *mockLabelNamesAndValuesServer) Send(response *client.LabelNamesAndValuesResponse) error {
+	if m.sendDelay > 0 {
+		time.Sleep(m.sendDelay)
+	}
+	items := make([]*client.LabelValues, len(response.Items))
+	for i, it := range response.Items {
+		values := make([]string, len(it.Values))
+		copy(values, it.Values)
+		items[i] = &client.LabelValues{LabelName: it.LabelName, Values: values, ValueCount: it.ValueCount}
+		if len(it.Presence) > 0 {
+			items[i].Presence = append([]client.LabelValuePresence(nil), it.Presence...)
+		}
+		if len(it.ValueIds) > 0 {
+			items[i].ValueIds = append([]uint32(nil), it.ValueIds...)
+		}
+		if len(it.CompressedValues) > 0 {
+			items[i].CompressedValues = append([]byte(nil), it.CompressedValues...)
+		}
+		if len(it.RelabelOutcomes) > 0 {
+			items[i].RelabelOutcomes = append([]client.LabelValueRelabelOutcome(nil), it.RelabelOutcomes...)
+		}
+	}
+	var longValues []*client.LongLabelValues
+	if len(response.LongValues) > 0 {
+		longValues = append(longValues, response.LongValues...)
+	}
+	var dictionary []string
+	if len(response.Dictionary) > 0 {
+		dictionary = append(dictionary, response.Dictionary...)
+	}
+	m.SentResponses = append(m.SentResponses, client.LabelNamesAndValuesResponse{
+		Items:                         items,
+		SeriesCount:                   response.SeriesCount,
+		LongValues:                    longValues,
+		PartitionKey:                  response.PartitionKey,
+		Dictionary:                    dictionary,
+		ValuesCompressionDictionaryId: response.ValuesCompressionDictionaryId,
+		Truncated:                     response.Truncated,
+		LabelNamesTruncated:           response.LabelNamesTruncated,
+		ExportObjectName:              response.ExportObjectName,
+	})
+	return nil
+}
+
+func (m *mockLabelNamesAndValuesServer) Context() context.Context {
+	return m.context
+}
+
+type mockLabelValuesCardinalityServer struct {
+	client.Ingester_LabelValuesCardinalityServer
+	SentResponses []client.LabelValuesCardinalityResponse
+	context       context.Context
+}
+
+func (m *mockLabelValuesCardinalityServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	var sentResp client.LabelValuesCardinalityResponse
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+	if err := json.NewDecoder(bytes.NewReader(b)).Decode(&sentResp); err != nil {
+		return err
+	}
+	m.SentResponses = append(m.SentResponses, sentResp)
+	return nil
+}
+
+func (m *mockLabelValuesCardinalityServer) Context() context.Context {
 	return m.context
 }
+
+// maxTrackingGauge is a gauge which tracks the maximum value it has been set to by Inc() and Dec().
+type maxTrackingGauge struct {
+	prometheus.Gauge
+	current atomic.Int64
+	max     atomic.Int64
+}
+
+func (g *maxTrackingGauge) Inc() {
+	current := g.current.Inc()
+	for {
+		max := g.max.Load()
+		if current <= max || g.max.CAS(max, current) {
+			return
+		}
+	}
+}
+
+func (g *maxTrackingGauge) Dec() {
+	g.current.Dec()
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"sync"
+
+	"github.com/gogo/status"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// labelStreamRegistry tracks the in-flight streaming label requests of each tenant, so that an operator can cancel
+// all the requests of a tenant at once, for example during an incident caused by its expensive requests.
+type labelStreamRegistry struct {
+	mtx     sync.Mutex
+	streams map[string]map[*labelStreamRegistration]struct{}
+}
+
+func newLabelStreamRegistry() *labelStreamRegistry {
+	return &labelStreamRegistry{streams: map[string]map[*labelStreamRegistration]struct{}{}}
+}
+
+// labelStreamRegistration is an in-flight streaming label request tracked by a labelStreamRegistry.
+type labelStreamRegistration struct {
+	ctx       context.Context
+	cancel    context.CancelFunc
+	cancelled atomic.Bool
+}
+
+// register tracks a request of the tenant, and returns the registration holding the context the request must use,
+// which is cancelled when the requests of the tenant are cancelled. The request must be unregistered once done.
+func (r *labelStreamRegistry) register(ctx context.Context, userID string) *labelStreamRegistration {
+	ctx, cancel := context.WithCancel(ctx)
+	reg := &labelStreamRegistration{ctx: ctx, cancel: cancel}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	if r.streams[userID] == nil {
+		r.streams[userID] = map[*labelStreamRegistration]struct{}{}
+	}
+	r.streams[userID][reg] = struct{}{}
+	return reg
+}
+
+// unregister stops tracking the request of the tenant, and releases its context.
+func (r *labelStreamRegistry) unregister(userID string, reg *labelStreamRegistration) {
+	reg.cancel()
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	delete(r.streams[userID], reg)
+	if len(r.streams[userID]) == 0 {
+		delete(r.streams, userID)
+	}
+}
+
+// cancelTenant cancels all the in-flight requests of the tenant, and returns how many have been cancelled.
+func (r *labelStreamRegistry) cancelTenant(userID string) int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	for reg := range r.streams[userID] {
+		reg.cancelled.Store(true)
+		reg.cancel()
+	}
+	return len(r.streams[userID])
+}
+
+// wrapErr returns the error of the request, replaced by a cancellation error if the request has been cancelled
+// with the other requests of the tenant.
+func (reg *labelStreamRegistration) wrapErr(err error) error {
+	if err == nil || !reg.cancelled.Load() {
+		return err
+	}
+	return status.Error(codes.Canceled, "the request has been cancelled by an operator along with all the requests of the tenant")
+}
+
+// labelNamesAndValuesServerWithContext is a client.Ingester_LabelNamesAndValuesServer using a different context.
+type labelNamesAndValuesServerWithContext struct {
+	client.Ingester_LabelNamesAndValuesServer
+	ctx context.Context
+}
+
+func (s *labelNamesAndValuesServerWithContext) Context() context.Context {
+	return s.ctx
+}
+
+// labelValuesCardinalityServerWithContext is a client.Ingester_LabelValuesCardinalityServer using a different context.
+type labelValuesCardinalityServerWithContext struct {
+	client.Ingester_LabelValuesCardinalityServer
+	ctx context.Context
+}
+
+func (s *labelValuesCardinalityServerWithContext) Context() context.Context {
+	return s.ctx
+}
+
+// labelValuesCardinalityStreamServerWithContext is a client.Ingester_LabelValuesCardinalityStreamServer using
+// a different context.
+type labelValuesCardinalityStreamServerWithContext struct {
+	client.Ingester_LabelValuesCardinalityStreamServer
+	ctx context.Context
+}
+
+func (s *labelValuesCardinalityStreamServerWithContext) Context() context.Context {
+	return s.ctx
+}
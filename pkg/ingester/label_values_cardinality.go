@@ -0,0 +1,1292 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"math"
+	"regexp"
+	"runtime"
+	runtime_metrics "runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/gogo/status"
+	"github.com/grafana/dskit/concurrency"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/chunks"
+	"github.com/prometheus/prometheus/tsdb/index"
+	"go.uber.org/atomic"
+	"google.golang.org/grpc/codes"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/util"
+)
+
+const checkContextErrorSeriesCount = 1000 // series count interval in which context cancellation must be checked.
+
+const labelValuesCardinalityContextCheckIntervalFlag = "ingester.label-values-cardinality-context-check-interval-series"
+
+var errInvalidLabelValuesCardinalityContextCheckInterval = errors.New("the label values cardinality context check interval, configured with -" + labelValuesCardinalityContextCheckIntervalFlag + ", must be greater than 0")
+
+const labelValuesCardinalityMaxSeriesFlag = "ingester.label-values-cardinality-max-series"
+
+var errLabelValuesCardinalityResumeMismatch = status.Error(codes.Aborted, "the label values cardinality response can't be resumed from the requested offset, because it doesn't match the messages which have been received: the request must be restarted from the beginning")
+
+var errLabelValuesCardinalityMaxSeriesExceeded = errors.New("the label values cardinality request has been aborted because it exceeded the maximum number of series it can count, configured with -" + labelValuesCardinalityMaxSeriesFlag)
+
+// LabelValuesCardinalityConfig configures the label values cardinality requests served by the ingester.
+type LabelValuesCardinalityConfig struct {
+	SendStallTimeout     time.Duration `yaml:"label_values_cardinality_send_stall_timeout" category:"experimental"`
+	EmptyResultCacheTTL  time.Duration `yaml:"label_values_cardinality_empty_result_cache_ttl" category:"experimental"`
+	ContextCheckInterval int           `yaml:"label_values_cardinality_context_check_interval_series" category:"experimental"`
+
+	Limits      LabelValuesCardinalityLimitsConfig      `yaml:",inline"`
+	Concurrency LabelValuesCardinalityConcurrencyConfig `yaml:",inline"`
+	Profile     LabelValuesCardinalityProfileConfig     `yaml:",inline"`
+}
+
+func (cfg *LabelValuesCardinalityConfig) RegisterFlags(f *flag.FlagSet) {
+	f.DurationVar(&cfg.SendStallTimeout, "ingester.label-values-cardinality-send-stall-timeout", 0, "Maximum time sending a message of the label values cardinality response can be blocked, for example because the client stopped reading the response, before the request is aborted. 0 = no timeout.")
+	f.DurationVar(&cfg.EmptyResultCacheTTL, "ingester.label-values-cardinality-empty-result-cache-ttl", 0, "How long the label values cardinality requests which returned an empty result are cached, so that the clients retrying them don't recompute them. 0 to disable.")
+	f.IntVar(&cfg.ContextCheckInterval, labelValuesCardinalityContextCheckIntervalFlag, checkContextErrorSeriesCount, "Number of series counted by the label values cardinality requests between two checks of whether the request has been cancelled. A lower interval cancels the requests faster, for example at shutdown, at a small CPU cost. Requests can ask for a different interval.")
+
+	cfg.Limits.RegisterFlags(f)
+	cfg.Concurrency.RegisterFlags(f)
+	cfg.Profile.RegisterFlags(f)
+}
+
+func (cfg *LabelValuesCardinalityConfig) Validate() error {
+	if cfg.ContextCheckInterval <= 0 {
+		return errInvalidLabelValuesCardinalityContextCheckInterval
+	}
+	return cfg.Profile.Validate()
+}
+
+// LabelValuesCardinalityLimitsConfig configures the limits protecting the ingester from the expensive label values
+// cardinality requests.
+type LabelValuesCardinalityLimitsConfig struct {
+	MaxSeries                int     `yaml:"label_values_cardinality_max_series" category:"experimental"`
+	SeriesBudgetWarningRatio float64 `yaml:"label_values_cardinality_series_budget_warning_ratio" category:"experimental"`
+	RejectContradictions     bool    `yaml:"label_values_cardinality_reject_contradictory_matchers" category:"experimental"`
+	RejectAllMatching        bool    `yaml:"label_values_cardinality_reject_all_matching_matchers" category:"experimental"`
+	MaxSelectedSeriesRatio   float64 `yaml:"label_values_cardinality_max_selected_series_ratio" category:"experimental"`
+	MaxRegexCandidateValues  int     `yaml:"label_values_cardinality_max_regex_candidate_values" category:"experimental"`
+}
+
+func (cfg *LabelValuesCardinalityLimitsConfig) RegisterFlags(f *flag.FlagSet) {
+	f.IntVar(&cfg.MaxSeries, labelValuesCardinalityMaxSeriesFlag, 0, "Maximum number of series that a single label values cardinality request can count. Requests exceeding the limit are aborted. 0 = unlimited.")
+	f.Float64Var(&cfg.SeriesBudgetWarningRatio, "ingester.label-values-cardinality-series-budget-warning-ratio", 0.8, "Ratio of -"+labelValuesCardinalityMaxSeriesFlag+" after which the label values cardinality response is flagged with a budget warning, so that clients can narrow down the request before hitting the limit.")
+	f.BoolVar(&cfg.RejectContradictions, "ingester.label-values-cardinality-reject-contradictory-matchers", false, "Reject the label values cardinality requests having several matchers on the same label name which can't all match, such as foo=\"a\" and foo=~\"b.*\". The matchers are always combined with AND semantics, so such requests otherwise return an empty result.")
+	f.BoolVar(&cfg.RejectAllMatching, "ingester.label-values-cardinality-reject-all-matching-matchers", false, "Reject the label values cardinality requests without any matcher which doesn't match the empty string, such as requests without matchers or with only foo=~\".*\", because they select all the series of the tenant and iterate the whole index.")
+	f.Float64Var(&cfg.MaxSelectedSeriesRatio, "ingester.label-values-cardinality-max-selected-series-ratio", 0, "Maximum ratio of the series of the tenant that the matchers of a label values cardinality request can select. Requests without matchers, or whose matchers select more series, are rejected, so that they don't scan the whole tenant. 0 to disable.")
+	f.IntVar(&cfg.MaxRegexCandidateValues, "ingester.label-values-cardinality-max-regex-candidate-values", 0, "Maximum number of label values a regex matcher of a label values cardinality request can be evaluated against. Requests with a regex matcher on a label with more values are rejected, unless the regex is an alternation of literal values, whose series are looked up directly. 0 to disable.")
+}
+
+// LabelValuesCardinalityConcurrencyConfig configures how many labels and label values the label values cardinality
+// requests process concurrently.
+type LabelValuesCardinalityConcurrencyConfig struct {
+	PerLabel                int `yaml:"label_values_cardinality_per_label_concurrency" category:"experimental" doc:"default=<2 * GOMAXPROCS>"`
+	CountingMemoryBudget    int `yaml:"label_values_cardinality_counting_memory_budget_bytes" category:"experimental"`
+	AllLabels               int `yaml:"label_values_cardinality_all_labels_concurrency" category:"experimental"`
+	LabelNames              int `yaml:"label_values_cardinality_label_names_concurrency" category:"experimental"`
+	SerialCountingHeapBytes int `yaml:"label_values_cardinality_serial_counting_heap_bytes" category:"experimental"`
+}
+
+func (cfg *LabelValuesCardinalityConcurrencyConfig) RegisterFlags(f *flag.FlagSet) {
+	f.IntVar(&cfg.PerLabel, "ingester.label-values-cardinality-per-label-concurrency", 2*runtime.GOMAXPROCS(0), "Maximum number of values of a single label whose series are counted concurrently by a label values cardinality request. The values are counted by a pool of as many workers, so a label with many values doesn't spawn a goroutine per value.")
+	f.IntVar(&cfg.CountingMemoryBudget, "ingester.label-values-cardinality-counting-memory-budget-bytes", 0, "Maximum memory in bytes that the goroutines counting the series of the values of a single label are estimated to allocate. The number of values counted concurrently is reduced to fit in the budget. 0 = unlimited.")
+	f.IntVar(&cfg.AllLabels, "ingester.label-values-cardinality-all-labels-concurrency", 1, "Maximum number of labels processed concurrently by a label values cardinality request of all labels. The values of each label are counted with up to -ingester.label-values-cardinality-per-label-concurrency goroutines.")
+	f.IntVar(&cfg.LabelNames, "ingester.label-values-cardinality-label-names-concurrency", 1, "Maximum number of the requested labels processed concurrently by a label values cardinality request. The labels are still sent in the requested order, while the following labels are processed.")
+	f.IntVar(&cfg.SerialCountingHeapBytes, "ingester.label-values-cardinality-serial-counting-heap-bytes", 0, "Size in bytes of the heap objects above which the label values cardinality requests count the series of the label values serially, ignoring -ingester.label-values-cardinality-per-label-concurrency, to protect the ingestion under memory pressure. 0 to disable.")
+}
+
+// invalidLabelValuesCardinalityRequestError is returned when a label values cardinality request is not valid.
+type invalidLabelValuesCardinalityRequestError string
+
+func (e invalidLabelValuesCardinalityRequestError) Error() string {
+	return string(e)
+}
+
+// labelCardinalityRejectionReason returns the reason why a label values cardinality request has been rejected
+// with the error, or false if the error isn't caused by the request being rejected.
+func labelCardinalityRejectionReason(err error) (string, bool) {
+	var invalidErr invalidLabelValuesCardinalityRequestError
+	switch {
+	case errors.Is(err, errLabelValuesCardinalityMaxSeriesExceeded):
+		return labelCardinalityRejectedMaxSeriesExceeded, true
+	case errors.As(err, &invalidErr):
+		return labelCardinalityRejectedInvalidRequest, true
+	case status.Code(err) == codes.FailedPrecondition:
+		return labelCardinalityRejectedTooManySelectedSeries, true
+	case status.Code(err) == codes.ResourceExhausted:
+		return labelCardinalityRejectedTooManyRegexCandidates, true
+	case status.Code(err) == codes.InvalidArgument:
+		return labelCardinalityRejectedInvalidRequest, true
+	default:
+		return "", false
+	}
+}
+
+// labelValuesCardinalityOptions holds the optional behaviours of labelValuesCardinality.
+type labelValuesCardinalityOptions struct {
+	// groupByMetricName enables the breakdown of each label value series count by metric name.
+	groupByMetricName bool
+	// metricNamesTopK, if greater than 0, limits the breakdown by metric name to the metricNamesTopK metric names
+	// with the most series.
+	metricNamesTopK int
+	// shardIndex and shardCount restrict the counted label values to the ones whose hash falls in the shard.
+	// Sharding is disabled if shardCount is 0.
+	shardIndex uint64
+	shardCount uint64
+	// instanceID is the ID of the ingester computing the response, which is echoed in every message.
+	instanceID string
+	// allLabels enables counting the series of the values of all the labels matching the matchers,
+	// instead of the requested label names.
+	allLabels bool
+	// sortValues enables sending the values of each label in sorted order, instead of the order of the index.
+	sortValues bool
+	// groupByMagnitude enables partitioning the values of each label by the order of magnitude of their series count,
+	// and sending each partition in its own items, from the highest magnitude to the lowest.
+	groupByMagnitude bool
+	// includeSummary enables sending the number of values and the total series of each label in the last message.
+	includeSummary bool
+	// minSeriesCount is the minimum number of series of a label value to be returned. The values with
+	// fewer series are omitted from the response.
+	minSeriesCount uint64
+	// includeChunkCount enables counting the chunks of the series of each label value.
+	includeChunkCount bool
+	// includeRatios enables computing the ratio of the series of each label value to the series of the label.
+	includeRatios bool
+	// explain enables annotating the last message with a timing breakdown of the request.
+	explain bool
+	// startMs and endMs, if not 0, are the time range in milliseconds in which the series must have samples to be
+	// counted, so that only the series active in the time range are counted. An end of 0 is unbounded.
+	startMs, endMs int64
+	// bestEffort enables omitting the label values whose series can't be counted because of an error, instead of
+	// aborting the request. The failed values are reported in the last message.
+	bestEffort bool
+	// valueGroupRegex, if not empty, is the regex whose first capture group extracts the key by which the counts
+	// of the label values are aggregated.
+	valueGroupRegex string
+	// coOccurrenceTopK, if greater than 0, enables reporting the coOccurrenceTopK label values most frequently found
+	// in the series of each of the coOccurrenceTopK values with the most series of each label.
+	coOccurrenceTopK int
+	// changedLabelsOnly, if set, holds the fingerprints and the series counts of the labels computed by the previous
+	// run of the request. Only the labels whose fingerprint changed are counted, and it's updated with their counts.
+	changedLabelsOnly *labelCardinalityFingerprints
+	// valueHashSalt, if not empty, is the key of the HMAC-SHA256 hash replacing the label values in the response.
+	valueHashSalt string
+	// logger is used to log diagnostic messages. If nil, nothing is logged.
+	logger log.Logger
+
+	limits      labelValuesCardinalityLimits
+	concurrency labelValuesCardinalityConcurrencyOptions
+	stream      labelValuesCardinalityStreamOptions
+	estimation  labelValuesCardinalityEstimationOptions
+}
+
+// labelValuesCardinalityLimits holds the limits protecting the ingester from the expensive label values
+// cardinality requests.
+type labelValuesCardinalityLimits struct {
+	// maxSeries is the maximum number of series the request can count. 0 means unlimited.
+	maxSeries uint64
+	// countedSeries, if set, is the number of series counted so far by the request, so that the counting is aborted
+	// as soon as it exceeds maxSeries, instead of once the values of the whole label have been counted.
+	// It's set by labelValuesCardinality when maxSeries is set.
+	countedSeries *atomic.Uint64
+	// seriesBudgetWarningRatio is the ratio of maxSeries after which the response is flagged with a budget warning.
+	seriesBudgetWarningRatio float64
+	// maxSelectedSeriesRatio, if greater than 0, is the maximum ratio of the totalSeries that the matchers can select.
+	// The requests without matchers, or whose matchers select more series, are rejected.
+	maxSelectedSeriesRatio float64
+	// totalSeries is the number of series of the tenant, which the ingester takes from the head stats so that
+	// maxSelectedSeriesRatio can be checked without iterating all the series.
+	totalSeries uint64
+	// maxRegexCandidateValues, if greater than 0, is the maximum number of label values a regex matcher can be
+	// evaluated against. The requests with a regex matcher on a label name with more values are rejected.
+	maxRegexCandidateValues int
+	// rejectContradictions enables rejecting the requests with several matchers on the same label name
+	// which can't all match. Otherwise, the matchers are combined with AND semantics and match no series.
+	rejectContradictions bool
+	// rejectAllMatching enables rejecting the requests without any matcher which doesn't match the empty
+	// string, because such matchers select all the series of the tenant.
+	rejectAllMatching bool
+	// rejectComplexRegexes enables rejecting the regex matchers which nest unbounded quantifiers or compile
+	// to too many instructions.
+	rejectComplexRegexes bool
+}
+
+// checkMatchers returns an error if the request must be rejected because of its matchers.
+func (l labelValuesCardinalityLimits) checkMatchers(ctx context.Context, idxReader tsdb.IndexReader, postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error), matchers []*labels.Matcher) error {
+	if l.rejectComplexRegexes {
+		if err := checkRegexMatchers(matchers); err != nil {
+			return err
+		}
+	}
+	if l.rejectAllMatching {
+		if err := checkNonEmptyMatcher(matchers); err != nil {
+			return err
+		}
+	}
+	if l.rejectContradictions {
+		if err := checkContradictoryMatchers(matchers); err != nil {
+			return err
+		}
+	}
+	if l.maxSelectedSeriesRatio > 0 {
+		if err := checkSelectedSeriesRatio(ctx, idxReader, postingsForMatchersFn, matchers, l.maxSelectedSeriesRatio, l.totalSeries); err != nil {
+			return err
+		}
+	}
+	if l.maxRegexCandidateValues > 0 {
+		if err := checkRegexMatchersCandidateValues(idxReader, matchers, l.maxRegexCandidateValues); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// labelValuesCardinalityConcurrencyOptions holds how the labels and their values are processed concurrently.
+type labelValuesCardinalityConcurrencyOptions struct {
+	// perLabelConcurrency is the maximum number of values of a single label whose series are counted concurrently.
+	// Values lower than 1 are treated as 1.
+	perLabelConcurrency int
+	// highLoad, if set, reports whether the ingester is under high load, in which case the series of the label values
+	// are counted serially, to protect the ingestion.
+	highLoad func() bool
+	// countingMemoryBudget, if greater than 0, is the maximum memory in bytes that the goroutines counting the series
+	// of the values of a label are estimated to allocate. The per-label concurrency is reduced to fit in the budget.
+	countingMemoryBudget int
+	// inflightLabelValues, if set, tracks the number of label values whose series are currently being counted.
+	inflightLabelValues prometheus.Gauge
+	// indexReadPool, if set, bounds the number of workers looking up the label values and counting their series,
+	// together with the workers of the other requests sharing the pool.
+	indexReadPool *labelIndexReadPool
+	// allLabelsConcurrency is the maximum number of labels processed concurrently in all-labels mode.
+	// Values lower than 1 are treated as 1.
+	allLabelsConcurrency int
+	// labelNamesConcurrency is the maximum number of requested labels processed concurrently, when not in all-labels
+	// mode. Values lower than 1 are treated as 1.
+	labelNamesConcurrency int
+	// inflightLabels, if set, tracks the number of labels which are currently being processed.
+	inflightLabels prometheus.Gauge
+	// contextCheckInterval, if greater than 0, is the number of series counted between two checks of the context
+	// cancellation, instead of checkContextErrorSeriesCount. The ingester defaults it to its configured interval.
+	contextCheckInterval int
+	// backgroundLookups, if set, tracks the label names lookup left running in the background when the context is
+	// done, so that the request can return right away. The index reader must then be closed through it.
+	backgroundLookups *backgroundLookups
+}
+
+// stopped returns whether the client asked to stop the request.
+func (o labelValuesCardinalityOptions) stopped() bool {
+	select {
+	case <-o.stream.stop:
+		return true
+	default:
+		return false
+	}
+}
+
+// validate returns an error if the options are not valid.
+func (o labelValuesCardinalityOptions) validate() error {
+	if o.shardCount > 0 && o.shardIndex >= o.shardCount {
+		return invalidLabelValuesCardinalityRequestError(fmt.Sprintf("invalid shard index %d: it must be lower than the shard count %d", o.shardIndex, o.shardCount))
+	}
+	if len(o.estimation.seriesCountPercentiles) > maxSeriesCountPercentiles {
+		return invalidLabelValuesCardinalityRequestError(fmt.Sprintf("too many series count percentiles: at most %d can be requested, got %d", maxSeriesCountPercentiles, len(o.estimation.seriesCountPercentiles)))
+	}
+	for _, p := range o.estimation.seriesCountPercentiles {
+		if !(p >= 0 && p <= 1) {
+			return invalidLabelValuesCardinalityRequestError(fmt.Sprintf("invalid series count percentile %v: it must be between 0 and 1", p))
+		}
+	}
+	if o.stream.resumeOffset > 0 && !o.stream.includeChecksums {
+		return invalidLabelValuesCardinalityRequestError("the label values cardinality response can only be resumed when the checksums are included")
+	}
+	if o.endMs != 0 && o.startMs > o.endMs {
+		return invalidLabelValuesCardinalityRequestError(fmt.Sprintf("invalid time range: the start %d must not be after the end %d", o.startMs, o.endMs))
+	}
+	// Only the series counts of the labels are reused when the labels haven't changed.
+	if o.changedLabelsOnly != nil && (o.groupByMetricName || o.includeChunkCount || o.estimation.estimateLabelSeries || o.bestEffort || o.startMs != 0 || o.endMs != 0) {
+		return invalidLabelValuesCardinalityRequestError("only the series counts of the label values can be computed for the changed labels only")
+	}
+	if o.valueGroupRegex != "" {
+		if _, err := compileLabelValuesGroupRegex(o.valueGroupRegex); err != nil {
+			return err
+		}
+		// The co-occurrences are looked up by label value, which is replaced by the group key.
+		if o.coOccurrenceTopK > 0 {
+			return invalidLabelValuesCardinalityRequestError("the label values co-occurrences can't be returned when the label values are grouped")
+		}
+	}
+	return nil
+}
+
+// compileLabelValuesGroupRegex compiles the regex grouping the label values. Like the relabeling regexes,
+// it's anchored at both ends.
+func compileLabelValuesGroupRegex(expr string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("^(?:" + expr + ")$")
+	if err != nil {
+		return nil, invalidLabelValuesCardinalityRequestError(fmt.Sprintf("invalid value group regex %q: %v", expr, err))
+	}
+	if re.NumSubexp() == 0 {
+		return nil, invalidLabelValuesCardinalityRequestError(fmt.Sprintf("invalid value group regex %q: it must have a capture group", expr))
+	}
+	return re, nil
+}
+
+// inShard returns whether the label value belongs to the shard configured in the options.
+func (o labelValuesCardinalityOptions) inShard(lbValue string) bool {
+	if o.shardCount == 0 {
+		return true
+	}
+	return uint64(client.HashAdd32a(client.HashNew32a(), lbValue))%o.shardCount == o.shardIndex
+}
+
+// labelsConcurrency returns the number of labels processed concurrently.
+func (o labelValuesCardinalityOptions) labelsConcurrency() int {
+	concurrency := o.concurrency.labelNamesConcurrency
+	if o.allLabels {
+		concurrency = o.concurrency.allLabelsConcurrency
+	}
+	if concurrency < 1 {
+		return 1
+	}
+	return concurrency
+}
+
+// countingConcurrency returns the number of goroutines used to count the series of the values of the label.
+// If there's a counting memory budget, the concurrency is reduced so that the estimated footprint of the goroutines
+// fits in the budget.
+func (o labelValuesCardinalityOptions) countingConcurrency(lbName string, lbValues []string, matchers []*labels.Matcher) int {
+	concurrencyLimit := o.concurrency.perLabelConcurrency
+	if concurrencyLimit < 1 || (o.concurrency.highLoad != nil && o.concurrency.highLoad()) {
+		concurrencyLimit = 1
+	}
+	if o.concurrency.countingMemoryBudget > 0 {
+		budgetLimit := o.concurrency.countingMemoryBudget / labelValueCountingFootprint(lbName, lbValues, matchers)
+		if budgetLimit < 1 {
+			budgetLimit = 1
+		}
+		if budgetLimit < concurrencyLimit {
+			concurrencyLimit = budgetLimit
+		}
+	}
+	if len(lbValues) < concurrencyLimit {
+		return len(lbValues)
+	}
+	return concurrencyLimit
+}
+
+// contextCheckSeriesInterval returns the number of series counted between two checks of the context cancellation.
+func (o labelValuesCardinalityOptions) contextCheckSeriesInterval() uint64 {
+	if o.concurrency.contextCheckInterval > 0 {
+		return uint64(o.concurrency.contextCheckInterval)
+	}
+	return checkContextErrorSeriesCount
+}
+
+// heapObjectsMetric is the runtime metric of the memory occupied by the live and not yet swept objects of the heap.
+const heapObjectsMetric = "/memory/classes/heap/objects:bytes"
+
+// heapObjectsAbove returns a function reporting whether the memory occupied by the heap objects is above the
+// threshold. Reading the runtime metric doesn't stop the world, so it can be called on each request.
+// It returns nil if the threshold isn't greater than 0.
+func heapObjectsAbove(threshold int) func() bool {
+	if threshold <= 0 {
+		return nil
+	}
+	return func() bool {
+		sample := []runtime_metrics.Sample{{Name: heapObjectsMetric}}
+		runtime_metrics.Read(sample)
+		return sample[0].Value.Kind() == runtime_metrics.KindUint64 && sample[0].Value.Uint64() > uint64(threshold)
+	}
+}
+
+// labelValueCountingGoroutineOverheadBytes is the estimated memory used by a goroutine counting the series of
+// label values, in addition to its matchers: its stack, and the buffers of the postings it iterates.
+const labelValueCountingGoroutineOverheadBytes = 8 * 1024
+
+// labelValueCountingFootprint returns the estimated memory in bytes allocated by a goroutine counting the series
+// of the values of the label: the copy of the matchers with the matcher of the label value, and its overhead.
+func labelValueCountingFootprint(lbName string, lbValues []string, matchers []*labels.Matcher) int {
+	longestValue := 0
+	for _, lbValue := range lbValues {
+		if len(lbValue) > longestValue {
+			longestValue = len(lbValue)
+		}
+	}
+	matchersSize := (len(matchers) + 1) * int(unsafe.Sizeof(&labels.Matcher{}))
+	valueMatcherSize := int(unsafe.Sizeof(labels.Matcher{})) + len(lbName) + longestValue
+	return matchersSize + valueMatcherSize + labelValueCountingGoroutineOverheadBytes
+}
+
+// budgetWarningThreshold returns the number of counted series after which the response must be flagged
+// with a budget warning, or 0 if there's no budget.
+func (o labelValuesCardinalityOptions) budgetWarningThreshold() uint64 {
+	if o.limits.maxSeries == 0 || o.limits.seriesBudgetWarningRatio <= 0 {
+		return 0
+	}
+	return uint64(float64(o.limits.maxSeries) * o.limits.seriesBudgetWarningRatio)
+}
+
+// labelValuesCardinality returns all values and series total count for label_names labels that match the matchers.
+// Messages are immediately sent as soon they reach message size threshold.
+func labelValuesCardinality(
+	lbNames []string,
+	matchers []*labels.Matcher,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	msgSizeThreshold int,
+	opts labelValuesCardinalityOptions,
+	srv client.Ingester_LabelValuesCardinalityServer,
+) error {
+	// The index isn't read for the requests whose client has already given up.
+	if err := srv.Context().Err(); err != nil {
+		return err
+	}
+	if err := opts.validate(); err != nil {
+		return err
+	}
+	if timeout := opts.stream.sendStallTimeout; timeout > 0 {
+		stallSrv := newSendStallTimeoutServer(srv, timeout)
+		defer stallSrv.cancel()
+
+		opts.stream.sendStallTimeout = 0
+		err := labelValuesCardinality(lbNames, matchers, idxReader, postingsForMatchersFn, msgSizeThreshold, opts, stallSrv)
+		if stallSrv.isStalled() {
+			return status.Errorf(codes.DeadlineExceeded, "the label values cardinality request has been aborted because sending a message has been blocked for more than %s", timeout)
+		}
+		return err
+	}
+	if opts.stream.pause != nil {
+		// The messages are held before the send stall timeout starts.
+		srv = &pausableLabelValuesCardinalityServer{Ingester_LabelValuesCardinalityServer: srv, pause: opts.stream.pause, stop: opts.stream.stop}
+	}
+	ctx := srv.Context()
+	matchers = normalizeMatchers(matchers)
+	postingsForMatchersFn = nilSafePostingsForMatchers(postingsForMatchersFn, opts.logger)
+	if err := opts.limits.checkMatchers(ctx, idxReader, postingsForMatchersFn, matchers); err != nil {
+		return err
+	}
+	if opts.changedLabelsOnly != nil {
+		opts.changedLabelsOnly.begin(labelCardinalityFingerprintsRequestKey(matchers, opts))
+	}
+	if opts.limits.maxSeries > 0 {
+		opts.limits.countedSeries = atomic.NewUint64(0)
+	}
+
+	var groupRegex *regexp.Regexp
+	if opts.valueGroupRegex != "" {
+		var err error
+		if groupRegex, err = compileLabelValuesGroupRegex(opts.valueGroupRegex); err != nil {
+			return err
+		}
+	}
+
+	var progress *labelValuesCardinalityProgress
+	if opts.stream.progressInterval > 0 {
+		progress = &labelValuesCardinalityProgress{}
+		// The progress messages are sent while the items are sent.
+		srv = &serializedLabelValuesCardinalityServer{Ingester_LabelValuesCardinalityServer: srv}
+	}
+
+	if opts.allLabels {
+		if len(lbNames) > 0 {
+			return invalidLabelValuesCardinalityRequestError("the label names can't be set when the cardinality of all labels is requested")
+		}
+		var err error
+		if lbNames, err = labelNamesWithContext(ctx, idxReader, matchers, opts.concurrency.backgroundLookups); err != nil {
+			return err
+		}
+	}
+	// A label name requested more than once would be returned in several items with the same name.
+	lbNames = uniqueLabelNames(lbNames)
+	if opts.estimation.orderByLabelSeries {
+		var err error
+		if lbNames, err = orderLabelNamesBySeries(ctx, idxReader, lbNames, matchers, postingsForMatchersFn); err != nil {
+			return err
+		}
+	}
+
+	s := newLabelValuesCardinalitySender(ctx, srv, msgSizeThreshold, opts)
+	e := &labelCardinalityEmitter{
+		labelValuesCardinalitySender: s,
+		matchers:                     matchers,
+		idxReader:                    idxReader,
+		postingsForMatchersFn:        postingsForMatchersFn,
+		groupRegex:                   groupRegex,
+	}
+
+	// The labels are collected concurrently while the labels already collected are sent in order.
+	stopProgress := progress.report(srv, opts.stream.progressInterval)
+	stopped, err := pipelineLabelCardinalities(ctx, lbNames, opts.labelsConcurrency(),
+		func(ctx context.Context, lbName string) (labelCardinality, error) {
+			if opts.concurrency.inflightLabels != nil {
+				opts.concurrency.inflightLabels.Inc()
+				defer opts.concurrency.inflightLabels.Dec()
+			}
+			return collectLabelCardinality(ctx, lbName, matchers, idxReader, postingsForMatchersFn, opts, progress)
+		},
+		func(lbName string, card labelCardinality) (stopped bool, _ error) {
+			if opts.stopped() {
+				return true, s.sendStopped()
+			}
+			return e.emit(lbName, card)
+		},
+	)
+	if progressErr := stopProgress(); err == nil {
+		err = progressErr
+	}
+	if err != nil || stopped {
+		return err
+	}
+	return s.finish()
+}
+
+// hashLabelValue returns the hex-encoded HMAC-SHA256 of the label value, keyed with the salt.
+func hashLabelValue(salt, lbValue string) string {
+	h := hmac.New(sha256.New, []byte(salt))
+	_, _ = h.Write([]byte(lbValue))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// uniqueLabelNames returns the label names without the duplicates, keeping the order of their first occurrence.
+func uniqueLabelNames(lbNames []string) []string {
+	seen := make(map[string]struct{}, len(lbNames))
+	unique := make([]string, 0, len(lbNames))
+	for _, lbName := range lbNames {
+		if _, ok := seen[lbName]; ok {
+			continue
+		}
+		seen[lbName] = struct{}{}
+		unique = append(unique, lbName)
+	}
+	return unique
+}
+
+// labelCardinality holds the series counts of the values of a label.
+type labelCardinality struct {
+	values       []string
+	seriesCounts []labelValueSeriesCount
+	// sketch is only set when the distinct series of the label are estimated.
+	sketch              *labelSeriesSketch
+	labelSeriesEstimate uint64
+
+	labelValuesDuration time.Duration
+	countingDuration    time.Duration
+}
+
+// collectLabelCardinality looks up the values of the label and counts their series.
+func collectLabelCardinality(
+	ctx context.Context,
+	lbName string,
+	matchers []*labels.Matcher,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	opts labelValuesCardinalityOptions,
+	progress *labelValuesCardinalityProgress,
+) (labelCardinality, error) {
+	var card labelCardinality
+
+	// Obtain all values for current label name.
+	labelValuesStart := time.Now()
+	lbValues, err := pooledLabelValues(ctx, idxReader, lbName, matchers, opts.concurrency.indexReadPool)
+	if err != nil {
+		return card, err
+	}
+	card.labelValuesDuration = time.Since(labelValuesStart)
+	card.values = sampleLabelValues(shardLabelValues(lbValues, opts), opts)
+
+	var fingerprint uint64
+	if opts.changedLabelsOnly != nil {
+		if fingerprint, err = labelSeriesFingerprint(ctx, idxReader, lbName, card.values); err != nil {
+			return card, err
+		}
+		if seriesCounts, ok := opts.changedLabelsOnly.reuse(lbName, fingerprint, card.values); ok {
+			card.seriesCounts = seriesCounts
+			return card, nil
+		}
+	}
+
+	countPostingsForMatchersFn := postingsForMatchersFn
+	if opts.estimation.estimateLabelSeries {
+		if card.sketch, err = newLabelSeriesSketch(); err != nil {
+			return card, err
+		}
+		countPostingsForMatchersFn = card.sketch.wrapPostingsForMatchers(postingsForMatchersFn)
+	}
+	countingStart := time.Now()
+	if card.seriesCounts, err = computeLabelValuesSeriesCount(ctx, lbName, card.values, matchers, idxReader, countPostingsForMatchersFn, opts, progress); err != nil {
+		return card, err
+	}
+	card.countingDuration = time.Since(countingStart)
+	if card.sketch != nil {
+		card.labelSeriesEstimate = card.sketch.estimate()
+	}
+	if opts.changedLabelsOnly != nil {
+		opts.changedLabelsOnly.record(lbName, fingerprint, card.seriesCounts)
+	}
+	return card, nil
+}
+
+// pooledLabelValues looks up the values of the label name while holding a worker slot of the pool.
+func pooledLabelValues(ctx context.Context, idxReader labelsReader, lbName string, matchers []*labels.Matcher, pool *labelIndexReadPool) ([]string, error) {
+	release, err := pool.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return idxReader.LabelValues(lbName, matchers...)
+}
+
+// nilSafePostingsForMatchers wraps postingsForMatchersFn so that nil postings returned without an error
+// are treated as empty postings, instead of making the callers panic when iterating them.
+func nilSafePostingsForMatchers(
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	logger log.Logger,
+) func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+	if logger == nil {
+		logger = log.NewNopLogger()
+	}
+	return func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		p, err := postingsForMatchersFn(r, matchers...)
+		if err == nil && p == nil {
+			level.Debug(logger).Log("msg", "postings for matchers returned nil postings, treating them as empty", "matchers", util.MatchersStringer(matchers))
+			return index.EmptyPostings(), nil
+		}
+		return p, err
+	}
+}
+
+// shardLabelValues returns the label values which belong to the shard configured in the options.
+func shardLabelValues(lbValues []string, opts labelValuesCardinalityOptions) []string {
+	if opts.shardCount == 0 {
+		return lbValues
+	}
+	sharded := make([]string, 0, len(lbValues)/int(opts.shardCount)+1)
+	for _, lbValue := range lbValues {
+		if opts.inShard(lbValue) {
+			sharded = append(sharded, lbValue)
+		}
+	}
+	return sharded
+}
+
+// labelValueSeriesCount holds the number of series of a label value.
+type labelValueSeriesCount struct {
+	seriesCount uint64
+	// metricNames is only set when the series count is broken down by metric name.
+	metricNames []*client.MetricNameSeriesCount
+	// chunkCount is the number of chunks of the series. It's only set when the chunks are counted.
+	chunkCount uint64
+	// err is the error counting the series. It's only set in best-effort mode, in which case the counts are unset.
+	err error
+}
+
+// maxReportedLabelValuesErrors is the maximum number of errors of the failed label values returned in best-effort mode.
+const maxReportedLabelValuesErrors = 10
+
+// withoutFailedLabelValues returns the label values whose series have been counted, and their counts in the same
+// order, along with the errors of the failed ones. The inputs are left untouched.
+func withoutFailedLabelValues(lbValues []string, seriesCounts []labelValueSeriesCount) ([]string, []labelValueSeriesCount, []error) {
+	var errs []error
+	for _, seriesCount := range seriesCounts {
+		if seriesCount.err != nil {
+			errs = append(errs, seriesCount.err)
+		}
+	}
+	if len(errs) == 0 {
+		return lbValues, seriesCounts, nil
+	}
+	values := make([]string, 0, len(lbValues)-len(errs))
+	counts := make([]labelValueSeriesCount, 0, len(seriesCounts)-len(errs))
+	for i, seriesCount := range seriesCounts {
+		if seriesCount.err == nil {
+			values = append(values, lbValues[i])
+			counts = append(counts, seriesCount)
+		}
+	}
+	return values, counts, errs
+}
+
+// sortLabelValuesSeriesCounts returns the label values sorted, and their counts in the same order. The inputs are
+// left untouched, because the values may be shared with the index reader.
+func sortLabelValuesSeriesCounts(lbValues []string, seriesCounts []labelValueSeriesCount) ([]string, []labelValueSeriesCount) {
+	if sort.StringsAreSorted(lbValues) {
+		return lbValues, seriesCounts
+	}
+	indexes := make([]int, len(lbValues))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return lbValues[indexes[i]] < lbValues[indexes[j]]
+	})
+	sortedValues := make([]string, len(lbValues))
+	sortedCounts := make([]labelValueSeriesCount, len(seriesCounts))
+	for i, idx := range indexes {
+		sortedValues[i] = lbValues[idx]
+		sortedCounts[i] = seriesCounts[idx]
+	}
+	return sortedValues, sortedCounts
+}
+
+// maxSeriesCountMagnitude is the highest order of magnitude of the series count of the label values, which also
+// holds the values with more series.
+const maxSeriesCountMagnitude = 1000
+
+// seriesCountMagnitude returns the order of magnitude of the series count: 0, 1, 10, 100 or maxSeriesCountMagnitude.
+func seriesCountMagnitude(seriesCount uint64) uint64 {
+	magnitude := uint64(0)
+	for next := uint64(1); next <= seriesCount && next <= maxSeriesCountMagnitude; next *= 10 {
+		magnitude = next
+	}
+	return magnitude
+}
+
+// sortLabelValuesByMagnitude returns the label values sorted by descending order of magnitude of their series count,
+// and their counts in the same order. The values with the same magnitude keep their relative order. The inputs are
+// left untouched, because the values may be shared with the index reader.
+func sortLabelValuesByMagnitude(lbValues []string, seriesCounts []labelValueSeriesCount) ([]string, []labelValueSeriesCount) {
+	indexes := make([]int, len(lbValues))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return seriesCountMagnitude(seriesCounts[indexes[i]].seriesCount) > seriesCountMagnitude(seriesCounts[indexes[j]].seriesCount)
+	})
+	sortedValues := make([]string, len(lbValues))
+	sortedCounts := make([]labelValueSeriesCount, len(seriesCounts))
+	for i, idx := range indexes {
+		sortedValues[i] = lbValues[idx]
+		sortedCounts[i] = seriesCounts[idx]
+	}
+	return sortedValues, sortedCounts
+}
+
+// unmatchedLabelValuesGroup is the key of the group of the label values not matching the value group regex.
+const unmatchedLabelValuesGroup = "__unmatched__"
+
+// groupLabelValues aggregates the counts of the label values by the first capture group of the regex.
+// The returned keys are sorted, and their counts are in the same order.
+func groupLabelValues(re *regexp.Regexp, lbValues []string, seriesCounts []labelValueSeriesCount) ([]string, []labelValueSeriesCount) {
+	groups := map[string]*labelValueSeriesCount{}
+	for i, lbValue := range lbValues {
+		key := unmatchedLabelValuesGroup
+		if match := re.FindStringSubmatch(lbValue); match != nil {
+			key = match[1]
+		}
+		group, ok := groups[key]
+		if !ok {
+			group = &labelValueSeriesCount{}
+			groups[key] = group
+		}
+		group.seriesCount += seriesCounts[i].seriesCount
+		group.chunkCount += seriesCounts[i].chunkCount
+		group.metricNames = mergeMetricNameSeriesCounts(group.metricNames, seriesCounts[i].metricNames)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	counts := make([]labelValueSeriesCount, 0, len(keys))
+	for _, key := range keys {
+		counts = append(counts, *groups[key])
+	}
+	return keys, counts
+}
+
+// mergeMetricNameSeriesCounts returns the sum of the series counts by metric name, sorted by metric name.
+// Both inputs must be sorted by metric name.
+func mergeMetricNameSeriesCounts(a, b []*client.MetricNameSeriesCount) []*client.MetricNameSeriesCount {
+	if len(a) == 0 {
+		return b
+	}
+	if len(b) == 0 {
+		return a
+	}
+	merged := make([]*client.MetricNameSeriesCount, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		switch {
+		case a[0].MetricName < b[0].MetricName:
+			merged, a = append(merged, a[0]), a[1:]
+		case a[0].MetricName > b[0].MetricName:
+			merged, b = append(merged, b[0]), b[1:]
+		default:
+			merged = append(merged, &client.MetricNameSeriesCount{MetricName: a[0].MetricName, SeriesCount: a[0].SeriesCount + b[0].SeriesCount})
+			a, b = a[1:], b[1:]
+		}
+	}
+	merged = append(merged, a...)
+	return append(merged, b...)
+}
+
+// computeLabelValuesSeriesCount counts the series matching the matchers for each of the label values,
+// with a bounded pool of opts.countingConcurrency workers picking the values to count, instead of a goroutine per
+// value. The returned counts are in the same order as the values.
+// The matcher of each label value is ANDed with the matchers, even if some of them are on the same label name.
+func computeLabelValuesSeriesCount(
+	ctx context.Context,
+	lbName string,
+	lbValues []string,
+	matchers []*labels.Matcher,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	opts labelValuesCardinalityOptions,
+	progress *labelValuesCardinalityProgress,
+) ([]labelValueSeriesCount, error) {
+	counts := make([]labelValueSeriesCount, len(lbValues))
+	// countValue counts the series of the label value at idx, and stores its counts at the same index.
+	countValue := func(ctx context.Context, idx int) error {
+		if opts.concurrency.inflightLabelValues != nil {
+			opts.concurrency.inflightLabelValues.Inc()
+			defer opts.concurrency.inflightLabelValues.Dec()
+		}
+
+		// We will use original matchers + one extra matcher for label value.
+		// Each job needs its own matchers because jobs run concurrently.
+		lblValMatchers := make([]*labels.Matcher, len(matchers)+1)
+		copy(lblValMatchers, matchers)
+		lblValMatchers[len(matchers)] = labels.MustNewMatcher(labels.MatchEqual, lbName, lbValues[idx])
+
+		if err := opts.stream.pause.wait(ctx, opts.stream.stop); err != nil {
+			return err
+		}
+		// The slot is acquired once the request is resumed, so that paused requests don't hold it.
+		release, err := opts.concurrency.indexReadPool.acquire(ctx)
+		if err != nil {
+			return err
+		}
+		defer release()
+
+		if opts.groupByMetricName {
+			seriesCount, metricNames, err := countLabelValueSeriesByMetricName(ctx, idxReader, postingsForMatchersFn, lblValMatchers, opts.startMs, opts.endMs, opts.contextCheckSeriesInterval())
+			if err != nil {
+				return err
+			}
+			counts[idx] = labelValueSeriesCount{seriesCount: seriesCount, metricNames: metricNames}
+		} else {
+			seriesCount, err := countLabelValueSeries(ctx, idxReader, postingsForMatchersFn, lblValMatchers, opts.startMs, opts.endMs, opts.contextCheckSeriesInterval())
+			if err != nil {
+				return err
+			}
+			counts[idx] = labelValueSeriesCount{seriesCount: seriesCount}
+		}
+
+		if opts.includeChunkCount {
+			chunkCount, err := countLabelValueChunks(ctx, idxReader, postingsForMatchersFn, lblValMatchers, opts.startMs, opts.endMs, opts.contextCheckSeriesInterval())
+			if err != nil {
+				return err
+			}
+			counts[idx].chunkCount = chunkCount
+		}
+		progress.labelValueCounted(counts[idx].seriesCount)
+		return nil
+	}
+	err := concurrency.ForEachJob(ctx, len(lbValues), opts.countingConcurrency(lbName, lbValues, matchers), func(ctx context.Context, idx int) error {
+		err := countValue(ctx, idx)
+		// Exceeding the series limit cancels the counting of the other values, and skips the next labels.
+		if err == nil && opts.limits.countedSeries != nil && opts.limits.countedSeries.Add(counts[idx].seriesCount) > opts.limits.maxSeries {
+			return errLabelValuesCardinalityMaxSeriesExceeded
+		}
+		// The cancellation of the request still aborts it in best-effort mode.
+		if err != nil && opts.bestEffort && ctx.Err() == nil {
+			lbValue := lbValues[idx]
+			if opts.valueHashSalt != "" {
+				lbValue = hashLabelValue(opts.valueHashSalt, lbValue)
+			}
+			counts[idx] = labelValueSeriesCount{err: errors.Wrapf(err, "failed to count the series of %s=%q", lbName, lbValue)}
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
+
+// labelCardinalityResult is the cardinality of a label collected by pipelineLabelCardinalities.
+type labelCardinalityResult struct {
+	card labelCardinality
+	err  error
+}
+
+// pipelineLabelCardinalities collects the cardinality of the labels with up to concurrency goroutines, and passes
+// the cardinality of each label to emit in the order of the labels, from the calling goroutine, which is then the
+// only one sending the items. A label is only collected once fewer than concurrency labels are being collected or
+// waiting to be emitted, so that the labels collected ahead are bounded. It returns at the first error, or once
+// emit returns true, in which case it returns true too, after all the goroutines have returned.
+func pipelineLabelCardinalities(
+	ctx context.Context,
+	lbNames []string,
+	concurrency int,
+	collect func(ctx context.Context, lbName string) (labelCardinality, error),
+	emit func(lbName string, card labelCardinality) (stop bool, _ error),
+) (stopped bool, _ error) {
+	ctx, cancel := context.WithCancel(ctx)
+	var wg sync.WaitGroup
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// slots holds a token for each label being collected or waiting to be emitted.
+	slots := make(chan struct{}, concurrency)
+	results := make([]chan labelCardinalityResult, len(lbNames))
+	for idx := range results {
+		results[idx] = make(chan labelCardinalityResult, 1)
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for idx, lbName := range lbNames {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			wg.Add(1)
+			go func(idx int, lbName string) {
+				defer wg.Done()
+				card, err := collect(ctx, lbName)
+				results[idx] <- labelCardinalityResult{card: card, err: err}
+			}(idx, lbName)
+		}
+	}()
+
+	for idx, lbName := range lbNames {
+		var res labelCardinalityResult
+		select {
+		case res = <-results[idx]:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		if res.err != nil {
+			return false, res.err
+		}
+		if stop, err := emit(lbName, res.card); err != nil || stop {
+			return stop, err
+		}
+		<-slots
+	}
+	return false, nil
+}
+
+func countLabelValueSeries(
+	ctx context.Context,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	lblValMatchers []*labels.Matcher,
+	start, end int64,
+	checkInterval uint64,
+) (uint64, error) {
+	var (
+		iterated, count uint64
+		inRange         = newSeriesTimeRangeFilter(idxReader, start, end)
+	)
+
+	p, err := postingsForMatchersFn(idxReader, lblValMatchers...)
+	if err != nil {
+		return 0, err
+	}
+	for p.Next() {
+		iterated++
+		if iterated%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+		if inRange == nil {
+			count++
+			continue
+		}
+		if ok, err := inRange(p.At()); err != nil {
+			return 0, err
+		} else if ok {
+			count++
+		}
+	}
+	if p.Err() != nil {
+		return 0, p.Err()
+	}
+	return count, nil
+}
+
+// newSeriesTimeRangeFilter returns a function returning whether the series has samples in the time range in
+// milliseconds, according to the time range of its chunks in the index. The head chunks being appended to are open
+// ended, so the active series are always in the time ranges after their first sample. If both start and end are 0,
+// all the series are in the time range, and nil is returned so that the series don't need to be looked up.
+// An end of 0 is unbounded.
+func newSeriesTimeRangeFilter(idxReader tsdb.IndexReader, start, end int64) func(storage.SeriesRef) (bool, error) {
+	if start == 0 && end == 0 {
+		return nil
+	}
+	var (
+		lset labels.Labels
+		chks []chunks.Meta
+	)
+	return func(ref storage.SeriesRef) (bool, error) {
+		if err := idxReader.Series(ref, &lset, &chks); err != nil {
+			// The series may have been garbage collected since the postings have been looked up.
+			if errors.Is(err, storage.ErrNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		return chunksInTimeRange(chks, start, end), nil
+	}
+}
+
+// chunksInTimeRange returns whether any of the chunks overlaps the time range in milliseconds.
+// An end of 0 is unbounded.
+func chunksInTimeRange(chks []chunks.Meta, start, end int64) bool {
+	if end == 0 {
+		end = math.MaxInt64
+	}
+	for _, chk := range chks {
+		if chk.MinTime <= end && chk.MaxTime >= start {
+			return true
+		}
+	}
+	return false
+}
+
+// countLabelValueChunks returns the number of chunks of the series matching the matchers,
+// reading the chunks metadata of each series from the index.
+func countLabelValueChunks(
+	ctx context.Context,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	lblValMatchers []*labels.Matcher,
+	start, end int64,
+	checkInterval uint64,
+) (uint64, error) {
+	var (
+		seriesCount uint64
+		chunkCount  uint64
+		lset        labels.Labels
+		chks        []chunks.Meta
+	)
+
+	p, err := postingsForMatchersFn(idxReader, lblValMatchers...)
+	if err != nil {
+		return 0, err
+	}
+	for p.Next() {
+		seriesCount++
+		if seriesCount%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, err
+			}
+		}
+		if err := idxReader.Series(p.At(), &lset, &chks); err != nil {
+			// The series may have been garbage collected since the postings have been looked up.
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return 0, err
+		}
+		// The chunks of the series having samples in the time range are all counted.
+		if (start != 0 || end != 0) && !chunksInTimeRange(chks, start, end) {
+			continue
+		}
+		chunkCount += uint64(len(chks))
+	}
+	if p.Err() != nil {
+		return 0, p.Err()
+	}
+	return chunkCount, nil
+}
+
+// topLabelValues returns the indexes of the k label values with the most series. Values with the same number
+// of series are ordered by value. It returns nil if k isn't greater than 0.
+func topLabelValues(lbValues []string, seriesCounts []labelValueSeriesCount, k int) map[int]struct{} {
+	if k <= 0 {
+		return nil
+	}
+	indexes := make([]int, len(lbValues))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		a, b := indexes[i], indexes[j]
+		if seriesCounts[a].seriesCount != seriesCounts[b].seriesCount {
+			return seriesCounts[a].seriesCount > seriesCounts[b].seriesCount
+		}
+		return lbValues[a] < lbValues[b]
+	})
+	if len(indexes) > k {
+		indexes = indexes[:k]
+	}
+	top := make(map[int]struct{}, len(indexes))
+	for _, i := range indexes {
+		top[i] = struct{}{}
+	}
+	return top
+}
+
+// topMetricNames returns the k metric names with the most series, sorted by metric name. Metric names with the same
+// number of series are picked by name. All the metric names are returned if k isn't greater than 0.
+func topMetricNames(metricNames []*client.MetricNameSeriesCount, k int) []*client.MetricNameSeriesCount {
+	if k <= 0 || len(metricNames) <= k {
+		return metricNames
+	}
+	top := make([]*client.MetricNameSeriesCount, len(metricNames))
+	copy(top, metricNames)
+	sort.SliceStable(top, func(i, j int) bool {
+		return top[i].SeriesCount > top[j].SeriesCount
+	})
+	top = top[:k]
+	sort.Slice(top, func(i, j int) bool {
+		return top[i].MetricName < top[j].MetricName
+	})
+	return top
+}
+
+// countLabelValueCoOccurrences returns the topK label values most frequently found in the series matching the
+// matchers and having the label value, excluding the values of the label itself. They're sorted by series count
+// in descending order, and then by label name and value.
+func countLabelValueCoOccurrences(
+	ctx context.Context,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	lbName, lbValue string,
+	matchers []*labels.Matcher,
+	topK int,
+	checkInterval uint64,
+) ([]*client.LabelValueCoOccurrence, error) {
+	lblValMatchers := make([]*labels.Matcher, len(matchers)+1)
+	copy(lblValMatchers, matchers)
+	lblValMatchers[len(matchers)] = labels.MustNewMatcher(labels.MatchEqual, lbName, lbValue)
+
+	p, err := postingsForMatchersFn(idxReader, lblValMatchers...)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		counts = map[labels.Label]uint64{}
+		lset   labels.Labels
+		chks   []chunks.Meta
+		series uint64
+	)
+	for p.Next() {
+		series++
+		if series%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+		if err := idxReader.Series(p.At(), &lset, &chks); err != nil {
+			// The series may have been garbage collected in the meantime.
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		for _, l := range lset {
+			if l.Name != lbName {
+				counts[l]++
+			}
+		}
+	}
+	if p.Err() != nil {
+		return nil, p.Err()
+	}
+
+	coOccurrences := make([]*client.LabelValueCoOccurrence, 0, len(counts))
+	for l, c := range counts {
+		coOccurrences = append(coOccurrences, &client.LabelValueCoOccurrence{LabelName: l.Name, LabelValue: l.Value, SeriesCount: c})
+	}
+	sort.Slice(coOccurrences, func(i, j int) bool {
+		a, b := coOccurrences[i], coOccurrences[j]
+		if a.SeriesCount != b.SeriesCount {
+			return a.SeriesCount > b.SeriesCount
+		}
+		if a.LabelName != b.LabelName {
+			return a.LabelName < b.LabelName
+		}
+		return a.LabelValue < b.LabelValue
+	})
+	if len(coOccurrences) > topK {
+		coOccurrences = coOccurrences[:topK]
+	}
+	return coOccurrences, nil
+}
+
+// countLabelValueSeriesByMetricName works like countLabelValueSeries, but it additionally breaks down
+// the series count by metric name. The returned metric names are sorted.
+func countLabelValueSeriesByMetricName(
+	ctx context.Context,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	lblValMatchers []*labels.Matcher,
+	start, end int64,
+	checkInterval uint64,
+) (uint64, []*client.MetricNameSeriesCount, error) {
+	var (
+		iterated, count  uint64
+		metricNamesCount = map[string]uint64{}
+		inRange          = newSeriesTimeRangeFilter(idxReader, start, end)
+	)
+
+	p, err := postingsForMatchersFn(idxReader, lblValMatchers...)
+	if err != nil {
+		return 0, nil, err
+	}
+	for p.Next() {
+		iterated++
+		if iterated%checkInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return 0, nil, err
+			}
+		}
+		if inRange != nil {
+			if ok, err := inRange(p.At()); err != nil {
+				return 0, nil, err
+			} else if !ok {
+				continue
+			}
+		}
+		count++
+		metricName, err := idxReader.LabelValueFor(p.At(), labels.MetricName)
+		if err != nil {
+			// Series without a metric name are counted, but excluded from the breakdown.
+			if errors.Is(err, storage.ErrNotFound) {
+				continue
+			}
+			return 0, nil, err
+		}
+		metricNamesCount[metricName]++
+	}
+	if p.Err() != nil {
+		return 0, nil, p.Err()
+	}
+
+	metricNames := make([]*client.MetricNameSeriesCount, 0, len(metricNamesCount))
+	for name, c := range metricNamesCount {
+		metricNames = append(metricNames, &client.MetricNameSeriesCount{MetricName: name, SeriesCount: c})
+	}
+	sort.Slice(metricNames, func(i, j int) bool {
+		return metricNames[i].MetricName < metricNames[j].MetricName
+	})
+	return count, metricNames, nil
+}
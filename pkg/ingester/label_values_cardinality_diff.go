@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/index"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// labelValuesCardinalityRecorder is a client.Ingester_LabelValuesCardinalityServer which records the sent items.
+// If the wrapped server is set, the messages are also forwarded to it.
+type labelValuesCardinalityRecorder struct {
+	client.Ingester_LabelValuesCardinalityServer
+	ctx context.Context
+
+	items   []*client.LabelValueSeriesCount
+	stopped bool
+}
+
+func (r *labelValuesCardinalityRecorder) Send(resp *client.LabelValuesCardinalityResponse) error {
+	r.items = append(r.items, resp.Items...)
+	r.stopped = r.stopped || resp.Stopped
+	if r.Ingester_LabelValuesCardinalityServer == nil {
+		return nil
+	}
+	return r.Ingester_LabelValuesCardinalityServer.Send(resp)
+}
+
+func (r *labelValuesCardinalityRecorder) Context() context.Context {
+	return r.ctx
+}
+
+// labelValuesCardinalityDeltas tracks the series count of each label value sent to a watch mode client,
+// in order to compute the changes to send in the following updates.
+type labelValuesCardinalityDeltas struct {
+	previous map[string]map[string]uint64
+}
+
+func newLabelValuesCardinalityDeltas(items []*client.LabelValueSeriesCount) *labelValuesCardinalityDeltas {
+	return &labelValuesCardinalityDeltas{previous: mergeLabelValueSeriesCounts(items)}
+}
+
+// update returns the changes of the series counts since the previous update, and records the current counts.
+// Label values which disappeared are returned with a negative delta equal to their previous count.
+func (d *labelValuesCardinalityDeltas) update(items []*client.LabelValueSeriesCount) []*client.LabelValueSeriesCount {
+	current := mergeLabelValueSeriesCounts(items)
+
+	lbNames := make([]string, 0, len(current))
+	for lbName := range current {
+		lbNames = append(lbNames, lbName)
+	}
+	for lbName := range d.previous {
+		if _, ok := current[lbName]; !ok {
+			lbNames = append(lbNames, lbName)
+		}
+	}
+	sort.Strings(lbNames)
+
+	var deltas []*client.LabelValueSeriesCount
+	for _, lbName := range lbNames {
+		prev, curr := d.previous[lbName], current[lbName]
+		lbDeltas := map[string]int64{}
+		for lbValue, count := range curr {
+			if delta := int64(count) - int64(prev[lbValue]); delta != 0 {
+				lbDeltas[lbValue] = delta
+			}
+		}
+		for lbValue, count := range prev {
+			if _, ok := curr[lbValue]; !ok {
+				lbDeltas[lbValue] = -int64(count)
+			}
+		}
+		if len(lbDeltas) > 0 {
+			deltas = append(deltas, &client.LabelValueSeriesCount{LabelName: lbName, LabelValueSeriesDelta: lbDeltas})
+		}
+	}
+
+	d.previous = current
+	return deltas
+}
+
+// mergeLabelValueSeriesCounts returns the series count of each label value, merging the items of the same label name.
+func mergeLabelValueSeriesCounts(items []*client.LabelValueSeriesCount) map[string]map[string]uint64 {
+	merged := map[string]map[string]uint64{}
+	for _, item := range items {
+		if merged[item.LabelName] == nil {
+			merged[item.LabelName] = map[string]uint64{}
+		}
+		for lbValue, count := range item.LabelValueSeries {
+			merged[item.LabelName][lbValue] = count
+		}
+	}
+	return merged
+}
+
+// labelValuesCardinalityWindow is a time window whose label values cardinality is compared by
+// labelValuesCardinalityDiff: only the series having samples between startMs and endMs are counted.
+type labelValuesCardinalityWindow struct {
+	startMs, endMs int64
+}
+
+// labelValuesCardinalityDiff computes the label values cardinality of the series matching the matchers in both windows,
+// and streams the change of the series count of each label value from window a to window b. Label values which are only
+// in window a are returned with a negative delta equal to their series count. The label values whose series count didn't
+// change are omitted.
+func labelValuesCardinalityDiff(
+	lbNames []string,
+	matchers []*labels.Matcher,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	a, b labelValuesCardinalityWindow,
+	msgSizeThreshold int,
+	opts labelValuesCardinalityOptions,
+	srv client.Ingester_LabelValuesCardinalityServer,
+) error {
+	deltas, stopped, err := collectLabelValuesCardinalityDeltas(srv.Context(), lbNames, matchers, idxReader, postingsForMatchersFn, a, b, msgSizeThreshold, opts, srv)
+	if err != nil || stopped {
+		return err
+	}
+	return sendLabelValuesCardinalityDeltas(srv, deltas, msgSizeThreshold)
+}
+
+// labelValuesCardinalityGrowthRate computes the label values cardinality of both windows, and streams the change of the
+// series count of each label value from window a to window b divided by the duration between the ends of the windows,
+// in series per second. Like with labelValuesCardinalityDiff, the label values whose series count didn't change are omitted.
+func labelValuesCardinalityGrowthRate(
+	lbNames []string,
+	matchers []*labels.Matcher,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	a, b labelValuesCardinalityWindow,
+	msgSizeThreshold int,
+	opts labelValuesCardinalityOptions,
+	srv client.Ingester_LabelValuesCardinalityServer,
+) error {
+	if b.endMs <= a.endMs {
+		return invalidLabelValuesCardinalityRequestError(fmt.Sprintf("invalid windows: the end %d of the second window must be after the end %d of the first one to compute the growth rate", b.endMs, a.endMs))
+	}
+	duration := time.Duration(b.endMs-a.endMs) * time.Millisecond
+	deltas, stopped, err := collectLabelValuesCardinalityDeltas(srv.Context(), lbNames, matchers, idxReader, postingsForMatchersFn, a, b, msgSizeThreshold, opts, srv)
+	if err != nil || stopped {
+		return err
+	}
+	return sendLabelValuesCardinalityChanges(srv, deltas, msgSizeThreshold, func(item *client.LabelValueSeriesCount, lbValue string, delta int64) {
+		if item.LabelValueSeriesGrowthRate == nil {
+			item.LabelValueSeriesGrowthRate = make(map[string]float64)
+		}
+		item.LabelValueSeriesGrowthRate[lbValue] = float64(delta) / duration.Seconds()
+	})
+}
+
+// collectLabelValuesCardinalityDeltas computes the label values cardinality of both windows, and returns the change
+// of the series count of each label value from window a to window b. If the request has been stopped by the client
+// while computing the cardinality, the stopped message is sent and the returned bool is true.
+func collectLabelValuesCardinalityDeltas(
+	ctx context.Context,
+	lbNames []string,
+	matchers []*labels.Matcher,
+	idxReader tsdb.IndexReader,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+	a, b labelValuesCardinalityWindow,
+	msgSizeThreshold int,
+	opts labelValuesCardinalityOptions,
+	srv client.Ingester_LabelValuesCardinalityServer,
+) ([]*client.LabelValueSeriesCount, bool, error) {
+	// Only the items of the windows are compared, so the options adding anything else to the messages are rejected.
+	if opts.stream.includeChecksums || opts.explain || opts.stream.progressInterval > 0 || opts.includeSummary || opts.bestEffort {
+		return nil, false, invalidLabelValuesCardinalityRequestError("the label values cardinality of two time windows can't be compared with the checksums, the explain, the progress messages, the summary or the best effort mode")
+	}
+
+	collect := func(w labelValuesCardinalityWindow) (*labelValuesCardinalityRecorder, error) {
+		windowOpts := opts
+		windowOpts.startMs, windowOpts.endMs = w.startMs, w.endMs
+		recorder := &labelValuesCardinalityRecorder{ctx: ctx}
+		if err := labelValuesCardinality(lbNames, matchers, idxReader, postingsForMatchersFn, msgSizeThreshold, windowOpts, recorder); err != nil {
+			return nil, err
+		}
+		return recorder, nil
+	}
+
+	recorderA, err := collect(a)
+	if err != nil {
+		return nil, false, err
+	}
+	var recorderB *labelValuesCardinalityRecorder
+	if !recorderA.stopped {
+		if recorderB, err = collect(b); err != nil {
+			return nil, false, err
+		}
+	}
+	if recorderA.stopped || recorderB.stopped {
+		return nil, true, client.SendLabelValuesCardinalityResponse(srv, &client.LabelValuesCardinalityResponse{Stopped: true})
+	}
+	return newLabelValuesCardinalityDeltas(recorderA.items).update(recorderB.items), false, nil
+}
+
+// sendLabelValuesCardinalityDeltas streams the deltas, splitting them in messages once they reach msgSizeThreshold.
+func sendLabelValuesCardinalityDeltas(srv client.Ingester_LabelValuesCardinalityServer, deltas []*client.LabelValueSeriesCount, msgSizeThreshold int) error {
+	return sendLabelValuesCardinalityChanges(srv, deltas, msgSizeThreshold, func(item *client.LabelValueSeriesCount, lbValue string, delta int64) {
+		if item.LabelValueSeriesDelta == nil {
+			item.LabelValueSeriesDelta = make(map[string]int64)
+		}
+		item.LabelValueSeriesDelta[lbValue] = delta
+	})
+}
+
+// sendLabelValuesCardinalityChanges streams the deltas, splitting them in messages once they reach msgSizeThreshold.
+// The delta of each label value is set in the response item with set.
+func sendLabelValuesCardinalityChanges(
+	srv client.Ingester_LabelValuesCardinalityServer,
+	deltas []*client.LabelValueSeriesCount,
+	msgSizeThreshold int,
+	set func(item *client.LabelValueSeriesCount, lbValue string, delta int64),
+) error {
+	resp := client.LabelValuesCardinalityResponse{}
+	respSize := 0
+
+	for _, delta := range deltas {
+		lbValues := make([]string, 0, len(delta.LabelValueSeriesDelta))
+		for lbValue := range delta.LabelValueSeriesDelta {
+			lbValues = append(lbValues, lbValue)
+		}
+		sort.Strings(lbValues)
+
+		var respItem *client.LabelValueSeriesCount
+		for _, lbValue := range lbValues {
+			if respItem == nil {
+				respItem = &client.LabelValueSeriesCount{LabelName: delta.LabelName}
+				resp.Items = append(resp.Items, respItem)
+			}
+			set(respItem, lbValue, delta.LabelValueSeriesDelta[lbValue])
+
+			respSize += len(lbValue)
+			if respSize < msgSizeThreshold {
+				continue
+			}
+			if err := client.SendLabelValuesCardinalityResponse(srv, &resp); err != nil {
+				return err
+			}
+			resp.Items = resp.Items[:0]
+			respSize = 0
+			respItem = nil
+		}
+	}
+	if len(resp.Items) > 0 {
+		return client.SendLabelValuesCardinalityResponse(srv, &resp)
+	}
+	return nil
+}
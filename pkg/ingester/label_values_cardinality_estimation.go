@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"sort"
+	"sync"
+
+	"github.com/beorn7/perks/quantile"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/storage"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/index"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/util/hll"
+)
+
+// labelValuesCardinalityEstimationOptions holds the label values cardinality features which estimate or sample
+// the series, instead of counting them exactly.
+type labelValuesCardinalityEstimationOptions struct {
+	// estimateLabelSeries enables estimating the number of distinct series of each label with a HyperLogLog sketch.
+	estimateLabelSeries bool
+	// orderByLabelSeries enables processing the labels in descending order of their estimated series,
+	// so that the most impactful labels are streamed first.
+	orderByLabelSeries bool
+	// seriesCountPercentiles, if not empty, are the percentiles of the distribution of the series count of the values
+	// of each label which are approximated with a streaming sketch.
+	seriesCountPercentiles []float64
+	// sampleValues, if greater than 0, is the maximum number of values of each label whose series are counted.
+	// The values are selected by their hash seeded with sampleSeed.
+	sampleValues int
+	sampleSeed   int64
+}
+
+// orderLabelNamesBySeries returns the label names in descending order of their series selected by the matchers.
+// The series of a label are estimated from the postings lengths of its values restricted to the postings of the
+// matchers, which are expanded once for all the labels. The labels with the same estimate keep their relative order.
+func orderLabelNamesBySeries(
+	ctx context.Context,
+	idxReader tsdb.IndexReader,
+	lbNames []string,
+	matchers []*labels.Matcher,
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+) ([]string, error) {
+	// Without matchers, the postings of the values aren't restricted.
+	var selected []storage.SeriesRef
+	if len(matchers) > 0 {
+		p, err := postingsForMatchersFn(idxReader, matchers...)
+		if err != nil {
+			return nil, err
+		}
+		if selected, err = index.ExpandPostings(p); err != nil {
+			return nil, err
+		}
+	}
+
+	labelSeries := make(map[string]int, len(lbNames))
+	for _, lbName := range lbNames {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		lbValues, err := idxReader.LabelValues(lbName)
+		if err != nil {
+			return nil, err
+		}
+		if len(lbValues) == 0 {
+			continue
+		}
+		// Each series has a single value of the label, so the postings of the values don't overlap.
+		p, err := idxReader.Postings(lbName, lbValues...)
+		if err != nil {
+			return nil, err
+		}
+		if len(matchers) > 0 {
+			p = index.Intersect(p, index.NewListPostings(selected))
+		}
+		for p.Next() {
+			labelSeries[lbName]++
+		}
+		if err := p.Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	ordered := make([]string, len(lbNames))
+	copy(ordered, lbNames)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return labelSeries[ordered[i]] > labelSeries[ordered[j]]
+	})
+	return ordered, nil
+}
+
+// sampleLabelValues returns the sampleValues label values with the lowest hash seeded with sampleSeed, keeping their
+// order. The selection only depends on the seed and on the values, so it's reproducible across requests and ingesters.
+func sampleLabelValues(lbValues []string, opts labelValuesCardinalityOptions) []string {
+	if opts.estimation.sampleValues <= 0 || len(lbValues) <= opts.estimation.sampleValues {
+		return lbValues
+	}
+	hashes := make([]uint64, len(lbValues))
+	indexes := make([]int, len(lbValues))
+	for i, lbValue := range lbValues {
+		hashes[i] = sampleLabelValueHash(opts.estimation.sampleSeed, lbValue)
+		indexes[i] = i
+	}
+	sort.Slice(indexes, func(i, j int) bool {
+		return hashes[indexes[i]] < hashes[indexes[j]]
+	})
+	indexes = indexes[:opts.estimation.sampleValues]
+	sort.Ints(indexes)
+
+	sampled := make([]string, 0, len(indexes))
+	for _, i := range indexes {
+		sampled = append(sampled, lbValues[i])
+	}
+	return sampled
+}
+
+// sampleLabelValueHash returns the fnv64a hash of the label value seeded with the seed, mixed with the murmur3
+// finalizer so that similar values are spread evenly.
+func sampleLabelValueHash(seed int64, lbValue string) uint64 {
+	var seedBytes [8]byte
+	binary.LittleEndian.PutUint64(seedBytes[:], uint64(seed))
+	h := fnv.New64a()
+	_, _ = h.Write(seedBytes[:])
+	_, _ = h.Write([]byte(lbValue))
+
+	v := h.Sum64()
+	v ^= v >> 33
+	v *= 0xff51afd7ed558ccd
+	v ^= v >> 33
+	v *= 0xc4ceb9fe1a85ec53
+	v ^= v >> 33
+	return v
+}
+
+// labelSeriesSketchPrecision is the precision of the sketches used to estimate the distinct series of a label.
+// It gives a relative standard error of about 0.8%, using 16KB of memory per label.
+const labelSeriesSketchPrecision = 14
+
+// labelSeriesSketch estimates the number of distinct series of a label. It's safe for concurrent use.
+type labelSeriesSketch struct {
+	mtx    sync.Mutex
+	sketch *hll.Sketch
+}
+
+func newLabelSeriesSketch() (*labelSeriesSketch, error) {
+	sketch, err := hll.New(labelSeriesSketchPrecision)
+	if err != nil {
+		return nil, err
+	}
+	return &labelSeriesSketch{sketch: sketch}, nil
+}
+
+func (s *labelSeriesSketch) add(ref storage.SeriesRef) {
+	s.mtx.Lock()
+	s.sketch.Add(hll.HashUint64(uint64(ref)))
+	s.mtx.Unlock()
+}
+
+func (s *labelSeriesSketch) estimate() uint64 {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.sketch.Estimate()
+}
+
+func (s *labelSeriesSketch) relativeError() float64 {
+	return s.sketch.RelativeError()
+}
+
+// wrapPostingsForMatchers returns a postingsForMatchersFn which adds the series iterated with Next() to the sketch.
+func (s *labelSeriesSketch) wrapPostingsForMatchers(
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error),
+) func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error) {
+	return func(r tsdb.IndexPostingsReader, matchers ...*labels.Matcher) (index.Postings, error) {
+		p, err := postingsForMatchersFn(r, matchers...)
+		if err != nil {
+			return nil, err
+		}
+		return &sketchPostings{Postings: p, sketch: s}, nil
+	}
+}
+
+type sketchPostings struct {
+	index.Postings
+	sketch *labelSeriesSketch
+}
+
+func (p *sketchPostings) Next() bool {
+	if !p.Postings.Next() {
+		return false
+	}
+	p.sketch.add(p.At())
+	return true
+}
+
+const (
+	// maxSeriesCountPercentiles is the maximum number of series count percentiles a request can ask for.
+	maxSeriesCountPercentiles = 16
+	// seriesCountPercentilesRankError is the maximum rank error of the approximated series count percentiles.
+	seriesCountPercentilesRankError = 0.001
+)
+
+// seriesCountPercentiles returns the approximate percentiles of the distribution of the series counts, computed
+// with a streaming sketch using bounded memory, or nil if no percentiles are requested or there are no series counts.
+func seriesCountPercentiles(seriesCounts []labelValueSeriesCount, percentiles []float64) []*client.SeriesCountPercentile {
+	if len(percentiles) == 0 || len(seriesCounts) == 0 {
+		return nil
+	}
+	targets := make(map[float64]float64, len(percentiles))
+	for _, p := range percentiles {
+		targets[p] = seriesCountPercentilesRankError
+	}
+	sketch := quantile.NewTargeted(targets)
+	for _, seriesCount := range seriesCounts {
+		sketch.Insert(float64(seriesCount.seriesCount))
+	}
+
+	result := make([]*client.SeriesCountPercentile, 0, len(percentiles))
+	for _, p := range percentiles {
+		result = append(result, &client.SeriesCountPercentile{Percentile: p, SeriesCount: sketch.Query(p)})
+	}
+	return result
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+
+	"github.com/grafana/mimir/pkg/util"
+)
+
+// labelCardinalityFingerprints holds the fingerprint of the series of each label, and the series counts of its
+// values, computed by the previous run of a periodic label values cardinality request. When it's set in the options,
+// only the labels whose fingerprint changed since the previous run are counted again, and the counts of the other
+// ones are reused. It's updated by each run, and it can be encoded to JSON to be persisted between the runs.
+type labelCardinalityFingerprints struct {
+	mtx sync.Mutex
+
+	// RequestKey identifies the matchers and the options selecting the label values counted by the previous run.
+	// The labels are discarded when a run doesn't select the label values in the same way.
+	RequestKey string                                  `json:"request_key"`
+	Labels     map[string]*labelCardinalityFingerprint `json:"labels"`
+}
+
+// labelCardinalityFingerprint is the fingerprint of the series of a label, and the series counts of its values.
+type labelCardinalityFingerprint struct {
+	Fingerprint  uint64   `json:"fingerprint"`
+	SeriesCounts []uint64 `json:"series_counts"`
+}
+
+// decodeLabelCardinalityFingerprints decodes the fingerprints encoded by the previous run of the request. Empty data
+// is decoded to empty fingerprints, for the first run.
+func decodeLabelCardinalityFingerprints(data []byte) (*labelCardinalityFingerprints, error) {
+	f := &labelCardinalityFingerprints{}
+	if len(data) == 0 {
+		return f, nil
+	}
+	if err := json.Unmarshal(data, f); err != nil {
+		return nil, invalidLabelValuesCardinalityRequestError(fmt.Sprintf("invalid changed labels state: %v", err))
+	}
+	return f, nil
+}
+
+// encode returns the fingerprints encoded to JSON, to be decoded by the next run of the request.
+func (f *labelCardinalityFingerprints) encode() ([]byte, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return json.Marshal(f)
+}
+
+// labelCardinalityFingerprintsRequestKey returns the key identifying how the label values are selected by the request.
+func labelCardinalityFingerprintsRequestKey(matchers []*labels.Matcher, opts labelValuesCardinalityOptions) string {
+	return fmt.Sprintf("%s shard=%d/%d sample=%d/%d", util.MatchersStringer(matchers), opts.shardIndex, opts.shardCount, opts.estimation.sampleValues, opts.estimation.sampleSeed)
+}
+
+// begin starts a run with the request key, discarding the labels of the previous run if its key is different.
+func (f *labelCardinalityFingerprints) begin(requestKey string) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	if f.RequestKey != requestKey || f.Labels == nil {
+		f.RequestKey = requestKey
+		f.Labels = map[string]*labelCardinalityFingerprint{}
+	}
+}
+
+// reuse returns the series counts of the label values computed by the previous run, if the fingerprint of the label
+// hasn't changed since then. The fingerprint covers the label values, so they're the same as in the previous run.
+func (f *labelCardinalityFingerprints) reuse(lbName string, fingerprint uint64, lbValues []string) ([]labelValueSeriesCount, bool) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	prev, ok := f.Labels[lbName]
+	if !ok || prev.Fingerprint != fingerprint || len(prev.SeriesCounts) != len(lbValues) {
+		return nil, false
+	}
+	counts := make([]labelValueSeriesCount, len(prev.SeriesCounts))
+	for i, c := range prev.SeriesCounts {
+		counts[i].seriesCount = c
+	}
+	return counts, true
+}
+
+// record stores the fingerprint of the label and the series counts of its values, to be reused by the next run.
+func (f *labelCardinalityFingerprints) record(lbName string, fingerprint uint64, seriesCounts []labelValueSeriesCount) {
+	counts := make([]uint64, len(seriesCounts))
+	for i, c := range seriesCounts {
+		counts[i] = c.seriesCount
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.Labels[lbName] = &labelCardinalityFingerprint{Fingerprint: fingerprint, SeriesCounts: counts}
+}
+
+// labelSeriesFingerprint returns the hash of the label values and of the references of their series. The series
+// references of the TSDB head are never reused, so the fingerprint changes whenever a series with one of the label
+// values is added or removed. Reading the postings of the label values is cheaper than counting their series,
+// which requires intersecting them with the postings of the matchers.
+func labelSeriesFingerprint(ctx context.Context, idxReader tsdb.IndexReader, lbName string, lbValues []string) (uint64, error) {
+	h := fnv.New64a()
+	buf := make([]byte, binary.MaxVarintLen64)
+	for _, lbValue := range lbValues {
+		if err := ctx.Err(); err != nil {
+			return 0, err
+		}
+		_, _ = h.Write([]byte(lbValue))
+		_, _ = h.Write([]byte{0xff})
+
+		p, err := idxReader.Postings(lbName, lbValue)
+		if err != nil {
+			return 0, err
+		}
+		for p.Next() {
+			n := binary.PutUvarint(buf, uint64(p.At()))
+			_, _ = h.Write(buf[:n])
+		}
+		if err := p.Err(); err != nil {
+			return 0, err
+		}
+		_, _ = h.Write([]byte{0xff})
+	}
+	return h.Sum64(), nil
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/index"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLabelValuesCardinality_ChangedLabelsOnly(t *testing.T) {
+	var inputSeries []labels.Labels
+	for i := 0; i < 10; i++ {
+		inputSeries = append(inputSeries, labels.FromStrings(labels.MetricName, "up", "pod", fmt.Sprintf("pod-%d", i), "zone", fmt.Sprintf("zone-%d", i%2)))
+	}
+	lbNames := []string{"pod", "zone"}
+	matchers := []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "up")}
+
+	// run computes the label values cardinality with the fingerprints of the previous run, and returns the series
+	// counts and the labels whose series have been counted.
+	run := func(t *testing.T, idxReader mockSeriesIndex, fingerprints *labelCardinalityFingerprints) (map[string]map[string]uint64, map[string]int) {
+		var (
+			mtx     sync.Mutex
+			counted = map[string]int{}
+		)
+		countingPostingsForMatchers := func(r tsdb.IndexPostingsReader, ms ...*labels.Matcher) (index.Postings, error) {
+			mtx.Lock()
+			counted[ms[len(ms)-1].Name]++
+			mtx.Unlock()
+			return idxReader.postingsForMatchers(r, ms...)
+		}
+
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		opts := labelValuesCardinalityOptions{changedLabelsOnly: fingerprints}
+		require.NoError(t, labelValuesCardinality(lbNames, matchers, idxReader, countingPostingsForMatchers, 1*1024*1024, opts, mockServer))
+		require.Len(t, mockServer.SentResponses, 1)
+		return mergeLabelValueSeriesCounts(mockServer.SentResponses[0].Items), counted
+	}
+
+	// expected returns the series counts computed from scratch.
+	expected := func(t *testing.T, idxReader mockSeriesIndex) map[string]map[string]uint64 {
+		mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+		require.NoError(t, labelValuesCardinality(lbNames, matchers, idxReader, idxReader.postingsForMatchers, 1*1024*1024, labelValuesCardinalityOptions{}, mockServer))
+		require.Len(t, mockServer.SentResponses, 1)
+		return mergeLabelValueSeriesCounts(mockServer.SentResponses[0].Items)
+	}
+
+	// The first run counts the series of all the labels.
+	idxReader := mockSeriesIndex{series: inputSeries}
+	fingerprints := &labelCardinalityFingerprints{}
+	counts, counted := run(t, idxReader, fingerprints)
+	require.Equal(t, expected(t, idxReader), counts)
+	require.Equal(t, map[string]int{"pod": 10, "zone": 2}, counted)
+
+	// The fingerprints are persisted between the runs.
+	data, err := json.Marshal(fingerprints)
+	require.NoError(t, err)
+	fingerprints = &labelCardinalityFingerprints{}
+	require.NoError(t, json.Unmarshal(data, fingerprints))
+
+	// Without any change, the counts of all the labels are reused.
+	counts, counted = run(t, idxReader, fingerprints)
+	require.Equal(t, expected(t, idxReader), counts)
+	require.Empty(t, counted)
+
+	// A series without the zone label only changes the pod label, whose series are counted again.
+	idxReader = mockSeriesIndex{series: append(inputSeries, labels.FromStrings(labels.MetricName, "up", "pod", "pod-new"))}
+	counts, counted = run(t, idxReader, fingerprints)
+	require.Equal(t, expected(t, idxReader), counts)
+	require.Equal(t, uint64(1), counts["pod"]["pod-new"])
+	require.Equal(t, map[string]int{"pod": 11}, counted)
+
+	// A series with an existing zone changes the zone label, even if its values are the same.
+	idxReader = mockSeriesIndex{series: append(idxReader.series, labels.FromStrings(labels.MetricName, "up", "zone", "zone-0"))}
+	counts, counted = run(t, idxReader, fingerprints)
+	require.Equal(t, expected(t, idxReader), counts)
+	require.Equal(t, uint64(6), counts["zone"]["zone-0"])
+	require.Equal(t, map[string]int{"zone": 2}, counted)
+
+	// The counts aren't reused when the label values are selected by different matchers.
+	matchers = []*labels.Matcher{labels.MustNewMatcher(labels.MatchRegexp, labels.MetricName, "up|down")}
+	counts, counted = run(t, idxReader, fingerprints)
+	require.Equal(t, expected(t, idxReader), counts)
+	require.Equal(t, map[string]int{"pod": 11, "zone": 2}, counted)
+}
+
+func TestLabelValuesCardinality_ChangedLabelsOnlyValidation(t *testing.T) {
+	idxReader := mockSeriesIndex{series: []labels.Labels{labels.FromStrings(labels.MetricName, "up", "pod", "pod-1")}}
+
+	for name, opts := range map[string]labelValuesCardinalityOptions{
+		"group by metric name": {groupByMetricName: true},
+		"chunk count":          {includeChunkCount: true},
+		"time range":           {startMs: 1000, endMs: 2000},
+	} {
+		t.Run(name, func(t *testing.T) {
+			opts.changedLabelsOnly = &labelCardinalityFingerprints{}
+			mockServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			err := labelValuesCardinality([]string{"pod"}, []*labels.Matcher{}, idxReader, idxReader.postingsForMatchers, 1024, opts, mockServer)
+			require.ErrorAs(t, err, new(invalidLabelValuesCardinalityRequestError))
+			require.EqualError(t, err, "only the series counts of the label values can be computed for the changed labels only")
+		})
+	}
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/gogo/status"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/promql/parser"
+	"google.golang.org/grpc/codes"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// LabelValuesCardinalityHandler streams the label values cardinality of the tenant as newline-delimited JSON,
+// one client.LabelValueSeriesCount per line, for debugging and tooling. The items of each message of the gRPC
+// response are flushed together, so that the lines are streamed in the same batches.
+func (i *Ingester) LabelValuesCardinalityHandler(w http.ResponseWriter, r *http.Request) {
+	serveLabelValuesCardinalityNDJSON(w, r, i.LabelValuesCardinality)
+}
+
+// labelValuesCardinalityErrorLine is the last line of a newline-delimited JSON label values cardinality response
+// which failed after some lines have been sent.
+type labelValuesCardinalityErrorLine struct {
+	Error string `json:"error"`
+}
+
+// serveLabelValuesCardinalityNDJSON parses the label values cardinality request of the HTTP request, and streams
+// the response of serve as newline-delimited JSON. If serve fails before any line has been sent, the HTTP status
+// reflects the error. Otherwise, the error is sent in a last line.
+func serveLabelValuesCardinalityNDJSON(
+	w http.ResponseWriter,
+	r *http.Request,
+	serve func(*client.LabelValuesCardinalityRequest, client.Ingester_LabelValuesCardinalityServer) error,
+) {
+	req, err := parseLabelValuesCardinalityHTTPRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	srv := &ndjsonLabelValuesCardinalityServer{ctx: r.Context(), w: w, enc: json.NewEncoder(w)}
+	err = serve(req, srv)
+	if err == nil {
+		return
+	}
+	if !srv.written {
+		http.Error(w, err.Error(), labelValuesCardinalityHTTPStatus(err))
+		return
+	}
+	_ = srv.enc.Encode(labelValuesCardinalityErrorLine{Error: err.Error()})
+}
+
+// parseLabelValuesCardinalityHTTPRequest parses the required label_names[] parameter, and the optional selector
+// parameter holding the matchers of the series.
+func parseLabelValuesCardinalityHTTPRequest(r *http.Request) (*client.LabelValuesCardinalityRequest, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+
+	labelNames := r.Form["label_names[]"]
+	if len(labelNames) == 0 {
+		return nil, fmt.Errorf("'label_names[]' param is required")
+	}
+	for _, name := range labelNames {
+		if !model.LabelName(name).IsValid() {
+			return nil, fmt.Errorf("invalid 'label_names' param '%v'", name)
+		}
+	}
+
+	var matchers []*labels.Matcher
+	switch selectors := r.Form["selector"]; len(selectors) {
+	case 0:
+	case 1:
+		var err error
+		if matchers, err = parser.ParseMetricSelector(selectors[0]); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("multiple 'selector' params are not allowed")
+	}
+	labelMatchers, err := client.ToLabelMatchers(matchers)
+	if err != nil {
+		return nil, err
+	}
+	return &client.LabelValuesCardinalityRequest{LabelNames: labelNames, Matchers: labelMatchers}, nil
+}
+
+// labelValuesCardinalityHTTPStatus returns the HTTP status of the label values cardinality request failed with the error.
+func labelValuesCardinalityHTTPStatus(err error) int {
+	if _, rejected := labelCardinalityRejectionReason(err); rejected {
+		return http.StatusBadRequest
+	}
+	if status.Code(err) == codes.Unavailable {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
+
+// ndjsonLabelValuesCardinalityServer is a client.Ingester_LabelValuesCardinalityServer writing the items of each
+// sent message as newline-delimited JSON, and flushing them once the message has been written.
+type ndjsonLabelValuesCardinalityServer struct {
+	client.Ingester_LabelValuesCardinalityServer
+	ctx context.Context
+	w   http.ResponseWriter
+	enc *json.Encoder
+
+	// written is set once some data has been written, after which the HTTP status can't be changed.
+	written bool
+}
+
+func (s *ndjsonLabelValuesCardinalityServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	// The messages without items, like the progress messages, have no line.
+	if len(resp.Items) == 0 {
+		return nil
+	}
+	for _, item := range resp.Items {
+		s.written = true
+		if err := s.enc.Encode(item); err != nil {
+			return err
+		}
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+func (s *ndjsonLabelValuesCardinalityServer) Context() context.Context {
+	return s.ctx
+}
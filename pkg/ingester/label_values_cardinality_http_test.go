@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gogo/status"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+func TestServeLabelValuesCardinalityNDJSON(t *testing.T) {
+	var series []labels.Labels
+	for i := 0; i < 20; i++ {
+		series = append(series, labels.FromStrings(labels.MetricName, fmt.Sprintf("metric_%d", i%2), "pod", fmt.Sprintf("pod-%d", i), "zone", fmt.Sprintf("zone-%d", i%3)))
+	}
+	idxReader := mockSeriesIndex{series: series}
+	// The small message size threshold splits the values of each label across messages.
+	const msgSizeThreshold = 20
+	serve := func(req *client.LabelValuesCardinalityRequest, srv client.Ingester_LabelValuesCardinalityServer) error {
+		matchers, err := client.FromLabelMatchers(req.GetMatchers())
+		if err != nil {
+			return err
+		}
+		return labelValuesCardinality(req.GetLabelNames(), matchers, idxReader, idxReader.postingsForMatchers, msgSizeThreshold, labelValuesCardinalityOptions{}, srv)
+	}
+
+	for name, tc := range map[string]struct {
+		query    string
+		matchers []*labels.Matcher
+	}{
+		"without selector": {
+			query: "label_names[]=pod&label_names[]=zone",
+		},
+		"with selector": {
+			query:    "label_names[]=pod&label_names[]=zone&selector=" + `{__name__="metric_1"}`,
+			matchers: []*labels.Matcher{labels.MustNewMatcher(labels.MatchEqual, labels.MetricName, "metric_1")},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// The expected batches are the messages streamed to a gRPC client.
+			grpcServer := &mockLabelValuesCardinalityServer{context: context.Background()}
+			require.NoError(t, labelValuesCardinality([]string{"pod", "zone"}, tc.matchers, idxReader, idxReader.postingsForMatchers, msgSizeThreshold, labelValuesCardinalityOptions{}, grpcServer))
+			require.Greater(t, len(grpcServer.SentResponses), 1)
+
+			rec := &flushRecordingResponseWriter{ResponseRecorder: httptest.NewRecorder()}
+			serveLabelValuesCardinalityNDJSON(rec, httptest.NewRequest(http.MethodGet, "/ingester/label-values-cardinality?"+tc.query, nil), serve)
+			require.Equal(t, http.StatusOK, rec.Code)
+			require.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+			// Each flush carries the items of a message, one per line.
+			require.Len(t, rec.flushes, len(grpcServer.SentResponses))
+			for i, resp := range grpcServer.SentResponses {
+				lines := readLabelValuesCardinalityNDJSON(t, rec.flushes[i])
+				require.Equal(t, resp.Items, lines, "message %d", i)
+			}
+		})
+	}
+}
+
+func TestServeLabelValuesCardinalityNDJSON_Errors(t *testing.T) {
+	item := &client.LabelValueSeriesCount{LabelName: "pod", LabelValueSeries: map[string]uint64{"pod-1": 1}}
+
+	for name, tc := range map[string]struct {
+		query          string
+		serveErr       error
+		sendFirst      bool
+		expectedStatus int
+		expectedBody   string
+	}{
+		"missing label names": {
+			query:          "selector={job=\"test\"}",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "'label_names[]' param is required",
+		},
+		"invalid label name": {
+			query:          "label_names[]=pod-name",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "invalid 'label_names' param 'pod-name'",
+		},
+		"invalid selector": {
+			query:          "label_names[]=pod&selector={job",
+			expectedStatus: http.StatusBadRequest,
+		},
+		"multiple selectors": {
+			query:          "label_names[]=pod&selector={job=\"a\"}&selector={job=\"b\"}",
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   "multiple 'selector' params are not allowed",
+		},
+		"rejected request": {
+			query:          "label_names[]=pod",
+			serveErr:       errLabelValuesCardinalityMaxSeriesExceeded,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   errLabelValuesCardinalityMaxSeriesExceeded.Error(),
+		},
+		"unavailable ingester": {
+			query:          "label_names[]=pod",
+			serveErr:       status.Error(codes.Unavailable, "Starting"),
+			expectedStatus: http.StatusServiceUnavailable,
+		},
+		"failure after some lines have been sent": {
+			query:          "label_names[]=pod",
+			serveErr:       fmt.Errorf("index corrupted"),
+			sendFirst:      true,
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"error":"index corrupted"}`,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			serve := func(_ *client.LabelValuesCardinalityRequest, srv client.Ingester_LabelValuesCardinalityServer) error {
+				if tc.sendFirst {
+					require.NoError(t, srv.Send(&client.LabelValuesCardinalityResponse{Items: []*client.LabelValueSeriesCount{item}}))
+				}
+				return tc.serveErr
+			}
+			rec := httptest.NewRecorder()
+			serveLabelValuesCardinalityNDJSON(rec, httptest.NewRequest(http.MethodGet, "/ingester/label-values-cardinality?"+tc.query, nil), serve)
+			require.Equal(t, tc.expectedStatus, rec.Code)
+			require.Contains(t, rec.Body.String(), tc.expectedBody)
+		})
+	}
+}
+
+// flushRecordingResponseWriter records the data written between two flushes.
+type flushRecordingResponseWriter struct {
+	*httptest.ResponseRecorder
+	flushed int
+	flushes []string
+}
+
+func (w *flushRecordingResponseWriter) Flush() {
+	body := w.Body.String()
+	w.flushes = append(w.flushes, body[w.flushed:])
+	w.flushed = len(body)
+	w.ResponseRecorder.Flush()
+}
+
+func readLabelValuesCardinalityNDJSON(t *testing.T, data string) []*client.LabelValueSeriesCount {
+	var items []*client.LabelValueSeriesCount
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		item := &client.LabelValueSeriesCount{}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), item))
+		items = append(items, item)
+	}
+	require.NoError(t, scanner.Err())
+	return items
+}
@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"os"
+	"runtime/pprof"
+	"sync"
+
+	"github.com/go-kit/log"
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/tenant"
+	grpc_metadata "google.golang.org/grpc/metadata"
+)
+
+// labelValuesCardinalityProfileHeader is the gRPC metadata key which enables profiling a label values cardinality request.
+const labelValuesCardinalityProfileHeader = "x-label-values-cardinality-profile"
+
+const labelValuesCardinalityProfileMaxFilesFlag = "ingester.label-values-cardinality-profile-max-files"
+
+var errInvalidLabelValuesCardinalityProfileMaxFiles = errors.New("the maximum number of label values cardinality request profiles, configured with -" + labelValuesCardinalityProfileMaxFilesFlag + ", must be greater than 0")
+
+// LabelValuesCardinalityProfileConfig configures the CPU profiling of the label values cardinality requests.
+type LabelValuesCardinalityProfileConfig struct {
+	Dir      string `yaml:"label_values_cardinality_profile_dir" category:"experimental"`
+	MaxFiles int    `yaml:"label_values_cardinality_profile_max_files" category:"experimental"`
+}
+
+func (cfg *LabelValuesCardinalityProfileConfig) RegisterFlags(f *flag.FlagSet) {
+	f.StringVar(&cfg.Dir, "ingester.label-values-cardinality-profile-dir", "", "Directory where the CPU profiles of the label values cardinality requests sent with the "+labelValuesCardinalityProfileHeader+" header are written. If empty, requests can't be profiled.")
+	f.IntVar(&cfg.MaxFiles, labelValuesCardinalityProfileMaxFilesFlag, 10, "Maximum number of CPU profiles of the label values cardinality requests kept in -ingester.label-values-cardinality-profile-dir. The oldest profiles are deleted when new ones are written, and all of them are deleted when the ingester stops.")
+}
+
+func (cfg *LabelValuesCardinalityProfileConfig) Validate() error {
+	if cfg.MaxFiles <= 0 {
+		return errInvalidLabelValuesCardinalityProfileMaxFiles
+	}
+	return nil
+}
+
+// labelValuesCardinalityProfiles writes the CPU profiles of the label values cardinality requests to a directory.
+// At most maxFiles profiles are kept: the oldest ones are deleted when new ones are written, and all of them are
+// deleted by removeAll. A nil labelValuesCardinalityProfiles never profiles any request.
+type labelValuesCardinalityProfiles struct {
+	dir      string
+	maxFiles int
+	logger   log.Logger
+
+	mtx sync.Mutex
+	// files holds the paths of the profiles which have been written, from the oldest to the newest.
+	files []string
+}
+
+func newLabelValuesCardinalityProfiles(dir string, maxFiles int, logger log.Logger) *labelValuesCardinalityProfiles {
+	if dir == "" {
+		return nil
+	}
+	return &labelValuesCardinalityProfiles{dir: dir, maxFiles: maxFiles, logger: logger}
+}
+
+// start starts a CPU profile of the request, if it has been sent with the profile header, and returns the function
+// to stop it. The profile is written to a temporary file in the directory. Since only one CPU profile can run at a
+// time in the process, the request isn't profiled if another profile is running.
+func (p *labelValuesCardinalityProfiles) start(ctx context.Context) (stop func()) {
+	noop := func() {}
+	if p == nil {
+		return noop
+	}
+	if md, ok := grpc_metadata.FromIncomingContext(ctx); !ok || len(md.Get(labelValuesCardinalityProfileHeader)) == 0 {
+		return noop
+	}
+	userID, err := tenant.TenantID(ctx)
+	if err != nil {
+		return noop
+	}
+	logger := log.With(p.logger, "user", userID)
+
+	f, err := os.CreateTemp(p.dir, "label-values-cardinality-*.pprof")
+	if err != nil {
+		level.Warn(logger).Log("msg", "failed to create the label values cardinality request profile file", "err", err)
+		return noop
+	}
+	if err := pprof.StartCPUProfile(f); err != nil {
+		level.Warn(logger).Log("msg", "failed to start the label values cardinality request profile", "err", err)
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return noop
+	}
+
+	return func() {
+		pprof.StopCPUProfile()
+		if err := f.Close(); err != nil {
+			level.Warn(logger).Log("msg", "failed to close the label values cardinality request profile file", "file", f.Name(), "err", err)
+			_ = os.Remove(f.Name())
+			return
+		}
+		level.Info(logger).Log("msg", "label values cardinality request profile written", "file", f.Name())
+		p.add(f.Name())
+	}
+}
+
+// add records the profile which has been written, and deletes the oldest profiles in excess of maxFiles.
+func (p *labelValuesCardinalityProfiles) add(file string) {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	p.files = append(p.files, file)
+	for len(p.files) > p.maxFiles {
+		p.remove(p.files[0])
+		p.files = p.files[1:]
+	}
+}
+
+// removeAll deletes all the profiles which have been written.
+func (p *labelValuesCardinalityProfiles) removeAll() {
+	if p == nil {
+		return
+	}
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	for _, file := range p.files {
+		p.remove(file)
+	}
+	p.files = nil
+}
+
+func (p *labelValuesCardinalityProfiles) remove(file string) {
+	if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+		level.Warn(p.logger).Log("msg", "failed to delete the label values cardinality request profile file", "file", file, "err", err)
+	}
+}
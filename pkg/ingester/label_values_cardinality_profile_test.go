@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-kit/log"
+	"github.com/stretchr/testify/require"
+	"github.com/weaveworks/common/user"
+	grpc_metadata "google.golang.org/grpc/metadata"
+)
+
+func TestLabelValuesCardinalityProfiles(t *testing.T) {
+	dir := t.TempDir()
+	p := newLabelValuesCardinalityProfiles(dir, 2, log.NewNopLogger())
+
+	var files []string
+	for _, name := range []string{"a.pprof", "b.pprof", "c.pprof"} {
+		file := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(file, []byte("profile"), 0o600))
+		files = append(files, file)
+		p.add(file)
+	}
+
+	// Only the newest profiles are kept.
+	require.NoFileExists(t, files[0])
+	require.FileExists(t, files[1])
+	require.FileExists(t, files[2])
+
+	p.removeAll()
+	require.NoFileExists(t, files[1])
+	require.NoFileExists(t, files[2])
+	require.Empty(t, p.files)
+}
+
+func TestLabelValuesCardinalityProfiles_Disabled(t *testing.T) {
+	p := newLabelValuesCardinalityProfiles("", 2, log.NewNopLogger())
+	require.Nil(t, p)
+
+	// A nil labelValuesCardinalityProfiles doesn't profile the requests.
+	ctx := user.InjectOrgID(context.Background(), "test")
+	ctx = grpc_metadata.NewIncomingContext(ctx, grpc_metadata.Pairs(labelValuesCardinalityProfileHeader, "true"))
+	p.start(ctx)()
+	p.removeAll()
+}
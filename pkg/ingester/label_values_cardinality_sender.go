@@ -0,0 +1,348 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"regexp"
+	"runtime"
+	"time"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/tsdb"
+	"github.com/prometheus/prometheus/tsdb/index"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// labelValuesCardinalitySender builds the messages of a label values cardinality response, and sends them with
+// their checksums, checking that a resumed response matches the messages which have already been received.
+type labelValuesCardinalitySender struct {
+	ctx              context.Context
+	srv              client.Ingester_LabelValuesCardinalityServer
+	msgSizeThreshold int
+	opts             labelValuesCardinalityOptions
+
+	resp     client.LabelValuesCardinalityResponse
+	respSize int
+
+	totalSeries            uint64
+	budgetWarningThreshold uint64
+
+	// explain is the timing breakdown of the request, if requested.
+	explain *client.LabelValuesCardinalityExplain
+
+	// The label values whose series couldn't be counted in best-effort mode, and the errors of the first ones.
+	failedLabelValues uint64
+	labelValuesErrors []string
+
+	// The summary of each label emitted so far.
+	summaries []*client.LabelValuesCardinalitySummary
+
+	sequenceNumber  uint64
+	byteOffset      uint64
+	runningChecksum uint32
+	// resumed is set once the response has reached the offset from which it's resumed.
+	resumed bool
+}
+
+func newLabelValuesCardinalitySender(ctx context.Context, srv client.Ingester_LabelValuesCardinalityServer, msgSizeThreshold int, opts labelValuesCardinalityOptions) *labelValuesCardinalitySender {
+	s := &labelValuesCardinalitySender{
+		ctx:                    ctx,
+		srv:                    srv,
+		msgSizeThreshold:       msgSizeThreshold,
+		opts:                   opts,
+		resp:                   client.LabelValuesCardinalityResponse{InstanceId: opts.instanceID},
+		budgetWarningThreshold: opts.budgetWarningThreshold(),
+		resumed:                opts.stream.resumeOffset == 0,
+	}
+	if opts.estimation.sampleValues > 0 {
+		s.resp.SampleSeed = opts.estimation.sampleSeed
+	}
+	if opts.explain {
+		s.explain = &client.LabelValuesCardinalityExplain{}
+	}
+	return s
+}
+
+// send sends the response, with its checksums if requested. The messages before the resume offset aren't sent.
+func (s *labelValuesCardinalitySender) send() error {
+	if s.opts.stream.includeChecksums {
+		prevRunningChecksum := s.runningChecksum
+		var size uint64
+		s.resp.SequenceNumber = s.sequenceNumber
+		s.resp.ByteOffset = s.byteOffset
+		s.resp.ItemsChecksum, s.runningChecksum, size = s.resp.ComputeChainedItemsChecksums(s.runningChecksum)
+		s.resp.RunningChecksum = s.runningChecksum
+		s.sequenceNumber++
+		s.byteOffset += size
+
+		if !s.resumed {
+			// The messages before the resume offset have already been received, and they must end at the offset.
+			if s.resp.ByteOffset < s.opts.stream.resumeOffset {
+				if s.byteOffset > s.opts.stream.resumeOffset {
+					return errLabelValuesCardinalityResumeMismatch
+				}
+				return nil
+			}
+			if prevRunningChecksum != s.opts.stream.resumeChecksum {
+				return errLabelValuesCardinalityResumeMismatch
+			}
+			s.resumed = true
+		}
+	}
+	if s.explain == nil {
+		return client.SendLabelValuesCardinalityResponse(s.srv, &s.resp)
+	}
+	start := time.Now()
+	err := client.SendLabelValuesCardinalityResponse(s.srv, &s.resp)
+	s.explain.SendingDurationNs += time.Since(start).Nanoseconds()
+	return err
+}
+
+// flush sends the items of the response, and resets it for the next items.
+func (s *labelValuesCardinalitySender) flush() error {
+	if err := s.send(); err != nil {
+		return err
+	}
+	s.resp.Items = s.resp.Items[:0]
+	s.respSize = 0
+	return nil
+}
+
+// sendLast sends the pending items in the last message, annotated with the timing breakdown if requested,
+// and with the failed label values and the summaries of the labels.
+func (s *labelValuesCardinalitySender) sendLast() error {
+	if s.explain != nil {
+		s.explain.Goroutines = uint32(runtime.NumGoroutine())
+		s.resp.Explain = s.explain
+	}
+	s.resp.FailedLabelValues = s.failedLabelValues
+	s.resp.Errors = s.labelValuesErrors
+	s.resp.Summaries = s.summaries
+	if s.opts.changedLabelsOnly != nil {
+		state, err := s.opts.changedLabelsOnly.encode()
+		if err != nil {
+			return err
+		}
+		s.resp.ChangedLabelsState = state
+	}
+	return s.send()
+}
+
+// sendStopped sends the pending items in a last message flagged as stopped.
+func (s *labelValuesCardinalitySender) sendStopped() error {
+	s.resp.Stopped = true
+	return s.sendLast()
+}
+
+// finish sends the response in case there are any pending items, or to carry the timing breakdown, the failed label
+// values, the summaries or the changed labels state. The items added after the last flush are below the message size
+// threshold, so this trailing flush is the only one sending them.
+func (s *labelValuesCardinalitySender) finish() error {
+	if len(s.resp.Items) > 0 || s.explain != nil || s.failedLabelValues > 0 || s.opts.includeSummary || s.opts.changedLabelsOnly != nil {
+		if err := s.sendLast(); err != nil {
+			return err
+		}
+	}
+	// The stream must not end before the resume offset. If it ends at the offset, all its messages have been received.
+	if !s.resumed && (s.byteOffset != s.opts.stream.resumeOffset || s.runningChecksum != s.opts.stream.resumeChecksum) {
+		return errLabelValuesCardinalityResumeMismatch
+	}
+	return nil
+}
+
+// addSeries accounts the series of a label value in the total of the response, flagging it with the budget warning
+// once the total reaches the warning threshold.
+func (s *labelValuesCardinalitySender) addSeries(seriesCount uint64) error {
+	s.totalSeries += seriesCount
+	if s.opts.limits.maxSeries > 0 && s.totalSeries > s.opts.limits.maxSeries {
+		return errLabelValuesCardinalityMaxSeriesExceeded
+	}
+	// Once set, the budget warning is kept in all the following messages.
+	if s.budgetWarningThreshold > 0 && s.totalSeries >= s.budgetWarningThreshold {
+		s.resp.BudgetWarning = true
+	}
+	return nil
+}
+
+// labelCardinalityEmitter adds the cardinality of the collected labels to the response.
+type labelCardinalityEmitter struct {
+	*labelValuesCardinalitySender
+
+	matchers              []*labels.Matcher
+	idxReader             tsdb.IndexReader
+	postingsForMatchersFn func(tsdb.IndexPostingsReader, ...*labels.Matcher) (index.Postings, error)
+	groupRegex            *regexp.Regexp
+}
+
+// emit adds the cardinality of the label to the response, flushing it when it reaches the size threshold.
+// It returns whether the request has been stopped by the client, in which case the last message has been sent.
+func (e *labelCardinalityEmitter) emit(lbName string, card labelCardinality) (stopped bool, _ error) {
+	opts := e.opts
+	if e.explain != nil {
+		e.explain.LabelValuesDurationNs += card.labelValuesDuration.Nanoseconds()
+		e.explain.CountingDurationNs += card.countingDuration.Nanoseconds()
+		if goroutines := uint32(opts.countingConcurrency(lbName, card.values, e.matchers)); goroutines > e.explain.CountingGoroutines {
+			e.explain.CountingGoroutines = goroutines
+		}
+	}
+	lbValues, seriesCounts, errs := withoutFailedLabelValues(card.values, card.seriesCounts)
+	e.failedLabelValues += uint64(len(errs))
+	for _, err := range errs {
+		if len(e.labelValuesErrors) < maxReportedLabelValuesErrors {
+			e.labelValuesErrors = append(e.labelValuesErrors, err.Error())
+		}
+	}
+	if e.groupRegex != nil {
+		// The groups are already sorted.
+		lbValues, seriesCounts = groupLabelValues(e.groupRegex, lbValues, seriesCounts)
+	} else if opts.sortValues {
+		lbValues, seriesCounts = sortLabelValuesSeriesCounts(lbValues, seriesCounts)
+	}
+	if opts.groupByMagnitude {
+		lbValues, seriesCounts = sortLabelValuesByMagnitude(lbValues, seriesCounts)
+	}
+	coOccurrenceValues := topLabelValues(lbValues, seriesCounts, opts.coOccurrenceTopK)
+	percentiles := seriesCountPercentiles(seriesCounts, opts.estimation.seriesCountPercentiles)
+
+	// Each series has a single value of the label, so the series of the label are the sum of the series of its values.
+	var labelSeries uint64
+	if opts.includeRatios {
+		for _, seriesCount := range seriesCounts {
+			labelSeries += seriesCount.seriesCount
+		}
+	}
+
+	var summary *client.LabelValuesCardinalitySummary
+	if opts.includeSummary {
+		summary = &client.LabelValuesCardinalitySummary{LabelName: lbName}
+		e.summaries = append(e.summaries, summary)
+	}
+
+	// For each value store the total number of series into cardinality response item.
+	var respItem *client.LabelValueSeriesCount
+
+	for lbValueIdx, lbValue := range lbValues {
+		if opts.stopped() {
+			return true, e.sendStopped()
+		}
+		seriesCount := seriesCounts[lbValueIdx]
+		if err := e.addSeries(seriesCount.seriesCount); err != nil {
+			return false, err
+		}
+
+		// The values with fewer series than the minimum are counted, but not returned.
+		if seriesCount.seriesCount < opts.minSeriesCount {
+			continue
+		}
+
+		// Each magnitude has its own items.
+		magnitude := seriesCountMagnitude(seriesCount.seriesCount)
+		if opts.groupByMagnitude && respItem != nil && respItem.SeriesCountMagnitude != magnitude {
+			respItem = nil
+		}
+
+		// Create label name response item entry.
+		if respItem == nil {
+			respItem = e.newItem(lbName, card, percentiles, magnitude)
+		}
+		_, coOccurrence := coOccurrenceValues[lbValueIdx]
+		valueKey, err := e.addLabelValue(respItem, lbValue, seriesCount, labelSeries, coOccurrence)
+		if err != nil {
+			return false, err
+		}
+		if summary != nil {
+			summary.LabelValuesCount++
+			summary.SeriesCount += seriesCount.seriesCount
+		}
+
+		e.respSize += len(valueKey)
+		if e.respSize < e.msgSizeThreshold {
+			continue
+		}
+		// Flush the response when reached message threshold.
+		if err := e.flush(); err != nil {
+			return false, err
+		}
+		respItem = nil
+	}
+	return false, nil
+}
+
+// newItem adds a new item of the label to the response.
+func (e *labelCardinalityEmitter) newItem(lbName string, card labelCardinality, percentiles []*client.SeriesCountPercentile, magnitude uint64) *client.LabelValueSeriesCount {
+	respItem := &client.LabelValueSeriesCount{
+		LabelName:        lbName,
+		LabelValueSeries: make(map[string]uint64),
+	}
+	if card.sketch != nil {
+		respItem.LabelSeriesEstimate = card.labelSeriesEstimate
+		respItem.LabelSeriesEstimateRelativeError = card.sketch.relativeError()
+	}
+	respItem.SeriesCountPercentiles = percentiles
+	if e.opts.groupByMagnitude {
+		respItem.SeriesCountMagnitude = magnitude
+	}
+	e.resp.Items = append(e.resp.Items, respItem)
+	return respItem
+}
+
+// addLabelValue adds the series count of the label value to the item, with its ratio of the series of the label,
+// its chunk count, its co-occurrences and its metric names if requested. It returns the key of the value in the item,
+// which is hashed if a salt is set.
+func (e *labelCardinalityEmitter) addLabelValue(respItem *client.LabelValueSeriesCount, lbValue string, seriesCount labelValueSeriesCount, labelSeries uint64, coOccurrence bool) (string, error) {
+	opts := e.opts
+	valueKey := lbValue
+	if opts.valueHashSalt != "" {
+		valueKey = hashLabelValue(opts.valueHashSalt, lbValue)
+	}
+	respItem.LabelValueSeries[valueKey] = seriesCount.seriesCount
+
+	if opts.includeRatios {
+		if respItem.LabelValueRatios == nil {
+			respItem.LabelValueRatios = make(map[string]float64)
+		}
+		ratio := 0.0
+		if labelSeries > 0 {
+			ratio = float64(seriesCount.seriesCount) / float64(labelSeries)
+		}
+		respItem.LabelValueRatios[valueKey] = ratio
+	}
+
+	if opts.includeChunkCount {
+		if respItem.LabelValueChunks == nil {
+			respItem.LabelValueChunks = make(map[string]uint64)
+		}
+		respItem.LabelValueChunks[valueKey] = seriesCount.chunkCount
+	}
+
+	if coOccurrence {
+		coOccurrences, err := countLabelValueCoOccurrences(e.ctx, e.idxReader, e.postingsForMatchersFn, respItem.LabelName, lbValue, e.matchers, opts.coOccurrenceTopK, opts.contextCheckSeriesInterval())
+		if err != nil {
+			return "", err
+		}
+		if respItem.LabelValueCoOccurrences == nil {
+			respItem.LabelValueCoOccurrences = make(map[string]*client.LabelValueCoOccurrences)
+		}
+		for _, c := range coOccurrences {
+			if opts.valueHashSalt != "" {
+				c.LabelValue = hashLabelValue(opts.valueHashSalt, c.LabelValue)
+			}
+			e.respSize += len(c.LabelName) + len(c.LabelValue)
+		}
+		respItem.LabelValueCoOccurrences[valueKey] = &client.LabelValueCoOccurrences{Items: coOccurrences}
+	}
+
+	if opts.groupByMetricName {
+		if respItem.LabelValueMetricNamesSeries == nil {
+			respItem.LabelValueMetricNamesSeries = make(map[string]*client.MetricNamesSeriesCount)
+		}
+		metricNames := topMetricNames(seriesCount.metricNames, opts.metricNamesTopK)
+		respItem.LabelValueMetricNamesSeries[valueKey] = &client.MetricNamesSeriesCount{Items: metricNames}
+		for _, m := range metricNames {
+			e.respSize += len(m.MetricName)
+		}
+	}
+	return valueKey, nil
+}
@@ -0,0 +1,238 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package ingester
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/grafana/mimir/pkg/ingester/client"
+)
+
+// labelValuesCardinalityStreamOptions holds how the client controls the stream of the label values cardinality response.
+type labelValuesCardinalityStreamOptions struct {
+	// includeChecksums enables setting the sequence number, the items checksum, the byte offset and the running
+	// checksum on each message.
+	includeChecksums bool
+	// resumeOffset, if greater than 0, is the byte offset of the stream from which the response is resumed. The
+	// messages before it aren't sent, and resumeChecksum must be the running checksum of the stream at the offset.
+	resumeOffset   uint64
+	resumeChecksum uint32
+	// progressInterval, if greater than 0, is the interval at which progress messages are sent while counting series.
+	progressInterval time.Duration
+	// sendStallTimeout, if greater than 0, is the maximum time sending a message can block before the request is aborted.
+	sendStallTimeout time.Duration
+	// stop, if set, is closed when the client asks to stop the request. The pending items are then sent
+	// in a last message flagged as stopped.
+	stop <-chan struct{}
+	// pause, if set, holds the sending of the messages and the counting of the series while the client
+	// has paused the request.
+	pause *labelValuesCardinalityPause
+}
+
+// labelValuesCardinalityPause tracks whether the client has paused a label values cardinality request.
+type labelValuesCardinalityPause struct {
+	mtx sync.Mutex
+	// resumed is closed when the request is resumed. It's nil while the request isn't paused.
+	resumed chan struct{}
+}
+
+func (p *labelValuesCardinalityPause) pause() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.resumed == nil {
+		p.resumed = make(chan struct{})
+	}
+}
+
+func (p *labelValuesCardinalityPause) resume() {
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+	if p.resumed != nil {
+		close(p.resumed)
+		p.resumed = nil
+	}
+}
+
+// wait blocks while the request is paused, until it's resumed, stopped, or the context is done.
+// It's a no-op on a nil pause.
+func (p *labelValuesCardinalityPause) wait(ctx context.Context, stop <-chan struct{}) error {
+	if p == nil {
+		return nil
+	}
+	p.mtx.Lock()
+	resumed := p.resumed
+	p.mtx.Unlock()
+	if resumed == nil {
+		return nil
+	}
+
+	select {
+	case <-resumed:
+		return nil
+	case <-stop:
+		// The pending items of a stopped request are sent without waiting for the request to be resumed.
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// pausableLabelValuesCardinalityServer holds the messages while the request is paused.
+type pausableLabelValuesCardinalityServer struct {
+	client.Ingester_LabelValuesCardinalityServer
+	pause *labelValuesCardinalityPause
+	stop  <-chan struct{}
+}
+
+func (s *pausableLabelValuesCardinalityServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	if err := s.pause.wait(s.Context(), s.stop); err != nil {
+		return err
+	}
+	return s.Ingester_LabelValuesCardinalityServer.Send(resp)
+}
+
+// sendStallTimeoutServer aborts the request if sending a message blocks for longer than the timeout, for example
+// because the client stopped reading the stream. When that happens, its context is done, so that the goroutines
+// counting series stop, and the context error is context.DeadlineExceeded. The messages are sent by the calling
+// goroutine: a stalled send returns once the underlying stream is done, since it can't be abandoned in flight,
+// and no message is sent after it.
+type sendStallTimeoutServer struct {
+	client.Ingester_LabelValuesCardinalityServer
+	ctx     context.Context
+	cancel  context.CancelFunc
+	timeout time.Duration
+
+	stallOnce sync.Once
+	stalled   atomic.Bool
+	// stallDone is closed once the request has been flagged as stalled and its context cancelled.
+	stallDone chan struct{}
+}
+
+func newSendStallTimeoutServer(srv client.Ingester_LabelValuesCardinalityServer, timeout time.Duration) *sendStallTimeoutServer {
+	s := &sendStallTimeoutServer{Ingester_LabelValuesCardinalityServer: srv, timeout: timeout, stallDone: make(chan struct{})}
+	ctx, cancel := context.WithCancel(srv.Context())
+	s.ctx = &sendStallContext{Context: ctx, server: s}
+	s.cancel = cancel
+	return s
+}
+
+func (s *sendStallTimeoutServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	if s.isStalled() {
+		return context.DeadlineExceeded
+	}
+
+	timer := time.AfterFunc(s.timeout, s.stall)
+	err := s.Ingester_LabelValuesCardinalityServer.Send(resp)
+	if !timer.Stop() {
+		// The timer has fired: wait until the request is aborted, so that the stall is never reported late.
+		<-s.stallDone
+	}
+	if s.isStalled() {
+		return context.DeadlineExceeded
+	}
+	return err
+}
+
+// stall flags the request as stalled and cancels its context.
+func (s *sendStallTimeoutServer) stall() {
+	s.stallOnce.Do(func() {
+		s.stalled.Store(true)
+		s.cancel()
+		close(s.stallDone)
+	})
+}
+
+func (s *sendStallTimeoutServer) Context() context.Context {
+	return s.ctx
+}
+
+func (s *sendStallTimeoutServer) isStalled() bool {
+	return s.stalled.Load()
+}
+
+// sendStallContext is the context of a sendStallTimeoutServer, returning context.DeadlineExceeded once a send has stalled.
+type sendStallContext struct {
+	context.Context
+	server *sendStallTimeoutServer
+}
+
+func (c *sendStallContext) Err() error {
+	if c.server.isStalled() {
+		return context.DeadlineExceeded
+	}
+	return c.Context.Err()
+}
+
+// serializedLabelValuesCardinalityServer serializes the messages sent from multiple goroutines.
+type serializedLabelValuesCardinalityServer struct {
+	client.Ingester_LabelValuesCardinalityServer
+	mtx sync.Mutex
+}
+
+func (s *serializedLabelValuesCardinalityServer) Send(resp *client.LabelValuesCardinalityResponse) error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.Ingester_LabelValuesCardinalityServer.Send(resp)
+}
+
+// labelValuesCardinalityProgress tracks the progress of a label values cardinality request.
+// It's safe for concurrent use, and a nil progress tracks nothing.
+type labelValuesCardinalityProgress struct {
+	labelValues atomic.Uint64
+	series      atomic.Uint64
+}
+
+// labelValueCounted records that the series of a label value have been counted.
+func (p *labelValuesCardinalityProgress) labelValueCounted(seriesCount uint64) {
+	if p == nil {
+		return
+	}
+	p.labelValues.Inc()
+	p.series.Add(seriesCount)
+}
+
+// report sends a progress message at every interval, until the returned function is called. The returned
+// function waits until no progress message is being sent, so that the caller can safely send the following
+// messages, and returns the error occurred sending a progress message, if any.
+func (p *labelValuesCardinalityProgress) report(srv client.Ingester_LabelValuesCardinalityServer, interval time.Duration) (stop func() error) {
+	if p == nil || interval <= 0 {
+		return func() error { return nil }
+	}
+
+	done := make(chan struct{})
+	var sendErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+			}
+			// The progress messages aren't part of the checksum chain, because they aren't sent at the same
+			// points of the stream by two runs of the same request, which couldn't be resumed otherwise.
+			resp := &client.LabelValuesCardinalityResponse{Progress: &client.LabelValuesCardinalityProgress{
+				LabelValues: p.labelValues.Load(),
+				Series:      p.series.Load(),
+			}}
+			if err := client.SendLabelValuesCardinalityResponse(srv, resp); err != nil {
+				sendErr = err
+				return
+			}
+		}
+	}()
+
+	return func() error {
+		close(done)
+		wg.Wait()
+		return sendErr
+	}
+}
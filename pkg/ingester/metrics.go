@@ -6,6 +6,10 @@
 package ingester
 
 import (
+	"context"
+	"sync"
+
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.uber.org/atomic"
@@ -49,6 +53,62 @@ type ingesterMetrics struct {
 	appenderAddDuration    prometheus.Histogram
 	appenderCommitDuration prometheus.Histogram
 	idleTsdbChecks         *prometheus.CounterVec
+
+	// Label names and values streaming endpoints metrics.
+	labelStreamTerminations                    *prometheus.CounterVec
+	labelValuesCardinalityInflightLabelValues  prometheus.Gauge
+	labelValuesCardinalityInflightLabels       prometheus.Gauge
+	labelValuesCardinalityEmptyResultCacheHits prometheus.Counter
+	labelCardinalityRejected                   *prometheus.CounterVec
+	labelCardinalityRejectedTenantBuckets      *tenantBuckets
+}
+
+const (
+	labelStreamEndpointLabelNamesAndValues    = "label_names_and_values"
+	labelStreamEndpointLabelValuesCardinality = "label_values_cardinality"
+
+	labelStreamTerminationCancelled        = "cancelled"
+	labelStreamTerminationDeadlineExceeded = "deadline_exceeded"
+
+	labelCardinalityRejectedMaxSeriesExceeded      = "max_series_exceeded"
+	labelCardinalityRejectedInvalidRequest         = "invalid_request"
+	labelCardinalityRejectedTooManySelectedSeries  = "too_many_selected_series"
+	labelCardinalityRejectedTooManyRegexCandidates = "too_many_regex_candidate_values"
+
+	// labelCardinalityRejectedMaxTenantBuckets is the maximum number of tenants tracked in their own bucket
+	// by cortex_ingester_label_cardinality_rejected_total.
+	labelCardinalityRejectedMaxTenantBuckets = 10
+)
+
+// otherTenantBucket is the bucket shared by the tenants exceeding the maximum number of tenant buckets.
+const otherTenantBucket = "other"
+
+// tenantBuckets bounds the cardinality of per-tenant metrics: the first maxTenants tenants get their own
+// bucket, named after the tenant ID, while the following ones share the "other" bucket.
+type tenantBuckets struct {
+	maxTenants int
+
+	mtx     sync.Mutex
+	tenants map[string]struct{}
+}
+
+func newTenantBuckets(maxTenants int) *tenantBuckets {
+	return &tenantBuckets{maxTenants: maxTenants, tenants: map[string]struct{}{}}
+}
+
+// bucket returns the bucket of the tenant, assigning it a bucket of its own if there's still room.
+func (b *tenantBuckets) bucket(userID string) string {
+	b.mtx.Lock()
+	defer b.mtx.Unlock()
+
+	if _, ok := b.tenants[userID]; ok {
+		return userID
+	}
+	if len(b.tenants) < b.maxTenants {
+		b.tenants[userID] = struct{}{}
+		return userID
+	}
+	return otherTenantBucket
 }
 
 func newIngesterMetrics(
@@ -80,6 +140,16 @@ func newIngesterMetrics(
 	idleTsdbChecks.WithLabelValues(string(tsdbTenantMarkedForDeletion))
 	idleTsdbChecks.WithLabelValues(string(tsdbIdleClosed))
 
+	labelStreamTerminations := promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+		Name: "cortex_ingester_label_stream_terminations_total",
+		Help: "The total number of label names and values streaming requests terminated because their context was cancelled or its deadline exceeded.",
+	}, []string{"endpoint", "reason"})
+
+	for _, endpoint := range []string{labelStreamEndpointLabelNamesAndValues, labelStreamEndpointLabelValuesCardinality} {
+		labelStreamTerminations.WithLabelValues(endpoint, labelStreamTerminationCancelled)
+		labelStreamTerminations.WithLabelValues(endpoint, labelStreamTerminationDeadlineExceeded)
+	}
+
 	// Active series metrics are registered only if enabled.
 	var activeSeriesReg prometheus.Registerer
 	if activeSeriesEnabled {
@@ -258,11 +328,46 @@ func newIngesterMetrics(
 		}),
 
 		idleTsdbChecks: idleTsdbChecks,
+
+		labelStreamTerminations: labelStreamTerminations,
+		labelValuesCardinalityInflightLabelValues: promauto.With(r).NewGauge(prometheus.GaugeOpts{
+			Name: "cortex_ingester_label_values_cardinality_inflight_label_values",
+			Help: "The current number of label values whose series are being counted by label values cardinality requests.",
+		}),
+		labelValuesCardinalityInflightLabels: promauto.With(r).NewGauge(prometheus.GaugeOpts{
+			Name: "cortex_ingester_label_values_cardinality_inflight_labels",
+			Help: "The current number of labels being processed by label values cardinality requests.",
+		}),
+		labelValuesCardinalityEmptyResultCacheHits: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Name: "cortex_ingester_label_values_cardinality_empty_result_cache_hits_total",
+			Help: "The total number of label values cardinality requests served from the cache of the requests which returned an empty result.",
+		}),
+		labelCardinalityRejected: promauto.With(r).NewCounterVec(prometheus.CounterOpts{
+			Name: "cortex_ingester_label_cardinality_rejected_total",
+			Help: "The total number of label values cardinality requests rejected by the ingester, by tenant bucket and reason. Only a limited number of tenants get their own bucket, the other ones are tracked in the \"" + otherTenantBucket + "\" bucket.",
+		}, []string{"tenant_bucket", "reason"}),
+		labelCardinalityRejectedTenantBuckets: newTenantBuckets(labelCardinalityRejectedMaxTenantBuckets),
 	}
 
 	return m
 }
 
+// observeLabelStreamTermination tracks the termination of a label names and values streaming request,
+// if the error is caused by the request context being cancelled or its deadline exceeded.
+func (m *ingesterMetrics) observeLabelStreamTermination(endpoint string, err error) {
+	switch {
+	case errors.Is(err, context.Canceled):
+		m.labelStreamTerminations.WithLabelValues(endpoint, labelStreamTerminationCancelled).Inc()
+	case errors.Is(err, context.DeadlineExceeded):
+		m.labelStreamTerminations.WithLabelValues(endpoint, labelStreamTerminationDeadlineExceeded).Inc()
+	}
+}
+
+// observeLabelCardinalityRejection tracks the rejection of a label values cardinality request of the tenant.
+func (m *ingesterMetrics) observeLabelCardinalityRejection(userID, reason string) {
+	m.labelCardinalityRejected.WithLabelValues(m.labelCardinalityRejectedTenantBuckets.bucket(userID), reason).Inc()
+}
+
 func (m *ingesterMetrics) deletePerUserMetrics(userID string) {
 	m.ingestedSamples.DeleteLabelValues(userID)
 	m.ingestedSamplesFail.DeleteLabelValues(userID)
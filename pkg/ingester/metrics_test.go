@@ -754,3 +754,16 @@ func populateTSDBMetrics(base float64) *prometheus.Registry {
 
 	return r
 }
+
+func TestTenantBuckets(t *testing.T) {
+	buckets := newTenantBuckets(2)
+
+	require.Equal(t, "user-1", buckets.bucket("user-1"))
+	require.Equal(t, "user-2", buckets.bucket("user-2"))
+	require.Equal(t, otherTenantBucket, buckets.bucket("user-3"))
+	require.Equal(t, otherTenantBucket, buckets.bucket("user-4"))
+
+	// Tenants which got their own bucket keep it.
+	require.Equal(t, "user-1", buckets.bucket("user-1"))
+	require.Equal(t, "user-2", buckets.bucket("user-2"))
+}
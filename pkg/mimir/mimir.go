@@ -73,6 +73,8 @@ import (
 
 var errInvalidBucketConfig = errors.New("invalid bucket config")
 
+var errInvalidIngesterReplicationFactor = errors.New("invalid ingester replication factor")
+
 // The design pattern for Mimir is a series of config objects, which are
 // registered for command line flags, and then a series of components that
 // are instantiated and composed.  Some rules of thumb:
@@ -224,6 +226,12 @@ func (c *Config) Validate(log log.Logger) error {
 	if err := c.IngesterClient.Validate(log); err != nil {
 		return errors.Wrap(err, "invalid ingester_client config")
 	}
+	if err := c.Ingester.Validate(); err != nil {
+		return errors.Wrap(err, "invalid ingester config")
+	}
+	if err := c.validateIngesterReplicationFactor(log); err != nil {
+		return err
+	}
 	if err := c.Worker.Validate(log); err != nil {
 		return errors.Wrap(err, "invalid frontend_worker config")
 	}
@@ -250,10 +258,40 @@ func (c *Config) Validate(log log.Logger) error {
 	return nil
 }
 
+// validateIngesterReplicationFactor checks that the ingester ring can have enough instances to satisfy the replication
+// factor. If it can't, an error is returned when the strict validation is enabled, and a warning is logged otherwise.
+func (c *Config) validateIngesterReplicationFactor(logger log.Logger) error {
+	ringCfg := c.Ingester.IngesterRing
+	// The inmemory KV store isn't shared between processes, so the ring only has the ingester of the process.
+	if ringCfg.ReplicationFactor <= 1 || ringCfg.KVStore.Store != "inmemory" || !c.isAnyModuleEnabled(All, Write, Ingester) {
+		return nil
+	}
+	err := fmt.Errorf("%w: the replication factor is %d, but the ingester ring can only have 1 instance because it's stored in the inmemory KV store, so every write will fail", errInvalidIngesterReplicationFactor, ringCfg.ReplicationFactor)
+	if ringCfg.StrictReplicationFactorValidation {
+		return err
+	}
+	level.Warn(logger).Log("msg", "the ingester ring can't satisfy the replication factor", "err", err)
+	return nil
+}
+
 func (c *Config) isModuleEnabled(m string) bool {
 	return util.StringsContain(c.Target, m)
 }
 
+// uniqueTargets returns the input targets without duplicates, preserving their order.
+func uniqueTargets(targets []string) []string {
+	seen := make(map[string]struct{}, len(targets))
+	unique := make([]string, 0, len(targets))
+	for _, target := range targets {
+		if _, ok := seen[target]; ok {
+			continue
+		}
+		seen[target] = struct{}{}
+		unique = append(unique, target)
+	}
+	return unique
+}
+
 func (c *Config) isAnyModuleEnabled(modules ...string) bool {
 	for _, m := range modules {
 		if c.isModuleEnabled(m) {
@@ -267,6 +305,17 @@ func (c *Config) isAnyModuleEnabled(modules ...string) bool {
 func (c *Config) validateBucketConfigs() error {
 	errs := multierror.New()
 
+	// Validate that only the selected backend is configured in each bucket config. The values inherited from the
+	// common storage config aren't conflicting, and the bucket configs are ignored by the local ruler and alertmanager
+	// stores.
+	errs.Add(errors.Wrap(c.BlocksStorage.Bucket.ValidateConflictingBackends(&c.Common.Storage), "blocks storage"))
+	if c.RulerStorage.Backend != rulestorelocal.Name {
+		errs.Add(errors.Wrap(c.RulerStorage.ValidateConflictingBackends(&c.Common.Storage), "ruler storage"))
+	}
+	if c.AlertmanagerStorage.Backend != alertstorelocal.Name {
+		errs.Add(errors.Wrap(c.AlertmanagerStorage.ValidateConflictingBackends(&c.Common.Storage), "alertmanager storage"))
+	}
+
 	// Validate alertmanager bucket config.
 	if c.isAnyModuleEnabled(AlertManager, Backend) && c.AlertmanagerStorage.Backend != alertstorelocal.Name {
 		errs.Add(errors.Wrap(validateBucketConfig(c.AlertmanagerStorage.Config, c.BlocksStorage.Bucket), "alertmanager storage"))
@@ -391,7 +440,7 @@ func (c *Config) validateFilesystemPaths(logger log.Logger) error {
 	}
 
 	// Alertmanager.
-	if c.isAnyModuleEnabled(AlertManager) {
+	if c.isAnyModuleEnabled(AlertManager, Backend) {
 		paths = append(paths, pathConfig{
 			name:       "alertmanager data directory",
 			cfgValue:   c.Alertmanager.DataDir,
@@ -621,6 +670,8 @@ type Mimir struct {
 	// set during initialization
 	ServiceMap    map[string]services.Service
 	ModuleManager *modules.Manager
+	// moduleDependencies are the direct dependencies of each module registered in the module manager.
+	moduleDependencies map[string][]string
 
 	API                      *api.API
 	Server                   *server.Server
@@ -649,6 +700,8 @@ type Mimir struct {
 
 	// Queryables that the querier should use to query the long term storage.
 	StoreQueryables []querier.QueryableWithFilter
+
+	shutdownHooks shutdownHooks
 }
 
 // New makes a new Mimir.
@@ -660,6 +713,10 @@ func New(cfg Config, reg prometheus.Registerer) (*Mimir, error) {
 		os.Exit(0)
 	}
 
+	// The same module could be listed multiple times, e.g. when combining the
+	// "write" target with other targets, so we keep only the first occurrence.
+	cfg.Target = uniqueTargets(cfg.Target)
+
 	// Swap out the default resolver to support multiple tenant IDs separated by a '|'
 	if cfg.TenantFederation.Enabled {
 		tenant.WithDefaultResolver(tenant.NewMultiResolver())
@@ -812,6 +869,12 @@ func (t *Mimir) Run() error {
 			}
 		}
 	}
+
+	// The shutdown hooks run once all the services have stopped. Their errors have been logged,
+	// and they're reported only if the services stopped without problems.
+	if hooksErr := t.runShutdownHooks(); err == nil {
+		err = hooksErr
+	}
 	return err
 }
 
@@ -37,6 +37,7 @@ import (
 
 	"github.com/grafana/mimir/pkg/alertmanager"
 	"github.com/grafana/mimir/pkg/alertmanager/alertstore"
+	alertstorelocal "github.com/grafana/mimir/pkg/alertmanager/alertstore/local"
 	"github.com/grafana/mimir/pkg/cache"
 	"github.com/grafana/mimir/pkg/compactor"
 	"github.com/grafana/mimir/pkg/distributor"
@@ -44,6 +45,7 @@ import (
 	"github.com/grafana/mimir/pkg/ingester"
 	"github.com/grafana/mimir/pkg/ruler"
 	"github.com/grafana/mimir/pkg/ruler/rulestore"
+	rulestorelocal "github.com/grafana/mimir/pkg/ruler/rulestore/local"
 	"github.com/grafana/mimir/pkg/scheduler/schedulerpb"
 	"github.com/grafana/mimir/pkg/storage/bucket"
 	"github.com/grafana/mimir/pkg/storage/bucket/filesystem"
@@ -152,6 +154,7 @@ func TestMimir(t *testing.T) {
 
 	tests := map[string]struct {
 		target                  []string
+		expectedTarget          []string
 		expectedEnabledModules  []string
 		expectedDisabledModules []string
 	}{
@@ -180,6 +183,17 @@ func TestMimir(t *testing.T) {
 			expectedEnabledModules:  []string{QueryScheduler, Ruler, StoreGateway, Compactor, AlertManager},
 			expectedDisabledModules: []string{IngesterService, QueryFrontend, Querier},
 		},
+		"-target=write,ingester,write": {
+			target:                  []string{Write, Ingester, Write},
+			expectedTarget:          []string{Write, Ingester},
+			expectedEnabledModules:  []string{DistributorService, IngesterService},
+			expectedDisabledModules: []string{Querier, Ruler, StoreGateway, Compactor, AlertManager},
+		},
+		"-target=write,read": {
+			target:                  []string{Write, Read},
+			expectedEnabledModules:  []string{DistributorService, IngesterService, QueryFrontend, Querier},
+			expectedDisabledModules: []string{Ruler, StoreGateway, Compactor, AlertManager},
+		},
 	}
 
 	for testName, testData := range tests {
@@ -188,7 +202,13 @@ func TestMimir(t *testing.T) {
 			c, err := New(cfg, prometheus.NewPedanticRegistry())
 			require.NoError(t, err)
 
-			serviceMap, err := c.ModuleManager.InitModuleServices(cfg.Target...)
+			expectedTarget := testData.expectedTarget
+			if expectedTarget == nil {
+				expectedTarget = testData.target
+			}
+			require.Equal(t, expectedTarget, []string(c.Cfg.Target))
+
+			serviceMap, err := c.ModuleManager.InitModuleServices(c.Cfg.Target...)
 			require.NoError(t, err)
 			require.NotNil(t, serviceMap)
 
@@ -229,6 +249,17 @@ func TestMimirServerShutdownWithActivityTrackerEnabled(t *testing.T) {
 	c, err := New(cfg, prometheus.NewPedanticRegistry())
 	require.NoError(t, err)
 
+	// The shutdown hooks run once all the services have stopped.
+	var stoppedServicesOnShutdown []string
+	require.NoError(t, c.RegisterShutdownHook("test", func() error {
+		for name, s := range c.ServiceMap {
+			if s.State() == services.Terminated {
+				stoppedServicesOnShutdown = append(stoppedServicesOnShutdown, name)
+			}
+		}
+		return nil
+	}))
+
 	errCh := make(chan error)
 	go func() {
 		errCh <- c.Run()
@@ -255,14 +286,25 @@ func TestMimirServerShutdownWithActivityTrackerEnabled(t *testing.T) {
 	case err := <-errCh:
 		require.NoError(t, err)
 	}
+
+	require.Len(t, stoppedServicesOnShutdown, len(c.ServiceMap))
+}
+
+// inheritCommonStorage copies the common storage config to the bucket configs, like unmarshalling the common config does.
+func inheritCommonStorage(cfg *Config) {
+	for _, storageCfg := range []*bucket.StorageBackendConfig{&cfg.BlocksStorage.Bucket.StorageBackendConfig, &cfg.RulerStorage.StorageBackendConfig, &cfg.AlertmanagerStorage.StorageBackendConfig} {
+		storageCfg.Backend = cfg.Common.Storage.Backend
+		storageCfg.S3 = cfg.Common.Storage.S3
+	}
 }
 
 func TestConfigValidation(t *testing.T) {
 	for _, tc := range []struct {
-		name           string
-		getTestConfig  func() *Config
-		expectedError  error
-		expectAnyError bool
+		name            string
+		getTestConfig   func() *Config
+		expectedError   error
+		expectAnyError  bool
+		expectedWarning string
 	}{
 		{
 			name: "should pass validation if the http prefix is empty",
@@ -397,9 +439,132 @@ func TestConfigValidation(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "Blocks storage: should fail if the filesystem backend is configured while the S3 backend is selected",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				cfg.BlocksStorage.Bucket.Backend = bucket.S3
+				cfg.BlocksStorage.Bucket.S3.BucketName = "b1"
+				cfg.BlocksStorage.Bucket.Filesystem.Directory = "/data/blocks"
+				return cfg
+			},
+			expectedError: errInvalidBucketConfig,
+		},
+		{
+			name: "Ruler storage: should fail if the S3 backend is configured while the GCS backend is selected",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				cfg.RulerStorage.Backend = bucket.GCS
+				cfg.RulerStorage.GCS.BucketName = "b1"
+				cfg.RulerStorage.S3.BucketName = "b2"
+				return cfg
+			},
+			expectedError: errInvalidBucketConfig,
+		},
+		{
+			name: "Alertmanager storage: should fail if the Azure backend is configured while the filesystem backend is selected",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				cfg.AlertmanagerStorage.Backend = bucket.Filesystem
+				cfg.AlertmanagerStorage.Azure.ContainerName = "c1"
+				return cfg
+			},
+			expectedError: errInvalidBucketConfig,
+		},
+		{
+			name: "Blocks storage: should pass if the filesystem backend is left to its defaults while the S3 backend is selected",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				cfg.BlocksStorage.Bucket.Backend = bucket.S3
+				cfg.BlocksStorage.Bucket.S3.BucketName = "b1"
+				return cfg
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Common storage: should pass if the S3 backend is inherited while the local backend is selected for the ruler and alertmanager storage",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("all,alertmanager")
+				cfg.Common.Storage.Backend = bucket.S3
+				cfg.Common.Storage.S3.BucketName = "b1"
+				cfg.Common.Storage.S3.Region = "r1"
+				inheritCommonStorage(cfg)
+				cfg.RulerStorage.Backend = rulestorelocal.Name
+				cfg.RulerStorage.Local.Directory = "/data/rules"
+				cfg.AlertmanagerStorage.Backend = alertstorelocal.Name
+				cfg.AlertmanagerStorage.Local.Path = "/data/alertmanager"
+				return cfg
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Common storage: should fail if the S3 backend is inherited while the GCS backend is selected and configured with a different bucket",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				cfg.Common.Storage.Backend = bucket.S3
+				cfg.Common.Storage.S3.BucketName = "b1"
+				cfg.Common.Storage.S3.Region = "r1"
+				inheritCommonStorage(cfg)
+				cfg.RulerStorage.Backend = bucket.GCS
+				cfg.RulerStorage.GCS.BucketName = "b2"
+				cfg.RulerStorage.S3.BucketName = "b3"
+				return cfg
+			},
+			expectedError: errInvalidBucketConfig,
+		},
+		{
+			name: "Ingester ring: should warn if the replication factor is greater than 1 with a single-node inmemory ring",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("all")
+				cfg.Ingester.IngesterRing.KVStore.Store = "inmemory"
+				cfg.Ingester.IngesterRing.ReplicationFactor = 3
+				return cfg
+			},
+			expectedError:   nil,
+			expectedWarning: "the ingester ring can't satisfy the replication factor",
+		},
+		{
+			name: "Ingester ring: should fail if the replication factor is greater than 1 with a single-node inmemory ring and the strict validation",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("all")
+				cfg.Ingester.IngesterRing.KVStore.Store = "inmemory"
+				cfg.Ingester.IngesterRing.ReplicationFactor = 3
+				cfg.Ingester.IngesterRing.StrictReplicationFactorValidation = true
+				return cfg
+			},
+			expectedError: errInvalidIngesterReplicationFactor,
+		},
+		{
+			name: "Ingester ring: should pass if the replication factor is 1 with a single-node inmemory ring and the strict validation",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("all")
+				cfg.Ingester.IngesterRing.KVStore.Store = "inmemory"
+				cfg.Ingester.IngesterRing.ReplicationFactor = 1
+				cfg.Ingester.IngesterRing.StrictReplicationFactorValidation = true
+				return cfg
+			},
+			expectedError: nil,
+		},
+		{
+			name: "Ingester ring: should pass if the replication factor is greater than 1 with a ring shared between processes and the strict validation",
+			getTestConfig: func() *Config {
+				cfg := newDefaultConfig()
+				_ = cfg.Target.Set("all")
+				cfg.Ingester.IngesterRing.KVStore.Store = "memberlist"
+				cfg.Ingester.IngesterRing.ReplicationFactor = 3
+				cfg.Ingester.IngesterRing.StrictReplicationFactorValidation = true
+				return cfg
+			},
+			expectedError: nil,
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
-			err := tc.getTestConfig().Validate(nil)
+			logs := &bytes.Buffer{}
+			err := tc.getTestConfig().Validate(log.NewLogfmtLogger(logs))
 			if tc.expectAnyError {
 				require.Error(t, err)
 			} else if tc.expectedError != nil {
@@ -407,6 +572,11 @@ func TestConfigValidation(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 			}
+			if tc.expectedWarning != "" {
+				require.Contains(t, logs.String(), tc.expectedWarning)
+			} else {
+				require.NotContains(t, logs.String(), "level=warn")
+			}
 		})
 	}
 }
@@ -483,6 +653,22 @@ func TestConfig_validateFilesystemPaths(t *testing.T) {
 			},
 			expectedErr: `the configured bucket store sync directory "/path/to/data" cannot overlap with the configured compactor data directory "/path/to/data/compactor"`,
 		},
+		"should fail if ingester and store-gateway data directory overlap": {
+			setup: func(cfg *Config) {
+				cfg.Target = flagext.StringSliceCSV{Ingester, StoreGateway}
+				cfg.BlocksStorage.TSDB.Dir = "/path/to/data"
+				cfg.BlocksStorage.BucketStore.SyncDir = "/path/to/data"
+			},
+			expectedErr: `the configured tsdb directory "/path/to/data" cannot overlap with the configured bucket store sync directory "/path/to/data"`,
+		},
+		"should fail if alertmanager and ruler data directory overlap, and they're running as part of the backend target": {
+			setup: func(cfg *Config) {
+				cfg.Target = flagext.StringSliceCSV{Backend}
+				cfg.Ruler.RulePath = "/path/to/data"
+				cfg.Alertmanager.DataDir = "/path/to/data/alertmanager"
+			},
+			expectedErr: `the configured ruler data directory "/path/to/data" cannot overlap with the configured alertmanager data directory "/path/to/data/alertmanager"`,
+		},
 		"should succeed if store-gateway and compactor data directory overlap, but it's running only the store-gateway": {
 			setup: func(cfg *Config) {
 				cfg.Target = flagext.StringSliceCSV{StoreGateway}
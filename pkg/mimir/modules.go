@@ -7,9 +7,12 @@ package mimir
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
 
@@ -856,6 +859,50 @@ func (t *Mimir) setupModuleManager() error {
 	}
 
 	t.ModuleManager = mm
+	t.moduleDependencies = deps
 
 	return nil
 }
+
+// ModuleInfo describes a module of the module graph.
+type ModuleInfo struct {
+	Name string `json:"name"`
+	// Target is true if the module can be used as target.
+	Target bool `json:"target"`
+	// Dependencies are the modules the module directly depends on, sorted by name.
+	Dependencies []string `json:"dependencies"`
+}
+
+// ModuleGraph returns the modules registered in the module manager, sorted by name, with the dependencies used
+// to initialise their services.
+func (t *Mimir) ModuleGraph() []ModuleInfo {
+	// The modules without dependencies are either dependencies of other modules, or targets.
+	names := map[string]struct{}{}
+	for _, m := range t.ModuleManager.UserVisibleModuleNames() {
+		names[m] = struct{}{}
+	}
+	for m, deps := range t.moduleDependencies {
+		names[m] = struct{}{}
+		for _, d := range deps {
+			names[d] = struct{}{}
+		}
+	}
+
+	graph := make([]ModuleInfo, 0, len(names))
+	for m := range names {
+		deps := append([]string{}, t.moduleDependencies[m]...)
+		sort.Strings(deps)
+		graph = append(graph, ModuleInfo{Name: m, Target: t.ModuleManager.IsUserVisibleModule(m), Dependencies: deps})
+	}
+	sort.Slice(graph, func(i, j int) bool {
+		return graph[i].Name < graph[j].Name
+	})
+	return graph
+}
+
+// WriteModuleGraphJSON writes the module graph returned by ModuleGraph to w as JSON.
+func (t *Mimir) WriteModuleGraphJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t.ModuleGraph())
+}
@@ -6,7 +6,10 @@
 package mimir
 
 import (
+	"bytes"
+	"encoding/json"
 	"net/http/httptest"
+	"net/url"
 	"os"
 	"path/filepath"
 	"testing"
@@ -23,6 +26,16 @@ func changeTargetConfig(c *Config) {
 	c.Target = []string{"all", "ruler"}
 }
 
+func changeIngesterStreamingLimitsConfig(c *Config) {
+	c.Ingester.LabelNamesAndValuesMessageSizeBytes = 512 * 1024
+	c.Ingester.LabelValuesCardinalityMessageSizeBytes = 256 * 1024
+}
+
+func changeAlertmanagerExternalURLConfig(c *Config) {
+	changeIngesterStreamingLimitsConfig(c)
+	c.Alertmanager.ExternalURL.URL = &url.URL{Scheme: "http", Host: "localhost", Path: "/alertmanager"}
+}
+
 func TestAPIConfig(t *testing.T) {
 	actualCfg := newDefaultConfig()
 
@@ -55,6 +68,25 @@ func TestAPIConfig(t *testing.T) {
 				assert.Equal(t, "{}\n", body)
 			},
 		},
+		{
+			name:               "running with default ingester streaming limits",
+			path:               "/config",
+			expectedStatusCode: 200,
+			expectedBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, "label_names_and_values_message_size_bytes: 1048576\n")
+				assert.Contains(t, body, "label_values_cardinality_message_size_bytes: 1048576\n")
+			},
+		},
+		{
+			name:               "running with changed ingester streaming limits",
+			path:               "/config",
+			actualCfg:          changeIngesterStreamingLimitsConfig,
+			expectedStatusCode: 200,
+			expectedBody: func(t *testing.T, body string) {
+				assert.Contains(t, body, "label_names_and_values_message_size_bytes: 524288\n")
+				assert.Contains(t, body, "label_values_cardinality_message_size_bytes: 262144\n")
+			},
+		},
 		{
 			name:               "running with changed target config",
 			path:               "/config",
@@ -82,6 +114,19 @@ func TestAPIConfig(t *testing.T) {
 				assert.Equal(t, "target: all,ruler\n", body)
 			},
 		},
+		{
+			name:               "diff with changed nested and flagext config",
+			path:               "/config?mode=diff",
+			actualCfg:          changeAlertmanagerExternalURLConfig,
+			expectedStatusCode: 200,
+			expectedBody: func(t *testing.T, body string) {
+				assert.Equal(t, "alertmanager:\n"+
+					"    external_url: http://localhost/alertmanager\n"+
+					"ingester:\n"+
+					"    label_names_and_values_message_size_bytes: 524288\n"+
+					"    label_values_cardinality_message_size_bytes: 262144\n", body)
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			mimir.Server.HTTP = mux.NewRouter()
@@ -228,6 +273,10 @@ func TestMultiKVSetup(t *testing.T) {
 			// Must be set, otherwise MultiKV config provider will not be set.
 			cfg.RuntimeConfig.LoadPath = []string{filepath.Join(dir, "config.yaml")}
 
+			// Keep the files written by the modules out of the working directory.
+			cfg.ActivityTracker.Filepath = filepath.Join(t.TempDir(), "metrics-activity.log")
+			cfg.Alertmanager.DataDir = t.TempDir()
+
 			c, err := New(cfg, prometheus.NewPedanticRegistry())
 			require.NoError(t, err)
 
@@ -239,3 +288,48 @@ func TestMultiKVSetup(t *testing.T) {
 		})
 	}
 }
+
+func TestMimir_WriteModuleGraphJSON(t *testing.T) {
+	m := &Mimir{}
+	require.NoError(t, m.setupModuleManager())
+
+	buf := bytes.Buffer{}
+	require.NoError(t, m.WriteModuleGraphJSON(&buf))
+	var graph []ModuleInfo
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &graph))
+
+	modules := map[string]ModuleInfo{}
+	for _, module := range graph {
+		modules[module.Name] = module
+	}
+	assert.Equal(t, ModuleInfo{Name: Server, Dependencies: []string{ActivityTracker, SanityCheck, UsageStats}}, modules[Server])
+	assert.Equal(t, ModuleInfo{Name: Ring, Dependencies: []string{API, MemberlistKV, RuntimeConfig}}, modules[Ring])
+	assert.Equal(t, ModuleInfo{Name: IngesterService, Dependencies: []string{MemberlistKV, Overrides, RuntimeConfig}}, modules[IngesterService])
+	assert.Equal(t, ModuleInfo{Name: Ingester, Target: true, Dependencies: []string{API, IngesterService}}, modules[Ingester])
+	assert.Equal(t, ModuleInfo{Name: UsageStats, Dependencies: []string{}}, modules[UsageStats])
+
+	// The edges are the dependencies used by the module manager to initialise the services of the modules.
+	var transitiveDeps func(module string) map[string]struct{}
+	transitiveDeps = func(module string) map[string]struct{} {
+		deps := map[string]struct{}{}
+		for _, d := range modules[module].Dependencies {
+			deps[d] = struct{}{}
+			for td := range transitiveDeps(d) {
+				deps[td] = struct{}{}
+			}
+		}
+		return deps
+	}
+	for _, module := range graph {
+		expected := m.ModuleManager.DependenciesForModule(module.Name)
+		actual := make([]string, 0, len(expected))
+		for d := range transitiveDeps(module.Name) {
+			actual = append(actual, d)
+		}
+		assert.ElementsMatch(t, expected, actual, module.Name)
+		assert.Equal(t, m.ModuleManager.IsUserVisibleModule(module.Name), module.Target, module.Name)
+	}
+	for _, target := range m.ModuleManager.UserVisibleModuleNames() {
+		assert.Contains(t, modules, target)
+	}
+}
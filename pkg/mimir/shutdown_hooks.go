@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package mimir
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/go-kit/log/level"
+	"github.com/grafana/dskit/multierror"
+
+	util_log "github.com/grafana/mimir/pkg/util/log"
+)
+
+// shutdownHook is a teardown callback run once all the services of Mimir have stopped.
+type shutdownHook struct {
+	name         string
+	dependencies []string
+	fn           func() error
+}
+
+// shutdownHooks holds the shutdown hooks registered in Mimir, in the order they have been registered.
+type shutdownHooks struct {
+	mtx   sync.Mutex
+	hooks []shutdownHook
+}
+
+// RegisterShutdownHook registers a named hook run by Run once all the services have stopped, before it returns.
+// The hook runs after the hooks it depends on, which can be registered after it. The hooks without dependencies
+// between them run in the order they have been registered. The hooks always run, even if other hooks fail.
+func (t *Mimir) RegisterShutdownHook(name string, fn func() error, dependencies ...string) error {
+	t.shutdownHooks.mtx.Lock()
+	defer t.shutdownHooks.mtx.Unlock()
+
+	for _, h := range t.shutdownHooks.hooks {
+		if h.name == name {
+			return fmt.Errorf("shutdown hook %s is already registered", name)
+		}
+	}
+	t.shutdownHooks.hooks = append(t.shutdownHooks.hooks, shutdownHook{name: name, dependencies: dependencies, fn: fn})
+	return nil
+}
+
+// runShutdownHooks runs the registered shutdown hooks after their dependencies, and returns the errors of the hooks
+// and of their dependencies. An unknown or circular dependency is reported, but it doesn't prevent the hook from running.
+func (t *Mimir) runShutdownHooks() error {
+	t.shutdownHooks.mtx.Lock()
+	hooks := append([]shutdownHook(nil), t.shutdownHooks.hooks...)
+	t.shutdownHooks.mtx.Unlock()
+
+	byName := make(map[string]shutdownHook, len(hooks))
+	for _, h := range hooks {
+		byName[h.name] = h
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	var (
+		errs  = multierror.New()
+		state = make(map[string]int, len(hooks))
+		visit func(h shutdownHook)
+	)
+	fail := func(err error) {
+		level.Error(util_log.Logger).Log("msg", "shutdown hook failed", "err", err)
+		errs.Add(err)
+	}
+	visit = func(h shutdownHook) {
+		state[h.name] = visiting
+		for _, dep := range h.dependencies {
+			depHook, ok := byName[dep]
+			switch {
+			case !ok:
+				fail(fmt.Errorf("shutdown hook %s depends on unknown shutdown hook %s", h.name, dep))
+			case state[dep] == visiting:
+				fail(fmt.Errorf("shutdown hook %s has a circular dependency on shutdown hook %s", h.name, dep))
+			case state[dep] == 0:
+				visit(depHook)
+			}
+		}
+
+		level.Debug(util_log.Logger).Log("msg", "running shutdown hook", "hook", h.name)
+		if err := h.fn(); err != nil {
+			fail(fmt.Errorf("shutdown hook %s: %w", h.name, err))
+		}
+		state[h.name] = visited
+	}
+
+	for _, h := range hooks {
+		if state[h.name] == 0 {
+			visit(h)
+		}
+	}
+	return errs.Err()
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package mimir
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMimir_RunShutdownHooks(t *testing.T) {
+	t.Run("hooks run after their dependencies and errors are aggregated", func(t *testing.T) {
+		var (
+			c       = &Mimir{}
+			invoked []string
+		)
+
+		// The exporter hook is registered first, but it depends on the flusher one.
+		require.NoError(t, c.RegisterShutdownHook("exporter", func() error {
+			invoked = append(invoked, "exporter")
+			return errors.New("exporter failed")
+		}, "flusher"))
+		require.NoError(t, c.RegisterShutdownHook("flusher", func() error {
+			invoked = append(invoked, "flusher")
+			return errors.New("flusher failed")
+		}))
+
+		err := c.runShutdownHooks()
+		require.Equal(t, []string{"flusher", "exporter"}, invoked)
+		require.EqualError(t, err, "2 errors: shutdown hook flusher: flusher failed; shutdown hook exporter: exporter failed")
+	})
+
+	t.Run("hooks without dependencies run in the registration order", func(t *testing.T) {
+		var (
+			c       = &Mimir{}
+			invoked []string
+		)
+		for _, name := range []string{"c", "a", "b"} {
+			name := name
+			require.NoError(t, c.RegisterShutdownHook(name, func() error {
+				invoked = append(invoked, name)
+				return nil
+			}))
+		}
+
+		require.NoError(t, c.runShutdownHooks())
+		require.Equal(t, []string{"c", "a", "b"}, invoked)
+	})
+
+	t.Run("unknown and circular dependencies are reported but the hooks still run", func(t *testing.T) {
+		var (
+			c       = &Mimir{}
+			invoked []string
+		)
+		register := func(name string, dependencies ...string) {
+			require.NoError(t, c.RegisterShutdownHook(name, func() error {
+				invoked = append(invoked, name)
+				return nil
+			}, dependencies...))
+		}
+		register("a", "b")
+		register("b", "a")
+		register("c", "unknown")
+
+		err := c.runShutdownHooks()
+		require.Equal(t, []string{"b", "a", "c"}, invoked)
+		require.EqualError(t, err, "2 errors: shutdown hook b has a circular dependency on shutdown hook a; shutdown hook c depends on unknown shutdown hook unknown")
+	})
+
+	t.Run("hook names must be unique", func(t *testing.T) {
+		c := &Mimir{}
+		require.NoError(t, c.RegisterShutdownHook("hook", func() error { return nil }))
+		assert.EqualError(t, c.RegisterShutdownHook("hook", func() error { return nil }), "shutdown hook hook is already registered")
+	})
+}
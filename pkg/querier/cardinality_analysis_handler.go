@@ -3,12 +3,17 @@
 package querier
 
 import (
+	"bytes"
 	"fmt"
 	"net/http"
 	"sort"
 	"strconv"
+	"time"
 
+	"github.com/gogo/protobuf/proto"
 	"github.com/pkg/errors"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/promql/parser"
@@ -18,6 +23,7 @@ import (
 
 	ingester_client "github.com/grafana/mimir/pkg/ingester/client"
 	"github.com/grafana/mimir/pkg/util"
+	"github.com/grafana/mimir/pkg/util/arrowipc"
 	util_math "github.com/grafana/mimir/pkg/util/math"
 	"github.com/grafana/mimir/pkg/util/validation"
 )
@@ -26,6 +32,16 @@ const (
 	minLimit     = 0
 	maxLimit     = 500
 	defaultLimit = 20
+
+	// headCacheMaxAge is how long the responses including label data of the in-memory head of the ingesters can be
+	// cached. The head changes as series are ingested, so they're only cached briefly.
+	headCacheMaxAge = 10 * time.Second
+	// blocksCacheMaxAge is how long the responses including only label data of the blocks of the ingesters can be
+	// cached. The blocks are immutable, so they're cached longer.
+	blocksCacheMaxAge = 5 * time.Minute
+
+	// labelValueSeriesMetricName is the name of the gauge exposing the series count of each label value.
+	labelValueSeriesMetricName = "mimir_tenant_label_value_series"
 )
 
 // LabelNamesCardinalityHandler creates handler for label names cardinality endpoint.
@@ -41,18 +57,19 @@ func LabelNamesCardinalityHandler(d Distributor, limits *validation.Overrides) h
 			http.Error(w, fmt.Sprintf("cardinality analysis is disabled for the tenant: %v", tenantID), http.StatusBadRequest)
 			return
 		}
-		matchers, limit, err := extractLabelNamesRequestParams(r)
+		matchers, limit, includeBlocks, err := extractLabelNamesRequestParams(r)
 		if err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		response, err := d.LabelNamesAndValues(ctx, matchers)
+		response, err := d.LabelNamesAndValues(ctx, matchers, includeBlocks)
 		if err != nil {
 			respondFromError(err, w)
 			return
 		}
+		setCacheControl(w, labelNamesCacheMaxAge(response.Items))
 		cardinalityResponse := toLabelNamesCardinalityResponse(response, limit)
-		util.WriteJSONResponse(w, cardinalityResponse)
+		util.WriteJSONResponseWithETag(w, r, cardinalityResponse)
 	})
 }
 
@@ -83,24 +100,231 @@ func LabelValuesCardinalityHandler(distributor Distributor, limits *validation.O
 			return
 		}
 
-		util.WriteJSONResponse(w, toLabelValuesCardinalityResponse(seriesCountTotal, cardinalityResponse, limit))
+		// The series are only counted in the head.
+		setCacheControl(w, headCacheMaxAge)
+		util.WriteJSONResponseWithETag(w, r, toLabelValuesCardinalityResponse(seriesCountTotal, cardinalityResponse, limit))
+	})
+}
+
+// LabelValuesCardinalityMetricsHandler creates handler for the label values cardinality endpoint exposing the
+// cardinality as OpenMetrics gauges, so that Prometheus can scrape it to track the cardinality over time.
+// The values of each label are limited to the top ones by series count, like in LabelValuesCardinalityHandler.
+func LabelValuesCardinalityMetricsHandler(distributor Distributor, limits *validation.Overrides) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		// Guarantee request's context is for a single tenant id
+		tenantID, err := tenant.TenantID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !limits.CardinalityAnalysisEnabled(tenantID) {
+			http.Error(w, fmt.Sprintf("cardinality analysis is disabled for the tenant: %v", tenantID), http.StatusBadRequest)
+			return
+		}
+
+		labelNames, matchers, limit, err := extractLabelValuesRequestParams(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		seriesCountTotal, cardinalityResponse, err := distributor.LabelValuesCardinality(ctx, labelNames, matchers)
+		if err != nil {
+			respondFromError(err, w)
+			return
+		}
+
+		// The exposition is encoded before being written, so that an encoding error can still be returned.
+		var buf bytes.Buffer
+		enc := expfmt.NewEncoder(&buf, expfmt.FmtOpenMetrics)
+		if err := enc.Encode(toLabelValueSeriesMetricFamily(toLabelValuesCardinalityResponse(seriesCountTotal, cardinalityResponse, limit))); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if _, err := expfmt.FinalizeOpenMetrics(&buf); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", string(expfmt.FmtOpenMetrics))
+		_, _ = w.Write(buf.Bytes())
+	})
+}
+
+// toLabelValueSeriesMetricFamily converts the label values cardinality to a gauge with the series count of each
+// label value, in the order of the response.
+func toLabelValueSeriesMetricFamily(response *labelValuesCardinalityResponse) *dto.MetricFamily {
+	family := &dto.MetricFamily{
+		Name: proto.String(labelValueSeriesMetricName),
+		Help: proto.String("Number of series of the tenant having the label value."),
+		Type: dto.MetricType_GAUGE.Enum(),
+	}
+	for _, label := range response.Labels {
+		for _, value := range label.Cardinality {
+			family.Metric = append(family.Metric, &dto.Metric{
+				Label: []*dto.LabelPair{
+					{Name: proto.String("label"), Value: proto.String(label.LabelName)},
+					{Name: proto.String("value"), Value: proto.String(value.LabelValue)},
+				},
+				Gauge: &dto.Gauge{Value: proto.Float64(float64(value.SeriesCount))},
+			})
+		}
+	}
+	return family
+}
+
+var (
+	// labelNamesArrowFields are the columns of the record batches of LabelNamesCardinalityArrowHandler.
+	labelNamesArrowFields = []arrowipc.Field{
+		{Name: "label_name", Type: arrowipc.Utf8},
+		{Name: "label_value", Type: arrowipc.Utf8},
+	}
+	// labelValuesArrowFields are the columns of the record batches of LabelValuesCardinalityArrowHandler.
+	labelValuesArrowFields = []arrowipc.Field{
+		{Name: "label_name", Type: arrowipc.Utf8},
+		{Name: "label_value", Type: arrowipc.Utf8},
+		{Name: "series_count", Type: arrowipc.Uint64},
+	}
+)
+
+// LabelNamesCardinalityArrowHandler creates handler for the label names cardinality endpoint streaming the values of
+// the label names as Apache Arrow IPC record batches, for analytics clients loading them into data frames.
+// There's a record batch of the sorted values of each label name. The label names are sorted and limited like in
+// LabelNamesCardinalityHandler.
+func LabelNamesCardinalityArrowHandler(d Distributor, limits *validation.Overrides) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		tenantID, err := tenant.TenantID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !limits.CardinalityAnalysisEnabled(tenantID) {
+			http.Error(w, fmt.Sprintf("cardinality analysis is disabled for the tenant: %v", tenantID), http.StatusBadRequest)
+			return
+		}
+		matchers, limit, includeBlocks, err := extractLabelNamesRequestParams(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		response, err := d.LabelNamesAndValues(ctx, matchers, includeBlocks)
+		if err != nil {
+			respondFromError(err, w)
+			return
+		}
+
+		labelsWithValues := response.Items
+		sortByValuesCountAndName(labelsWithValues)
+		labelsWithValues = labelsWithValues[:util_math.Min(len(labelsWithValues), limit)]
+
+		setCacheControl(w, labelNamesCacheMaxAge(labelsWithValues))
+		writeArrowResponse(w, labelNamesArrowFields, func(aw *arrowipc.Writer) error {
+			for _, item := range labelsWithValues {
+				values := append([]string(nil), item.Values...)
+				sort.Strings(values)
+				if err := aw.Write(repeatedLabelName(item.LabelName, len(values)), values); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// LabelValuesCardinalityArrowHandler creates handler for the label values cardinality endpoint streaming the series
+// count of the label values as Apache Arrow IPC record batches, for analytics clients loading them into data frames.
+// There's a record batch of each label name, whose values are sorted and limited like in LabelValuesCardinalityHandler.
+func LabelValuesCardinalityArrowHandler(distributor Distributor, limits *validation.Overrides) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		// Guarantee request's context is for a single tenant id
+		tenantID, err := tenant.TenantID(ctx)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !limits.CardinalityAnalysisEnabled(tenantID) {
+			http.Error(w, fmt.Sprintf("cardinality analysis is disabled for the tenant: %v", tenantID), http.StatusBadRequest)
+			return
+		}
+
+		labelNames, matchers, limit, err := extractLabelValuesRequestParams(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		seriesCountTotal, cardinalityResponse, err := distributor.LabelValuesCardinality(ctx, labelNames, matchers)
+		if err != nil {
+			respondFromError(err, w)
+			return
+		}
+
+		// The series are only counted in the head.
+		setCacheControl(w, headCacheMaxAge)
+		response := toLabelValuesCardinalityResponse(seriesCountTotal, cardinalityResponse, limit)
+		writeArrowResponse(w, labelValuesArrowFields, func(aw *arrowipc.Writer) error {
+			for _, label := range response.Labels {
+				values := make([]string, 0, len(label.Cardinality))
+				seriesCounts := make([]uint64, 0, len(label.Cardinality))
+				for _, value := range label.Cardinality {
+					values = append(values, value.LabelValue)
+					seriesCounts = append(seriesCounts, value.SeriesCount)
+				}
+				if err := aw.Write(repeatedLabelName(label.LabelName, len(values)), values, seriesCounts); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
 	})
 }
 
-func extractLabelNamesRequestParams(r *http.Request) ([]*labels.Matcher, int, error) {
+// writeArrowResponse writes the Arrow IPC stream of the record batches written by write. The stream is encoded before
+// being written, so that an encoding error can still be returned.
+func writeArrowResponse(w http.ResponseWriter, fields []arrowipc.Field, write func(*arrowipc.Writer) error) {
+	var buf bytes.Buffer
+	aw := arrowipc.NewWriter(&buf, fields...)
+	if err := write(aw); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := aw.Close(); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", arrowipc.ContentType)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// repeatedLabelName returns the column of the label name of each of the count rows of a label.
+func repeatedLabelName(labelName string, count int) []string {
+	column := make([]string, count)
+	for i := range column {
+		column[i] = labelName
+	}
+	return column
+}
+
+func extractLabelNamesRequestParams(r *http.Request) ([]*labels.Matcher, int, bool, error) {
 	err := r.ParseForm()
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, false, err
 	}
 	matchers, err := extractSelector(r)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, false, err
 	}
 	limit, err := extractLimit(r)
 	if err != nil {
-		return nil, 0, err
+		return nil, 0, false, err
 	}
-	return matchers, limit, nil
+	includeBlocks, err := extractIncludeBlocks(r)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	return matchers, limit, includeBlocks, nil
 }
 
 // extractLabelValuesRequestParams parses query params from GET requests and parses request body from POST requests
@@ -161,6 +385,22 @@ func extractLimit(r *http.Request) (limit int, err error) {
 	return limit, nil
 }
 
+// extractIncludeBlocks parses request param `include_blocks` if it's defined, otherwise returns false.
+func extractIncludeBlocks(r *http.Request) (bool, error) {
+	includeBlocksParams := r.Form["include_blocks"]
+	if len(includeBlocksParams) == 0 {
+		return false, nil
+	}
+	if len(includeBlocksParams) > 1 {
+		return false, fmt.Errorf("multiple 'include_blocks' params are not allowed")
+	}
+	includeBlocks, err := strconv.ParseBool(includeBlocksParams[0])
+	if err != nil {
+		return false, fmt.Errorf("invalid 'include_blocks' param '%v'", includeBlocksParams[0])
+	}
+	return includeBlocks, nil
+}
+
 // extractLabelNames parses and gets label_names query parameter containing an array of label values
 func extractLabelNames(r *http.Request) ([]model.LabelName, error) {
 	labelNamesParams := r.Form["label_names[]"]
@@ -180,6 +420,30 @@ func extractLabelNames(r *http.Request) ([]model.LabelName, error) {
 	return labelNames, nil
 }
 
+// labelNamesCacheMaxAge returns how long the label names cardinality computed from the items can be cached.
+// The values without presence have been looked up in the head.
+func labelNamesCacheMaxAge(items []*ingester_client.LabelValues) time.Duration {
+	if len(items) == 0 {
+		return headCacheMaxAge
+	}
+	for _, item := range items {
+		if len(item.Presence) != len(item.Values) {
+			return headCacheMaxAge
+		}
+		for _, presence := range item.Presence {
+			if presence != ingester_client.PRESENT_IN_BLOCKS_ONLY {
+				return headCacheMaxAge
+			}
+		}
+	}
+	return blocksCacheMaxAge
+}
+
+// setCacheControl sets the Cache-Control header of the response, so that it can be cached for maxAge.
+func setCacheControl(w http.ResponseWriter, maxAge time.Duration) {
+	w.Header().Set("Cache-Control", fmt.Sprintf("max-age=%d", int(maxAge.Seconds())))
+}
+
 func respondFromError(err error, w http.ResponseWriter) {
 	httpResp, ok := httpgrpc.HTTPResponseFromError(errors.Cause(err))
 	if !ok {
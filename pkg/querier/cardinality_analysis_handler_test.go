@@ -3,8 +3,10 @@
 package querier
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -19,9 +21,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/weaveworks/common/httpgrpc"
+	"github.com/weaveworks/common/middleware"
 	"github.com/weaveworks/common/user"
 
 	"github.com/grafana/mimir/pkg/ingester/client"
+	"github.com/grafana/mimir/pkg/util/arrowipc"
 	"github.com/grafana/mimir/pkg/util/validation"
 )
 
@@ -62,6 +66,82 @@ func TestLabelNamesCardinalityHandler(t *testing.T) {
 		"items must be sorted by LabelValuesCount in DESC order and by LabelName in ASC order")
 }
 
+func TestCardinalityHandlers_ETag(t *testing.T) {
+	for name, tc := range map[string]struct {
+		handler func(Distributor, *validation.Overrides) http.Handler
+		url     string
+		// distributor returns a distributor whose data changed when the changed param is true.
+		distributor func(changed bool) *mockDistributor
+	}{
+		"label names cardinality": {
+			handler: LabelNamesCardinalityHandler,
+			url:     "/api/v1/cardinality/label_names",
+			distributor: func(changed bool) *mockDistributor {
+				values := []string{"0a"}
+				if changed {
+					values = append(values, "1a")
+				}
+				return mockDistributorLabelNamesAndValues([]*client.LabelValues{{LabelName: "label_a", Values: values}}, nil)
+			},
+		},
+		"label values cardinality": {
+			handler: LabelValuesCardinalityHandler,
+			url:     "/api/v1/cardinality/label_values?label_names[]=label_a",
+			distributor: func(changed bool) *mockDistributor {
+				seriesCount := uint64(1)
+				if changed {
+					seriesCount = 2
+				}
+				d := &mockDistributor{}
+				d.On("LabelValuesCardinality", mock.Anything, []model.LabelName{"label_a"}, mock.Anything).Return(seriesCount, &client.LabelValuesCardinalityResponse{
+					Items: []*client.LabelValueSeriesCount{{LabelName: "label_a", LabelValueSeries: map[string]uint64{"0a": seriesCount}}},
+				}, nil)
+				return d
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(middleware.AuthenticateUser.Wrap(createEnabledHandler(t, tc.handler, tc.distributor(false))))
+			t.Cleanup(server.Close)
+			changedServer := httptest.NewServer(middleware.AuthenticateUser.Wrap(createEnabledHandler(t, tc.handler, tc.distributor(true))))
+			t.Cleanup(changedServer.Close)
+
+			get := func(t *testing.T, serverURL, ifNoneMatch string) (*http.Response, []byte) {
+				req, err := http.NewRequest(http.MethodGet, serverURL+tc.url, http.NoBody)
+				require.NoError(t, err)
+				req.Header.Set(user.OrgIDHeaderName, "team-a")
+				if ifNoneMatch != "" {
+					req.Header.Set("If-None-Match", ifNoneMatch)
+				}
+				resp, err := server.Client().Do(req)
+				require.NoError(t, err)
+				defer resp.Body.Close()
+				body, err := io.ReadAll(resp.Body)
+				require.NoError(t, err)
+				return resp, body
+			}
+
+			first, firstBody := get(t, server.URL, "")
+			require.Equal(t, http.StatusOK, first.StatusCode, string(firstBody))
+			etag := first.Header.Get("ETag")
+			require.NotEmpty(t, etag)
+			require.NotEmpty(t, firstBody)
+
+			// The data didn't change, so the client's copy is still valid.
+			notModified, notModifiedBody := get(t, server.URL, etag)
+			require.Equal(t, http.StatusNotModified, notModified.StatusCode)
+			require.Equal(t, etag, notModified.Header.Get("ETag"))
+			require.Empty(t, notModifiedBody)
+
+			// The data changed, so the full data is returned with a new ETag.
+			changed, changedBody := get(t, changedServer.URL, etag)
+			require.Equal(t, http.StatusOK, changed.StatusCode)
+			require.NotEqual(t, etag, changed.Header.Get("ETag"))
+			require.NotEqual(t, firstBody, changedBody)
+		})
+	}
+}
+
 func TestLabelNamesCardinalityHandler_MatchersTest(t *testing.T) {
 	td := []struct {
 		name             string
@@ -113,7 +193,7 @@ func TestLabelNamesCardinalityHandler_MatchersTest(t *testing.T) {
 			bodyContent, err := io.ReadAll(body)
 			require.NoError(t, err)
 			require.Equal(t, http.StatusOK, recorder.Result().StatusCode, "unexpected error %v", string(bodyContent))
-			distributor.AssertCalled(t, "LabelNamesAndValues", mock.Anything, data.expectedMatchers)
+			distributor.AssertCalled(t, "LabelNamesAndValues", mock.Anything, data.expectedMatchers, false)
 		})
 	}
 }
@@ -231,6 +311,79 @@ func TestLabelNamesCardinalityHandler_DistributorError(t *testing.T) {
 	}
 }
 
+func TestLabelNamesCardinalityHandler_CacheControl(t *testing.T) {
+	tests := map[string]struct {
+		url                  string
+		expectedIncludeBlock bool
+		items                []*client.LabelValues
+		expectedCacheControl string
+	}{
+		"head data is cached briefly": {
+			url:                  "/ignored-url",
+			items:                []*client.LabelValues{{LabelName: "label-a", Values: []string{"0a", "1a"}}},
+			expectedCacheControl: "max-age=10",
+		},
+		"head and blocks data is cached briefly": {
+			url:                  "/ignored-url?include_blocks=true",
+			expectedIncludeBlock: true,
+			items: []*client.LabelValues{
+				{LabelName: "label-a", Values: []string{"0a", "1a"}, Presence: []client.LabelValuePresence{client.PRESENT_IN_BLOCKS_ONLY, client.PRESENT_IN_HEAD_AND_BLOCKS}},
+			},
+			expectedCacheControl: "max-age=10",
+		},
+		"blocks only data is cached longer": {
+			url:                  "/ignored-url?include_blocks=true",
+			expectedIncludeBlock: true,
+			items: []*client.LabelValues{
+				{LabelName: "label-a", Values: []string{"0a", "1a"}, Presence: []client.LabelValuePresence{client.PRESENT_IN_BLOCKS_ONLY, client.PRESENT_IN_BLOCKS_ONLY}},
+				{LabelName: "label-b", Values: []string{"0b"}, Presence: []client.LabelValuePresence{client.PRESENT_IN_BLOCKS_ONLY}},
+			},
+			expectedCacheControl: "max-age=300",
+		},
+		"empty result is cached briefly": {
+			url:                  "/ignored-url?include_blocks=true",
+			expectedIncludeBlock: true,
+			items:                []*client.LabelValues{},
+			expectedCacheControl: "max-age=10",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			distributor := &mockDistributor{}
+			distributor.On("LabelNamesAndValues", mock.Anything, mock.Anything, tc.expectedIncludeBlock).Return(&client.LabelNamesAndValuesResponse{Items: tc.items}, nil)
+			handler := createEnabledHandler(t, LabelNamesCardinalityHandler, distributor)
+			recorder := httptest.NewRecorder()
+
+			handler.ServeHTTP(recorder, createRequest(tc.url, "team-a"))
+
+			require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+			require.Equal(t, tc.expectedCacheControl, recorder.Result().Header.Get("Cache-Control"))
+			distributor.AssertExpectations(t)
+		})
+	}
+
+	t.Run("label values cardinality is cached briefly", func(t *testing.T) {
+		distributor := mockDistributorLabelValuesCardinality([]model.LabelName{"label_a"}, nil, 1, &client.LabelValuesCardinalityResponse{}, nil)
+		handler := createEnabledHandler(t, LabelValuesCardinalityHandler, distributor)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, createRequest("/ignored-url?label_names[]=label_a", "team-a"))
+
+		require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+		require.Equal(t, "max-age=10", recorder.Result().Header.Get("Cache-Control"))
+	})
+
+	t.Run("invalid include_blocks param", func(t *testing.T) {
+		handler := createEnabledHandler(t, LabelNamesCardinalityHandler, &mockDistributor{})
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, createRequest("/ignored-url?include_blocks=maybe", "team-a"))
+
+		require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		require.Empty(t, recorder.Result().Header.Get("Cache-Control"))
+	})
+}
+
 func TestLabelNamesCardinalityHandler_NegativeTests(t *testing.T) {
 	td := []struct {
 		name                        string
@@ -772,6 +925,180 @@ func TestLabelValuesCardinalityHandler_DistributorError(t *testing.T) {
 	}
 }
 
+func TestLabelValuesCardinalityMetricsHandler(t *testing.T) {
+	distributor := mockDistributorLabelValuesCardinality(
+		[]model.LabelName{"job", "pod"},
+		[]*labels.Matcher(nil),
+		100,
+		&client.LabelValuesCardinalityResponse{
+			Items: []*client.LabelValueSeriesCount{
+				{LabelName: "job", LabelValueSeries: map[string]uint64{"api": 30, "db": 20}},
+				{LabelName: "pod", LabelValueSeries: map[string]uint64{"pod-1": 15, "pod-2": 25, "pod-3": 5, "pod-\"4\"": 5}},
+			},
+		},
+		nil,
+	)
+	handler := createEnabledHandler(t, LabelValuesCardinalityMetricsHandler, distributor)
+	recorder := httptest.NewRecorder()
+
+	// The limit keeps the top 3 values of each label by series count.
+	handler.ServeHTTP(recorder, createRequest("/label_values/metrics?label_names[]=job&label_names[]=pod&limit=3", "team-a"))
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	require.Equal(t, "application/openmetrics-text; version=0.0.1; charset=utf-8", recorder.Result().Header.Get("Content-Type"))
+	body, err := io.ReadAll(recorder.Result().Body)
+	require.NoError(t, err)
+	require.Equal(t, `# HELP mimir_tenant_label_value_series Number of series of the tenant having the label value.
+# TYPE mimir_tenant_label_value_series gauge
+mimir_tenant_label_value_series{label="job",value="api"} 30.0
+mimir_tenant_label_value_series{label="job",value="db"} 20.0
+mimir_tenant_label_value_series{label="pod",value="pod-2"} 25.0
+mimir_tenant_label_value_series{label="pod",value="pod-1"} 15.0
+mimir_tenant_label_value_series{label="pod",value="pod-\"4\""} 5.0
+# EOF
+`, string(body))
+}
+
+func TestLabelValuesCardinalityMetricsHandler_Errors(t *testing.T) {
+	t.Run("label names are required", func(t *testing.T) {
+		handler := createEnabledHandler(t, LabelValuesCardinalityMetricsHandler, &mockDistributor{})
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, createRequest("/label_values/metrics", "team-a"))
+
+		require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		body, err := io.ReadAll(recorder.Result().Body)
+		require.NoError(t, err)
+		require.Equal(t, "'label_names[]' param is required\n", string(body))
+	})
+
+	t.Run("distributor error", func(t *testing.T) {
+		distributor := mockDistributorLabelValuesCardinality([]model.LabelName{"job"}, []*labels.Matcher(nil), 0, &client.LabelValuesCardinalityResponse{}, fmt.Errorf("ingesters unavailable"))
+		handler := createEnabledHandler(t, LabelValuesCardinalityMetricsHandler, distributor)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, createRequest("/label_values/metrics?label_names[]=job", "team-a"))
+
+		require.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+	})
+}
+
+func TestLabelNamesCardinalityArrowHandler(t *testing.T) {
+	items := []*client.LabelValues{
+		{LabelName: "label-c", Values: []string{"0c"}},
+		{LabelName: "label-b", Values: []string{"1b", "0b"}},
+		{LabelName: "label-a", Values: []string{"0a", "1a"}},
+		{LabelName: "label-z", Values: []string{"0z", "2z", "1z"}},
+	}
+	distributor := mockDistributorLabelNamesAndValues(items, nil)
+	handler := createEnabledHandler(t, LabelNamesCardinalityArrowHandler, distributor)
+	recorder := httptest.NewRecorder()
+
+	// The limit keeps the top 3 label names by values count.
+	handler.ServeHTTP(recorder, createRequest("/label_names/arrow?limit=3", "team-a"))
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	require.Equal(t, "application/vnd.apache.arrow.stream", recorder.Result().Header.Get("Content-Type"))
+	require.Equal(t, []arrowipc.Field{
+		{Name: "label_name", Type: arrowipc.Utf8},
+		{Name: "label_value", Type: arrowipc.Utf8},
+	}, readArrowFields(t, recorder.Body.Bytes()))
+	require.Equal(t, [][]interface{}{
+		{[]string{"label-z", "label-z", "label-z"}, []string{"0z", "1z", "2z"}},
+		{[]string{"label-a", "label-a"}, []string{"0a", "1a"}},
+		{[]string{"label-b", "label-b"}, []string{"0b", "1b"}},
+	}, readArrowRecordBatches(t, recorder.Body.Bytes()))
+}
+
+func TestLabelValuesCardinalityArrowHandler(t *testing.T) {
+	distributor := mockDistributorLabelValuesCardinality(
+		[]model.LabelName{"job", "pod"},
+		[]*labels.Matcher(nil),
+		100,
+		&client.LabelValuesCardinalityResponse{
+			Items: []*client.LabelValueSeriesCount{
+				{LabelName: "job", LabelValueSeries: map[string]uint64{"api": 30, "db": 20}},
+				{LabelName: "pod", LabelValueSeries: map[string]uint64{"pod-1": 15, "pod-2": 25, "pod-3": 5, "pod-4": 5}},
+			},
+		},
+		nil,
+	)
+	handler := createEnabledHandler(t, LabelValuesCardinalityArrowHandler, distributor)
+	recorder := httptest.NewRecorder()
+
+	// The limit keeps the top 3 values of each label by series count.
+	handler.ServeHTTP(recorder, createRequest("/label_values/arrow?label_names[]=job&label_names[]=pod&limit=3", "team-a"))
+
+	require.Equal(t, http.StatusOK, recorder.Result().StatusCode)
+	require.Equal(t, "application/vnd.apache.arrow.stream", recorder.Result().Header.Get("Content-Type"))
+	require.Equal(t, []arrowipc.Field{
+		{Name: "label_name", Type: arrowipc.Utf8},
+		{Name: "label_value", Type: arrowipc.Utf8},
+		{Name: "series_count", Type: arrowipc.Uint64},
+	}, readArrowFields(t, recorder.Body.Bytes()))
+	require.Equal(t, [][]interface{}{
+		{[]string{"job", "job"}, []string{"api", "db"}, []uint64{30, 20}},
+		{[]string{"pod", "pod", "pod"}, []string{"pod-2", "pod-1", "pod-3"}, []uint64{25, 15, 5}},
+	}, readArrowRecordBatches(t, recorder.Body.Bytes()))
+}
+
+func TestCardinalityArrowHandlers_Errors(t *testing.T) {
+	t.Run("label names are required by the label values endpoint", func(t *testing.T) {
+		handler := createEnabledHandler(t, LabelValuesCardinalityArrowHandler, &mockDistributor{})
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, createRequest("/label_values/arrow", "team-a"))
+
+		require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		require.Equal(t, "'label_names[]' param is required\n", recorder.Body.String())
+	})
+
+	t.Run("distributor error", func(t *testing.T) {
+		distributor := mockDistributorLabelNamesAndValues(nil, fmt.Errorf("ingesters unavailable"))
+		handler := createEnabledHandler(t, LabelNamesCardinalityArrowHandler, distributor)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, createRequest("/label_names/arrow", "team-a"))
+
+		require.Equal(t, http.StatusInternalServerError, recorder.Result().StatusCode)
+	})
+
+	t.Run("cardinality analysis disabled", func(t *testing.T) {
+		overrides, err := validation.NewOverrides(validation.Limits{CardinalityAnalysisEnabled: false}, nil)
+		require.NoError(t, err)
+		handler := LabelNamesCardinalityArrowHandler(&mockDistributor{}, overrides)
+		recorder := httptest.NewRecorder()
+
+		handler.ServeHTTP(recorder, createRequest("/label_names/arrow", "team-a"))
+
+		require.Equal(t, http.StatusBadRequest, recorder.Result().StatusCode)
+		require.Equal(t, "cardinality analysis is disabled for the tenant: team-a\n", recorder.Body.String())
+	})
+}
+
+// readArrowFields returns the fields of the schema of the Arrow IPC stream.
+func readArrowFields(t *testing.T, stream []byte) []arrowipc.Field {
+	r, err := arrowipc.NewReader(bytes.NewReader(stream))
+	require.NoError(t, err)
+	return r.Fields()
+}
+
+// readArrowRecordBatches returns the columns of each record batch of the Arrow IPC stream.
+func readArrowRecordBatches(t *testing.T, stream []byte) [][]interface{} {
+	r, err := arrowipc.NewReader(bytes.NewReader(stream))
+	require.NoError(t, err)
+
+	var batches [][]interface{}
+	for {
+		columns, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			return batches
+		}
+		require.NoError(t, err)
+		batches = append(batches, columns)
+	}
+}
+
 // createEnabledHandler creates a cardinalityHandler that can be either a LabelNamesCardinalityHandler or a LabelValuesCardinalityHandler
 func createEnabledHandler(t *testing.T, cardinalityHandler func(Distributor, *validation.Overrides) http.Handler, distributor *mockDistributor) http.Handler {
 	limits := validation.Limits{CardinalityAnalysisEnabled: true}
@@ -807,7 +1134,7 @@ func generateLabelValues(count int) ([]*client.LabelValues, int) {
 
 func mockDistributorLabelNamesAndValues(items []*client.LabelValues, err error) *mockDistributor {
 	distributor := &mockDistributor{}
-	distributor.On("LabelNamesAndValues", mock.Anything, mock.Anything).Return(&client.LabelNamesAndValuesResponse{Items: items}, err)
+	distributor.On("LabelNamesAndValues", mock.Anything, mock.Anything, mock.Anything).Return(&client.LabelNamesAndValuesResponse{Items: items}, err)
 	return distributor
 }
 
@@ -401,8 +401,8 @@ func (m *mockDistributor) MetricsMetadata(ctx context.Context) ([]scrape.MetricM
 	return args.Get(0).([]scrape.MetricMetadata), args.Error(1)
 }
 
-func (m *mockDistributor) LabelNamesAndValues(ctx context.Context, matchers []*labels.Matcher) (*client.LabelNamesAndValuesResponse, error) {
-	args := m.Called(ctx, matchers)
+func (m *mockDistributor) LabelNamesAndValues(ctx context.Context, matchers []*labels.Matcher, includeBlocks bool) (*client.LabelNamesAndValuesResponse, error) {
+	args := m.Called(ctx, matchers, includeBlocks)
 	return args.Get(0).(*client.LabelNamesAndValuesResponse), args.Error(1)
 }
 
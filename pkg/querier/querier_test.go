@@ -835,7 +835,7 @@ func testRangeQuery(t testing.TB, queryable storage.Queryable, end model.Time, q
 
 type errDistributor struct{}
 
-func (m *errDistributor) LabelNamesAndValues(_ context.Context, _ []*labels.Matcher) (*client.LabelNamesAndValuesResponse, error) {
+func (m *errDistributor) LabelNamesAndValues(_ context.Context, _ []*labels.Matcher, _ bool) (*client.LabelNamesAndValuesResponse, error) {
 	return nil, errors.New("method is not implemented")
 }
 
@@ -870,7 +870,7 @@ func (m *errDistributor) LabelValuesCardinality(ctx context.Context, labelNames
 
 type emptyDistributor struct{}
 
-func (d *emptyDistributor) LabelNamesAndValues(_ context.Context, _ []*labels.Matcher) (*client.LabelNamesAndValuesResponse, error) {
+func (d *emptyDistributor) LabelNamesAndValues(_ context.Context, _ []*labels.Matcher, _ bool) (*client.LabelNamesAndValuesResponse, error) {
 	return nil, errors.New("method is not implemented")
 }
 
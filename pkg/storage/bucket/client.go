@@ -10,6 +10,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"reflect"
 	"strings"
 
 	"github.com/go-kit/log"
@@ -55,6 +56,7 @@ var (
 
 	ErrUnsupportedStorageBackend        = errors.New("unsupported storage backend")
 	ErrInvalidCharactersInStoragePrefix = errors.New("storage prefix contains invalid characters, it may only contain digits and English alphabet letters")
+	ErrConflictingStorageBackends       = errors.New("the configuration of a storage backend other than the selected one is set")
 )
 
 type StorageBackendConfig struct {
@@ -115,6 +117,82 @@ func (cfg *StorageBackendConfig) Validate() error {
 	return nil
 }
 
+// ValidateConflictingBackends returns ErrConflictingStorageBackends if the configuration of a backend other than the
+// selected one has been changed from its defaults, because it would be silently ignored. The error names the
+// conflicting fields. If inherited isn't nil, it's the config whose values have been inherited by this one, like
+// the common storage config, and the fields set to the inherited value aren't conflicting.
+func (cfg *StorageBackendConfig) ValidateConflictingBackends(inherited *StorageBackendConfig) error {
+	defaults := cfg.defaults()
+	if inherited == nil {
+		inherited = &defaults
+	}
+
+	var fields []string
+	for _, b := range []struct {
+		name                     string
+		cfg, defaults, inherited interface{}
+	}{
+		{name: S3, cfg: cfg.S3, defaults: defaults.S3, inherited: inherited.S3},
+		{name: GCS, cfg: cfg.GCS, defaults: defaults.GCS, inherited: inherited.GCS},
+		{name: Azure, cfg: cfg.Azure, defaults: defaults.Azure, inherited: inherited.Azure},
+		{name: Swift, cfg: cfg.Swift, defaults: defaults.Swift, inherited: inherited.Swift},
+		{name: Filesystem, cfg: cfg.Filesystem, defaults: defaults.Filesystem, inherited: inherited.Filesystem},
+	} {
+		if b.name == cfg.Backend {
+			continue
+		}
+		notInherited := map[string]bool{}
+		for _, f := range changedFields(b.name, reflect.ValueOf(b.cfg), reflect.ValueOf(b.inherited)) {
+			notInherited[f] = true
+		}
+		for _, f := range changedFields(b.name, reflect.ValueOf(b.cfg), reflect.ValueOf(b.defaults)) {
+			if notInherited[f] {
+				fields = append(fields, f)
+			}
+		}
+	}
+	if len(fields) > 0 {
+		return fmt.Errorf("%w: the %s backend is selected, but the fields of other backends are set: %s", ErrConflictingStorageBackends, cfg.Backend, strings.Join(fields, ", "))
+	}
+	return nil
+}
+
+// defaults returns the default config of the backends. The default directory of the filesystem backend depends
+// on where the config is used, so it's taken from the registered flags.
+func (cfg *StorageBackendConfig) defaults() StorageBackendConfig {
+	dir := ""
+	if f, ok := cfg.RegisteredFlags.Flags["filesystem.dir"]; ok {
+		dir = f.DefValue
+	}
+	defaults := StorageBackendConfig{ExtraBackends: cfg.ExtraBackends}
+	defaults.RegisterFlagsWithPrefixAndDefaultDirectory("", dir, flag.NewFlagSet("", flag.PanicOnError))
+	return defaults
+}
+
+// changedFields returns the YAML paths of the fields of cfg whose value differs from the one in defaults.
+// The fields not configurable in YAML are ignored.
+func changedFields(prefix string, cfg, defaults reflect.Value) []string {
+	var changed []string
+	for i := 0; i < cfg.NumField(); i++ {
+		field := cfg.Type().Field(i)
+		name := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if name == "-" || name == "" {
+			continue
+		}
+		path := prefix + "." + name
+
+		// The values marshalled by themselves, like secrets, are compared as a whole.
+		if _, marshaler := cfg.Field(i).Interface().(interface{ MarshalYAML() (interface{}, error) }); field.Type.Kind() == reflect.Struct && !marshaler {
+			changed = append(changed, changedFields(path, cfg.Field(i), defaults.Field(i))...)
+			continue
+		}
+		if !reflect.DeepEqual(cfg.Field(i).Interface(), defaults.Field(i).Interface()) {
+			changed = append(changed, path)
+		}
+	}
+	return changed
+}
+
 // Config holds configuration for accessing long-term storage.
 type Config struct {
 	StorageBackendConfig `yaml:",inline"`
@@ -8,6 +8,7 @@ package bucket
 import (
 	"bytes"
 	"context"
+	"flag"
 	"io"
 	"os"
 	"path"
@@ -178,6 +179,44 @@ func TestClient_ConfigValidation(t *testing.T) {
 	}
 }
 
+func TestStorageBackendConfig_ValidateConflictingBackends(t *testing.T) {
+	testCases := map[string]struct {
+		config        string
+		expectedError string
+	}{
+		"only the selected backend is configured": {
+			config: configWithS3Backend,
+		},
+		"filesystem directory set with the s3 backend": {
+			config:        configWithS3Backend + "filesystem:\n  dir: /data\n",
+			expectedError: "the configuration of a storage backend other than the selected one is set: the s3 backend is selected, but the fields of other backends are set: filesystem.dir",
+		},
+		"several backends set with the filesystem backend": {
+			config:        "backend: filesystem\ngcs:\n  bucket_name: test\nazure:\n  account_key: secret\ns3:\n  http:\n    insecure_skip_verify: true\n",
+			expectedError: "the configuration of a storage backend other than the selected one is set: the filesystem backend is selected, but the fields of other backends are set: s3.http.insecure_skip_verify, gcs.bucket_name, azure.account_key",
+		},
+		"default filesystem directory with the s3 backend": {
+			config: "backend: s3\nfilesystem:\n  dir: default\n",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := StorageBackendConfig{}
+			cfg.RegisterFlagsWithPrefixAndDefaultDirectory("", "default", flag.NewFlagSet("", flag.PanicOnError))
+			require.NoError(t, yaml.Unmarshal([]byte(tc.config), &cfg))
+
+			err := cfg.ValidateConflictingBackends(nil)
+			if tc.expectedError == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrConflictingStorageBackends)
+			require.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
+
 func TestNewPrefixedBucketClient(t *testing.T) {
 	t.Run("with prefix", func(t *testing.T) {
 		ctx := context.Background()
@@ -38,13 +38,16 @@ func newS3Config(cfg Config) (s3.Config, error) {
 		return s3.Config{}, err
 	}
 
+	// The client doesn't support the scheme in the endpoint.
+	endpoint, insecure := splitEndpointScheme(cfg.Endpoint)
+
 	return s3.Config{
 		Bucket:    cfg.BucketName,
-		Endpoint:  cfg.Endpoint,
+		Endpoint:  endpoint,
 		Region:    cfg.Region,
 		AccessKey: cfg.AccessKeyID,
 		SecretKey: cfg.SecretAccessKey.String(),
-		Insecure:  cfg.Insecure,
+		Insecure:  cfg.Insecure || insecure,
 		SSEConfig: sseCfg,
 		HTTPConfig: s3.HTTPConfig{
 			IdleConnTimeout:       model.Duration(cfg.HTTP.IdleConnTimeout),
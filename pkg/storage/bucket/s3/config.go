@@ -9,7 +9,11 @@ import (
 	"encoding/json"
 	"flag"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -40,6 +44,16 @@ var (
 	errUnsupportedSignatureVersion = errors.New("unsupported signature version")
 	errUnsupportedSSEType          = errors.New("unsupported S3 SSE type")
 	errInvalidSSEContext           = errors.New("invalid S3 SSE encryption context")
+	errInvalidEndpoint             = errors.New("invalid S3 endpoint")
+
+	// endpointHostRegexp matches the DNS names and the IPv4 addresses. The labels can contain underscores, which
+	// aren't valid in hostnames but are resolved in practice, e.g. the service names of Docker Compose.
+	endpointHostRegexp = regexp.MustCompile(`^[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?(\.[a-zA-Z0-9_]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?)*$`)
+)
+
+const (
+	httpScheme  = "http://"
+	httpsScheme = "https://"
 )
 
 // HTTPConfig stores the http.Transport configuration for the s3 minio client.
@@ -94,7 +108,7 @@ func (cfg *Config) RegisterFlagsWithPrefix(prefix string, f *flag.FlagSet) {
 	f.Var(&cfg.SecretAccessKey, prefix+"s3.secret-access-key", "S3 secret access key")
 	f.StringVar(&cfg.BucketName, prefix+"s3.bucket-name", "", "S3 bucket name")
 	f.StringVar(&cfg.Region, prefix+"s3.region", "", "S3 region. If unset, the client will issue a S3 GetBucketLocation API call to autodetect it.")
-	f.StringVar(&cfg.Endpoint, prefix+"s3.endpoint", "", "The S3 bucket endpoint. It could be an AWS S3 endpoint listed at https://docs.aws.amazon.com/general/latest/gr/s3.html or the address of an S3-compatible service in hostname:port format.")
+	f.StringVar(&cfg.Endpoint, prefix+"s3.endpoint", "", "The S3 bucket endpoint. It could be an AWS S3 endpoint listed at https://docs.aws.amazon.com/general/latest/gr/s3.html or the address of an S3-compatible service in hostname:port format. The endpoint can be prefixed by http:// to connect without TLS, like with the insecure option, or by https://.")
 	f.BoolVar(&cfg.Insecure, prefix+"s3.insecure", false, "If enabled, use http:// for the S3 endpoint instead of https://. This could be useful in local dev/test environments while using an S3-compatible backend storage, like Minio.")
 	f.StringVar(&cfg.SignatureVersion, prefix+"s3.signature-version", SignatureVersionV4, fmt.Sprintf("The signature version to use for authenticating against S3. Supported values are: %s.", strings.Join(supportedSignatureVersions, ", ")))
 	cfg.SSE.RegisterFlagsWithPrefix(prefix+"s3.sse.", f)
@@ -111,9 +125,64 @@ func (cfg *Config) Validate() error {
 		return err
 	}
 
+	if err := validateEndpoint(cfg.Endpoint); err != nil {
+		return err
+	}
+	if strings.HasPrefix(strings.ToLower(cfg.Endpoint), httpsScheme) && cfg.Insecure {
+		return fmt.Errorf("%w %q: the https:// scheme can't be used when the insecure option is enabled", errInvalidEndpoint, cfg.Endpoint)
+	}
+
 	return nil
 }
 
+// validateEndpoint returns an error if the endpoint is not a host or a host:port, optionally prefixed by
+// the http:// or https:// scheme. It doesn't check whether the endpoint is reachable. An empty endpoint is valid.
+func validateEndpoint(endpoint string) error {
+	if endpoint == "" {
+		return nil
+	}
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w %q: %s", errInvalidEndpoint, endpoint, reason)
+	}
+
+	hostport, _ := splitEndpointScheme(endpoint)
+	if strings.Contains(hostport, "://") {
+		return invalid("only the http:// and https:// schemes are supported")
+	}
+	if strings.ContainsAny(hostport, "/?#@ \t") {
+		return invalid("it must be a host or a host:port, without path, query, credentials or whitespaces")
+	}
+	u, err := url.Parse(httpsScheme + hostport)
+	if err != nil {
+		return invalid(err.Error())
+	}
+
+	host := u.Hostname()
+	if port := u.Port(); port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+			return invalid("the port must be between 1 and 65535")
+		}
+	} else if strings.HasSuffix(u.Host, ":") {
+		return invalid("the port is missing after the colon")
+	}
+	if net.ParseIP(host) == nil && !endpointHostRegexp.MatchString(host) {
+		return invalid("the host must be a DNS name or an IP address")
+	}
+	return nil
+}
+
+// splitEndpointScheme returns the endpoint without its http:// or https:// scheme, and whether the scheme is http://.
+func splitEndpointScheme(endpoint string) (hostport string, insecure bool) {
+	switch lower := strings.ToLower(endpoint); {
+	case strings.HasPrefix(lower, httpScheme):
+		return endpoint[len(httpScheme):], true
+	case strings.HasPrefix(lower, httpsScheme):
+		return endpoint[len(httpsScheme):], false
+	default:
+		return endpoint, false
+	}
+}
+
 // SSEConfig configures S3 server side encryption
 // struct that is going to receive user input (through config file or CLI)
 type SSEConfig struct {
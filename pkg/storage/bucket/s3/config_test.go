@@ -62,6 +62,82 @@ func TestSSEConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_Validate_Endpoint(t *testing.T) {
+	tests := map[string]struct {
+		endpoint    string
+		insecure    bool
+		expectedErr string
+	}{
+		"empty endpoint":                 {endpoint: ""},
+		"host":                           {endpoint: "localhost"},
+		"host and port":                  {endpoint: "minio:9000"},
+		"host with underscores":          {endpoint: "minio_s3:9000"},
+		"AWS endpoint":                   {endpoint: "s3.dualstack.us-east-1.amazonaws.com"},
+		"IPv4 address and port":          {endpoint: "127.0.0.1:9000"},
+		"IPv6 address and port":          {endpoint: "[::1]:9000"},
+		"http scheme":                    {endpoint: "http://minio:9000"},
+		"https scheme":                   {endpoint: "HTTPS://s3.amazonaws.com"},
+		"http scheme with insecure":      {endpoint: "http://minio:9000", insecure: true},
+		"https scheme with insecure":     {endpoint: "https://minio:9000", insecure: true, expectedErr: "the https:// scheme can't be used when the insecure option is enabled"},
+		"unsupported scheme":             {endpoint: "s3://bucket", expectedErr: "only the http:// and https:// schemes are supported"},
+		"path":                           {endpoint: "minio:9000/bucket", expectedErr: "without path"},
+		"credentials":                    {endpoint: "user:pass@minio:9000", expectedErr: "without path, query, credentials"},
+		"whitespace":                     {endpoint: "minio 9000", expectedErr: "without path, query, credentials or whitespaces"},
+		"missing port after colon":       {endpoint: "minio:", expectedErr: "the port is missing"},
+		"non-numeric port":               {endpoint: "minio:abc", expectedErr: "invalid port"},
+		"port out of range":              {endpoint: "minio:70000", expectedErr: "the port must be between 1 and 65535"},
+		"invalid host":                   {endpoint: "mi$nio:9000", expectedErr: "the host must be a DNS name or an IP address"},
+		"host with empty label":          {endpoint: "minio..local", expectedErr: "the host must be a DNS name or an IP address"},
+		"scheme without host":            {endpoint: "https://", expectedErr: "the host must be a DNS name or an IP address"},
+		"scheme without host but a port": {endpoint: "http://:9000", expectedErr: "the host must be a DNS name or an IP address"},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			cfg := &Config{}
+			flagext.DefaultValues(cfg)
+			cfg.Endpoint = testData.endpoint
+			cfg.Insecure = testData.insecure
+
+			err := cfg.Validate()
+			if testData.expectedErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, errInvalidEndpoint)
+			require.ErrorContains(t, err, testData.expectedErr)
+		})
+	}
+}
+
+func TestNewS3Config_EndpointScheme(t *testing.T) {
+	tests := map[string]struct {
+		endpoint         string
+		insecure         bool
+		expectedEndpoint string
+		expectedInsecure bool
+	}{
+		"without scheme":              {endpoint: "minio:9000", expectedEndpoint: "minio:9000"},
+		"without scheme and insecure": {endpoint: "minio:9000", insecure: true, expectedEndpoint: "minio:9000", expectedInsecure: true},
+		"http scheme":                 {endpoint: "http://minio:9000", expectedEndpoint: "minio:9000", expectedInsecure: true},
+		"https scheme":                {endpoint: "https://s3.amazonaws.com", expectedEndpoint: "s3.amazonaws.com"},
+	}
+
+	for testName, testData := range tests {
+		t.Run(testName, func(t *testing.T) {
+			cfg := Config{}
+			flagext.DefaultValues(&cfg)
+			cfg.Endpoint = testData.endpoint
+			cfg.Insecure = testData.insecure
+
+			s3Cfg, err := newS3Config(cfg)
+			require.NoError(t, err)
+			assert.Equal(t, testData.expectedEndpoint, s3Cfg.Endpoint)
+			assert.Equal(t, testData.expectedInsecure, s3Cfg.Insecure)
+		})
+	}
+}
+
 func TestSSEConfig_BuildMinioConfig(t *testing.T) {
 	tests := map[string]struct {
 		cfg             *SSEConfig
@@ -0,0 +1,276 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package arrowipc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// Reader reads the record batches of an Arrow IPC stream of the columns supported by Writer.
+type Reader struct {
+	r      io.Reader
+	fields []Field
+}
+
+// NewReader returns a Reader of the stream, after reading its schema.
+func NewReader(r io.Reader) (*Reader, error) {
+	headerType, header, _, err := readMessage(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading the schema: %w", err)
+	}
+	if headerType != messageHeaderSchema {
+		return nil, fmt.Errorf("the stream starts with a message of type %d instead of the schema", headerType)
+	}
+	fields, err := parseSchema(header)
+	if err != nil {
+		return nil, err
+	}
+	return &Reader{r: r, fields: fields}, nil
+}
+
+// Fields returns the fields of the schema of the stream.
+func (r *Reader) Fields() []Field {
+	return r.fields
+}
+
+// Read returns the columns of the next record batch, in the order of the fields: a []string for the Utf8 fields
+// and a []uint64 for the Uint64 fields. It returns io.EOF at the end of the stream.
+func (r *Reader) Read() ([]interface{}, error) {
+	headerType, header, body, err := readMessage(r.r)
+	if err != nil {
+		return nil, err
+	}
+	if headerType != messageHeaderRecordBatch {
+		return nil, fmt.Errorf("unsupported message of type %d", headerType)
+	}
+	return parseRecordBatch(header, body, r.fields)
+}
+
+// readMessage reads an encapsulated message, and returns the type and the table of its header, and its body.
+// It returns io.EOF at the end of the stream.
+func readMessage(r io.Reader) (headerType byte, header flatbuffers.Table, body []byte, err error) {
+	prefix := make([]byte, 8)
+	if _, err = io.ReadFull(r, prefix); err != nil {
+		return 0, header, nil, err
+	}
+	if binary.LittleEndian.Uint32(prefix) != continuationMarker {
+		return 0, header, nil, fmt.Errorf("missing the continuation marker")
+	}
+	size := binary.LittleEndian.Uint32(prefix[4:])
+	if size == 0 {
+		return 0, header, nil, io.EOF
+	}
+	metadata, err := readFull(r, int64(size))
+	if err != nil {
+		return 0, header, nil, err
+	}
+
+	var bodyLength int64
+	err = parseFlatbuffers(func() error {
+		msg := flatbuffers.Table{Bytes: metadata, Pos: flatbuffers.GetUOffsetT(metadata)}
+		if version := msg.GetInt16Slot(slot(0), 0); version < metadataVersionV5 {
+			return fmt.Errorf("unsupported metadata version %d", version)
+		}
+		headerType = msg.GetByteSlot(slot(1), 0)
+		o := msg.Offset(slot(2))
+		if o == 0 {
+			return fmt.Errorf("the message has no header")
+		}
+		msg.Union(&header, flatbuffers.UOffsetT(o))
+		bodyLength = msg.GetInt64Slot(slot(3), 0)
+		return nil
+	})
+	if err != nil {
+		return 0, header, nil, err
+	}
+
+	if bodyLength < 0 {
+		return 0, header, nil, fmt.Errorf("invalid body length %d", bodyLength)
+	}
+	body, err = readFull(r, bodyLength)
+	if err != nil {
+		return 0, header, nil, err
+	}
+	return headerType, header, body, nil
+}
+
+func parseSchema(schema flatbuffers.Table) (fields []Field, err error) {
+	err = parseFlatbuffers(func() error {
+		o := schema.Offset(slot(1))
+		if o == 0 {
+			return nil
+		}
+		start := schema.Vector(flatbuffers.UOffsetT(o))
+		for i := 0; i < schema.VectorLen(flatbuffers.UOffsetT(o)); i++ {
+			field := flatbuffers.Table{Bytes: schema.Bytes, Pos: schema.Indirect(start + flatbuffers.UOffsetT(4*i))}
+
+			var name string
+			if o := field.Offset(slot(0)); o != 0 {
+				name = field.String(field.Pos + flatbuffers.UOffsetT(o))
+			}
+			if field.GetBoolSlot(slot(1), false) {
+				return fmt.Errorf("field %s: nullable fields aren't supported", name)
+			}
+
+			var typ flatbuffers.Table
+			if o := field.Offset(slot(3)); o != 0 {
+				field.Union(&typ, flatbuffers.UOffsetT(o))
+			}
+			switch typeType := field.GetByteSlot(slot(2), 0); {
+			case typeType == typeUtf8:
+				fields = append(fields, Field{Name: name, Type: Utf8})
+			case typeType == typeInt && typ.Bytes != nil && typ.GetInt32Slot(slot(0), 0) == 64 && !typ.GetBoolSlot(slot(1), false):
+				fields = append(fields, Field{Name: name, Type: Uint64})
+			default:
+				return fmt.Errorf("field %s: unsupported type %d", name, typeType)
+			}
+		}
+		return nil
+	})
+	return fields, err
+}
+
+func parseRecordBatch(batch flatbuffers.Table, body []byte, fields []Field) (columns []interface{}, err error) {
+	var (
+		rows    int64
+		nodes   [][2]int64
+		buffers [][2]int64
+	)
+	err = parseFlatbuffers(func() error {
+		if batch.Offset(slot(3)) != 0 {
+			return fmt.Errorf("compressed record batches aren't supported")
+		}
+		rows = batch.GetInt64Slot(slot(0), 0)
+		if nodes, err = structVector(batch, slot(1)); err != nil {
+			return err
+		}
+		buffers, err = structVector(batch, slot(2))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Every row takes at least a byte of the body, which bounds the allocations of corrupted record batches.
+	if rows < 0 || (len(fields) > 0 && rows > int64(len(body))) {
+		return nil, fmt.Errorf("invalid number of rows %d", rows)
+	}
+
+	if len(nodes) != len(fields) {
+		return nil, fmt.Errorf("got %d field nodes for %d fields", len(nodes), len(fields))
+	}
+	buffer := func(f Field, idx int) ([]byte, error) {
+		if idx >= len(buffers) {
+			return nil, fmt.Errorf("column %s: missing buffer %d", f.Name, idx)
+		}
+		offset, length := buffers[idx][0], buffers[idx][1]
+		if offset < 0 || length < 0 || offset+length > int64(len(body)) {
+			return nil, fmt.Errorf("column %s: buffer %d is out of the body", f.Name, idx)
+		}
+		return body[offset : offset+length], nil
+	}
+
+	nextBuffer := 0
+	for i, f := range fields {
+		length, nullCount := nodes[i][0], nodes[i][1]
+		if length != rows {
+			return nil, fmt.Errorf("column %s: got %d values for %d rows", f.Name, length, rows)
+		}
+		if nullCount != 0 {
+			return nil, fmt.Errorf("column %s: null values aren't supported", f.Name)
+		}
+
+		switch f.Type {
+		case Utf8:
+			offsets, err := buffer(f, nextBuffer+1)
+			if err != nil {
+				return nil, err
+			}
+			data, err := buffer(f, nextBuffer+2)
+			if err != nil {
+				return nil, err
+			}
+			nextBuffer += 3
+
+			if int64(len(offsets)) < 4*(rows+1) {
+				return nil, fmt.Errorf("column %s: got %d bytes of offsets for %d rows", f.Name, len(offsets), rows)
+			}
+			values := make([]string, rows)
+			for j := range values {
+				start, end := binary.LittleEndian.Uint32(offsets[4*j:]), binary.LittleEndian.Uint32(offsets[4*(j+1):])
+				if start > end || int(end) > len(data) {
+					return nil, fmt.Errorf("column %s: invalid offsets of value %d", f.Name, j)
+				}
+				values[j] = string(data[start:end])
+			}
+			columns = append(columns, values)
+		case Uint64:
+			data, err := buffer(f, nextBuffer+1)
+			if err != nil {
+				return nil, err
+			}
+			nextBuffer += 2
+
+			if int64(len(data)) < 8*rows {
+				return nil, fmt.Errorf("column %s: got %d bytes of values for %d rows", f.Name, len(data), rows)
+			}
+			values := make([]uint64, rows)
+			for j := range values {
+				values[j] = binary.LittleEndian.Uint64(data[8*j:])
+			}
+			columns = append(columns, values)
+		}
+	}
+	return columns, nil
+}
+
+// structVector returns the vector at the slot of the table, whose structs are made of two 64-bit integers.
+func structVector(t flatbuffers.Table, s flatbuffers.VOffsetT) ([][2]int64, error) {
+	o := t.Offset(s)
+	if o == 0 {
+		return nil, nil
+	}
+	start := t.Vector(flatbuffers.UOffsetT(o))
+	length := t.VectorLen(flatbuffers.UOffsetT(o))
+	if length > (len(t.Bytes)-int(start))/16 {
+		return nil, fmt.Errorf("the vector of %d structs exceeds the message", length)
+	}
+	structs := make([][2]int64, length)
+	for i := range structs {
+		pos := start + flatbuffers.UOffsetT(16*i)
+		structs[i] = [2]int64{t.GetInt64(pos), t.GetInt64(pos + 8)}
+	}
+	return structs, nil
+}
+
+// slot returns the offset in the vtable of the field with the index.
+func slot(idx int) flatbuffers.VOffsetT {
+	return flatbuffers.VOffsetT(4 + 2*idx)
+}
+
+// parseFlatbuffers runs parse, returning an error instead of panicking if the flatbuffers are malformed.
+func parseFlatbuffers(parse func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("malformed message metadata: %v", r)
+		}
+	}()
+	return parse()
+}
+
+// readFull reads exactly size bytes. The buffer grows as the bytes are read, so that a corrupted size doesn't
+// allocate more than the stream holds.
+func readFull(r io.Reader, size int64) ([]byte, error) {
+	var buf bytes.Buffer
+	n, err := io.CopyN(&buf, r, size)
+	if n < size && err == io.EOF {
+		// The stream can't end in the middle of a message.
+		err = io.ErrUnexpectedEOF
+	}
+	return buf.Bytes(), err
+}
@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package arrowipc
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReader_MalformedStream(t *testing.T) {
+	var stream bytes.Buffer
+	w := NewWriter(&stream, Field{Name: "label_name", Type: Utf8})
+	require.NoError(t, w.Write([]string{"job", "instance"}))
+	require.NoError(t, w.Close())
+	valid := stream.Bytes()
+
+	readAll := func(data []byte) error {
+		r, err := NewReader(bytes.NewReader(data))
+		if err != nil {
+			return err
+		}
+		for {
+			if _, err := r.Read(); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	require.NoError(t, readAll(valid))
+
+	t.Run("empty stream", func(t *testing.T) {
+		require.ErrorIs(t, readAll(nil), io.EOF)
+	})
+
+	t.Run("missing continuation marker", func(t *testing.T) {
+		data := append([]byte{}, valid...)
+		data[0] = 0
+		require.EqualError(t, readAll(data), "reading the schema: missing the continuation marker")
+	})
+
+	t.Run("truncated stream", func(t *testing.T) {
+		// Like the other Arrow readers, the end of the stream is accepted in place of the end-of-stream marker.
+		var schemaOnly bytes.Buffer
+		require.NoError(t, NewWriter(&schemaOnly, Field{Name: "label_name", Type: Utf8}).Close())
+		schemaSize := schemaOnly.Len() - 8
+		require.NoError(t, readAll(valid[:schemaSize]))
+		require.NoError(t, readAll(valid[:len(valid)-8]))
+
+		for size := 1; size < len(valid)-8; size++ {
+			if size != schemaSize {
+				require.Error(t, readAll(valid[:size]), "size: %d", size)
+			}
+		}
+	})
+
+	t.Run("corrupted metadata", func(t *testing.T) {
+		// Corrupting any byte of the messages must return an error or garbled values, but never panic.
+		for i := 8; i < len(valid)-8; i++ {
+			data := append([]byte{}, valid...)
+			data[i] ^= 0xFF
+			require.NotPanics(t, func() { _ = readAll(data) }, "byte: %d", i)
+		}
+	})
+}
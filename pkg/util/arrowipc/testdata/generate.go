@@ -0,0 +1,56 @@
+//go:build ignore
+
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// This program writes label_values.arrow with the Apache Arrow Go implementation, which the tests use to check
+// that the streams of the package are interoperable with the Arrow readers. It isn't a dependency of Mimir, so
+// copy the program to a scratch module requiring github.com/apache/arrow/go/v10 v10.0.1 and run:
+//
+//	go run generate.go <path to>/label_values.arrow
+package main
+
+import (
+	"math"
+	"os"
+
+	"github.com/apache/arrow/go/v10/arrow"
+	"github.com/apache/arrow/go/v10/arrow/array"
+	"github.com/apache/arrow/go/v10/arrow/ipc"
+	"github.com/apache/arrow/go/v10/arrow/memory"
+)
+
+func main() {
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "label_name", Type: arrow.BinaryTypes.String},
+		{Name: "label_value", Type: arrow.BinaryTypes.String},
+		{Name: "series_count", Type: arrow.PrimitiveTypes.Uint64},
+	}, nil)
+	batches := []struct {
+		names, values []string
+		counts        []uint64
+	}{
+		{[]string{"job", "job", "job"}, []string{"api", "", "ünïcode"}, []uint64{30, 0, math.MaxUint64}},
+		{[]string{}, []string{}, []uint64{}},
+		{[]string{"instance"}, []string{"host-1:9090"}, []uint64{42}},
+	}
+
+	f, err := os.Create(os.Args[1])
+	if err != nil {
+		panic(err)
+	}
+	defer f.Close()
+
+	w := ipc.NewWriter(f, ipc.WithSchema(schema))
+	for _, batch := range batches {
+		b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		b.Field(0).(*array.StringBuilder).AppendValues(batch.names, nil)
+		b.Field(1).(*array.StringBuilder).AppendValues(batch.values, nil)
+		b.Field(2).(*array.Uint64Builder).AppendValues(batch.counts, nil)
+		if err := w.Write(b.NewRecord()); err != nil {
+			panic(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		panic(err)
+	}
+}
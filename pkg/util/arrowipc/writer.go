@@ -0,0 +1,291 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package arrowipc writes and reads record batches in the Apache Arrow IPC streaming format
+// (https://arrow.apache.org/docs/format/Columnar.html#ipc-streaming-format), so that analytics clients can load them
+// straight into data frames. Only the non-nullable UTF-8 and unsigned 64-bit integer columns are supported.
+package arrowipc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+
+	flatbuffers "github.com/google/flatbuffers/go"
+)
+
+// ContentType is the media type of the Arrow IPC streams.
+const ContentType = "application/vnd.apache.arrow.stream"
+
+// Type is the type of the values of a column.
+type Type int
+
+const (
+	// Utf8 is the type of the columns of strings, passed as a []string.
+	Utf8 Type = iota
+	// Uint64 is the type of the columns of unsigned 64-bit integers, passed as a []uint64.
+	Uint64
+)
+
+func (t Type) String() string {
+	switch t {
+	case Utf8:
+		return "utf8"
+	case Uint64:
+		return "uint64"
+	default:
+		return fmt.Sprintf("unknown type %d", int(t))
+	}
+}
+
+// Field is a column of the record batches of a stream.
+type Field struct {
+	Name string
+	Type Type
+}
+
+// Values of the Arrow flatbuffers schema (https://github.com/apache/arrow/tree/main/format) used by the messages.
+const (
+	metadataVersionV5 = 4
+
+	messageHeaderSchema      = 1
+	messageHeaderRecordBatch = 3
+
+	typeInt  = 2
+	typeUtf8 = 5
+
+	// continuationMarker precedes the size of the metadata of each message.
+	continuationMarker = 0xFFFFFFFF
+	// alignment is the alignment in bytes of the messages and of the buffers of the record batches.
+	alignment = 8
+)
+
+// Writer writes record batches of the same columns as an Arrow IPC stream. The schema of the stream is written
+// before the first record batch, and Close must be called to end the stream.
+type Writer struct {
+	w             io.Writer
+	fields        []Field
+	schemaWritten bool
+}
+
+// NewWriter returns a Writer of record batches of the fields to w.
+func NewWriter(w io.Writer, fields ...Field) *Writer {
+	return &Writer{w: w, fields: fields}
+}
+
+// Write writes a record batch of the columns, which are in the order of the fields and have the same number of
+// values: a []string for the Utf8 fields, and a []uint64 for the Uint64 fields.
+func (w *Writer) Write(columns ...interface{}) error {
+	if len(columns) != len(w.fields) {
+		return fmt.Errorf("got %d columns for %d fields", len(columns), len(w.fields))
+	}
+
+	b := &recordBatchBuilder{rows: -1}
+	for i, f := range w.fields {
+		if err := b.addColumn(f, columns[i]); err != nil {
+			return err
+		}
+	}
+	if b.rows < 0 {
+		b.rows = 0
+	}
+
+	if err := w.writeSchema(); err != nil {
+		return err
+	}
+	return writeMessage(w.w, b.message(), b.body)
+}
+
+// Close ends the stream, writing the schema first if no record batch has been written.
+// It doesn't close the underlying io.Writer.
+func (w *Writer) Close() error {
+	if err := w.writeSchema(); err != nil {
+		return err
+	}
+	eos := make([]byte, 8)
+	binary.LittleEndian.PutUint32(eos, continuationMarker)
+	_, err := w.w.Write(eos)
+	return err
+}
+
+func (w *Writer) writeSchema() error {
+	if w.schemaWritten {
+		return nil
+	}
+	metadata, err := schemaMessage(w.fields)
+	if err != nil {
+		return err
+	}
+	w.schemaWritten = true
+	return writeMessage(w.w, metadata, nil)
+}
+
+// schemaMessage returns the flatbuffers metadata of the schema message of the fields.
+func schemaMessage(fields []Field) ([]byte, error) {
+	b := flatbuffers.NewBuilder(0)
+
+	fieldOffsets := make([]flatbuffers.UOffsetT, len(fields))
+	for i, f := range fields {
+		name := b.CreateString(f.Name)
+
+		var typeType byte
+		switch f.Type {
+		case Utf8:
+			typeType = typeUtf8
+			b.StartObject(0)
+		case Uint64:
+			typeType = typeInt
+			b.StartObject(2)
+			b.PrependInt32Slot(0, 64, 0)
+		default:
+			return nil, fmt.Errorf("field %s: unsupported %s", f.Name, f.Type)
+		}
+		typ := b.EndObject()
+
+		b.StartVector(4, 0, 4)
+		children := b.EndVector(0)
+
+		b.StartObject(7)
+		b.PrependUOffsetTSlot(0, name, 0)
+		b.PrependByteSlot(2, typeType, 0)
+		b.PrependUOffsetTSlot(3, typ, 0)
+		b.PrependUOffsetTSlot(5, children, 0)
+		fieldOffsets[i] = b.EndObject()
+	}
+
+	b.StartVector(4, len(fieldOffsets), 4)
+	for i := len(fieldOffsets) - 1; i >= 0; i-- {
+		b.PrependUOffsetT(fieldOffsets[i])
+	}
+	fieldsVector := b.EndVector(len(fieldOffsets))
+
+	b.StartObject(4)
+	b.PrependUOffsetTSlot(1, fieldsVector, 0)
+	return finishMessage(b, messageHeaderSchema, b.EndObject(), 0), nil
+}
+
+// recordBatchBuilder lays out the columns of a record batch in its body.
+type recordBatchBuilder struct {
+	rows    int
+	nodes   []int64
+	buffers [][2]int64
+	body    []byte
+}
+
+func (b *recordBatchBuilder) addColumn(f Field, column interface{}) error {
+	var rows int
+	switch values := column.(type) {
+	case []string:
+		if f.Type != Utf8 {
+			return fmt.Errorf("column %s: got strings for a %s field", f.Name, f.Type)
+		}
+		rows = len(values)
+
+		offsets := make([]byte, 4*(len(values)+1))
+		size := 0
+		for i, v := range values {
+			size += len(v)
+			if size > math.MaxInt32 {
+				return fmt.Errorf("column %s: the values exceed the maximum size of %d bytes", f.Name, math.MaxInt32)
+			}
+			binary.LittleEndian.PutUint32(offsets[4*(i+1):], uint32(size))
+		}
+		data := make([]byte, 0, size)
+		for _, v := range values {
+			data = append(data, v...)
+		}
+
+		// The values are never null, so the validity bitmap is omitted.
+		b.addBuffer(nil)
+		b.addBuffer(offsets)
+		b.addBuffer(data)
+	case []uint64:
+		if f.Type != Uint64 {
+			return fmt.Errorf("column %s: got unsigned integers for a %s field", f.Name, f.Type)
+		}
+		rows = len(values)
+
+		data := make([]byte, 8*len(values))
+		for i, v := range values {
+			binary.LittleEndian.PutUint64(data[8*i:], v)
+		}
+
+		b.addBuffer(nil)
+		b.addBuffer(data)
+	default:
+		return fmt.Errorf("column %s: unsupported column type %T", f.Name, column)
+	}
+
+	if b.rows >= 0 && rows != b.rows {
+		return fmt.Errorf("column %s: got %d values, while the previous columns have %d", f.Name, rows, b.rows)
+	}
+	b.rows = rows
+	b.nodes = append(b.nodes, int64(rows))
+	return nil
+}
+
+// addBuffer appends the buffer to the body, padded to the alignment.
+func (b *recordBatchBuilder) addBuffer(buf []byte) {
+	b.buffers = append(b.buffers, [2]int64{int64(len(b.body)), int64(len(buf))})
+	b.body = append(b.body, buf...)
+	b.body = append(b.body, make([]byte, padding(len(b.body)))...)
+}
+
+// message returns the flatbuffers metadata of the record batch message.
+func (b *recordBatchBuilder) message() []byte {
+	fb := flatbuffers.NewBuilder(0)
+
+	// The vectors of structs are built backwards. The field nodes are made of the length and the null count,
+	// and the buffers of the offset and the length.
+	fb.StartVector(16, len(b.nodes), 8)
+	for i := len(b.nodes) - 1; i >= 0; i-- {
+		fb.Prep(8, 16)
+		fb.PrependInt64(0)
+		fb.PrependInt64(b.nodes[i])
+	}
+	nodes := fb.EndVector(len(b.nodes))
+
+	fb.StartVector(16, len(b.buffers), 8)
+	for i := len(b.buffers) - 1; i >= 0; i-- {
+		fb.Prep(8, 16)
+		fb.PrependInt64(b.buffers[i][1])
+		fb.PrependInt64(b.buffers[i][0])
+	}
+	buffers := fb.EndVector(len(b.buffers))
+
+	fb.StartObject(4)
+	fb.PrependInt64Slot(0, int64(b.rows), 0)
+	fb.PrependUOffsetTSlot(1, nodes, 0)
+	fb.PrependUOffsetTSlot(2, buffers, 0)
+	return finishMessage(fb, messageHeaderRecordBatch, fb.EndObject(), int64(len(b.body)))
+}
+
+// finishMessage wraps the header in a message and returns the flatbuffers metadata of the message.
+func finishMessage(b *flatbuffers.Builder, headerType byte, header flatbuffers.UOffsetT, bodyLength int64) []byte {
+	b.StartObject(5)
+	b.PrependInt16Slot(0, metadataVersionV5, 0)
+	b.PrependByteSlot(1, headerType, 0)
+	b.PrependUOffsetTSlot(2, header, 0)
+	b.PrependInt64Slot(3, bodyLength, 0)
+	b.Finish(b.EndObject())
+	return b.FinishedBytes()
+}
+
+// writeMessage writes an encapsulated message: the continuation marker, the size of the metadata, the metadata
+// padded so that the body is aligned, and the body.
+func writeMessage(w io.Writer, metadata, body []byte) error {
+	pad := padding(8 + len(metadata))
+	buf := make([]byte, 8, 8+len(metadata)+pad+len(body))
+	binary.LittleEndian.PutUint32(buf, continuationMarker)
+	binary.LittleEndian.PutUint32(buf[4:], uint32(len(metadata)+pad))
+	buf = append(buf, metadata...)
+	buf = append(buf, make([]byte, pad)...)
+	buf = append(buf, body...)
+	_, err := w.Write(buf)
+	return err
+}
+
+// padding returns the number of bytes to add to size to align it.
+func padding(size int) int {
+	return (alignment - size%alignment) % alignment
+}
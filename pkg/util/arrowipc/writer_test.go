@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package arrowipc
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_RoundTrip(t *testing.T) {
+	fields := []Field{
+		{Name: "label_name", Type: Utf8},
+		{Name: "label_value", Type: Utf8},
+		{Name: "series_count", Type: Uint64},
+	}
+
+	tests := map[string]struct {
+		batches [][]interface{}
+	}{
+		"no record batches": {},
+		"single record batch": {
+			batches: [][]interface{}{
+				{[]string{"job", "job"}, []string{"api", "db"}, []uint64{10, 2}},
+			},
+		},
+		"multiple record batches": {
+			batches: [][]interface{}{
+				{[]string{"job", "job", "job"}, []string{"api", "", "ünïcode"}, []uint64{3, 0, math.MaxUint64}},
+				{[]string{}, []string{}, []uint64{}},
+				{[]string{"instance"}, []string{"host-1:9090"}, []uint64{42}},
+			},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, fields...)
+			for _, batch := range tc.batches {
+				require.NoError(t, w.Write(batch...))
+			}
+			require.NoError(t, w.Close())
+			require.Zero(t, buf.Len()%alignment, "the stream should be made of aligned messages")
+
+			r, err := NewReader(&buf)
+			require.NoError(t, err)
+			assert.Equal(t, fields, r.Fields())
+
+			for _, expected := range tc.batches {
+				columns, err := r.Read()
+				require.NoError(t, err)
+				assert.Equal(t, expected, columns)
+			}
+			_, err = r.Read()
+			require.Equal(t, io.EOF, err)
+			require.Zero(t, buf.Len(), "the whole stream should have been read")
+		})
+	}
+}
+
+// TestArrowInterop checks the package against testdata/label_values.arrow, written by the Apache Arrow Go
+// implementation with testdata/generate.go.
+func TestArrowInterop(t *testing.T) {
+	expected, err := os.ReadFile(filepath.Join("testdata", "label_values.arrow"))
+	require.NoError(t, err)
+
+	fields := []Field{
+		{Name: "label_name", Type: Utf8},
+		{Name: "label_value", Type: Utf8},
+		{Name: "series_count", Type: Uint64},
+	}
+	batches := [][]interface{}{
+		{[]string{"job", "job", "job"}, []string{"api", "", "ünïcode"}, []uint64{30, 0, math.MaxUint64}},
+		{[]string{}, []string{}, []uint64{}},
+		{[]string{"instance"}, []string{"host-1:9090"}, []uint64{42}},
+	}
+
+	t.Run("read the stream written by Arrow", func(t *testing.T) {
+		r, err := NewReader(bytes.NewReader(expected))
+		require.NoError(t, err)
+		assert.Equal(t, fields, r.Fields())
+
+		for _, batch := range batches {
+			columns, err := r.Read()
+			require.NoError(t, err)
+			assert.Equal(t, batch, columns)
+		}
+		_, err = r.Read()
+		require.Equal(t, io.EOF, err)
+	})
+
+	t.Run("write the same stream as Arrow", func(t *testing.T) {
+		var buf bytes.Buffer
+		w := NewWriter(&buf, fields...)
+		for _, batch := range batches {
+			require.NoError(t, w.Write(batch...))
+		}
+		require.NoError(t, w.Close())
+		require.Equal(t, expected, buf.Bytes())
+	})
+}
+
+func TestWriter_UnsupportedFieldType(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf, Field{Name: "label_name", Type: Utf8}, Field{Name: "ratio", Type: Type(10)})
+	require.EqualError(t, w.Close(), "field ratio: unsupported unknown type 10")
+	require.Zero(t, buf.Len())
+}
+
+func TestWriter_Write_InvalidColumns(t *testing.T) {
+	fields := []Field{
+		{Name: "label_name", Type: Utf8},
+		{Name: "series_count", Type: Uint64},
+	}
+
+	tests := map[string]struct {
+		columns       []interface{}
+		expectedError string
+	}{
+		"missing column": {
+			columns:       []interface{}{[]string{"job"}},
+			expectedError: "got 1 columns for 2 fields",
+		},
+		"column of the wrong type": {
+			columns:       []interface{}{[]string{"job"}, []string{"1"}},
+			expectedError: "column series_count: got strings for a uint64 field",
+		},
+		"unsupported column type": {
+			columns:       []interface{}{[]string{"job"}, []int{1}},
+			expectedError: "column series_count: unsupported column type []int",
+		},
+		"columns of different lengths": {
+			columns:       []interface{}{[]string{"job", "instance"}, []uint64{1}},
+			expectedError: "column series_count: got 1 values, while the previous columns have 2",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, fields...)
+			require.EqualError(t, w.Write(tc.columns...), tc.expectedError)
+			require.Zero(t, buf.Len(), "nothing should be written if the record batch is invalid")
+
+			// The stream can still be written after an invalid record batch.
+			require.NoError(t, w.Write([]string{"job"}, []uint64{1}))
+			require.NoError(t, w.Close())
+
+			r, err := NewReader(&buf)
+			require.NoError(t, err)
+			columns, err := r.Read()
+			require.NoError(t, err)
+			assert.Equal(t, []interface{}{[]string{"job"}, []uint64{1}}, columns)
+		})
+	}
+}
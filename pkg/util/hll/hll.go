@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+// Package hll implements a HyperLogLog sketch, to estimate the number of distinct items using bounded memory.
+package hll
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+)
+
+// Supported range of sketch precisions.
+const (
+	MinPrecision = 4
+	MaxPrecision = 18
+)
+
+// Sketch is a HyperLogLog sketch. It's not safe for concurrent use.
+type Sketch struct {
+	precision uint8
+	registers []uint8
+}
+
+// New returns a sketch with 2^precision registers. Higher precisions give more accurate estimates,
+// at the cost of more memory: the relative standard error is 1.04/sqrt(2^precision).
+func New(precision uint8) (*Sketch, error) {
+	if precision < MinPrecision || precision > MaxPrecision {
+		return nil, fmt.Errorf("invalid HyperLogLog precision %d: it must be between %d and %d", precision, MinPrecision, MaxPrecision)
+	}
+	return &Sketch{
+		precision: precision,
+		registers: make([]uint8, 1<<precision),
+	}, nil
+}
+
+// Add adds an item to the sketch, given its 64-bit hash. The hash must be uniformly distributed:
+// use HashUint64 to add items which aren't, like sequential IDs.
+func (s *Sketch) Add(hash uint64) {
+	idx := hash >> (64 - s.precision)
+	// The rank is the position of the leftmost 1-bit in the remaining bits, starting from 1.
+	// The sentinel bit caps the rank when all the remaining bits are 0.
+	rank := uint8(bits.LeadingZeros64(hash<<s.precision|1<<(s.precision-1))) + 1
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// Estimate returns the estimated number of distinct items added to the sketch.
+func (s *Sketch) Estimate() uint64 {
+	m := float64(len(s.registers))
+
+	sum := 0.0
+	zeros := 0
+	for _, r := range s.registers {
+		sum += 1 / float64(uint64(1)<<r)
+		if r == 0 {
+			zeros++
+		}
+	}
+	estimate := alpha(len(s.registers)) * m * m / sum
+
+	// Use linear counting for small cardinalities, where the raw estimate is biased.
+	if estimate <= 2.5*m && zeros > 0 {
+		estimate = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(estimate))
+}
+
+// RelativeError returns the relative standard error of the estimates.
+func (s *Sketch) RelativeError() float64 {
+	return 1.04 / math.Sqrt(float64(len(s.registers)))
+}
+
+// Merge merges other into s, so that s estimates the distinct items added to any of the two sketches.
+func (s *Sketch) Merge(other *Sketch) error {
+	if other.precision != s.precision {
+		return fmt.Errorf("cannot merge HyperLogLog sketches with different precisions %d and %d", s.precision, other.precision)
+	}
+	for i, r := range other.registers {
+		if r > s.registers[i] {
+			s.registers[i] = r
+		}
+	}
+	return nil
+}
+
+// HashUint64 returns a uniformly distributed hash of v, suitable to be passed to Sketch.Add.
+func HashUint64(v uint64) uint64 {
+	// SplitMix64 finalizer.
+	v ^= v >> 30
+	v *= 0xbf58476d1ce4e5b9
+	v ^= v >> 27
+	v *= 0x94d049bb133111eb
+	v ^= v >> 31
+	return v
+}
+
+func alpha(m int) float64 {
+	switch m {
+	case 16:
+		return 0.673
+	case 32:
+		return 0.697
+	case 64:
+		return 0.709
+	default:
+		return 0.7213 / (1 + 1.079/float64(m))
+	}
+}
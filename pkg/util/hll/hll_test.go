@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: AGPL-3.0-only
+
+package hll
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(MinPrecision - 1)
+	require.EqualError(t, err, "invalid HyperLogLog precision 3: it must be between 4 and 18")
+
+	_, err = New(MaxPrecision + 1)
+	require.EqualError(t, err, "invalid HyperLogLog precision 19: it must be between 4 and 18")
+
+	s, err := New(14)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), s.Estimate())
+	assert.InDelta(t, 0.008125, s.RelativeError(), 0.000001)
+}
+
+func TestSketch_Estimate(t *testing.T) {
+	for _, precision := range []uint8{10, 14} {
+		for _, distinct := range []int{1, 10, 100, 1000, 10000, 100000, 1000000} {
+			t.Run(fmt.Sprintf("precision=%d, distinct=%d", precision, distinct), func(t *testing.T) {
+				s, err := New(precision)
+				require.NoError(t, err)
+
+				for i := 0; i < distinct; i++ {
+					s.Add(HashUint64(uint64(i)))
+				}
+				// Adding the same items again doesn't change the estimate.
+				estimate := s.Estimate()
+				for i := 0; i < distinct; i++ {
+					s.Add(HashUint64(uint64(i)))
+				}
+				require.Equal(t, estimate, s.Estimate())
+
+				// The estimate is expected to be within 3 standard errors.
+				assert.InDelta(t, distinct, estimate, math.Max(1, 3*s.RelativeError()*float64(distinct)))
+			})
+		}
+	}
+}
+
+func TestSketch_Merge(t *testing.T) {
+	first, err := New(14)
+	require.NoError(t, err)
+	second, err := New(14)
+	require.NoError(t, err)
+
+	// The two sketches have 5000 items in common.
+	for i := 0; i < 10000; i++ {
+		first.Add(HashUint64(uint64(i)))
+	}
+	for i := 5000; i < 20000; i++ {
+		second.Add(HashUint64(uint64(i)))
+	}
+
+	require.NoError(t, first.Merge(second))
+	assert.InDelta(t, 20000, first.Estimate(), 3*first.RelativeError()*20000)
+
+	other, err := New(10)
+	require.NoError(t, err)
+	require.EqualError(t, first.Merge(other), "cannot merge HyperLogLog sketches with different precisions 14 and 10")
+}
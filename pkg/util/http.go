@@ -8,6 +8,8 @@ package util
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -63,6 +65,44 @@ func WriteJSONResponse(w http.ResponseWriter, v interface{}) {
 	_, _ = w.Write(data)
 }
 
+// WriteJSONResponseWithETag writes some JSON as a HTTP response, with an ETag computed from its content.
+// If the request has an If-None-Match header matching the ETag, the response is 304 Not Modified
+// without body, so that polling clients don't download the same content again.
+func WriteJSONResponseWithETag(w http.ResponseWriter, r *http.Request, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	sum := sha256.Sum256(data)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	// We ignore errors here, because we cannot do anything about them.
+	_, _ = w.Write(data)
+}
+
+// etagMatches returns whether the If-None-Match header matches the ETag. The header can be "*",
+// or a comma-separated list of ETags, which are compared with the weak comparison.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
 // WriteYAMLResponse writes some YAML as a HTTP response.
 func WriteYAMLResponse(w http.ResponseWriter, v interface{}) {
 	// There is not standardised content-type for YAML, text/plain ensures the